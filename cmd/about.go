@@ -0,0 +1,251 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/version"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// runtimeVersionCommands are the commands that print the versions of the languages that projects may use.
+var runtimeVersionCommands = map[string][]string{
+	"nodejs": {"node", "--version"},
+	"python": {"python3", "--version"},
+	"go":     {"go", "version"},
+	"dotnet": {"dotnet", "--version"},
+}
+
+func newAboutCmd() *cobra.Command {
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "about",
+		Short: "Print information about the Pulumi environment",
+		Long: "Print information about the Pulumi environment\n" +
+			"\n" +
+			"This command prints the versions of the CLI, of the Go runtime that it was built with, and of the\n" +
+			"installed plugins, along with the operating system, the backend in use, and the current project, its\n" +
+			"language runtime and the current stack.  Please include its output when reporting a bug.\n" +
+			"\n" +
+			"It does not log in to the backend.  Information that cannot be found is reported in a list of errors,\n" +
+			"rather than failing the command.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			about := getAbout()
+			if jsonOut {
+				return printJSON(about)
+			}
+			printAbout(about)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit output as JSON")
+
+	return cmd
+}
+
+// aboutJSON is the shape of the --json output of this command.  While we can add fields to this structure in the
+// future, we should not change existing fields.
+type aboutJSON struct {
+	CLI     aboutCLIJSON      `json:"cli"`
+	OS      aboutOSJSON       `json:"os"`
+	Backend *aboutBackendJSON `json:"backend,omitempty"`
+	Project *aboutProjectJSON `json:"project,omitempty"`
+	Plugins []aboutPluginJSON `json:"plugins"`
+	Errors  []string          `json:"errors,omitempty"`
+}
+
+type aboutCLIJSON struct {
+	Version    string `json:"version"`
+	GoVersion  string `json:"goVersion"`
+	GoCompiler string `json:"goCompiler"`
+}
+
+type aboutOSJSON struct {
+	Name string `json:"name"`
+	Arch string `json:"arch"`
+}
+
+type aboutBackendJSON struct {
+	URL string `json:"url"`
+}
+
+type aboutProjectJSON struct {
+	Name           string `json:"name"`
+	Runtime        string `json:"runtime"`
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+	CurrentStack   string `json:"currentStack,omitempty"`
+}
+
+type aboutPluginJSON struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Version string `json:"version"`
+}
+
+// getAbout gathers the information that this command prints.  Failures are recorded in the result's errors.
+func getAbout() aboutJSON {
+	about := aboutJSON{
+		CLI: aboutCLIJSON{
+			Version:    version.Version,
+			GoVersion:  runtime.Version(),
+			GoCompiler: runtime.Compiler,
+		},
+		OS: aboutOSJSON{
+			Name: runtime.GOOS,
+			Arch: runtime.GOARCH,
+		},
+		Plugins: []aboutPluginJSON{},
+	}
+	addError := func(err error) {
+		about.Errors = append(about.Errors, err.Error())
+	}
+
+	// The URL is empty if the user has not logged in.
+	if url, err := workspace.GetCurrentCloudURL(); err != nil {
+		addError(errors.Wrap(err, "getting the backend URL"))
+	} else if url != "" {
+		about.Backend = &aboutBackendJSON{URL: url}
+	}
+
+	// Outside of a project there is no project, runtime or current stack to report.
+	if path, err := workspace.DetectProjectPath(); err == nil && path != "" {
+		about.Project = getAboutProject(addError)
+	}
+
+	plugins, err := workspace.GetPlugins()
+	if err != nil {
+		addError(errors.Wrap(err, "loading plugins"))
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].String() < plugins[j].String()
+	})
+	for _, plugin := range plugins {
+		var version string
+		if plugin.Version != nil {
+			version = plugin.Version.String()
+		}
+		about.Plugins = append(about.Plugins, aboutPluginJSON{
+			Name:    plugin.Name,
+			Kind:    string(plugin.Kind),
+			Version: version,
+		})
+	}
+
+	return about
+}
+
+// getAboutProject returns the name and runtime of the current project, and the current stack, if one is selected.
+// Failures are passed to addError.
+func getAboutProject(addError func(error)) *aboutProjectJSON {
+	proj, _, err := readProject()
+	if err != nil {
+		addError(err)
+		return nil
+	}
+	project := &aboutProjectJSON{
+		Name:    string(proj.Name),
+		Runtime: proj.Runtime.Name(),
+	}
+
+	if command, has := runtimeVersionCommands[project.Runtime]; has {
+		out, err := exec.Command(command[0], command[1:]...).Output()
+		if err != nil {
+			addError(errors.Wrapf(err, "getting the version of the %s runtime", project.Runtime))
+		} else {
+			project.RuntimeVersion = strings.TrimSpace(string(out))
+		}
+	}
+
+	if w, err := workspace.New(); err != nil {
+		addError(errors.Wrap(err, "getting the current stack"))
+	} else {
+		project.CurrentStack = w.Settings().Stack
+	}
+
+	return project
+}
+
+func printAbout(about aboutJSON) {
+	orNA := func(s string) string {
+		if s == "" {
+			return naString
+		}
+		return s
+	}
+
+	// Sections of names and values are printed as tables without headers, so that their values line up.
+	printSection := func(title string, rows ...[]string) {
+		tableRows := []cmdutil.TableRow{}
+		for _, row := range rows[1:] {
+			tableRows = append(tableRows, cmdutil.TableRow{Columns: row})
+		}
+		fmt.Println(title)
+		cmdutil.PrintTableWithGap(cmdutil.Table{Headers: rows[0], Rows: tableRows, Prefix: "    "}, "  ")
+		fmt.Println()
+	}
+
+	printSection("CLI",
+		[]string{"Version", orNA(about.CLI.Version)},
+		[]string{"Go Version", about.CLI.GoVersion},
+		[]string{"Go Compiler", about.CLI.GoCompiler})
+	printSection("Host",
+		[]string{"OS", about.OS.Name},
+		[]string{"Arch", about.OS.Arch})
+	backendURL := "not logged in"
+	if about.Backend != nil {
+		backendURL = about.Backend.URL
+	}
+	printSection("Backend",
+		[]string{"URL", backendURL})
+	if project := about.Project; project != nil {
+		printSection("Project",
+			[]string{"Name", project.Name},
+			[]string{"Runtime", project.Runtime},
+			[]string{"Runtime Version", orNA(project.RuntimeVersion)},
+			[]string{"Current Stack", orNA(project.CurrentStack)})
+	}
+
+	fmt.Println("Plugins")
+	if len(about.Plugins) == 0 {
+		fmt.Printf("    %s\n", naString)
+	} else {
+		rows := []cmdutil.TableRow{}
+		for _, plugin := range about.Plugins {
+			rows = append(rows, cmdutil.TableRow{Columns: []string{plugin.Name, plugin.Kind, orNA(plugin.Version)}})
+		}
+		cmdutil.PrintTable(cmdutil.Table{Headers: []string{"NAME", "KIND", "VERSION"}, Rows: rows, Prefix: "    "})
+	}
+
+	if len(about.Errors) > 0 {
+		fmt.Println()
+		fmt.Println("Errors")
+		for _, err := range about.Errors {
+			fmt.Printf("    %s\n", err)
+		}
+	}
+}
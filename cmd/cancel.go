@@ -15,13 +15,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pulumi/pulumi/pkg/util/result"
 
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
@@ -41,7 +44,11 @@ func newCancelCmd() *cobra.Command {
 			"inconsistent state if a resource operation was pending when the update was canceled.\n" +
 			"\n" +
 			"After this command completes successfully, the stack will be ready for further\n" +
-			"updates.",
+			"updates.\n" +
+			"\n" +
+			"For stacks in local or cloud storage backends, the running update is asked to cancel\n" +
+			"as it is when it is interrupted: it starts no new resource operations, waits for those\n" +
+			"in flight to finish, and saves the stack's checkpoint before it exits.",
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			// Use the stack provided or, if missing, default to the current one.
 			if len(args) > 0 {
@@ -61,14 +68,21 @@ func newCancelCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			// Ensure that we are targeting the Pulumi cloud.
-			backend, ok := s.Backend().(httpstate.Backend)
-			if !ok {
-				return result.Error("the `cancel` command is not supported for local stacks")
+			stackName := string(s.Ref().Name())
+			var cancelCurrentUpdate func(ctx context.Context, stackRef backend.StackReference) error
+			canceled := fmt.Sprintf("The currently running update for '%s' has been canceled!", stackName)
+			switch b := s.Backend().(type) {
+			case httpstate.Backend:
+				cancelCurrentUpdate = b.CancelCurrentUpdate
+			case filestate.Backend:
+				cancelCurrentUpdate = b.CancelCurrentUpdate
+				canceled = fmt.Sprintf("The currently running update for '%s' has been asked to cancel; "+
+					"it will stop once its in-flight resource operations finish.", stackName)
+			default:
+				return result.Errorf("the `cancel` command is not supported for %s stacks", b.Name())
 			}
 
 			// Ensure the user really wants to do this.
-			stackName := string(s.Ref().Name())
 			prompt := fmt.Sprintf("This will irreversibly cancel the currently running update for '%s'!", stackName)
 			if !yes && !confirmPrompt(prompt, stackName, opts) {
 				fmt.Println("confirmation declined")
@@ -76,13 +90,11 @@ func newCancelCmd() *cobra.Command {
 			}
 
 			// Cancel the update.
-			if err := backend.CancelCurrentUpdate(commandContext(), s.Ref()); err != nil {
+			if err := cancelCurrentUpdate(commandContext(), s.Ref()); err != nil {
 				return result.FromError(err)
 			}
 
-			msg := fmt.Sprintf(
-				"%sThe currently running update for '%s' has been canceled!%s",
-				colors.SpecAttention, stackName, colors.Reset)
+			msg := colors.SpecAttention + canceled + colors.Reset
 			fmt.Println(opts.Color.Colorize(msg))
 
 			return nil
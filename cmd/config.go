@@ -17,6 +17,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"regexp"
@@ -76,6 +77,7 @@ func newConfigCmd() *cobra.Command {
 		&stackConfigFile, "config-file", "",
 		"Use the configuration values in the specified file rather than detecting the file name")
 
+	cmd.AddCommand(newConfigCpCmd(&stack))
 	cmd.AddCommand(newConfigGetCmd(&stack))
 	cmd.AddCommand(newConfigRmCmd(&stack))
 	cmd.AddCommand(newConfigSetCmd(&stack))
@@ -84,6 +86,69 @@ func newConfigCmd() *cobra.Command {
 	return cmd
 }
 
+func newConfigCpCmd(stack *string) *cobra.Command {
+	var dest string
+	var path bool
+
+	cpCmd := &cobra.Command{
+		Use:   "cp [key]...",
+		Short: "Copy configuration values to another stack",
+		Long: "Copy configuration values to another stack.\n\n" +
+			"Copies the given keys, or all of the stack's configuration if no keys are given, to the stack named\n" +
+			"by `--dest`, overwriting any values that it already has for them. Secrets are encrypted again with\n" +
+			"the destination stack's secrets provider.\n\n" +
+			"The `--path` flag can be used to copy a value inside a map or list, e.g.\n" +
+			"`pulumi config cp --dest prod --path 'servers[0].port'`.",
+		Args: cmdutil.ArgsFunc(cobra.ArbitraryArgs),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if dest == "" {
+				return errors.New("a destination stack must be given with --dest")
+			}
+			if stackConfigFile != "" {
+				return errors.New("--config-file cannot be used when copying configuration between stacks")
+			}
+
+			from, err := requireStack(*stack, true, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			to, err := requireStack(dest, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			if from.Ref().String() == to.Ref().String() {
+				return errors.Errorf("cannot copy configuration from stack '%s' to itself", from.Ref())
+			}
+
+			var keys []config.Key
+			for _, arg := range args {
+				key, err := parseConfigKey(arg)
+				if err != nil {
+					return errors.Wrap(err, "invalid configuration key")
+				}
+				keys = append(keys, key)
+			}
+			if path && len(keys) == 0 {
+				return errors.New("--path requires at least one key")
+			}
+
+			return copyStackConfig(from, to, keys, path)
+		}),
+	}
+	cpCmd.PersistentFlags().StringVarP(
+		&dest, "dest", "d", "",
+		"The name of the stack to copy the configuration to")
+	cpCmd.PersistentFlags().BoolVar(
+		&path, "path", false,
+		"The keys contain paths to properties in maps or lists to copy")
+
+	return cpCmd
+}
+
 func newConfigGetCmd(stack *string) *cobra.Command {
 	var jsonOut bool
 	var path bool
@@ -249,18 +314,25 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 	var plaintext bool
 	var secret bool
 	var path bool
+	var fromFile string
+	var fromStdin bool
 
 	setCmd := &cobra.Command{
 		Use:   "set <key> [value]",
 		Short: "Set configuration value",
 		Long: "Configuration values can be accessed when a stack is being deployed and used to configure behavior. \n" +
 			"If a value is not present on the command line, pulumi will prompt for the value. Multi-line values\n" +
-			"may be set by piping a file to standard in.\n\n" +
+			"may be set by piping a file to standard in, or read verbatim from a file with `--from-file` or from\n" +
+			"standard in with `--from-stdin`, e.g. `pulumi config set --secret tlsKey --from-file ./key.pem`.\n\n" +
 			"The `--path` flag can be used to set a value inside a map or list:\n\n" +
 			"    - `pulumi config set --path outer.inner value` " +
 			"will set the value of `outer` to a map `inner: value`.\n" +
 			"    - `pulumi config set --path names[0] a` " +
-			"will set the value to a list with the first item `a`.",
+			"will set the value to a list with the first item `a`.\n" +
+			"    - `pulumi config set --path 'servers[0].port' 80` " +
+			"will set the `port` of the first item of `servers` to the number 80.\n\n" +
+			"Structured values are stored as YAML maps and lists in the stack's settings file, and values\n" +
+			"such as `true` or `80` are stored as booleans and numbers.",
 		Args: cmdutil.RangeArgs(1, 2),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
@@ -280,6 +352,13 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 
 			var value string
 			switch {
+			case fromFile != "" || fromStdin:
+				if len(args) == 2 {
+					return errors.New("a value cannot be specified together with --from-file or --from-stdin")
+				}
+				if value, err = readConfigValueFrom(fromFile, fromStdin, os.Stdin); err != nil {
+					return err
+				}
 			case len(args) == 2:
 				value = args[1]
 			case !terminal.IsTerminal(int(os.Stdin.Fd())):
@@ -347,10 +426,35 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 	setCmd.PersistentFlags().BoolVar(
 		&secret, "secret", false,
 		"Encrypt the value instead of storing it in plaintext")
+	setCmd.PersistentFlags().StringVar(
+		&fromFile, "from-file", "",
+		"Read the value verbatim from the given file")
+	setCmd.PersistentFlags().BoolVar(
+		&fromStdin, "from-stdin", false,
+		"Read the value verbatim from standard in")
 
 	return setCmd
 }
 
+// readConfigValueFrom reads a configuration value verbatim from the named file, or from stdin if fromStdin is set.
+func readConfigValueFrom(file string, fromStdin bool, stdin io.Reader) (string, error) {
+	if file != "" && fromStdin {
+		return "", errors.New("only one of --from-file and --from-stdin may be specified")
+	}
+
+	var b []byte
+	var err error
+	if fromStdin {
+		b, err = ioutil.ReadAll(stdin)
+	} else {
+		b, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "reading configuration value")
+	}
+	return string(b), nil
+}
+
 var stackConfigFile string
 
 func getProjectStackPath(stack backend.Stack) (string, error) {
@@ -597,6 +701,22 @@ func getStackConfiguration(stack backend.Stack, sm secrets.Manager) (backend.Sta
 		return backend.StackConfiguration{}, errors.Wrap(err, "loading stack configuration")
 	}
 
+	// Fill in any keys that the stack does not set from the environment variables that the project names for them,
+	// and then from the project's defaults.  Secret values from the environment are encrypted like the stack's own.
+	proj, err := workspace.DetectProject()
+	if err != nil {
+		return backend.StackConfiguration{}, err
+	}
+	var encrypter config.Encrypter = config.NewPanicCrypter()
+	if proj.HasSecretConfigEnv() {
+		if encrypter, err = sm.Encrypter(); err != nil {
+			return backend.StackConfiguration{}, errors.Wrap(err, "getting configuration encrypter")
+		}
+	}
+	if workspaceStack.Config, err = addInheritedConfig(workspaceStack.Config, proj, encrypter); err != nil {
+		return backend.StackConfiguration{}, err
+	}
+
 	// If there are no secrets in the configuration, we should never use the decrypter, so it is safe to return
 	// one which panics if it is used. This provides for some nice UX in the common case (since, for example, building
 	// the correct decrypter for the local backend would involve prompting for a passphrase)
@@ -617,3 +737,28 @@ func getStackConfiguration(stack backend.Stack, sm secrets.Manager) (backend.Sta
 		Decrypter: crypter,
 	}, nil
 }
+
+// addInheritedConfig adds the configuration values that a stack inherits from its project to the stack's own
+// configuration, which may be nil.  Values that the stack sets take precedence over those read from the environment
+// variables named by the project, which take precedence over the project's defaults.
+func addInheritedConfig(cfg config.Map, proj *workspace.Project, encrypter config.Encrypter) (config.Map, error) {
+	envConfig, err := proj.EnvironmentConfig(encrypter)
+	if err != nil {
+		return nil, err
+	}
+	defaultConfig, err := proj.DefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	for _, inherited := range []config.Map{envConfig, defaultConfig} {
+		for k, v := range inherited {
+			if _, has := cfg[k]; !has {
+				if cfg == nil {
+					cfg = make(config.Map)
+				}
+				cfg[k] = v
+			}
+		}
+	}
+	return cfg, nil
+}
@@ -15,6 +15,10 @@
 package cmd
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,3 +45,72 @@ func TestSecretDetection(t *testing.T) {
 	// The key name does not match the, so even though this "looks like" a secret, we say it is not.
 	assert.False(t, looksLikeSecret(config.MustMakeKey("test", "okay"), "1415fc1f4eaeb5e096ee58c1480016638fff29bf"))
 }
+
+func TestReadConfigValueFrom(t *testing.T) {
+	const cert = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+	dir, err := ioutil.TempDir("", "config-value")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "cert.pem")
+	assert.NoError(t, ioutil.WriteFile(file, []byte(cert), 0600))
+
+	value, err := readConfigValueFrom(file, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, cert, value)
+
+	value, err = readConfigValueFrom("", true, strings.NewReader(cert))
+	assert.NoError(t, err)
+	assert.Equal(t, cert, value)
+
+	_, err = readConfigValueFrom(file, true, strings.NewReader(cert))
+	assert.Error(t, err)
+	_, err = readConfigValueFrom(filepath.Join(dir, "missing.pem"), false, nil)
+	assert.Error(t, err)
+}
+
+func TestAddInheritedConfig(t *testing.T) {
+	proj := &workspace.Project{
+		Name:    "proj",
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+		ConfigEnv: map[string]workspace.ConfigEnvVar{
+			"fromStack":   {Env: "PULUMI_TEST_FROM_STACK"},
+			"fromEnv":     {Env: "PULUMI_TEST_FROM_ENV"},
+			"secret":      {Env: "PULUMI_TEST_SECRET", Secret: true},
+			"fromDefault": {Env: "PULUMI_TEST_UNSET"},
+		},
+		ConfigDefaults: map[string]config.Value{
+			"fromStack":   config.NewValue("default"),
+			"fromEnv":     config.NewValue("default"),
+			"fromDefault": config.NewValue("default"),
+		},
+	}
+	for k, v := range map[string]string{
+		"PULUMI_TEST_FROM_STACK": "env",
+		"PULUMI_TEST_FROM_ENV":   "env",
+		"PULUMI_TEST_SECRET":     "hunter2",
+	} {
+		assert.NoError(t, os.Setenv(k, v))
+		defer func(k string) { assert.NoError(t, os.Unsetenv(k)) }(k)
+	}
+	assert.NoError(t, os.Unsetenv("PULUMI_TEST_UNSET"))
+
+	// Values set by the stack win over those from the environment, which win over the project's defaults.  Secrets
+	// from the environment are encrypted.
+	cfg, err := addInheritedConfig(config.Map{
+		config.MustMakeKey("proj", "fromStack"): config.NewValue("stack"),
+	}, proj, config.NopEncrypter)
+	assert.NoError(t, err)
+	assert.Equal(t, config.Map{
+		config.MustMakeKey("proj", "fromStack"):   config.NewValue("stack"),
+		config.MustMakeKey("proj", "fromEnv"):     config.NewValue("env"),
+		config.MustMakeKey("proj", "secret"):      config.NewSecureValue("hunter2"),
+		config.MustMakeKey("proj", "fromDefault"): config.NewValue("default"),
+	}, cfg)
+	assert.True(t, cfg.HasSecureValue())
+
+	// Stacks without configuration of their own inherit all of the values.
+	cfg, err = addInheritedConfig(nil, proj, config.NopEncrypter)
+	assert.NoError(t, err)
+	assert.Equal(t, config.NewValue("env"), cfg[config.MustMakeKey("proj", "fromStack")])
+}
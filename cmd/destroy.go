@@ -25,6 +25,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/result"
 )
@@ -44,10 +46,12 @@ func newDestroyCmd() *cobra.Command {
 	var showReplacementSteps bool
 	var showSames bool
 	var skipPreview bool
+	var skipResourceHooks bool
 	var suppressOutputs bool
 	var yes bool
 	var targets *[]string
 	var targetDependents bool
+	var excludeProtected bool
 
 	var cmd = &cobra.Command{
 		Use:        "destroy",
@@ -113,18 +117,48 @@ func newDestroyCmd() *cobra.Command {
 				return result.FromError(errors.Wrap(err, "getting stack configuration"))
 			}
 
+			ps, err := loadProjectStack(s)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "loading stack settings"))
+			}
+
 			targetUrns := []resource.URN{}
 			for _, t := range *targets {
 				targetUrns = append(targetUrns, resource.URN(t))
 			}
 
+			if excludeProtected {
+				if len(targetUrns) > 0 {
+					return result.FromError(errors.New("--exclude-protected cannot be used with --target"))
+				}
+				snap, err := s.Snapshot(commandContext())
+				if err != nil {
+					return result.FromError(err)
+				}
+				unprotected, skipped := unprotectedResources(snap)
+				if len(skipped) > 0 {
+					fmt.Printf("Skipping %d resources that are protected or that protected resources depend on:\n",
+						len(skipped))
+					for _, urn := range skipped {
+						fmt.Printf("    %s\n", urn)
+					}
+					if len(unprotected) == 0 {
+						fmt.Println("There are no unprotected resources to destroy.")
+						return nil
+					}
+					targetUrns = unprotected
+				}
+			}
+
 			opts.Engine = engine.UpdateOptions{
-				Parallel:         parallel,
-				Debug:            debug,
-				Refresh:          refresh,
-				DestroyTargets:   targetUrns,
-				TargetDependents: targetDependents,
-				UseLegacyDiff:    useLegacyDiff(),
+				Parallel:          getParallel(parallel, proj),
+				ProviderParallel:  ps.ProviderParallel,
+				Debug:             debug,
+				Refresh:           refresh,
+				DestroyTargets:    targetUrns,
+				TargetDependents:  targetDependents,
+				UseLegacyDiff:     useLegacyDiff(),
+				SkipResourceHooks: skipResourceHooks,
 			}
 
 			_, res := s.Destroy(commandContext(), backend.UpdateOperation{
@@ -137,7 +171,7 @@ func newDestroyCmd() *cobra.Command {
 				Scopes:             cancellationScopes,
 			})
 
-			if res == nil && len(*targets) == 0 {
+			if res == nil && len(targetUrns) == 0 {
 				fmt.Printf("The resources in the stack have been deleted, but the history and configuration "+
 					"associated with the stack are still maintained. \nIf you want to remove the stack "+
 					"completely, run 'pulumi stack rm %s'.\n", s.Ref())
@@ -168,6 +202,9 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows destroying of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().BoolVar(
+		&excludeProtected, "exclude-protected", false,
+		"Destroy every resource except protected resources and the resources they depend on, such as their parents")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().BoolVar(
@@ -175,7 +212,8 @@ func newDestroyCmd() *cobra.Command {
 		"Display operation as a rich diff showing the overall change")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
-		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to the project's"+
+			" 'parallel' setting, or unbounded.")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
@@ -191,6 +229,10 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&skipPreview, "skip-preview", false,
 		"Do not perform a preview before performing the destroy")
+	cmd.PersistentFlags().BoolVar(
+		&skipResourceHooks, "skip-resource-hooks", false,
+		"Destroy resources that have delete hooks without running them. Destroy does not run the program, which"+
+			" serves the hooks, so it otherwise refuses to destroy these resources")
 	cmd.PersistentFlags().BoolVar(
 		&suppressOutputs, "suppress-outputs", false,
 		"Suppress display of stack outputs (in case they contain sensitive values)")
@@ -206,3 +248,57 @@ func newDestroyCmd() *cobra.Command {
 	}
 	return cmd
 }
+
+// unprotectedResources returns the resources in the snapshot that can be destroyed without destroying any protected
+// resource, along with the resources that must be kept.  A resource must be kept if it is protected or if a kept
+// resource depends on it, is parented by it, or is managed by it as a provider.
+func unprotectedResources(snap *deploy.Snapshot) ([]resource.URN, []resource.URN) {
+	if snap == nil {
+		return nil, nil
+	}
+
+	byURN := make(map[resource.URN]*resource.State)
+	var worklist []*resource.State
+	for _, res := range snap.Resources {
+		if res.Delete {
+			continue
+		}
+		byURN[res.URN] = res
+		if res.Protect {
+			worklist = append(worklist, res)
+		}
+	}
+
+	kept := make(map[resource.URN]bool)
+	for len(worklist) > 0 {
+		res := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+		if kept[res.URN] {
+			continue
+		}
+		kept[res.URN] = true
+
+		deps := append([]resource.URN{res.Parent}, res.Dependencies...)
+		if ref, err := providers.ParseReference(res.Provider); err == nil {
+			deps = append(deps, ref.URN())
+		}
+		for _, dep := range deps {
+			if d, has := byURN[dep]; has && !kept[dep] {
+				worklist = append(worklist, d)
+			}
+		}
+	}
+
+	var unprotected, skipped []resource.URN
+	for _, res := range snap.Resources {
+		if res.Delete {
+			continue
+		}
+		if kept[res.URN] {
+			skipped = append(skipped, res.URN)
+		} else {
+			unprotected = append(unprotected, res.URN)
+		}
+	}
+	return unprotected, skipped
+}
@@ -0,0 +1,55 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestUnprotectedResources(t *testing.T) {
+	newResource := func(typ tokens.Type, name string, parent resource.URN, protect bool,
+		deps ...resource.URN) *resource.State {
+
+		urn := resource.NewURN("dev", "proj", "", typ, tokens.QName(name))
+		return resource.NewState(typ, urn, true, false, resource.ID(name+"-id"), resource.PropertyMap{}, nil, parent,
+			protect, false, deps, nil, "", nil, false, nil, nil, nil)
+	}
+
+	stack := newResource(resource.RootStackType, "proj-dev", "", false)
+	provider := newResource("pulumi:providers:aws", "default", "", false)
+	vpc := newResource("aws:ec2/vpc:Vpc", "vpc", stack.URN, false)
+	db := newResource("aws:rds/instance:Instance", "db", stack.URN, true, vpc.URN)
+	db.Provider = string(provider.URN) + "::" + string(provider.ID)
+	app := newResource("aws:ec2/instance:Instance", "app", stack.URN, false, vpc.URN, db.URN)
+	bucket := newResource("aws:s3/bucket:Bucket", "bucket", stack.URN, false)
+
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil,
+		[]*resource.State{stack, provider, vpc, db, app, bucket}, nil)
+	unprotected, skipped := unprotectedResources(snap)
+	assert.Equal(t, []resource.URN{app.URN, bucket.URN}, unprotected)
+	assert.Equal(t, []resource.URN{stack.URN, provider.URN, vpc.URN, db.URN}, skipped)
+
+	// Without protected resources, everything can be destroyed.
+	db.Protect = false
+	unprotected, skipped = unprotectedResources(snap)
+	assert.Len(t, unprotected, 6)
+	assert.Empty(t, skipped)
+}
@@ -16,16 +16,23 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
-// newCompletionCmd returns a new command that, when run, generates a bash or zsh completion script for the CLI.
+// stackArgCommands are the commands whose argument is the name of a stack, which the completion scripts complete.
+var stackArgCommands = []string{"stack rm", "stack select"}
+
+// newCompletionCmd returns a new command that, when run, generates a bash, zsh or fish completion script for the CLI.
+// Besides commands and flags, the scripts complete the names of stacks, using `pulumi stack ls`.
 // It is hidden by default since it's not commonly used outside of our own build processes.
 func newGenCompletionCmd(root *cobra.Command) *cobra.Command {
 	return &cobra.Command{
@@ -34,11 +41,16 @@ func newGenCompletionCmd(root *cobra.Command) *cobra.Command {
 		Short:  "Generate completion scripts for the Pulumi CLI",
 		Hidden: true,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			root.BashCompletionFunction = bashCompletionFunctions()
+			markStackFlags(root)
+
 			switch {
 			case args[0] == "bash":
 				return root.GenBashCompletion(os.Stdout)
 			case args[0] == "zsh":
 				return genZshCompletion(os.Stdout, root)
+			case args[0] == "fish":
+				return genFishCompletion(os.Stdout, root)
 			default:
 				return fmt.Errorf("%q is not a supported shell", args[0])
 			}
@@ -188,7 +200,7 @@ func genZshCompletion(out io.Writer, root *cobra.Command) error {
 		return err
 	}
 
-	if _, err := fmt.Fprint(out, zshHead); err != nil {
+	if _, err := io.WriteString(out, zshHead); err != nil {
 		return err
 	}
 
@@ -199,3 +211,128 @@ func genZshCompletion(out io.Writer, root *cobra.Command) error {
 	_, err := fmt.Fprint(out, zshTail)
 	return err
 }
+
+// bashCompletionFunctions returns the functions that the bash and zsh completion scripts use to complete stack names.
+// `__custom_func` is called by the script that cobra generates when a command has no other completions.
+func bashCompletionFunctions() string {
+	var cases []string
+	for _, c := range stackArgCommands {
+		cases = append(cases, "pulumi_"+strings.Replace(c, " ", "_", -1))
+	}
+
+	return `__pulumi_get_stacks()
+{
+    local stacks
+    stacks=$(pulumi stack ls --json 2>/dev/null | sed -n 's/^ *"name": "\(.*\)",$/\1/p')
+    COMPREPLY=( $(compgen -W "${stacks}" -- "$cur") )
+}
+
+__custom_func() {
+    case ${last_command} in
+        ` + strings.Join(cases, " | ") + `)
+            __pulumi_get_stacks
+            return
+            ;;
+        *)
+            ;;
+    esac
+}
+`
+}
+
+// markStackFlags marks every --stack flag in the command tree to be completed with the names of stacks.
+func markStackFlags(cmd *cobra.Command) {
+	for _, flags := range []*pflag.FlagSet{cmd.Flags(), cmd.PersistentFlags()} {
+		if flags.Lookup("stack") != nil {
+			err := cobra.MarkFlagCustom(flags, "stack", "__pulumi_get_stacks")
+			contract.AssertNoError(err)
+		}
+	}
+	for _, c := range cmd.Commands() {
+		markStackFlags(c)
+	}
+}
+
+const fishHead = `# fish completion for pulumi
+
+# __pulumi_using_command succeeds if the subcommands on the command line, ignoring flags, are exactly its arguments.
+function __pulumi_using_command
+    set -l words
+    for word in (commandline -opc)[2..-1]
+        switch $word
+            case '-*'
+            case '*'
+                set words $words $word
+        end
+    end
+    test "$words" = "$argv"
+end
+
+function __pulumi_stacks
+    pulumi stack ls --json 2>/dev/null | string replace -rf '^\s*"name": "(.*)",$' '$1'
+end
+
+complete -c pulumi -f
+`
+
+// genFishCompletion writes a fish completion script for the subcommands and flags of every command in the tree.
+func genFishCompletion(out io.Writer, root *cobra.Command) error {
+	buf := new(bytes.Buffer)
+	buf.WriteString(fishHead)
+	writeFishCompletions(buf, root)
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+func writeFishCompletions(buf *bytes.Buffer, cmd *cobra.Command) {
+	path := strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+	condition := fishQuote(strings.TrimSpace("__pulumi_using_command " + path))
+
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() {
+			continue
+		}
+		fmt.Fprintf(buf, "complete -c pulumi -n %s -a %s -d %s\n", condition, c.Name(), fishQuote(c.Short))
+	}
+
+	for _, c := range stackArgCommands {
+		if c == path {
+			fmt.Fprintf(buf, "complete -c pulumi -n %s -a '(__pulumi_stacks)'\n", condition)
+		}
+	}
+
+	writeFlag := func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		fmt.Fprintf(buf, "complete -c pulumi -n %s -l %s", condition, flag.Name)
+		if flag.Shorthand != "" {
+			fmt.Fprintf(buf, " -s %s", flag.Shorthand)
+		}
+		if flag.Value.Type() != "bool" {
+			buf.WriteString(" -r")
+			if flag.Name == "stack" {
+				buf.WriteString(" -a '(__pulumi_stacks)'")
+			}
+		}
+		fmt.Fprintf(buf, " -d %s\n", fishQuote(flag.Usage))
+	}
+	cmd.LocalFlags().VisitAll(writeFlag)
+	cmd.InheritedFlags().VisitAll(writeFlag)
+
+	for _, c := range cmd.Commands() {
+		if c.IsAvailableCommand() {
+			writeFishCompletions(buf, c)
+		}
+	}
+}
+
+// fishQuote quotes the first line of s as a single-quoted fish string.
+func fishQuote(s string) string {
+	if i := strings.Index(s, "\n"); i != -1 {
+		s = s[:i]
+	}
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, "'", `\'`, -1)
+	return "'" + s + "'"
+}
@@ -0,0 +1,50 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFishQuote(t *testing.T) {
+	assert.Equal(t, `'Manage stacks'`, fishQuote("Manage stacks"))
+	assert.Equal(t, `'Cancel a stack\'s update'`, fishQuote("Cancel a stack's update\nMore detail"))
+	assert.Equal(t, `'a\\b'`, fishQuote(`a\b`))
+}
+
+func TestGenFishCompletion(t *testing.T) {
+	root := &cobra.Command{Use: "pulumi"}
+	stack := &cobra.Command{Use: "stack", Short: "Manage stacks"}
+	sel := &cobra.Command{Use: "select", Short: "Switch the current workspace", Run: func(*cobra.Command, []string) {}}
+	sel.Flags().BoolP("create", "c", false, "Create the stack")
+	stack.PersistentFlags().StringP("stack", "s", "", "The name of the stack to operate on")
+	stack.AddCommand(sel)
+	root.AddCommand(stack)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, genFishCompletion(buf, root))
+
+	out := buf.String()
+	assert.Contains(t, out, "complete -c pulumi -n '__pulumi_using_command' -a stack -d 'Manage stacks'\n")
+	assert.Contains(t, out, "complete -c pulumi -n '__pulumi_using_command stack select' -a '(__pulumi_stacks)'\n")
+	assert.Contains(t, out,
+		"complete -c pulumi -n '__pulumi_using_command stack select' -l create -s c -d 'Create the stack'\n")
+	assert.Contains(t, out, "complete -c pulumi -n '__pulumi_using_command stack select' -l stack -s s -r "+
+		"-a '(__pulumi_stacks)' -d 'The name of the stack to operate on'\n")
+}
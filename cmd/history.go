@@ -37,6 +37,7 @@ func newHistoryCmd() *cobra.Command {
 	var stack string
 	var jsonOut bool
 	var showSecrets bool
+	var diffRange string
 	var cmd = &cobra.Command{
 		Use:        "history",
 		Aliases:    []string{"hist"},
@@ -44,7 +45,11 @@ func newHistoryCmd() *cobra.Command {
 		Short:      "[PREVIEW] Update history for a stack",
 		Long: `Update history for a stack
 
-This command lists data about previous updates for a stack.`,
+This command lists data about previous updates for a stack.
+
+Pass --diff FROM..TO to instead show the resources and properties that changed between two
+versions of the stack, e.g. --diff 3..7.  If TO is omitted, the latest version is used.  Secret
+values are never displayed.`,
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
@@ -59,6 +64,9 @@ This command lists data about previous updates for a stack.`,
 			if err != nil {
 				return errors.Wrap(err, "getting history")
 			}
+			if diffRange != "" {
+				return runHistoryDiff(s, updates, diffRange, jsonOut, opts)
+			}
 			var decrypter config.Decrypter
 			if showSecrets {
 				crypter, err := getStackDencrypter(s)
@@ -75,7 +83,7 @@ This command lists data about previous updates for a stack.`,
 			return displayUpdatesConsole(updates, opts)
 		}),
 	}
-	cmd.PersistentFlags().StringVarP(
+	cmd.Flags().StringVarP(
 		&stack, "stack", "s", "",
 		"Choose a stack other than the currently selected one")
 	cmd.Flags().BoolVar(
@@ -83,6 +91,11 @@ This command lists data about previous updates for a stack.`,
 		"Show secret values when listing config instead of displaying blinded values")
 	cmd.PersistentFlags().BoolVarP(
 		&jsonOut, "json", "j", false, "Emit output as JSON")
+	cmd.Flags().StringVar(
+		&diffRange, "diff", "",
+		"Show the changes between two versions of the stack, given as FROM..TO")
+
+	cmd.AddCommand(newHistoryPruneCmd())
 	return cmd
 }
 
@@ -95,6 +108,7 @@ type updateInfoJSON struct {
 	Environment map[string]string          `json:"environment"`
 	Config      map[string]configValueJSON `json:"config"`
 	Result      string                     `json:"result,omitempty"`
+	Version     int                        `json:"version,omitempty"`
 
 	// These values are only present once the update finishes
 	EndTime         *string         `json:"endTime,omitempty"`
@@ -137,6 +151,7 @@ func displayUpdatesJSON(updates []backend.UpdateInfo, decrypter config.Decrypter
 			info.Config[k.String()] = configValue
 		}
 		info.Result = string(update.Result)
+		info.Version = update.Version
 		if update.Result != backend.InProgressResult {
 			info.EndTime = makeStringRef(time.Unix(update.EndTime, 0).UTC().Format(timeFormat))
 			resourceChanges := make(map[string]int)
@@ -164,6 +179,9 @@ func displayUpdatesConsole(updates []backend.UpdateInfo, opts display.Options) e
 
 	for _, update := range updates {
 
+		if update.Version != 0 {
+			fmt.Printf("Version: %v\n", update.Version)
+		}
 		fmt.Printf("UpdateKind: %v\n", update.Kind)
 		if update.Result == "succeeded" {
 			fmt.Print(opts.Color.Colorize(fmt.Sprintf("%sStatus: %v%s\n", colors.Green, update.Result, colors.Reset)))
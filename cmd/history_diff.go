@@ -0,0 +1,272 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+)
+
+// redactedSecret is displayed in place of any value that is or contains a secret.
+const redactedSecret = "[secret]"
+
+// historyDiffJSON is the shape of the --json output of `pulumi history --diff`.  While we can add fields to this
+// structure in the future, we should not change existing fields.
+type historyDiffJSON struct {
+	From      int                   `json:"from"`
+	To        int                   `json:"to"`
+	Resources []resourceDiffJSON    `json:"resources"`
+	Summary   map[deploy.StepOp]int `json:"summary"`
+}
+
+// resourceDiffJSON describes how a single resource differs between two versions of a stack.
+type resourceDiffJSON struct {
+	URN        string             `json:"urn"`
+	Type       string             `json:"type"`
+	Op         deploy.StepOp      `json:"op"`
+	Properties []propertyDiffJSON `json:"properties,omitempty"`
+}
+
+// propertyDiffJSON describes how a single property of a resource differs between two versions of a stack.  Values
+// that are or contain secrets are redacted.
+type propertyDiffJSON struct {
+	Path string        `json:"path"`
+	Op   deploy.StepOp `json:"op"`
+	Old  interface{}   `json:"old,omitempty"`
+	New  interface{}   `json:"new,omitempty"`
+}
+
+// parseHistoryDiffRange parses a range of the form "FROM..TO" or "FROM".  If TO is omitted, latest is used.
+func parseHistoryDiffRange(s string, latest int) (int, int, error) {
+	parts := strings.SplitN(s, "..", 2)
+	from, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid version '%s'; expected a range of the form FROM..TO", parts[0])
+	}
+	to := latest
+	if len(parts) == 2 && parts[1] != "" {
+		if to, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, errors.Errorf("invalid version '%s'; expected a range of the form FROM..TO", parts[1])
+		}
+	}
+	if from < 1 || to < 1 || from > latest || to > latest {
+		return 0, 0, errors.Errorf("versions must be between 1 and %d", latest)
+	}
+	return from, to, nil
+}
+
+// runHistoryDiff diffs two versions of the given stack and displays the result.
+func runHistoryDiff(s backend.Stack, updates []backend.UpdateInfo, rng string, jsonOut bool,
+	opts display.Options) error {
+
+	latest := 0
+	for _, u := range updates {
+		if u.Version > latest {
+			latest = u.Version
+		}
+	}
+	if latest == 0 {
+		return errors.New("stack has never been updated")
+	}
+	from, to, err := parseHistoryDiffRange(rng, latest)
+	if err != nil {
+		return err
+	}
+
+	loadSnapshot := func(version int) (*deploy.Snapshot, error) {
+		deployment, err := s.Backend().ExportDeploymentVersion(commandContext(), s, version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "exporting version %d", version)
+		}
+		snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading version %d", version)
+		}
+		return snap, nil
+	}
+	fromSnap, err := loadSnapshot(from)
+	if err != nil {
+		return err
+	}
+	toSnap, err := loadSnapshot(to)
+	if err != nil {
+		return err
+	}
+
+	diff := diffSnapshots(fromSnap, toSnap)
+	diff.From, diff.To = from, to
+	if jsonOut {
+		return printJSON(diff)
+	}
+	displayHistoryDiff(diff, opts)
+	return nil
+}
+
+// diffSnapshots computes the differences between the resources in two snapshots.  Resources are matched by URN.
+func diffSnapshots(old, new *deploy.Snapshot) historyDiffJSON {
+	olds, news := make(map[resource.URN]*resource.State), make(map[resource.URN]*resource.State)
+	var urns []resource.URN
+	for _, res := range old.Resources {
+		if _, has := olds[res.URN]; !has {
+			urns = append(urns, res.URN)
+		}
+		olds[res.URN] = res
+	}
+	for _, res := range new.Resources {
+		if _, has := news[res.URN]; !has {
+			if _, has := olds[res.URN]; !has {
+				urns = append(urns, res.URN)
+			}
+		}
+		news[res.URN] = res
+	}
+	sort.Slice(urns, func(i, j int) bool { return urns[i] < urns[j] })
+
+	result := historyDiffJSON{Resources: []resourceDiffJSON{}, Summary: make(map[deploy.StepOp]int)}
+	for _, urn := range urns {
+		o, n := olds[urn], news[urn]
+		rd := resourceDiffJSON{URN: string(urn), Type: string(urn.Type())}
+		switch {
+		case o == nil:
+			rd.Op = deploy.OpCreate
+		case n == nil:
+			rd.Op = deploy.OpDelete
+		default:
+			if o.ID != n.ID {
+				rd.Properties = append(rd.Properties, propertyDiffJSON{
+					Path: "id", Op: deploy.OpUpdate, Old: string(o.ID), New: string(n.ID),
+				})
+			}
+			if diff := o.Outputs.Diff(n.Outputs); diff != nil {
+				rd.Properties = append(rd.Properties, flattenObjectDiff("", diff)...)
+			}
+			if len(rd.Properties) == 0 {
+				continue
+			}
+			rd.Op = deploy.OpUpdate
+		}
+		result.Resources = append(result.Resources, rd)
+		result.Summary[rd.Op]++
+	}
+	return result
+}
+
+// flattenObjectDiff turns a structured diff into a list of changes to individual property paths.
+func flattenObjectDiff(prefix string, diff *resource.ObjectDiff) []propertyDiffJSON {
+	var props []propertyDiffJSON
+	for _, k := range diff.Keys() {
+		path := string(k)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if v, has := diff.Adds[k]; has {
+			props = append(props, propertyDiffJSON{Path: path, Op: deploy.OpCreate, New: redactedValue(v)})
+		} else if v, has := diff.Deletes[k]; has {
+			props = append(props, propertyDiffJSON{Path: path, Op: deploy.OpDelete, Old: redactedValue(v)})
+		} else if update, has := diff.Updates[k]; has {
+			props = append(props, flattenValueDiff(path, update)...)
+		}
+	}
+	return props
+}
+
+func flattenValueDiff(path string, diff resource.ValueDiff) []propertyDiffJSON {
+	// Never descend into secrets, lest the structure of the diff reveal something about their contents.
+	if diff.Old.ContainsSecrets() || diff.New.ContainsSecrets() {
+		return []propertyDiffJSON{{Path: path, Op: deploy.OpUpdate, Old: redactedSecret, New: redactedSecret}}
+	}
+
+	switch {
+	case diff.Object != nil:
+		return flattenObjectDiff(path, diff.Object)
+	case diff.Array != nil:
+		var props []propertyDiffJSON
+		for i := 0; i < diff.Array.Len(); i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			if v, has := diff.Array.Adds[i]; has {
+				props = append(props, propertyDiffJSON{Path: elemPath, Op: deploy.OpCreate, New: redactedValue(v)})
+			} else if v, has := diff.Array.Deletes[i]; has {
+				props = append(props, propertyDiffJSON{Path: elemPath, Op: deploy.OpDelete, Old: redactedValue(v)})
+			} else if update, has := diff.Array.Updates[i]; has {
+				props = append(props, flattenValueDiff(elemPath, update)...)
+			}
+		}
+		return props
+	default:
+		return []propertyDiffJSON{{
+			Path: path, Op: deploy.OpUpdate, Old: redactedValue(diff.Old), New: redactedValue(diff.New),
+		}}
+	}
+}
+
+// redactedValue returns a plain representation of a property value, replacing it entirely if it contains secrets.
+func redactedValue(v resource.PropertyValue) interface{} {
+	if v.ContainsSecrets() {
+		return redactedSecret
+	}
+	return v.Mappable()
+}
+
+func displayHistoryDiff(diff historyDiffJSON, opts display.Options) {
+	fmt.Printf("Changes from version %d to version %d:\n", diff.From, diff.To)
+	if len(diff.Resources) == 0 {
+		fmt.Println("    no changes")
+		return
+	}
+
+	formatValue := func(v interface{}) string {
+		if s, ok := v.(string); ok && s == redactedSecret {
+			return s
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	}
+
+	for _, res := range diff.Resources {
+		color, sign := res.Op.Color(), res.Op.RawPrefix()
+		fmt.Print(opts.Color.Colorize(fmt.Sprintf("%s%s %s%s\n", color, sign, res.URN, colors.Reset)))
+		for _, prop := range res.Properties {
+			color, sign := prop.Op.Color(), prop.Op.RawPrefix()
+			var value string
+			switch prop.Op {
+			case deploy.OpCreate:
+				value = formatValue(prop.New)
+			case deploy.OpDelete:
+				value = formatValue(prop.Old)
+			default:
+				value = fmt.Sprintf("%s => %s", formatValue(prop.Old), formatValue(prop.New))
+			}
+			fmt.Print(opts.Color.Colorize(fmt.Sprintf("    %s%s %s: %s%s\n", color, sign, prop.Path, value, colors.Reset)))
+		}
+	}
+
+	fmt.Printf("%d added, %d removed, %d changed\n",
+		diff.Summary[deploy.OpCreate], diff.Summary[deploy.OpDelete], diff.Summary[deploy.OpUpdate])
+}
@@ -0,0 +1,92 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestParseHistoryDiffRange(t *testing.T) {
+	from, to, err := parseHistoryDiffRange("2..5", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, from)
+	assert.Equal(t, 5, to)
+
+	from, to, err = parseHistoryDiffRange("3", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, from)
+	assert.Equal(t, 7, to)
+
+	_, _, err = parseHistoryDiffRange("3..8", 7)
+	assert.Error(t, err)
+	_, _, err = parseHistoryDiffRange("a..b", 7)
+	assert.Error(t, err)
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	urn := func(name string) resource.URN {
+		return resource.NewURN("stack", "proj", "", tokens.Type("pkg:m:typ"), tokens.QName(name))
+	}
+	state := func(name string, outputs resource.PropertyMap) *resource.State {
+		return &resource.State{URN: urn(name), Type: "pkg:m:typ", ID: resource.ID(name), Outputs: outputs}
+	}
+
+	old := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{
+		state("same", resource.PropertyMap{"a": resource.NewStringProperty("a")}),
+		state("changed", resource.PropertyMap{
+			"a":      resource.NewStringProperty("a"),
+			"b":      resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(1)}),
+			"gone":   resource.NewBoolProperty(true),
+			"secret": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		}),
+		state("deleted", resource.PropertyMap{}),
+	}, nil)
+	new := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{
+		state("same", resource.PropertyMap{"a": resource.NewStringProperty("a")}),
+		state("changed", resource.PropertyMap{
+			"a":      resource.NewStringProperty("z"),
+			"b":      resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(2)}),
+			"secret": resource.MakeSecret(resource.NewStringProperty("hunter3")),
+			"added":  resource.NewStringProperty("new"),
+		}),
+		state("created", resource.PropertyMap{}),
+	}, nil)
+
+	diff := diffSnapshots(old, new)
+	assert.Equal(t, 1, diff.Summary[deploy.OpCreate])
+	assert.Equal(t, 1, diff.Summary[deploy.OpDelete])
+	assert.Equal(t, 1, diff.Summary[deploy.OpUpdate])
+	assert.Len(t, diff.Resources, 3)
+
+	changed := diff.Resources[0]
+	assert.Equal(t, string(urn("changed")), changed.URN)
+	assert.Equal(t, []propertyDiffJSON{
+		{Path: "a", Op: deploy.OpUpdate, Old: "a", New: "z"},
+		{Path: "added", Op: deploy.OpCreate, New: "new"},
+		{Path: "b[0]", Op: deploy.OpUpdate, Old: float64(1), New: float64(2)},
+		{Path: "gone", Op: deploy.OpDelete, Old: true},
+		{Path: "secret", Op: deploy.OpUpdate, Old: redactedSecret, New: redactedSecret},
+	}, changed.Properties)
+	assert.Equal(t, string(urn("created")), diff.Resources[1].URN)
+	assert.Equal(t, deploy.OpCreate, diff.Resources[1].Op)
+	assert.Equal(t, string(urn("deleted")), diff.Resources[2].URN)
+	assert.Equal(t, deploy.OpDelete, diff.Resources[2].Op)
+}
@@ -0,0 +1,115 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func newHistoryPruneCmd() *cobra.Command {
+	var stack string
+	var keep int
+	var olderThan string
+	var yes bool
+	var cmd = &cobra.Command{
+		Use:   "prune",
+		Short: "Remove old update history and checkpoint backups from a self-managed backend",
+		Long: "Remove old update history and checkpoint backups from a self-managed backend\n" +
+			"\n" +
+			"This command removes update history entries, and the checkpoints and backups saved alongside\n" +
+			"them, that fall outside of a retention policy.  Pass --keep to retain only the given number\n" +
+			"of most recent entries, and/or --older-than to remove entries older than the given age (for\n" +
+			"example, 720h or 30d).  The most recent entry is always kept.\n" +
+			"\n" +
+			"If neither flag is passed, the policy configured by the " + filestate.RetainHistoryCountEnvVar + "\n" +
+			"and " + filestate.RetainHistoryAgeEnvVar + " environment variables is used.  When these are set,\n" +
+			"history is also pruned automatically after each update.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			var policy filestate.RetentionPolicy
+			if keep != 0 || olderThan != "" {
+				if keep < 0 {
+					return result.Error("--keep must be a positive number")
+				}
+				policy.Count = keep
+				if olderThan != "" {
+					age, err := filestate.ParseRetentionAge(olderThan)
+					if err != nil {
+						return result.FromError(err)
+					}
+					policy.MaxAge = age
+				}
+			} else {
+				envPolicy, err := filestate.RetentionPolicyFromEnv()
+				if err != nil {
+					return result.FromError(err)
+				}
+				policy = envPolicy
+			}
+			if policy.IsZero() {
+				return result.Error("no retention policy given; pass --keep and/or --older-than")
+			}
+
+			s, err := requireStack(stack, false /*offerNew */, opts, false /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+			b, ok := s.Backend().(filestate.Backend)
+			if !ok {
+				return result.FromError(errors.Errorf(
+					"history for stack '%s' is managed by %s and cannot be pruned", s.Ref(), s.Backend().Name()))
+			}
+
+			prompt := fmt.Sprintf("This will permanently remove update history from the '%s' stack!", s.Ref())
+			if !yes && !confirmPrompt(prompt, s.Ref().String(), opts) {
+				fmt.Println("confirmation declined")
+				return result.Bail()
+			}
+
+			history, backups, err := b.PruneHistory(commandContext(), s.Ref(), policy)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "pruning history"))
+			}
+			fmt.Printf("Removed %d update history entries and %d checkpoint backups from stack '%s'\n",
+				history, backups, s.Ref())
+			return nil
+		}),
+	}
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"Choose a stack other than the currently selected one")
+	cmd.PersistentFlags().IntVar(
+		&keep, "keep", 0,
+		"The number of most recent update history entries to keep")
+	cmd.PersistentFlags().StringVar(
+		&olderThan, "older-than", "",
+		"Remove update history entries older than the given age, e.g. 720h or 30d")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with pruning anyway")
+	return cmd
+}
@@ -74,6 +74,13 @@ func newLoginCmd() *cobra.Command {
 			"\n" +
 			"    $ pulumi login s3://my-pulumi-state-bucket\n" +
 			"\n" +
+			"S3 URLs accept `region`, `endpoint` and `s3ForcePathStyle` query parameters, for S3-compatible stores\n" +
+			"such as MinIO, and `sse` and `sseKmsKeyId` parameters to encrypt the state with a KMS key. Each may also\n" +
+			"be set with a PULUMI_BACKEND_S3_* environment variable. For instance,\n" +
+			"\n" +
+			"    $ pulumi login 's3://my-pulumi-state-bucket?endpoint=minio.local:9000&s3ForcePathStyle=true'\n" +
+			"    $ pulumi login 's3://my-pulumi-state-bucket?sseKmsKeyId=alias/pulumi-state'\n" +
+			"\n" +
 			"GCP GCS:\n" +
 			"\n" +
 			"    $ pulumi login gs://my-pulumi-state-bucket\n" +
@@ -105,14 +105,7 @@ func newLogsCmd() *cobra.Command {
 
 					for _, logEntry := range logs {
 						if _, shownAlready := shown[logEntry]; !shownAlready {
-							eventTime := time.Unix(0, logEntry.Timestamp*1000000)
-
-							entries = append(entries, logEntryJSON{
-								ID:        logEntry.ID,
-								Timestamp: eventTime.UTC().Format(timeFormat),
-								Message:   logEntry.Message,
-							})
-
+							entries = append(entries, makeLogEntryJSON(logEntry))
 							shown[logEntry] = true
 						}
 					}
@@ -122,20 +115,12 @@ func newLogsCmd() *cobra.Command {
 
 				for _, logEntry := range logs {
 					if _, shownAlready := shown[logEntry]; !shownAlready {
-						eventTime := time.Unix(0, logEntry.Timestamp*1000000)
-
 						if !jsonOut {
+							eventTime := time.Unix(0, logEntry.Timestamp*1000000)
 							fmt.Printf("%30.30s[%30.30s] %v\n", eventTime.Format(timeFormat),
 								logEntry.ID, logEntry.Message)
-						} else {
-							err = printJSON(logEntryJSON{
-								ID:        logEntry.ID,
-								Timestamp: eventTime.UTC().Format(timeFormat),
-								Message:   logEntry.Message,
-							})
-							if err != nil {
-								return err
-							}
+						} else if err = printJSON(makeLogEntryJSON(logEntry)); err != nil {
+							return err
 						}
 
 						shown[logEntry] = true
@@ -194,6 +179,18 @@ func parseSince(since string, reference time.Time) (*time.Time, error) {
 // at top level.
 type logEntryJSON struct {
 	ID        string
+	URN       string `json:",omitempty"`
+	Stream    string `json:",omitempty"`
 	Timestamp string
 	Message   string
 }
+
+func makeLogEntryJSON(logEntry operations.LogEntry) logEntryJSON {
+	return logEntryJSON{
+		ID:        logEntry.ID,
+		URN:       string(logEntry.URN),
+		Stream:    logEntry.Stream,
+		Timestamp: time.Unix(0, logEntry.Timestamp*1000000).UTC().Format(timeFormat),
+		Message:   logEntry.Message,
+	}
+}
@@ -15,11 +15,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/operations"
 )
 
 func TestParseSince(t *testing.T) {
@@ -46,3 +49,21 @@ func TestParseSince(t *testing.T) {
 	f, _ := parseSince("2006-01-02-08:00", time.Now().In(pst))
 	assert.Equal(t, "2006-01-02T00:00:00-08:00", f.In(pst).Format(time.RFC3339))
 }
+
+func TestLogEntryJSON(t *testing.T) {
+	b, err := json.Marshal(makeLogEntryJSON(operations.LogEntry{
+		ID:        "fn",
+		URN:       "urn:pulumi:dev::proj::aws:lambda/function:Function::fn",
+		Stream:    "2019/11/20/[$LATEST]abc",
+		Timestamp: 1574208000123,
+		Message:   "hello",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ID":"fn","URN":"urn:pulumi:dev::proj::aws:lambda/function:Function::fn",`+
+		`"Stream":"2019/11/20/[$LATEST]abc","Timestamp":"2019-11-20T00:00:00.123Z","Message":"hello"}`, string(b))
+
+	// Entries that cannot be attributed to a resource or stream omit those fields.
+	b, err = json.Marshal(makeLogEntryJSON(operations.LogEntry{ID: "fn", Message: "hello"}))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ID":"fn","Timestamp":"1970-01-01T00:00:00.000Z","Message":"hello"}`, string(b))
+}
@@ -59,6 +59,7 @@ type newArgs struct {
 	name              string
 	offline           bool
 	prompt            promptForValueFunc
+	secretConfigArray []string
 	secretsProvider   string
 	stack             string
 	templateNameOrURL string
@@ -255,7 +256,8 @@ func runNew(args newArgs) error {
 
 	// Prompt for config values (if needed) and save.
 	if !args.generateOnly {
-		err = handleConfig(s, args.templateNameOrURL, template, args.configArray, args.yes, args.configPath, opts)
+		err = handleConfig(s, args.templateNameOrURL, template, args.configArray, args.secretConfigArray, args.yes,
+			args.configPath, opts)
 		if err != nil {
 			return err
 		}
@@ -387,6 +389,9 @@ func newNewCmd() *cobra.Command {
 	cmd.PersistentFlags().StringArrayVarP(
 		&args.configArray, "config", "c", []string{},
 		"Config to save")
+	cmd.PersistentFlags().StringArrayVar(
+		&args.secretConfigArray, "config-secret", []string{},
+		"Secret config to save, encrypted with the stack's secrets provider")
 	cmd.PersistentFlags().BoolVar(
 		&args.configPath, "config-path", false,
 		"Config keys contain a path to a property in a map or list to set")
@@ -779,6 +784,31 @@ func parseConfig(configArray []string, path bool) (config.Map, error) {
 	return configMap, nil
 }
 
+// parseSecretConfig parses the secret config values passed via command line flags, in the same format as those
+// accepted by parseConfig, encrypts them with the given encrypter, and adds them to configMap.
+func parseSecretConfig(configMap config.Map, configArray []string, path bool, encrypter config.Encrypter) error {
+	for _, c := range configArray {
+		kvp := strings.SplitN(c, "=", 2)
+
+		key, err := parseConfigKey(kvp[0])
+		if err != nil {
+			return err
+		}
+		if len(kvp) != 2 {
+			return errors.Errorf("missing value for secret config '%s'; expected KEY=VALUE", kvp[0])
+		}
+
+		enc, err := encrypter.EncryptValue(kvp[1])
+		if err != nil {
+			return err
+		}
+		if err = configMap.Set(key, config.NewSecureValue(enc), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // promptForConfig will go through each config key needed by the template and prompt for a value.
 // If a config value exists in commandLineConfig, it will be used without prompting.
 // If stackConfig is non-nil and a config value exists in stackConfig, it will be used as the default
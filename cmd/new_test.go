@@ -721,6 +721,29 @@ func TestSetFail(t *testing.T) {
 	}
 }
 
+func TestParseSecretConfig(t *testing.T) {
+	crypter := config.NewSymmetricCrypter(make([]byte, 32))
+
+	c, err := parseConfig([]string{"my:name=value", "my:obj.a=1"}, true /*path*/)
+	assert.NoError(t, err)
+	err = parseSecretConfig(c, []string{"my:password=hunter2", "my:obj.b=2"}, true /*path*/, crypter)
+	assert.NoError(t, err)
+
+	password := c[config.MustMakeKey("my", "password")]
+	assert.True(t, password.Secure())
+	v, err := password.Value(crypter)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", v)
+
+	obj := c[config.MustMakeKey("my", "obj")]
+	assert.True(t, obj.Secure())
+	v, err = obj.Value(crypter)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":1,"b":"2"}`, v)
+
+	assert.Error(t, parseSecretConfig(c, []string{"my:password"}, false /*path*/, crypter))
+}
+
 const projectName = "test_project"
 const stackName = "test_stack"
 
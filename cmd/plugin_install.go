@@ -25,6 +25,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/workspace"
@@ -150,7 +151,7 @@ func newPluginInstallCmd() *cobra.Command {
 							diag.Message("", "%s downloading from %s"), label, install.ServerURL)
 					}
 					var size int64
-					if tarball, size, err = install.Download(); err != nil {
+					if tarball, size, err = plugin.DownloadPlugin(install); err != nil {
 						return errors.Wrapf(err, "%s downloading from %s", label, install.ServerURL)
 					}
 					tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", displayOpts.Color)
@@ -21,6 +21,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/result"
 )
@@ -44,6 +46,11 @@ func newPreviewCmd() *cobra.Command {
 	var showSames bool
 	var showReads bool
 	var suppressOutputs bool
+	var targets []string
+	var replaces []string
+	var targetReplaces []string
+	var targetDependents bool
+	var savePlanPath string
 
 	var cmd = &cobra.Command{
 		Use:        "preview",
@@ -68,12 +75,29 @@ func newPreviewCmd() *cobra.Command {
 				displayType = display.DisplayDiff
 			}
 
+			targetURNs := []resource.URN{}
+			for _, t := range targets {
+				targetURNs = append(targetURNs, resource.URN(t))
+			}
+
+			replaceURNs := []resource.URN{}
+			for _, r := range replaces {
+				replaceURNs = append(replaceURNs, resource.URN(r))
+			}
+
+			for _, tr := range targetReplaces {
+				targetURNs = append(targetURNs, resource.URN(tr))
+				replaceURNs = append(replaceURNs, resource.URN(tr))
+			}
+
 			opts := backend.UpdateOptions{
 				Engine: engine.UpdateOptions{
 					LocalPolicyPackPaths: policyPackPaths,
-					Parallel:             parallel,
 					Debug:                debug,
 					UseLegacyDiff:        useLegacyDiff(),
+					ReplaceTargets:       replaceURNs,
+					UpdateTargets:        targetURNs,
+					TargetDependents:     targetDependents,
 				},
 				Display: display.Options{
 					Color:                cmdutil.GetGlobalColorization(),
@@ -120,6 +144,23 @@ func newPreviewCmd() *cobra.Command {
 				return result.FromError(errors.Wrap(err, "getting stack configuration"))
 			}
 
+			ps, err := loadProjectStack(s)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "loading stack settings"))
+			}
+			if opts.Engine.DefaultTimeouts, err = deploy.NewDefaultTimeouts(ps.Timeouts); err != nil {
+				return result.FromError(err)
+			}
+			opts.Engine.Parallel = getParallel(parallel, proj)
+			opts.Engine.ProviderParallel = ps.ProviderParallel
+			if opts.Engine.Naming, err = namingPolicy(proj, ps, s); err != nil {
+				return result.FromError(err)
+			}
+
+			if savePlanPath != "" {
+				opts.Engine.SavePlan = deploy.NewSavedPlan()
+			}
+
 			changes, res := s.Preview(commandContext(), backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
@@ -130,11 +171,18 @@ func newPreviewCmd() *cobra.Command {
 				Scopes:             cancellationScopes,
 			})
 
+			if res == nil && savePlanPath != "" {
+				if err = writeSavedPlan(savePlanPath, opts.Engine.SavePlan); err != nil {
+					return result.FromError(err)
+				}
+			}
+
 			switch {
 			case res != nil:
 				return PrintEngineResult(res)
 			case expectNop && changes != nil && changes.HasChanges():
-				return result.FromError(errors.New("error: no changes were expected but changes were proposed"))
+				return result.FromError(cmdutil.ErrorWithExitCode(
+					errors.New("error: no changes were expected but changes were proposed"), cmdutil.ExitCodeChanges))
 			default:
 				return nil
 			}
@@ -164,6 +212,25 @@ func newPreviewCmd() *cobra.Command {
 		&message, "message", "m", "",
 		"Optional message to associate with the preview operation")
 
+	cmd.PersistentFlags().StringArrayVarP(
+		&targets, "target", "t", []string{},
+		"Specify a single resource URN to preview updating. Other resources will not be previewed."+
+			" Multiple resources can be specified using --target urn1 --target urn2")
+	cmd.PersistentFlags().StringArrayVar(
+		&replaces, "replace", []string{},
+		"Specify resources to preview replacing."+
+			" Multiple resources can be specified using --replace urn1 --replace urn2")
+	cmd.PersistentFlags().StringArrayVar(
+		&targetReplaces, "target-replace", []string{},
+		"Specify a single resource URN to preview replacing. Other resources will not be previewed."+
+			" Shorthand for --target urn --replace urn.")
+	cmd.PersistentFlags().BoolVar(
+		&targetDependents, "target-dependents", false,
+		"Allows previewing updates of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().StringVar(
+		&savePlanPath, "save-plan", "",
+		"Save the steps that the preview proposes to a file, for `pulumi up --plan` to apply")
+
 	// Flags for engine.UpdateOptions.
 	if hasDebugCommands() || hasExperimentalCommands() {
 		cmd.PersistentFlags().StringSliceVar(
@@ -178,7 +245,8 @@ func newPreviewCmd() *cobra.Command {
 		"Serialize the preview diffs, operations, and overall output as JSON")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
-		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to the project's"+
+			" 'parallel' setting, or unbounded.")
 	cmd.PersistentFlags().BoolVar(
 		&showConfig, "show-config", false,
 		"Show configuration keys and variables")
@@ -41,6 +41,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/httpstate/client"
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/util/ciutil"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/httputil"
@@ -80,6 +81,14 @@ func NewPulumiCmd() *cobra.Command {
 			"    - pulumi config   : Alter your stack's configuration or secrets\n" +
 			"    - pulumi destroy  : Tear down your stack's resources entirely\n" +
 			"\n" +
+			"Commands that fail exit with a code that describes the failure, for use by scripts:\n" +
+			"\n" +
+			"    - 2   : Changes were found when run with --expect-no-changes\n" +
+			"    - 3   : An update, refresh or destroy failed after it started changing resources\n" +
+			"    - 4   : Another update of the stack is in progress\n" +
+			"    - 5   : Not logged in, or the credentials are invalid\n" +
+			"    - 255 : Any other failure (-1 on Windows)\n" +
+			"\n" +
 			"For more information, please visit the project page: https://www.pulumi.com/docs/",
 		PersistentPreRun: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			// We run this method for its side-effects. On windows, this will enable the windows terminal
@@ -115,7 +124,7 @@ func NewPulumiCmd() *cobra.Command {
 				}
 			}
 
-			if cmdutil.IsTruthy(os.Getenv("PULUMI_SKIP_UPDATE_CHECK")) {
+			if skipUpdateCheck() {
 				logging.Infof("skipping update check")
 				close(updateCheckResult)
 			} else {
@@ -169,6 +178,7 @@ func NewPulumiCmd() *cobra.Command {
 
 	// Common commands:
 	//     - Getting Started Commands:
+	cmd.AddCommand(newTemplateCmd())
 	cmd.AddCommand(newNewCmd())
 	//     - Deploy Commands:
 	cmd.AddCommand(newUpCmd())
@@ -188,7 +198,9 @@ func NewPulumiCmd() *cobra.Command {
 	//     - Other Commands:
 	cmd.AddCommand(newLogsCmd())
 	cmd.AddCommand(newPluginCmd())
+	cmd.AddCommand(newAboutCmd())
 	cmd.AddCommand(newVersionCmd())
+	cmd.AddCommand(newUpgradeCmd())
 	cmd.AddCommand(newHistoryCmd())
 
 	// Less common, and thus hidden, commands:
@@ -227,6 +239,19 @@ func NewPulumiCmd() *cobra.Command {
 	return cmd
 }
 
+// skipUpdateCheckEnvVar is the name of an environment variable that, if true, disables the check for a newer version
+// of the CLI, or if false, enables it even in CI systems.
+const skipUpdateCheckEnvVar = "PULUMI_SKIP_UPDATE_CHECK"
+
+// skipUpdateCheck returns true if the CLI should not check for a newer version of itself.  Unless configured
+// otherwise, the check is skipped in CI systems, where there is nobody to act on the message.
+func skipUpdateCheck() bool {
+	if v := os.Getenv(skipUpdateCheckEnvVar); v != "" {
+		return cmdutil.IsTruthy(v)
+	}
+	return ciutil.IsCI()
+}
+
 // checkForUpdate checks to see if the CLI needs to be updated, and if so emits a warning, as well as information
 // as to how it can be upgraded.
 func checkForUpdate() *diag.Diag {
@@ -37,6 +37,7 @@ func newRefreshCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var diffDisplay bool
 	var eventLogPath string
+	var jsonDisplay bool
 	var parallel int
 	var showConfig bool
 	var showReplacementSteps bool
@@ -65,6 +66,15 @@ func newRefreshCmd() *cobra.Command {
 				yes = true // auto-approve changes, since we cannot prompt.
 			}
 
+			// JSON output must be a single well-formed document, so the refresh is performed directly,
+			// without a separate preview or a confirmation prompt.
+			if jsonDisplay {
+				if !yes {
+					return result.FromError(errors.New("--yes must be passed in to proceed when using --json"))
+				}
+				skipPreview = true
+			}
+
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
 				return result.FromError(err)
@@ -83,6 +93,7 @@ func newRefreshCmd() *cobra.Command {
 				SuppressOutputs:      suppressOutputs,
 				IsInteractive:        interactive,
 				Type:                 displayType,
+				JSONDisplay:          jsonDisplay,
 				EventLogPath:         eventLogPath,
 				Debug:                debug,
 			}
@@ -112,16 +123,22 @@ func newRefreshCmd() *cobra.Command {
 				return result.FromError(errors.Wrap(err, "getting stack configuration"))
 			}
 
+			ps, err := loadProjectStack(s)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "loading stack settings"))
+			}
+
 			targetUrns := []resource.URN{}
 			for _, t := range *targets {
 				targetUrns = append(targetUrns, resource.URN(t))
 			}
 
 			opts.Engine = engine.UpdateOptions{
-				Parallel:       parallel,
-				Debug:          debug,
-				UseLegacyDiff:  useLegacyDiff(),
-				RefreshTargets: targetUrns,
+				Parallel:         getParallel(parallel, proj),
+				ProviderParallel: ps.ProviderParallel,
+				Debug:            debug,
+				UseLegacyDiff:    useLegacyDiff(),
+				RefreshTargets:   targetUrns,
 			}
 
 			changes, res := s.Refresh(commandContext(), backend.UpdateOperation{
@@ -140,7 +157,8 @@ func newRefreshCmd() *cobra.Command {
 			case res != nil:
 				return PrintEngineResult(res)
 			case expectNop && changes != nil && changes.HasChanges():
-				return result.FromError(errors.New("error: no changes were expected but changes occurred"))
+				return result.FromError(cmdutil.ErrorWithExitCode(
+					errors.New("error: no changes were expected but changes occurred"), cmdutil.ExitCodeChanges))
 			default:
 				return nil
 			}
@@ -166,15 +184,19 @@ func newRefreshCmd() *cobra.Command {
 
 	targets = cmd.PersistentFlags().StringArrayP(
 		"target", "t", []string{},
-		"Specify a single resource URN to refresh. Multiple resource can be specified using: --target urn1 --target urn2")
+		"Specify a single resource URN to refresh. Multiple resources can be specified using: --target urn1 --target urn2")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.Flags().BoolVarP(
+		&jsonDisplay, "json", "j", false,
+		"Serialize the refresh results, including any drift discovered, as JSON")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
-		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to the project's"+
+			" 'parallel' setting, or unbounded.")
 	cmd.PersistentFlags().BoolVar(
 		&showReplacementSteps, "show-replacement-steps", false,
 		"Show detailed resource replacement creates and deletes instead of a single step")
@@ -152,6 +152,7 @@ func newStackCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&showSecrets, "show-secrets", false, "Display stack outputs which are marked as secret in plaintext")
 
+	cmd.AddCommand(newStackCodegenCmd())
 	cmd.AddCommand(newStackExportCmd())
 	cmd.AddCommand(newStackGraphCmd())
 	cmd.AddCommand(newStackImportCmd())
@@ -299,6 +300,9 @@ func renderResourceRow(res *resource.State, prefix, infoPrefix string, showURN,
 	if showID && res.ID != "" {
 		additionalInfo += fmt.Sprintf("    %sID: %s\n", infoPrefix, res.ID)
 	}
+	for _, reason := range res.InitErrors {
+		additionalInfo += fmt.Sprintf("    %sFailed to initialize: %s\n", infoPrefix, reason)
+	}
 
 	return cmdutil.TableRow{Columns: columns, AdditionalInfo: additionalInfo}
 }
@@ -0,0 +1,88 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/codegen/golang"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+func newStackCodegenCmd() *cobra.Command {
+	var file string
+	var stackName string
+	var showSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "codegen",
+		Args:  cmdutil.NoArgs,
+		Short: "Generate a Go program from a stack's current state",
+		Long: "Generate a Go program from a stack's current state.\n" +
+			"\n" +
+			"This command writes a skeleton Go program that declares each resource in the stack with its\n" +
+			"current inputs as literal values, and with its current ID as the resource's import ID. It can be\n" +
+			"used as a starting point for codifying stacks whose resources were imported or edited outside of\n" +
+			"a Pulumi program. References between resources appear as the literal values they resolved to, so\n" +
+			"the result should be reviewed and refined.\n" +
+			"\n" +
+			"If any input is secret, the command fails unless --show-secrets is passed, in which case the\n" +
+			"secret's plaintext is written to the program.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+
+			writer := os.Stdout
+			if file != "" {
+				writer, err = os.Create(file)
+				if err != nil {
+					return errors.Wrap(err, "could not open file")
+				}
+				defer contract.IgnoreClose(writer)
+			}
+
+			if showSecrets {
+				cmdutil.Diag().Warningf(diag.Message("", "secret values are written to the program in plaintext"))
+			}
+			if err = golang.GenerateProgram(writer, snap, showSecrets); err != nil {
+				return errors.Wrap(err, "could not generate program")
+			}
+			return nil
+		}),
+	}
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVarP(
+		&file, "file", "", "", "A filename to write the program to")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false, "Write the plaintext of secret inputs to the program")
+	return cmd
+}
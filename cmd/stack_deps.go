@@ -0,0 +1,283 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// stackOutputs returns the outputs of the root stack resource in a deployment.  Secret outputs are compared in their
+// serialized form, so they never need to be decrypted.
+func stackOutputs(deployment *apitype.UntypedDeployment) (map[string]interface{}, error) {
+	var d struct {
+		Resources []struct {
+			Type    string                 `json:"type"`
+			Outputs map[string]interface{} `json:"outputs"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(deployment.Deployment, &d); err != nil {
+		return nil, err
+	}
+	for _, res := range d.Resources {
+		if res.Type == string(resource.RootStackType) {
+			return res.Outputs, nil
+		}
+	}
+	return nil, nil
+}
+
+// changedStackOutputs returns the sorted names of the outputs that were added, removed, or changed between old and new.
+func changedStackOutputs(old, new map[string]interface{}) []string {
+	var changed []string
+	for k, v := range old {
+		if nv, has := new[k]; !has || !reflect.DeepEqual(v, nv) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range new {
+		if _, has := old[k]; !has {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// checkUpstreamStacks warns about each stack that s declares a dependency on whose outputs have changed since s was
+// last updated.  Problems inspecting the upstream stacks are reported as warnings, as they should not block updates.
+func checkUpstreamStacks(ctx context.Context, s backend.Stack, ps *workspace.ProjectStack) {
+	if len(ps.DependsOn) == 0 {
+		return
+	}
+
+	history, err := s.Backend().GetHistory(ctx, s.Ref())
+	if err != nil {
+		cmdutil.Diag().Warningf(diag.Message("", "could not check upstream stacks: %v"), err)
+		return
+	}
+	if len(history) == 0 {
+		return
+	}
+	lastUpdate := history[0].EndTime
+
+	for _, name := range ps.DependsOn {
+		changed, err := upstreamChanges(ctx, s.Backend(), name, lastUpdate)
+		if err != nil {
+			cmdutil.Diag().Warningf(diag.Message("", "could not check upstream stack '%s': %v"), name, err)
+		} else if changed != nil {
+			msg := fmt.Sprintf("upstream stack '%s' has been updated since this stack was last updated", name)
+			if len(changed) > 0 {
+				msg += fmt.Sprintf("; changed outputs: %s", strings.Join(changed, ", "))
+			}
+			cmdutil.Diag().Warningf(diag.RawMessage("", msg))
+		}
+	}
+}
+
+// upstreamChanges returns the names of the outputs of the named stack that changed after the given time.  If the stack
+// has been updated since then, but the changed outputs cannot be determined, an empty non-nil slice is returned.  If
+// the stack has not been updated since then, nil is returned.
+func upstreamChanges(ctx context.Context, b backend.Backend, name string, since int64) ([]string, error) {
+	ref, err := b.ParseStackReference(name)
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := b.GetStack(ctx, ref)
+	if err != nil {
+		return nil, err
+	} else if upstream == nil {
+		return nil, errors.New("stack not found")
+	}
+	history, err := b.GetHistory(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 || history[0].EndTime <= since {
+		return nil, nil
+	}
+
+	// Find the version of the upstream stack that was current when this stack was last updated, and compare its
+	// outputs to those of the latest version.
+	latest, base := history[0].Version, 0
+	for _, update := range history {
+		if update.EndTime <= since {
+			base = update.Version
+			break
+		}
+	}
+	if latest == 0 || base == 0 {
+		return []string{}, nil
+	}
+
+	outputsOf := func(version int) (map[string]interface{}, error) {
+		deployment, err := b.ExportDeploymentVersion(ctx, upstream, version)
+		if err != nil {
+			return nil, err
+		}
+		return stackOutputs(deployment)
+	}
+	oldOutputs, err := outputsOf(base)
+	if err != nil {
+		return nil, err
+	}
+	newOutputs, err := outputsOf(latest)
+	if err != nil {
+		return nil, err
+	}
+	changed := changedStackOutputs(oldOutputs, newOutputs)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	return changed, nil
+}
+
+// cascadeTarget is a stack that is updated as part of a cascading update.
+type cascadeTarget struct {
+	// Dir is the directory containing the stack's project.
+	Dir string
+	// Stack is the name of the stack.
+	Stack string
+}
+
+// loadDependents returns the stacks that the given stack declares as dependents.
+type loadDependents func(target cascadeTarget) ([]cascadeTarget, error)
+
+// cascadeOrder returns the transitive dependents of root, ordered such that each stack appears after every stack that
+// declares it as a dependent.  The root itself is not included.
+func cascadeOrder(root cascadeTarget, load loadDependents) ([]cascadeTarget, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[cascadeTarget]int)
+	var order []cascadeTarget
+	var visit func(t cascadeTarget) error
+	visit = func(t cascadeTarget) error {
+		switch state[t] {
+		case visiting:
+			return errors.Errorf("stack '%s' in %s depends on itself", t.Stack, t.Dir)
+		case visited:
+			return nil
+		}
+		state[t] = visiting
+		deps, err := load(t)
+		if err != nil {
+			return err
+		}
+		for _, dep := range deps {
+			if err = visit(dep); err != nil {
+				return err
+			}
+		}
+		state[t] = visited
+		order = append(order, t)
+		return nil
+	}
+	if err := visit(root); err != nil {
+		return nil, err
+	}
+
+	// The stacks were collected in post-order, so reverse them and drop the root.
+	result := make([]cascadeTarget, 0, len(order)-1)
+	for i := len(order) - 2; i >= 0; i-- {
+		result = append(result, order[i])
+	}
+	return result, nil
+}
+
+// loadStackDependents reads the dependents declared in the given stack's settings file.
+func loadStackDependents(target cascadeTarget) ([]cascadeTarget, error) {
+	projPath, err := workspace.DetectProjectPathFrom(target.Dir)
+	if err != nil {
+		return nil, err
+	} else if projPath == "" {
+		return nil, errors.Errorf("no Pulumi.yaml project file found in %s", target.Dir)
+	}
+	proj, err := workspace.LoadProject(projPath)
+	if err != nil {
+		return nil, err
+	}
+	ps, err := workspace.LoadProjectStack(workspace.ProjectStackPath(proj, projPath, stackNameOf(target.Stack)))
+	if err != nil {
+		return nil, err
+	}
+	return dependentTargets(filepath.Dir(projPath), target.Stack, ps), nil
+}
+
+// dependentTargets returns the dependents declared by a stack's settings, resolved relative to its project directory.
+func dependentTargets(dir, stack string, ps *workspace.ProjectStack) []cascadeTarget {
+	var targets []cascadeTarget
+	for _, dep := range ps.Dependents {
+		name := dep.Stack
+		if name == "" {
+			name = string(stackNameOf(stack))
+		}
+		targets = append(targets, cascadeTarget{Dir: filepath.Clean(filepath.Join(dir, dep.Path)), Stack: name})
+	}
+	return targets
+}
+
+// stackNameOf returns the unqualified name of a stack, given a possibly fully qualified stack reference.
+func stackNameOf(stack string) tokens.QName {
+	return tokens.QName(stack[strings.LastIndex(stack, "/")+1:])
+}
+
+// cascadeUpdate updates each of the transitive dependents of the given stack, in dependency order, by running
+// `pulumi up` in their project directories with the given additional arguments.
+func cascadeUpdate(s backend.Stack, ps *workspace.ProjectStack, root string, args []string) error {
+	stackName := string(s.Ref().Name())
+	start := cascadeTarget{Dir: root, Stack: stackName}
+	order, err := cascadeOrder(start, func(t cascadeTarget) ([]cascadeTarget, error) {
+		if t == start {
+			return dependentTargets(root, stackName, ps), nil
+		}
+		return loadStackDependents(t)
+	})
+	if err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	for _, t := range order {
+		fmt.Printf("Updating dependent stack '%s' in %s\n", t.Stack, t.Dir)
+		cmd := exec.Command(exe, append([]string{"up", "--cwd", t.Dir, "--stack", t.Stack}, args...)...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err = cmd.Run(); err != nil {
+			return errors.Wrapf(err, "updating dependent stack '%s'", t.Stack)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCascadeOrder(t *testing.T) {
+	network := cascadeTarget{Dir: "network", Stack: "dev"}
+	db := cascadeTarget{Dir: "db", Stack: "dev"}
+	app := cascadeTarget{Dir: "app", Stack: "dev"}
+	dashboards := cascadeTarget{Dir: "dashboards", Stack: "dev"}
+
+	graph := map[cascadeTarget][]cascadeTarget{
+		network: {app, db},
+		db:      {app},
+		app:     {dashboards},
+	}
+	load := func(t cascadeTarget) ([]cascadeTarget, error) {
+		return graph[t], nil
+	}
+
+	order, err := cascadeOrder(network, load)
+	assert.NoError(t, err)
+	assert.Equal(t, []cascadeTarget{db, app, dashboards}, order)
+
+	order, err = cascadeOrder(dashboards, load)
+	assert.NoError(t, err)
+	assert.Empty(t, order)
+
+	graph[dashboards] = []cascadeTarget{db}
+	_, err = cascadeOrder(network, load)
+	assert.Error(t, err)
+}
+
+func TestChangedStackOutputs(t *testing.T) {
+	old := map[string]interface{}{"a": "x", "b": []interface{}{"y"}, "c": 1.0}
+	new := map[string]interface{}{"a": "x", "b": []interface{}{"z"}, "d": true}
+	assert.Equal(t, []string{"b", "c", "d"}, changedStackOutputs(old, new))
+	assert.Empty(t, changedStackOutputs(old, old))
+}
+
+func TestStackNameOf(t *testing.T) {
+	assert.Equal(t, "dev", string(stackNameOf("dev")))
+	assert.Equal(t, "dev", string(stackNameOf("acme/network/dev")))
+}
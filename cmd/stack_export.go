@@ -21,13 +21,16 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
 func newStackExportCmd() *cobra.Command {
 	var file string
 	var stackName string
+	var showSecrets bool
 
 	cmd := &cobra.Command{
 		Use:   "export",
@@ -38,7 +41,10 @@ func newStackExportCmd() *cobra.Command {
 			"The deployment can then be hand-edited and used to update the stack via\n" +
 			"`pulumi stack import`. This process may be used to correct inconsistencies\n" +
 			"in a stack's state due to failed deployments, manual changes to cloud\n" +
-			"resources, etc.",
+			"resources, etc.\n" +
+			"\n" +
+			"Secret values remain encrypted with the stack's secrets provider, unless\n" +
+			"`--show-secrets` is passed, in which case they are written in plaintext.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -54,6 +60,11 @@ func newStackExportCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if showSecrets {
+				if deployment, err = decryptDeployment(deployment); err != nil {
+					return err
+				}
+			}
 
 			// Read from stdin or a specified file.
 			writer := os.Stdout
@@ -77,5 +88,27 @@ func newStackExportCmd() *cobra.Command {
 		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
 	cmd.PersistentFlags().StringVarP(
 		&file, "file", "", "", "A filename to write stack output to")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false, "Write secret values in plaintext rather than encrypted")
 	return cmd
 }
+
+// decryptDeployment returns a copy of the deployment in which secret values are written in plaintext.
+func decryptDeployment(deployment *apitype.UntypedDeployment) (*apitype.UntypedDeployment, error) {
+	snapshot, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not deserialize deployment")
+	}
+	sdp, err := stack.SerializeDeploymentWithPlaintextSecrets(snapshot, snapshot.SecretsManager)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt deployment")
+	}
+	bytes, err := json.Marshal(sdp)
+	if err != nil {
+		return nil, err
+	}
+	return &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: bytes,
+	}, nil
+}
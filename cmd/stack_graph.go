@@ -15,12 +15,19 @@
 package cmd
 
 import (
+	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"strings"
 
+	"github.com/pkg/errors"
+	"github.com/skratchdot/open-golang/open"
+
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/graph"
 	"github.com/pulumi/pulumi/pkg/graph/dotconv"
+	"github.com/pulumi/pulumi/pkg/graph/webview"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
@@ -41,17 +48,27 @@ var parentEdgeColor string
 
 func newStackGraphCmd() *cobra.Command {
 	var stackName string
+	var web bool
+	var port int
 
 	cmd := &cobra.Command{
 		Use:   "graph [filename]",
-		Args:  cmdutil.ExactArgs(1),
+		Args:  cmdutil.RangeArgs(0, 1),
 		Short: "Export a stack's dependency graph to a file",
 		Long: "Export a stack's dependency graph to a file.\n" +
 			"\n" +
 			"This command can be used to view the dependency graph that a Pulumi program\n" +
 			"admitted when it was ran. This graph is output in the DOT format. This command operates\n" +
-			"on your stack's most recent deployment.",
+			"on your stack's most recent deployment.\n" +
+			"\n" +
+			"If --web is passed, the graph is instead served on a local port as an interactive web\n" +
+			"page that supports zooming, searching, filtering by resource type, and highlighting\n" +
+			"the dependents of a resource.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if !web && len(args) == 0 {
+				return errors.New("missing filename; pass a filename to write, or --web to view the graph in a browser")
+			}
+
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
 			}
@@ -64,8 +81,15 @@ func newStackGraphCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if snap == nil {
+				return errors.Errorf("stack '%s' has no resources", s.Ref())
+			}
 
 			dg := makeDependencyGraph(snap)
+			if web {
+				return serveDependencyGraph(cmd, dg, port)
+			}
+
 			file, err := os.Create(args[0])
 			if err != nil {
 				return err
@@ -91,9 +115,34 @@ func newStackGraphCmd() *cobra.Command {
 		"Sets the color of dependency edges in the graph")
 	cmd.PersistentFlags().StringVar(&parentEdgeColor, "parent-edge-color", "#AA6639",
 		"Sets the color of parent edges in the graph")
+	cmd.PersistentFlags().BoolVar(&web, "web", false,
+		"Serve an interactive view of the graph on a local port and open it in a browser")
+	cmd.PersistentFlags().IntVar(&port, "port", 0,
+		"The port the --web viewer will listen on. Defaults to a random free port")
 	return cmd
 }
 
+// serveDependencyGraph serves an interactive view of the graph on localhost until the process is interrupted.
+func serveDependencyGraph(cmd *cobra.Command, dg *dependencyGraph, port int) error {
+	handler, err := webview.NewHandler(dg)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("http://%s/", listener.Addr())
+	cmd.Printf("%sServing stack dependency graph at %s (press ^C to stop)", cmdutil.EmojiOr("🔍 ", ""), url)
+	cmd.Println()
+	if openErr := open.Run(url); openErr != nil {
+		cmd.Printf("Could not open a browser; visit %s to view the graph", url)
+		cmd.Println()
+	}
+	return http.Serve(listener, handler)
+}
+
 // All of the types and code within this file are to provide implementations of the interfaces
 // in the `graph` package, so that we can use the `dotconv` package to output our graph in the
 // DOT format.
@@ -156,6 +205,13 @@ func (edge *parentEdge) Color() string {
 	return parentEdgeColor
 }
 
+var _ webview.ParentEdge = (*parentEdge)(nil)
+
+// IsParentEdge tells the interactive view not to treat the edge as a dependency.
+func (edge *parentEdge) IsParentEdge() bool {
+	return true
+}
+
 // A dependencyVertex contains a reference to the graph to which it belongs
 // and to the resource state that it represents. Incoming and outgoing edges
 // are calculated on-demand using the combination of the graph and the state.
@@ -43,7 +43,10 @@ func newStackImportCmd() *cobra.Command {
 			"A deployment that was exported from a stack using `pulumi stack export` and\n" +
 			"hand-edited to correct inconsistencies due to failed updates, manual changes\n" +
 			"to cloud resources, etc. can be reimported to the stack using this command.\n" +
-			"The updated deployment will be read from standard in.",
+			"The updated deployment will be read from standard in.\n" +
+			"\n" +
+			"Secret values, whether in plaintext or encrypted by another stack's secrets provider,\n" +
+			"are encrypted using this stack's secrets provider.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -130,7 +133,14 @@ func newStackImportCmd() *cobra.Command {
 
 				snapshot.PendingOperations = nil
 			}
-			sdp, err := stack.SerializeDeployment(snapshot, snapshot.SecretsManager)
+
+			// Encrypt any secrets with the destination stack's secrets manager, as the deployment may have come from
+			// another stack or have been exported with its secrets in plaintext.
+			sm, err := getStackSecretsManager(s)
+			if err != nil {
+				return errors.Wrap(err, "getting secrets manager")
+			}
+			sdp, err := stack.SerializeDeployment(snapshot, sm)
 			if err != nil {
 				return errors.Wrap(err, "constructing deployment for upload")
 			}
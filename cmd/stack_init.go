@@ -44,7 +44,8 @@ func newStackInitCmd() *cobra.Command {
 			"By default, a stack created using the pulumi.com backend will use the pulumi.com secrets\n" +
 			"provider and a stack created using the local or cloud object storage backend will use the\n" +
 			"`passphrase` secrets provider.  A different secrets provider can be selected by passing the\n" +
-			"`--secrets-provider` flag.\n" +
+			"`--secrets-provider` flag, or by declaring a `secretsProvider` in the project's Pulumi.yaml,\n" +
+			"which may refer to the project and stack as `${project}` and `${stack}`.\n" +
 			"\n" +
 			"To use the `passphrase` secrets provider with the pulumi.com backend, use:\n" +
 			"\n" +
@@ -158,6 +158,13 @@ type stackSummaryJSON struct {
 }
 
 func formatStackSummariesJSON(b backend.Backend, currentStack string, stackSummaries []backend.StackSummary) error {
+	return printJSON(stackSummariesToJSON(b, currentStack, stackSummaries))
+}
+
+// stackSummariesToJSON converts the summaries of stacks into the objects that are printed by --json.
+func stackSummariesToJSON(b backend.Backend, currentStack string,
+	stackSummaries []backend.StackSummary) []stackSummaryJSON {
+
 	output := make([]stackSummaryJSON, len(stackSummaries))
 	for idx, summary := range stackSummaries {
 		summaryJSON := stackSummaryJSON{
@@ -183,7 +190,7 @@ func formatStackSummariesJSON(b backend.Backend, currentStack string, stackSumma
 		output[idx] = summaryJSON
 	}
 
-	return printJSON(output)
+	return output
 }
 
 func formatStackSummariesConsole(b backend.Backend, currentStack string, stackSummaries []backend.StackSummary) error {
@@ -16,8 +16,12 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/tokens"
 )
 
 func TestParseTagFilter(t *testing.T) {
@@ -61,3 +65,35 @@ func TestParseTagFilter(t *testing.T) {
 		}
 	}
 }
+
+type testStackReference string
+
+func (r testStackReference) String() string     { return string(r) }
+func (r testStackReference) Name() tokens.QName { return tokens.QName(r) }
+
+type testStackSummary struct {
+	name          string
+	lastUpdate    *time.Time
+	resourceCount *int
+}
+
+func (s testStackSummary) Name() backend.StackReference { return testStackReference(s.name) }
+func (s testStackSummary) LastUpdate() *time.Time       { return s.lastUpdate }
+func (s testStackSummary) ResourceCount() *int          { return s.resourceCount }
+
+func TestStackSummariesToJSON(t *testing.T) {
+	lastUpdate := time.Date(2019, 11, 20, 10, 30, 0, 0, time.UTC)
+	inProgress := time.Unix(0, 0)
+	count := 3
+
+	summaries := []backend.StackSummary{
+		testStackSummary{name: "dev", lastUpdate: &lastUpdate, resourceCount: &count},
+		testStackSummary{name: "prod", lastUpdate: &inProgress},
+		testStackSummary{name: "test"},
+	}
+	assert.Equal(t, []stackSummaryJSON{
+		{Name: "dev", Current: true, LastUpdate: "2019-11-20T10:30:00.000Z", ResourceCount: &count},
+		{Name: "prod", UpdateInProgress: true},
+		{Name: "test"},
+	}, stackSummariesToJSON(&backend.MockBackend{}, "dev", summaries))
+}
@@ -18,6 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 )
 
 func TestStringifyOutput(t *testing.T) {
@@ -36,3 +39,29 @@ func TestStringifyOutput(t *testing.T) {
 	assert.Equal(t, "[\"hello\",\"goodbye\"]", stringifyOutput(arr))
 	assert.Equal(t, "{\"bar\":{\"baz\":true},\"foo\":42}", stringifyOutput(obj))
 }
+
+func TestGetStackOutputs(t *testing.T) {
+	root := &resource.State{
+		Type: resource.RootStackType,
+		URN:  resource.DefaultRootStackURN("dev", "proj"),
+		Outputs: resource.PropertyMap{
+			"name":     resource.NewStringProperty("app"),
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		},
+	}
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{root}, nil)
+
+	// Secrets are masked unless they are asked for.
+	outputs, err := getStackOutputs(snap, false /*showSecrets*/)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "app", "password": "[secret]"}, outputs)
+
+	outputs, err = getStackOutputs(snap, true /*showSecrets*/)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "app", "password": "hunter2"}, outputs)
+
+	// A stack that has never been updated has no outputs.
+	outputs, err = getStackOutputs(nil, false /*showSecrets*/)
+	assert.NoError(t, err)
+	assert.Empty(t, outputs)
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pulumi/pulumi/pkg/tokens"
 
@@ -76,6 +77,9 @@ func newStackRenameCmd() *cobra.Command {
 			case os.IsNotExist(configStatErr):
 				// Stack doesn't have any configuration, ignore.
 			case configStatErr == nil:
+				// Keep the format of the existing configuration file.
+				newConfigPath = strings.TrimSuffix(newConfigPath, filepath.Ext(newConfigPath)) +
+					filepath.Ext(oldConfigPath)
 				if err := os.Rename(oldConfigPath, newConfigPath); err != nil {
 					return errors.Wrapf(err, "renaming configuration file to %s", filepath.Base(newConfigPath))
 				}
@@ -18,14 +18,19 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/backend/state"
+	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
 // newStackSelectCmd handles both the "local" and "cloud" scenarios in its implementation.
 func newStackSelectCmd() *cobra.Command {
 	var stack string
+	var create bool
+	var secretsProvider string
+	var copyConfigFrom string
 	cmd := &cobra.Command{
 		Use:   "select [<stack>]",
 		Short: "Switch the current workspace to the given stack",
@@ -34,7 +39,11 @@ func newStackSelectCmd() *cobra.Command {
 			"Selecting a stack allows you to use commands like `config`, `preview`, and `update`\n" +
 			"without needing to type the stack name each time.\n" +
 			"\n" +
-			"If no <stack> argument is supplied, you will be prompted to select one interactively.",
+			"If no <stack> argument is supplied, you will be prompted to select one interactively.\n" +
+			"\n" +
+			"If `--create` is passed and the stack does not exist, it is created, using the secrets provider\n" +
+			"given by `--secrets-provider` and a copy of the configuration of the stack given by\n" +
+			"`--copy-config-from`, if any.",
 		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
@@ -54,6 +63,13 @@ func newStackSelectCmd() *cobra.Command {
 				stack = args[0]
 			}
 
+			if !create && (secretsProvider != "default" || copyConfigFrom != "") {
+				return errors.New("--secrets-provider and --copy-config-from may only be used with --create")
+			}
+			if err := validateSecretsProvider(secretsProvider); err != nil {
+				return err
+			}
+
 			if stack != "" {
 				// A stack was given, ask the backend about it
 				stackRef, stackErr := b.ParseStackReference(stack)
@@ -68,6 +84,10 @@ func newStackSelectCmd() *cobra.Command {
 					return state.SetCurrentStack(stackRef.String())
 				}
 
+				if create {
+					return createAndSelectStack(b, stackRef, secretsProvider, copyConfigFrom)
+				}
+
 				return errors.Errorf("no stack named '%s' found", stackRef)
 			}
 
@@ -83,5 +103,102 @@ func newStackSelectCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to select")
+	cmd.PersistentFlags().BoolVarP(
+		&create, "create", "c", false,
+		"If the selected stack does not exist, create it")
+	cmd.PersistentFlags().StringVar(
+		&secretsProvider, "secrets-provider", "default", "The type of the provider that should be used to encrypt and "+
+			"decrypt secrets of a created stack (possible choices: default, passphrase, awskms, azurekeyvault, "+
+			"gcpkms, hashivault)")
+	cmd.PersistentFlags().StringVar(
+		&copyConfigFrom, "copy-config-from", "",
+		"The name of a stack whose configuration is copied to a created stack")
 	return cmd
 }
+
+// createAndSelectStack creates the given stack and selects it as the current stack.  If copyConfigFrom names a stack,
+// its configuration is copied to the new stack.
+func createAndSelectStack(b backend.Backend, stackRef backend.StackReference, secretsProvider,
+	copyConfigFrom string) error {
+
+	// Look up the stack to copy from before creating the new one, so that a typo does not leave a stack behind.
+	var from backend.Stack
+	if copyConfigFrom != "" {
+		fromRef, err := b.ParseStackReference(copyConfigFrom)
+		if err != nil {
+			return err
+		}
+		if from, err = b.GetStack(commandContext(), fromRef); err != nil {
+			return err
+		} else if from == nil {
+			return errors.Errorf("no stack named '%s' found", fromRef)
+		}
+	}
+
+	var createOpts interface{} // Backend-specific config options, none currently.
+	s, err := createStack(b, stackRef, createOpts, true /*setCurrent*/, secretsProvider)
+	if err != nil {
+		return err
+	}
+	if from != nil {
+		if err = copyStackConfig(from, s, nil, false /*path*/); err != nil {
+			return errors.Wrapf(err, "copying configuration from stack '%s'", from.Ref())
+		}
+	}
+	return nil
+}
+
+// copyStackConfig copies the configuration of one stack to another.  If keys is non-empty, only the values of those
+// keys are copied, and if path is true, the keys' names are treated as paths.  Secrets are encrypted again using the
+// secrets provider of the destination stack.
+func copyStackConfig(from, to backend.Stack, keys []config.Key, path bool) error {
+	fromStack, err := loadProjectStack(from)
+	if err != nil {
+		return err
+	}
+	toStack, err := loadProjectStack(to)
+	if err != nil {
+		return err
+	}
+
+	values := fromStack.Config
+	if len(keys) > 0 {
+		values = make(config.Map)
+		for _, k := range keys {
+			v, ok, err := fromStack.Config.Get(k, path)
+			if err != nil {
+				return err
+			} else if !ok {
+				return errors.Errorf("configuration key '%s' not found for stack '%s'", prettyKey(k), from.Ref())
+			}
+			values[k] = v
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	var decrypter config.Decrypter = config.NopDecrypter
+	var encrypter config.Encrypter = config.NopEncrypter
+	if values.HasSecureValue() {
+		if decrypter, err = getStackDencrypter(from); err != nil {
+			return err
+		}
+		if encrypter, err = getStackEncrypter(to); err != nil {
+			return err
+		}
+	}
+
+	if toStack.Config == nil {
+		toStack.Config = make(config.Map)
+	}
+	for k, v := range values {
+		if v, err = v.Reencrypt(decrypter, encrypter); err != nil {
+			return err
+		}
+		if err = toStack.Config.Set(k, v, path); err != nil {
+			return err
+		}
+	}
+	return saveProjectStack(to, toStack)
+}
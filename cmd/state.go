@@ -48,6 +48,8 @@ troubleshooting a stack or when performing specific edits that otherwise would r
 	}
 
 	cmd.AddCommand(newStateDeleteCommand())
+	cmd.AddCommand(newStateMoveCommand())
+	cmd.AddCommand(newStateRenameCommand())
 	cmd.AddCommand(newStateUnprotectCommand())
 	return cmd
 }
@@ -0,0 +1,73 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func newStateMoveCommand() *cobra.Command {
+	var stack string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "move <resource URN> <new resource URN>",
+		Short: "Moves a resource to a new URN in a stack's state",
+		Long: `Moves a resource to a new URN in a stack's state
+
+This command changes the URN of a resource in a stack's state, without changing the resource itself. Use it after
+changing the name or type of a resource in your program, so that the next update does not replace the resource.
+References to the resource from other resources, such as its children and dependents, are updated to match. The new
+URN must belong to the same stack, must not already be in use, and must keep the resource's parent. The type of a
+resource that has children cannot be changed, nor can a provider be given a type that is not a provider's.
+
+Make sure that URNs are single-quoted to avoid having characters unexpectedly interpreted by the shell.
+
+Example:
+pulumi state move 'urn:pulumi:stage::demo::aws:s3/bucket:Bucket::my-bucket' \
+    'urn:pulumi:stage::demo::aws:s3/bucket:Bucket::site-bucket'
+`,
+		Args: cmdutil.ExactArgs(2),
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			urn := resource.URN(args[0])
+			newURN := resource.URN(args[1])
+			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
+			showPrompt := !yes
+
+			res := runStateEdit(stack, showPrompt, urn, func(snap *deploy.Snapshot, res *resource.State) error {
+				return edit.MoveResource(snap, res, newURN)
+			})
+			if res != nil {
+				return res
+			}
+			fmt.Println("Resource moved successfully")
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
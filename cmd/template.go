@@ -0,0 +1,41 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newTemplateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "List and validate project templates",
+		Long: "List and validate project templates.\n" +
+			"\n" +
+			"Templates are used by `pulumi new` to create projects.  They come from the built-in Pulumi\n" +
+			"templates, from Git repositories shared by an organization, and from local directories.\n" +
+			"\n" +
+			"The template family of commands helps template authors check their templates before\n" +
+			"publishing them.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newTemplateLsCmd())
+	cmd.AddCommand(newTemplateValidateCmd())
+
+	return cmd
+}
@@ -0,0 +1,177 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// The sources that templates may be listed from.
+const (
+	templateSourceBuiltIn = "built-in"
+	templateSourceOrg     = "org"
+	templateSourceLocal   = "local"
+)
+
+func newTemplateLsCmd() *cobra.Command {
+	var orgURLs []string
+	var localDirs []string
+	var offline bool
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List templates",
+		Long: "List templates.\n" +
+			"\n" +
+			"Lists the built-in Pulumi templates, along with the templates in any Git repositories shared by\n" +
+			"an organization (passed with --org) and in any local directories (passed with --dir).",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			var entries []templateEntry
+
+			builtIn, err := listTemplates(templateSourceBuiltIn, "", offline)
+			if err != nil {
+				return errors.Wrap(err, "listing built-in templates")
+			}
+			entries = append(entries, builtIn...)
+
+			for _, u := range orgURLs {
+				if !workspace.IsTemplateURL(u) {
+					return errors.Errorf("'%s' is not an https:// URL", u)
+				}
+				org, err := listTemplates(templateSourceOrg, u, offline)
+				if err != nil {
+					return errors.Wrapf(err, "listing templates in %s", u)
+				}
+				entries = append(entries, org...)
+			}
+
+			for _, dir := range localDirs {
+				if _, err := os.Stat(dir); err != nil {
+					return err
+				}
+				local, err := listTemplates(templateSourceLocal, dir, offline)
+				if err != nil {
+					return errors.Wrapf(err, "listing templates in %s", dir)
+				}
+				entries = append(entries, local...)
+			}
+
+			if jsonOut {
+				return formatTemplatesJSON(entries)
+			}
+			return formatTemplatesConsole(entries)
+		}),
+	}
+
+	cmd.PersistentFlags().StringArrayVar(
+		&orgURLs, "org", nil,
+		"The URL of a Git repository of templates shared by an organization; may be specified more than once")
+	cmd.PersistentFlags().StringArrayVar(
+		&localDirs, "dir", nil,
+		"A local directory of templates; may be specified more than once")
+	cmd.PersistentFlags().BoolVar(
+		&offline, "offline", false,
+		"Use locally cached built-in templates without making any network requests")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON")
+
+	return cmd
+}
+
+// templateEntry is a template along with the source it was listed from.
+type templateEntry struct {
+	Source   string // the kind of source, e.g. templateSourceOrg.
+	Location string // the URL or directory of the source, or empty for the built-in templates.
+	Template workspace.Template
+}
+
+// listTemplates lists the templates from a source.  The location of the built-in templates is empty.
+func listTemplates(source, location string, offline bool) ([]templateEntry, error) {
+	repo, err := workspace.RetrieveTemplates(location, offline, workspace.TemplateKindPulumiProject)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { contract.IgnoreError(repo.Delete()) }()
+
+	templates, err := repo.Templates()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]templateEntry, len(templates))
+	for i, t := range templates {
+		entries[i] = templateEntry{Source: source, Location: location, Template: t}
+	}
+	return entries, nil
+}
+
+// templateJSON is the shape of the --json output of a template.  While we can add fields to this structure in the
+// future, we should not change existing fields.
+type templateJSON struct {
+	Name        string                                          `json:"name"`
+	Description string                                          `json:"description,omitempty"`
+	Source      string                                          `json:"source"`
+	Location    string                                          `json:"location,omitempty"`
+	Important   bool                                            `json:"important,omitempty"`
+	Config      map[string]workspace.ProjectTemplateConfigValue `json:"config,omitempty"`
+}
+
+func makeTemplateJSON(entry templateEntry) templateJSON {
+	return templateJSON{
+		Name: entry.Template.Name,
+		Description: workspace.ValueOrDefaultProjectDescription(
+			"", entry.Template.ProjectDescription, entry.Template.Description),
+		Source:    entry.Source,
+		Location:  entry.Location,
+		Important: entry.Template.Important,
+		Config:    entry.Template.Config,
+	}
+}
+
+func formatTemplatesJSON(entries []templateEntry) error {
+	result := make([]templateJSON, len(entries))
+	for i, entry := range entries {
+		result[i] = makeTemplateJSON(entry)
+	}
+	return printJSON(result)
+}
+
+func formatTemplatesConsole(entries []templateEntry) error {
+	rows := []cmdutil.TableRow{}
+	for _, entry := range entries {
+		source := entry.Source
+		if entry.Location != "" {
+			source = source + " (" + entry.Location + ")"
+		}
+		desc := workspace.ValueOrDefaultProjectDescription(
+			"", entry.Template.ProjectDescription, entry.Template.Description)
+		rows = append(rows, cmdutil.TableRow{Columns: []string{entry.Template.Name, source, desc}})
+	}
+
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"NAME", "SOURCE", "DESCRIPTION"},
+		Rows:    rows,
+	})
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newTemplateValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate <dir-or-url>",
+		Short: "Validate a template or a repository of templates",
+		Long: "Validate a template or a repository of templates.\n" +
+			"\n" +
+			"Checks that each template's Pulumi.yaml has a valid template section and config, and that the\n" +
+			"template can be scaffolded into a valid project, without creating a project.  The argument is\n" +
+			"either a local directory or the https:// URL of a Git repository, and may name a single template\n" +
+			"or a directory of templates.",
+		Args: cmdutil.ExactArgs(1),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			location := args[0]
+			if !workspace.IsTemplateURL(location) {
+				if _, err := os.Stat(location); err != nil {
+					return err
+				}
+			}
+
+			repo, err := workspace.RetrieveTemplates(location, false /*offline*/, workspace.TemplateKindPulumiProject)
+			if err != nil {
+				return err
+			}
+			defer func() { contract.IgnoreError(repo.Delete()) }()
+
+			dirs, err := templateDirs(repo.SubDirectory)
+			if err != nil {
+				return err
+			}
+			if len(dirs) == 0 {
+				return errors.Errorf("no templates found in %s", location)
+			}
+
+			invalid := 0
+			for _, dir := range dirs {
+				errs := workspace.ValidateTemplate(dir)
+				if len(errs) == 0 {
+					fmt.Printf("%s: ok\n", filepath.Base(dir))
+					continue
+				}
+
+				invalid++
+				fmt.Printf("%s:\n", filepath.Base(dir))
+				for _, err := range errs {
+					fmt.Printf("    %v\n", err)
+				}
+			}
+			if invalid > 0 {
+				return errors.Errorf("%d of %d template(s) are invalid", invalid, len(dirs))
+			}
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+// templateDirs returns the directory itself if it contains a Pulumi.yaml, and otherwise those of its subdirectories
+// that do.
+func templateDirs(dir string) ([]string, error) {
+	if _, err := os.Stat(filepath.Join(dir, "Pulumi.yaml")); err == nil {
+		return []string{dir}, nil
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, info := range infos {
+		if !info.IsDir() || info.Name() == workspace.GitDir {
+			continue
+		}
+		sub := filepath.Join(dir, info.Name())
+		if _, err := os.Stat(filepath.Join(sub, "Pulumi.yaml")); err == nil {
+			dirs = append(dirs, sub)
+		}
+	}
+	return dirs, nil
+}
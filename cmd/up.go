@@ -71,10 +71,16 @@ func newUpCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var cascade bool
+	var createMissingStack bool
+	var maxDeletes int
+	var maxDeletePercent float64
+	var planPath string
 
 	// up implementation used when the source of the Pulumi program is in the current working directory.
 	upWorkingDirectory := func(opts backend.UpdateOptions) result.Result {
-		s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
+		s, err := requireOrCreateStack(
+			stack, createMissingStack, opts.Display, true /*setCurrent*/, secretsProvider)
 		if err != nil {
 			return result.FromError(err)
 		}
@@ -104,6 +110,22 @@ func newUpCmd() *cobra.Command {
 			return result.FromError(errors.Wrap(err, "getting stack configuration"))
 		}
 
+		ps, err := loadProjectStack(s)
+		if err != nil {
+			return result.FromError(errors.Wrap(err, "loading stack settings"))
+		}
+		checkUpstreamStacks(commandContext(), s, ps)
+
+		// Guardrails passed as flags take precedence over those in the stack's settings.
+		if ps.Guardrails != nil {
+			if opts.Guardrails.MaxDeletes <= 0 {
+				opts.Guardrails.MaxDeletes = ps.Guardrails.MaxDeletes
+			}
+			if opts.Guardrails.MaxDeletePercent <= 0 {
+				opts.Guardrails.MaxDeletePercent = ps.Guardrails.MaxDeletePercent
+			}
+		}
+
 		targetURNs := []resource.URN{}
 		for _, t := range targets {
 			targetURNs = append(targetURNs, resource.URN(t))
@@ -119,15 +141,32 @@ func newUpCmd() *cobra.Command {
 			replaceURNs = append(replaceURNs, resource.URN(tr))
 		}
 
+		defaultTimeouts, err := deploy.NewDefaultTimeouts(ps.Timeouts)
+		if err != nil {
+			return result.FromError(err)
+		}
+		naming, err := namingPolicy(proj, ps, s)
+		if err != nil {
+			return result.FromError(err)
+		}
+
 		opts.Engine = engine.UpdateOptions{
 			LocalPolicyPackPaths: policyPackPaths,
-			Parallel:             parallel,
+			Parallel:             getParallel(parallel, proj),
+			ProviderParallel:     ps.ProviderParallel,
 			Debug:                debug,
 			Refresh:              refresh,
 			ReplaceTargets:       replaceURNs,
 			UseLegacyDiff:        useLegacyDiff(),
 			UpdateTargets:        targetURNs,
 			TargetDependents:     targetDependents,
+			DefaultTimeouts:      defaultTimeouts,
+			Naming:               naming,
+		}
+		if planPath != "" {
+			if opts.Engine.ApplyPlan, err = readSavedPlan(planPath); err != nil {
+				return result.FromError(err)
+			}
 		}
 
 		changes, res := s.Update(commandContext(), backend.UpdateOperation{
@@ -145,7 +184,17 @@ func newUpCmd() *cobra.Command {
 		case res != nil:
 			return PrintEngineResult(res)
 		case expectNop && changes != nil && changes.HasChanges():
-			return result.FromError(errors.New("error: no changes were expected but changes occurred"))
+			return result.FromError(cmdutil.ErrorWithExitCode(
+				errors.New("error: no changes were expected but changes occurred"), cmdutil.ExitCodeChanges))
+		case cascade:
+			var args []string
+			if yes {
+				args = append(args, "--yes")
+			}
+			if skipPreview {
+				args = append(args, "--skip-preview")
+			}
+			return result.WrapIfNonNil(cascadeUpdate(s, ps, root, args))
 		default:
 			return nil
 		}
@@ -256,7 +305,7 @@ func newUpCmd() *cobra.Command {
 		}
 
 		// Prompt for config values (if needed) and save.
-		if err = handleConfig(s, templateNameOrURL, template, configArray, yes, path, opts.Display); err != nil {
+		if err = handleConfig(s, templateNameOrURL, template, configArray, nil, yes, path, opts.Display); err != nil {
 			return result.FromError(err)
 		}
 
@@ -282,7 +331,7 @@ func newUpCmd() *cobra.Command {
 
 		opts.Engine = engine.UpdateOptions{
 			LocalPolicyPackPaths: policyPackPaths,
-			Parallel:             parallel,
+			Parallel:             getParallel(parallel, proj),
 			Debug:                debug,
 			Refresh:              refresh,
 		}
@@ -307,7 +356,8 @@ func newUpCmd() *cobra.Command {
 		case res != nil:
 			return PrintEngineResult(res)
 		case expectNop && changes != nil && changes.HasChanges():
-			return result.FromError(errors.New("error: no changes were expected but changes occurred"))
+			return result.FromError(cmdutil.ErrorWithExitCode(
+				errors.New("error: no changes were expected but changes occurred"), cmdutil.ExitCodeChanges))
 		default:
 			return nil
 		}
@@ -331,6 +381,11 @@ func newUpCmd() *cobra.Command {
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			// An explicit --yes also approves creating the stack named by --stack, if it doesn't exist yet.
+			if yes {
+				createMissingStack = true
+			}
+
 			interactive := cmdutil.Interactive()
 			if !interactive {
 				yes = true // auto-approve changes, since we cannot prompt.
@@ -359,7 +414,12 @@ func newUpCmd() *cobra.Command {
 				Debug:                debug,
 			}
 
+			opts.Guardrails = backend.ChangeGuardrails{MaxDeletes: maxDeletes, MaxDeletePercent: maxDeletePercent}
+
 			if len(args) > 0 {
+				if planPath != "" {
+					return result.FromError(errors.New("--plan cannot be used when creating a project from a template"))
+				}
 				return upTemplateNameOrURL(args[0], opts)
 			}
 
@@ -387,8 +447,11 @@ func newUpCmd() *cobra.Command {
 		"Config keys contain a path to a property in a map or list to set")
 	cmd.PersistentFlags().StringVar(
 		&secretsProvider, "secrets-provider", "default", "The type of the provider that should be used to encrypt and "+
-			"decrypt secrets (possible choices: default, passphrase, awskms, azurekeyvault, gcpkms, hashivault). Only"+
-			"used when creating a new stack from an existing template")
+			"decrypt secrets (possible choices: default, passphrase, awskms, azurekeyvault, gcpkms, hashivault). Only "+
+			"used when creating a new stack")
+	cmd.PersistentFlags().BoolVar(
+		&createMissingStack, "create-stack", false,
+		"Create the stack named by --stack, without prompting, if it does not exist")
 
 	cmd.PersistentFlags().StringVarP(
 		&message, "message", "m", "",
@@ -400,7 +463,7 @@ func newUpCmd() *cobra.Command {
 			" Multiple resources can be specified using --target urn1 --target urn2")
 	cmd.PersistentFlags().StringArrayVar(
 		&replaces, "replace", []string{},
-		"Specify resources to replace. Multiple resources can be specified using --replace run1 --replace urn2")
+		"Specify resources to replace. Multiple resources can be specified using --replace urn1 --replace urn2")
 	cmd.PersistentFlags().StringArrayVar(
 		&targetReplaces, "target-replace", []string{},
 		"Specify a single resource URN to replace. Other resources will not be updated."+
@@ -408,6 +471,20 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows updating of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().StringVar(
+		&planPath, "plan", "",
+		"Apply a plan saved by `pulumi preview --save-plan`, failing if the update's steps diverge from it")
+	cmd.PersistentFlags().BoolVar(
+		&cascade, "cascade", false,
+		"After a successful update, also update the stacks declared as dependents of this stack, in dependency order")
+	cmd.PersistentFlags().IntVar(
+		&maxDeletes, "max-deletes", 0,
+		"Require extra confirmation, or abort if non-interactive, if the update would delete or replace more than "+
+			"this many resources")
+	cmd.PersistentFlags().Float64Var(
+		&maxDeletePercent, "max-delete-percent", 0,
+		"Require extra confirmation, or abort if non-interactive, if the update would delete or replace more than "+
+			"this percentage of the stack's resources")
 
 	// Flags for engine.UpdateOptions.
 	if hasDebugCommands() || hasExperimentalCommands() {
@@ -420,7 +497,8 @@ func newUpCmd() *cobra.Command {
 		"Display operation as a rich diff showing the overall change")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
-		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to the project's"+
+			" 'parallel' setting, or unbounded.")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
@@ -462,6 +540,7 @@ func handleConfig(
 	templateNameOrURL string,
 	template workspace.Template,
 	configArray []string,
+	secretConfigArray []string,
 	yes bool,
 	path bool,
 	opts display.Options) error {
@@ -494,6 +573,19 @@ func handleConfig(
 		if parseErr != nil {
 			return parseErr
 		}
+		if len(secretConfigArray) > 0 {
+			sm, smErr := getStackSecretsManager(s)
+			if smErr != nil {
+				return smErr
+			}
+			encrypter, encErr := sm.Encrypter()
+			if encErr != nil {
+				return encErr
+			}
+			if parseErr = parseSecretConfig(commandLineConfig, secretConfigArray, path, encrypter); parseErr != nil {
+				return parseErr
+			}
+		}
 
 		// Prompt for config as needed.
 		c, err = promptForConfig(s, template.Config, commandLineConfig, stackConfig, yes, opts)
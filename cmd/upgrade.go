@@ -0,0 +1,298 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/httputil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/version"
+)
+
+// cliDownloadURLEnvVar is the name of an environment variable that, if set, overrides the server from which CLI
+// releases are downloaded, e.g. to use a mirror.
+const cliDownloadURLEnvVar = "PULUMI_CLI_DOWNLOAD_URL"
+
+func newUpgradeCmd() *cobra.Command {
+	var targetVersion string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade the Pulumi CLI to the latest or a specific version",
+		Long: "Upgrade the Pulumi CLI to the latest or a specific version.\n" +
+			"\n" +
+			"This command downloads the release of the CLI for the current platform, verifies its checksum, and\n" +
+			"replaces the running executable and the language hosts installed alongside it. CLIs installed with\n" +
+			"a package manager such as Homebrew should be upgraded with that package manager instead.\n" +
+			"\n" +
+			"Releases are downloaded from " + defaultCLIDownloadURL + " unless the " + cliDownloadURLEnvVar + "\n" +
+			"environment variable names a mirror.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			// Development builds may not have a version, in which case any release is considered newer.
+			current, err := semver.ParseTolerant(version.Version)
+			if err != nil {
+				logging.V(3).Infof("error parsing current version: %s", err)
+			}
+
+			var target semver.Version
+			if targetVersion != "" {
+				if target, err = semver.ParseTolerant(targetVersion); err != nil {
+					return errors.Wrapf(err, "invalid version '%s'", targetVersion)
+				}
+			} else {
+				if target, _, err = getCLIVersionInfo(); err != nil {
+					return errors.Wrap(err, "determining the latest version")
+				}
+				if !target.GT(current) {
+					fmt.Printf("The Pulumi CLI is up to date (version %s).\n", current)
+					return nil
+				}
+			}
+
+			exe, err := os.Executable()
+			if err != nil {
+				return err
+			}
+			if exe, err = filepath.EvalSymlinks(exe); err != nil {
+				return err
+			}
+			if isBrew, _ := isBrewInstall(exe); isBrew {
+				return errors.New("the Pulumi CLI was installed with Homebrew; run `brew upgrade pulumi` instead")
+			}
+
+			dir := filepath.Dir(exe)
+			prompt := fmt.Sprintf("This will replace the Pulumi CLI in %s (version %s) with version %s.",
+				dir, current, target)
+			if !yes && !confirmPrompt(prompt, "yes", opts) {
+				fmt.Println("confirmation declined")
+				return nil
+			}
+
+			name, err := cliArchiveName(target, runtime.GOOS, runtime.GOARCH)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Downloading %s...\n", name)
+			archive, err := downloadCLIFile(name)
+			if err != nil {
+				return err
+			}
+			checksums, err := downloadCLIFile(fmt.Sprintf("pulumi-%s-checksums.txt", target))
+			if err != nil {
+				return err
+			}
+			if err = verifyChecksum(name, archive, checksums); err != nil {
+				return err
+			}
+
+			files, err := extractCLIFiles(name, archive)
+			if err != nil {
+				return errors.Wrapf(err, "extracting %s", name)
+			}
+			if err = installCLIFiles(dir, files); err != nil {
+				return err
+			}
+			fmt.Printf("Upgraded the Pulumi CLI to version %s.\n", target)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&targetVersion, "version", "", "The version to install, instead of the latest version")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false, "Skip confirmation prompts, and proceed with the upgrade anyway")
+
+	return cmd
+}
+
+const defaultCLIDownloadURL = "https://get.pulumi.com/releases/sdk"
+
+// cliArchiveName returns the name of the release archive of the given version of the CLI for a platform.
+func cliArchiveName(v semver.Version, goos, goarch string) (string, error) {
+	if goarch != "amd64" {
+		return "", errors.Errorf("there are no releases of the Pulumi CLI for %s/%s", goos, goarch)
+	}
+	switch goos {
+	case "darwin", "linux":
+		return fmt.Sprintf("pulumi-v%s-%s-x64.tar.gz", v, goos), nil
+	case "windows":
+		return fmt.Sprintf("pulumi-v%s-windows-x64.zip", v), nil
+	default:
+		return "", errors.Errorf("there are no releases of the Pulumi CLI for %s/%s", goos, goarch)
+	}
+}
+
+// downloadCLIFile downloads a file from the server that hosts CLI releases.
+func downloadCLIFile(name string) ([]byte, error) {
+	base := defaultCLIDownloadURL
+	if mirror := os.Getenv(cliDownloadURLEnvVar); mirror != "" {
+		base = strings.TrimSuffix(mirror, "/")
+	}
+	u, err := url.Parse(base + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httputil.DoWithRetry(&http.Request{Method: http.MethodGet, URL: u}, http.DefaultClient)
+	if err != nil {
+		return nil, errors.Wrapf(err, "downloading %s", u)
+	}
+	defer contract.IgnoreClose(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("downloading %s: %s", u, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// verifyChecksum checks the SHA-256 checksum of a downloaded file against the entry for it in a checksums file, in
+// which each line has the form "<hex digest>  <file name>".
+func verifyChecksum(name string, contents, checksums []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+		sum := sha256.Sum256(contents)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return errors.Errorf("the checksum of %s does not match the published checksum", name)
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return errors.Errorf("there is no published checksum for %s", name)
+}
+
+// extractCLIFiles returns the executables in a CLI release archive, keyed by file name.  Releases place the
+// executables in a top-level "pulumi" directory, or in "pulumi/bin" on Windows.
+func extractCLIFiles(name string, archive []byte) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	add := func(p string, r io.Reader) error {
+		dir, file := path.Split(strings.TrimPrefix(p, "./"))
+		if file == "" || (dir != "pulumi/" && dir != "pulumi/bin/") {
+			return nil
+		}
+		contents, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		files[file] = contents
+		return nil
+	}
+
+	if strings.HasSuffix(name, ".zip") {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			r, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			err = add(f.Name, r)
+			contract.IgnoreClose(r)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		gzr, err := gzip.NewReader(bytes.NewReader(archive))
+		if err != nil {
+			return nil, err
+		}
+		tr := tar.NewReader(gzr)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			if header.Typeflag != tar.TypeReg {
+				continue
+			}
+			if err = add(header.Name, tr); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if _, has := files["pulumi"]; !has {
+		if _, has = files["pulumi.exe"]; !has {
+			return nil, errors.New("the archive does not contain the pulumi executable")
+		}
+	}
+	return files, nil
+}
+
+// installCLIFiles writes the given executables into a directory, replacing any existing files.  Each file is first
+// written alongside its destination and then renamed over it, so that a failure never leaves a partially written
+// executable behind.  Running executables cannot be replaced on Windows, so any existing file is moved aside first.
+func installCLIFiles(dir string, files map[string][]byte) error {
+	for name, contents := range files {
+		dest := filepath.Join(dir, name)
+		temp := dest + ".new"
+		if err := ioutil.WriteFile(temp, contents, 0755); err != nil {
+			return errors.Wrapf(err, "writing %s", temp)
+		}
+		if runtime.GOOS == "windows" {
+			old := dest + ".old"
+			contract.IgnoreError(os.Remove(old))
+			if err := os.Rename(dest, old); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "moving %s aside", dest)
+			}
+		}
+		if err := os.Rename(temp, dest); err != nil {
+			return errors.Wrapf(err, "replacing %s", dest)
+		}
+	}
+	return nil
+}
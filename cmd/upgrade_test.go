@@ -0,0 +1,102 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLIArchiveName(t *testing.T) {
+	v := semver.MustParse("1.6.0")
+
+	name, err := cliArchiveName(v, "linux", "amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, "pulumi-v1.6.0-linux-x64.tar.gz", name)
+
+	name, err = cliArchiveName(v, "windows", "amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, "pulumi-v1.6.0-windows-x64.zip", name)
+
+	_, err = cliArchiveName(v, "linux", "arm")
+	assert.Error(t, err)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	contents := []byte("release")
+	sum := sha256.Sum256(contents)
+	checksums := []byte("0000  other.tar.gz\n" + hex.EncodeToString(sum[:]) + "  release.tar.gz\n")
+
+	assert.NoError(t, verifyChecksum("release.tar.gz", contents, checksums))
+	assert.Error(t, verifyChecksum("release.tar.gz", []byte("tampered"), checksums))
+	assert.Error(t, verifyChecksum("missing.tar.gz", contents, checksums))
+}
+
+func TestExtractAndInstallCLIFiles(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for name, contents := range map[string]string{
+		"pulumi/pulumi":                 "cli",
+		"pulumi/pulumi-language-nodejs": "nodejs",
+		"README.md":                     "ignored",
+	} {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Mode: 0755, Size: int64(len(contents)), Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(contents))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gzw.Close())
+
+	files, err := extractCLIFiles("pulumi-v1.6.0-linux-x64.tar.gz", buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"pulumi":                 []byte("cli"),
+		"pulumi-language-nodejs": []byte("nodejs"),
+	}, files)
+
+	dir, err := ioutil.TempDir("", "pulumi-upgrade-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "pulumi"), []byte("old"), 0755))
+
+	assert.NoError(t, installCLIFiles(dir, files))
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "pulumi"))
+	assert.NoError(t, err)
+	assert.Equal(t, "cli", string(contents))
+	_, err = os.Stat(filepath.Join(dir, "pulumi.new"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSkipUpdateCheck(t *testing.T) {
+	defer os.Setenv(skipUpdateCheckEnvVar, os.Getenv(skipUpdateCheckEnvVar))
+
+	assert.NoError(t, os.Setenv(skipUpdateCheckEnvVar, "true"))
+	assert.True(t, skipUpdateCheck())
+	assert.NoError(t, os.Setenv(skipUpdateCheckEnvVar, "false"))
+	assert.False(t, skipUpdateCheck())
+}
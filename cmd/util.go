@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"os/exec"
@@ -42,6 +43,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/state"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
 	"github.com/pulumi/pulumi/pkg/util/cancel"
 	"github.com/pulumi/pulumi/pkg/util/ciutil"
@@ -113,6 +115,21 @@ func createStack(
 	// secrets provider or one of the cloud-backed secrets providers.  We do not need to do this
 	// for the Pulumi service backend secrets provider.
 	isDefaultSecretsProvider := secretsProvider == "" || secretsProvider == "default"
+	if isDefaultSecretsProvider {
+		// If the project declares a secrets provider for its stacks, use it in place of the backend's default.
+		if proj, _, err := readProject(); err == nil {
+			projectProvider, err := proj.StackSecretsProvider(stackRef.Name())
+			if err != nil {
+				return nil, err
+			}
+			if projectProvider != "" {
+				if err = validateSecretsProvider(projectProvider); err != nil {
+					return nil, errors.Wrap(err, "invalid project 'secretsProvider'")
+				}
+				secretsProvider, isDefaultSecretsProvider = projectProvider, projectProvider == "default"
+			}
+		}
+	}
 	if _, ok := b.(filestate.Backend); ok && isDefaultSecretsProvider {
 		// The default when using the filestate backend is the passphrase secrets provider
 		secretsProvider = passphrase.Type
@@ -208,6 +225,37 @@ func requireStack(
 	return nil, errors.Errorf("no stack named '%s' found", stackName)
 }
 
+// requireOrCreateStack is like requireStack, except that if create is true and the named stack does not exist, it is
+// created without prompting, using the given secrets provider.
+func requireOrCreateStack(stackName string, create bool, opts display.Options, setCurrent bool,
+	secretsProvider string) (backend.Stack, error) {
+
+	if stackName == "" || !create {
+		return requireStack(stackName, true, opts, setCurrent)
+	}
+
+	if err := validateSecretsProvider(secretsProvider); err != nil {
+		return nil, err
+	}
+
+	b, err := currentBackend(opts)
+	if err != nil {
+		return nil, err
+	}
+	stackRef, err := b.ParseStackReference(stackName)
+	if err != nil {
+		return nil, err
+	}
+	stack, err := b.GetStack(commandContext(), stackRef)
+	if err != nil {
+		return nil, err
+	} else if stack != nil {
+		return stack, nil
+	}
+
+	return createStack(b, stackRef, nil, setCurrent, secretsProvider)
+}
+
 func requireCurrentStack(offerNew bool, opts display.Options, setCurrent bool) (backend.Stack, error) {
 	// Search for the current stack.
 	b, err := currentBackend(opts)
@@ -353,6 +401,15 @@ func parseAndSaveConfigArray(s backend.Stack, configArray []string, path bool) e
 	return nil
 }
 
+// getParallel returns the largest number of resource operations that an update may run at once: the value of
+// --parallel, if it was given, or else the project's default, if it has one.
+func getParallel(parallel int, proj *workspace.Project) int {
+	if parallel == defaultParallel && proj.Parallel > 0 {
+		return proj.Parallel
+	}
+	return parallel
+}
+
 // readProject attempts to detect and read a Pulumi project for the current workspace. If the
 // project is successfully detected and read, it is returned along with the path to its containing
 // directory, which will be used as the root of the project's Pulumi program.
@@ -380,6 +437,20 @@ func readProject() (*workspace.Project, string, error) {
 	return proj, filepath.Dir(path), nil
 }
 
+// namingPolicy returns the naming policy for the given stack of a project, which is taken from the stack's settings
+// if they have one, and otherwise from the project.
+func namingPolicy(proj *workspace.Project, ps *workspace.ProjectStack, s backend.Stack) (*deploy.NamingPolicy, error) {
+	policy := proj.Naming
+	if ps.Naming != nil {
+		policy = ps.Naming
+	}
+	result, err := deploy.NewNamingPolicy(policy, proj.Name, s.Ref().Name())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid naming policy")
+	}
+	return result, nil
+}
+
 // readPolicyProject attempts to detect and read a Pulumi PolicyPack project for the current
 // workspace. If the project is successfully detected and read, it is returned along with the path
 // to its containing directory, which will be used as the root of the project's Pulumi program.
@@ -693,3 +764,25 @@ func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOpt
 		SkipPreview: skipPreview,
 	}, nil
 }
+
+// readSavedPlan reads a plan that was saved by `pulumi preview --save-plan`.
+func readSavedPlan(path string) (*deploy.SavedPlan, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading plan")
+	}
+	var plan deploy.SavedPlan
+	if err = json.Unmarshal(b, &plan); err != nil {
+		return nil, errors.Wrapf(err, "could not parse plan '%s'", path)
+	}
+	return &plan, nil
+}
+
+// writeSavedPlan writes a plan for `pulumi up --plan` to apply.
+func writeSavedPlan(path string, plan *deploy.SavedPlan) error {
+	b, err := json.MarshalIndent(plan, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "serializing plan")
+	}
+	return errors.Wrap(ioutil.WriteFile(path, b, 0644), "writing plan")
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend"
 	pul_testing "github.com/pulumi/pulumi/pkg/testing"
 	"github.com/pulumi/pulumi/pkg/util/gitutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -226,3 +227,14 @@ func TestReadingGitLabMetadata(t *testing.T) {
 		assertEnvValue(t, test, backend.VCSRepoKind, gitutil.GitLabHostName)
 	}
 }
+
+func TestGetParallel(t *testing.T) {
+	proj := &workspace.Project{Name: "proj"}
+	assert.Equal(t, defaultParallel, getParallel(defaultParallel, proj))
+	assert.Equal(t, 4, getParallel(4, proj))
+
+	// The project's default applies only when --parallel is not given.
+	proj.Parallel = 8
+	assert.Equal(t, 8, getParallel(defaultParallel, proj))
+	assert.Equal(t, 4, getParallel(4, proj))
+}
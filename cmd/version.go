@@ -17,19 +17,48 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/version"
-	"github.com/spf13/cobra"
 )
 
 func newVersionCmd() *cobra.Command {
-	return &cobra.Command{
+	var check bool
+
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print Pulumi's version number",
 		Args:  cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			fmt.Printf("%v\n", version.Version)
+			if !check {
+				return nil
+			}
+
+			// Development builds may not have a version, in which case any release is considered newer.
+			current, err := semver.ParseTolerant(version.Version)
+			if err != nil {
+				logging.V(3).Infof("error parsing current version: %s", err)
+			}
+			latest, _, err := getCLIVersionInfo()
+			if err != nil {
+				return errors.Wrap(err, "determining the latest version")
+			}
+			if latest.GT(current) {
+				fmt.Printf("A newer version, %s, is available. Run `pulumi upgrade` to install it.\n", latest)
+			} else {
+				fmt.Println("This is the latest version.")
+			}
 			return nil
 		}),
 	}
+
+	cmd.PersistentFlags().BoolVar(
+		&check, "check", false, "Check whether a newer version of the CLI is available")
+
+	return cmd
 }
@@ -108,7 +108,7 @@ func newWatchCmd() *cobra.Command {
 
 			opts.Engine = engine.UpdateOptions{
 				LocalPolicyPackPaths: policyPackPaths,
-				Parallel:             parallel,
+				Parallel:             getParallel(parallel, proj),
 				Debug:                debug,
 				Refresh:              refresh,
 				UseLegacyDiff:        useLegacyDiff(),
@@ -166,7 +166,8 @@ func newWatchCmd() *cobra.Command {
 	}
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
-		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to the project's"+
+			" 'parallel' setting, or unbounded.")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before each update")
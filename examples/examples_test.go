@@ -333,7 +333,8 @@ func getCwd(t *testing.T) string {
 
 func getBaseOptions() integration.ProgramTestOptions {
 	return integration.ProgramTestOptions{
-		Dependencies: []string{"@pulumi/pulumi"},
+		Dependencies:     []string{"@pulumi/pulumi"},
+		CheckIdempotency: true,
 	}
 }
 
@@ -287,6 +287,11 @@ type ResourceV3 struct {
 	Aliases []resource.URN `json:"aliases,omitempty" yaml:"aliases,omitempty"`
 	// CustomTimeouts is a configuration block that can be used to control timeouts of CRUD operations
 	CustomTimeouts *resource.CustomTimeouts `json:"customTimeouts,omitempty" yaml:"customTimeouts,omitempty"`
+	// InputHash is a hash of the program's inputs for the resource and of its provider's inputs.  If neither have
+	// changed, the resource's checked inputs may be reused without consulting its provider.
+	InputHash string `json:"inputHash,omitempty" yaml:"inputHash,omitempty"`
+	// Hooks are the names of the hooks, registered by the program, to run around the resource's operations.
+	Hooks *resource.Hooks `json:"hooks,omitempty" yaml:"hooks,omitempty"`
 }
 
 // ManifestV1 captures meta-information about this checkpoint file, such as versions of binaries, etc.
@@ -314,7 +319,9 @@ type PluginInfoV1 struct {
 // NOTE: nothing produces these values yet. This type is merely a placeholder for future use.
 type SecretV1 struct {
 	Sig        string `json:"4dabf18193072939515e22adb298388d" yaml:"4dabf18193072939515e22adb298388d"`
-	Ciphertext string `json:"ciphertext" yaml:"ciphertext"`
+	Ciphertext string `json:"ciphertext,omitempty" yaml:"ciphertext,omitempty"`
+	// Plaintext is the JSON-encoded value of the secret, for deployments exported with their secrets decrypted.
+	Plaintext string `json:"plaintext,omitempty" yaml:"plaintext,omitempty"`
 }
 
 // ConfigValue describes a single (possibly secret) configuration value.
@@ -167,6 +167,8 @@ type ResourcePreEvent struct {
 type ResOutputsEvent struct {
 	Metadata StepEventMetadata `json:"metadata"`
 	Planning bool              `json:"planning,omitempty"`
+	// DurationSeconds is the number of seconds the resource operation took, if it was performed.
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
 }
 
 // ResOpFailedEvent is emitted when a resource operation fails. Typically a DiagnosticEvent is
@@ -175,6 +177,8 @@ type ResOpFailedEvent struct {
 	Metadata StepEventMetadata `json:"metadata"`
 	Status   int               `json:"status"`
 	Steps    int               `json:"steps"`
+	// DurationSeconds is the number of seconds the resource operation ran before it failed.
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
 }
 
 // EngineEvent describes a Pulumi engine event, such as a change to a resource or diagnostic
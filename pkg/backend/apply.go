@@ -30,6 +30,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/result"
 )
@@ -116,6 +118,14 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 		return changes, res
 	}
 
+	// Make sure the update stays within its guardrails.
+	if kind == apitype.UpdateUpdate {
+		if res = checkGuardrails(ctx, stack, changes, op.Opts); res != nil {
+			close(eventsChannel)
+			return changes, res
+		}
+	}
+
 	// If there are no changes, or we're auto-approving or just previewing, we can skip the confirmation prompt.
 	if op.Opts.AutoApprove || kind == apitype.PreviewUpdate {
 		close(eventsChannel)
@@ -192,7 +202,7 @@ func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind,
 	op UpdateOperation, apply Applier) (engine.ResourceChanges, result.Result) {
 	// Preview the operation to the user and ask them if they want to proceed.
 
-	if !op.Opts.SkipPreview {
+	if !op.Opts.SkipPreview || (kind == apitype.UpdateUpdate && !op.Opts.Guardrails.IsZero()) {
 		changes, res := PreviewThenPrompt(ctx, kind, stack, op, apply)
 		if res != nil || kind == apitype.PreviewUpdate {
 			return changes, res
@@ -200,12 +210,34 @@ func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind,
 	}
 
 	// Perform the change (!DryRun) and show the cloud link to the result.
-	// We don't care about the events it issues, so just pass a nil channel along.
 	opts := ApplierOptions{
 		DryRun:   false,
 		ShowLink: true,
 	}
-	return apply(ctx, kind, stack, op, opts, nil /*events*/)
+	//
+	// We only watch the events for resource operations, so that a failure is reported as one that may have changed
+	// the stack's resources only if the update got as far as operating on one.
+	eventsChannel := make(chan engine.Event)
+	operated := make(chan bool, 1)
+	go func() {
+		started := false
+		for e := range eventsChannel {
+			switch e.Type {
+			case engine.ResourceOperationFailed:
+				started = true
+			case engine.ResourceOutputsEvent:
+				started = started || e.Payload.(engine.ResourceOutputsEventPayload).Metadata.Op != deploy.OpSame
+			}
+		}
+		operated <- started
+	}()
+
+	changes, res := apply(ctx, kind, stack, op, opts, eventsChannel)
+	close(eventsChannel)
+	if <-operated {
+		res = cmdutil.ResultWithExitCode(res, cmdutil.ExitCodeUpdateFailed)
+	}
+	return changes, res
 }
 
 func createDiff(updateKind apitype.UpdateKind, events []engine.Event, displayOpts display.Options) string {
@@ -0,0 +1,69 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func TestExecuteExitCode(t *testing.T) {
+	// failingApplier returns an applier that emits the given events and then fails.
+	failingApplier := func(events ...engine.Event) Applier {
+		return func(ctx context.Context, kind apitype.UpdateKind, stack Stack, op UpdateOperation,
+			opts ApplierOptions, eventsOpt chan<- engine.Event) (engine.ResourceChanges, result.Result) {
+
+			for _, e := range events {
+				eventsOpt <- e
+			}
+			return nil, result.FromError(errors.New("update failed"))
+		}
+	}
+	execute := func(apply Applier) result.Result {
+		op := UpdateOperation{Opts: UpdateOptions{SkipPreview: true}}
+		_, res := PreviewThenPromptThenExecute(context.Background(), apitype.UpdateUpdate, nil, op, apply)
+		return res
+	}
+	outputs := func(op deploy.StepOp) engine.Event {
+		return engine.Event{
+			Type:    engine.ResourceOutputsEvent,
+			Payload: engine.ResourceOutputsEventPayload{Metadata: engine.StepEventMetadata{Op: op}},
+		}
+	}
+
+	// An update that fails before it operates on any resource does not exit with ExitCodeUpdateFailed.
+	res := execute(failingApplier(outputs(deploy.OpSame)))
+	assert.NotNil(t, res)
+	_, ok := res.(cmdutil.ExitCoder)
+	assert.False(t, ok)
+
+	// An update that fails once it has operated on a resource does.
+	for _, e := range []engine.Event{outputs(deploy.OpCreate), {Type: engine.ResourceOperationFailed}} {
+		res = execute(failingApplier(e))
+		coder, ok := res.(cmdutil.ExitCoder)
+		if assert.True(t, ok) {
+			assert.Equal(t, cmdutil.ExitCodeUpdateFailed, coder.ExitCode())
+		}
+	}
+}
@@ -172,6 +172,9 @@ type Backend interface {
 
 	// ExportDeployment exports the deployment for the given stack as an opaque JSON message.
 	ExportDeployment(ctx context.Context, stack Stack) (*apitype.UntypedDeployment, error)
+	// ExportDeploymentVersion exports the deployment that resulted from the update with the given version, as
+	// reported by GetHistory, as an opaque JSON message.
+	ExportDeploymentVersion(ctx context.Context, stack Stack, version int) (*apitype.UntypedDeployment, error)
 	// ImportDeployment imports the given deployment into the indicated stack.
 	ImportDeployment(ctx context.Context, stack Stack, deployment *apitype.UntypedDeployment) error
 	// Logout logs you out of the backend and removes any stored credentials.
@@ -218,6 +221,9 @@ type UpdateOptions struct {
 	AutoApprove bool
 	// SkipPreview, when true, causes the preview step to be skipped.
 	SkipPreview bool
+	// Guardrails limit the resources an update may delete or replace without additional confirmation.  Because they
+	// are checked against a preview, updates subject to guardrails are always previewed.
+	Guardrails ChangeGuardrails
 }
 
 // QueryOptions configures a query to operate against a backend and the engine.
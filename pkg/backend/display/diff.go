@@ -242,11 +242,82 @@ func renderSummaryEvent(action apitype.UpdateKind, event engine.SummaryEventPayl
 
 		fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("\n%sDuration:%s %s\n",
 			colors.SpecHeadline, colors.Reset, roundedDuration)))
+
+		if event.Duration >= timingSummaryThreshold {
+			renderResourceTimings(out, event.ResourceTimings, opts)
+		}
 	}
 
 	return out.String()
 }
 
+const (
+	// timingSummaryThreshold is the shortest update for which the time taken by its resources is summarized.
+	timingSummaryThreshold = time.Minute
+	// slowestResourceCount is the number of slowest resources that are listed in the summary.
+	slowestResourceCount = 5
+)
+
+// renderResourceTimings writes a summary of the resource operations that took the longest time, followed by the
+// total time taken by the operations on each provider's resources.
+func renderResourceTimings(out io.Writer, timings []engine.ResourceTiming, opts Options) {
+	if len(timings) == 0 {
+		return
+	}
+	round := func(d time.Duration) string {
+		return (time.Duration(math.Ceil(d.Seconds())) * time.Second).String()
+	}
+
+	slowest := make([]engine.ResourceTiming, len(timings))
+	copy(slowest, timings)
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].Duration > slowest[j].Duration
+	})
+	if len(slowest) > slowestResourceCount {
+		slowest = slowest[:slowestResourceCount]
+	}
+	fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("\n%sSlowest resources:%s\n",
+		colors.SpecHeadline, colors.Reset)))
+	maxLen := 0
+	for _, t := range slowest {
+		if l := len(round(t.Duration)); l > maxLen {
+			maxLen = l
+		}
+	}
+	for _, t := range slowest {
+		d := round(t.Duration)
+		fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("    %s%s%s%s %s %s%s\n",
+			d, messagePadding(d, maxLen, 2), t.Op.Prefix(), t.Op, t.Type, t.URN.Name(), colors.Reset)))
+	}
+
+	totals := make(map[string]time.Duration)
+	var providers []string
+	for _, t := range timings {
+		pkg := string(t.Type.Package())
+		if _, has := totals[pkg]; !has {
+			providers = append(providers, pkg)
+		}
+		totals[pkg] += t.Duration
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		if totals[providers[i]] != totals[providers[j]] {
+			return totals[providers[i]] > totals[providers[j]]
+		}
+		return providers[i] < providers[j]
+	})
+	fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("\n%sTime by provider:%s\n",
+		colors.SpecHeadline, colors.Reset)))
+	maxLen = 0
+	for _, pkg := range providers {
+		if len(pkg) > maxLen {
+			maxLen = len(pkg)
+		}
+	}
+	for _, pkg := range providers {
+		fprintfIgnoreError(out, "    %s%s%s\n", pkg, messagePadding(pkg, maxLen, 2), round(totals[pkg]))
+	}
+}
+
 func renderPreludeEvent(event engine.PreludeEventPayload, opts Options) string {
 	// Only if we have been instructed to show configuration values will we print anything during the prelude.
 	if !opts.ShowConfig {
@@ -0,0 +1,64 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestRenderResourceTimings(t *testing.T) {
+	timing := func(typ tokens.Type, name tokens.QName, op deploy.StepOp, d time.Duration) engine.ResourceTiming {
+		return engine.ResourceTiming{
+			URN:      resource.NewURN("dev", "proj", "", typ, name),
+			Type:     typ,
+			Op:       op,
+			Duration: d,
+		}
+	}
+	event := engine.SummaryEventPayload{
+		Duration:        20 * time.Minute,
+		ResourceChanges: engine.ResourceChanges{deploy.OpCreate: 2, deploy.OpUpdate: 1},
+		ResourceTimings: []engine.ResourceTiming{
+			timing("aws:s3/bucket:Bucket", "site", deploy.OpCreate, 1500*time.Millisecond),
+			timing("aws:rds/instance:Instance", "db", deploy.OpCreate, 12*time.Minute),
+			timing("kubernetes:apps/v1:Deployment", "app", deploy.OpUpdate, 2*time.Minute),
+		},
+	}
+	opts := Options{Color: colors.Never}
+
+	out := renderSummaryEvent(apitype.UpdateUpdate, event, opts)
+	assert.Contains(t, out, "Slowest resources:\n"+
+		"    12m0s  + create aws:rds/instance:Instance db\n"+
+		"    2m0s   ~ update kubernetes:apps/v1:Deployment app\n"+
+		"    2s     + create aws:s3/bucket:Bucket site\n")
+	assert.Contains(t, out, "Time by provider:\n"+
+		"    aws         12m2s\n"+
+		"    kubernetes  2m0s\n")
+
+	// Short updates are not summarized.
+	event.Duration = 30 * time.Second
+	out = renderSummaryEvent(apitype.UpdateUpdate, event, opts)
+	assert.NotContains(t, out, "Slowest resources:")
+}
@@ -42,7 +42,8 @@ func ShowEvents(
 
 	if opts.JSONDisplay {
 		// TODO[pulumi/pulumi#2390]: enable JSON display for real deployments.
-		contract.Assertf(isPreview, "JSON display only available in preview mode")
+		contract.Assertf(isPreview || action == apitype.RefreshUpdate,
+			"JSON display only available in preview mode and for refreshes")
 		ShowJSONEvents(op, action, events, done, opts)
 		return
 	}
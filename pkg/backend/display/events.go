@@ -107,8 +107,9 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			return apiEvent, eventTypePayloadMismatch
 		}
 		apiEvent.ResOutputsEvent = &apitype.ResOutputsEvent{
-			Metadata: convertStepEventMetadata(p.Metadata),
-			Planning: p.Planning,
+			Metadata:        convertStepEventMetadata(p.Metadata),
+			Planning:        p.Planning,
+			DurationSeconds: p.Duration.Seconds(),
 		}
 
 	case engine.ResourceOperationFailed:
@@ -117,9 +118,10 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			return apiEvent, eventTypePayloadMismatch
 		}
 		apiEvent.ResOpFailedEvent = &apitype.ResOpFailedEvent{
-			Metadata: convertStepEventMetadata(p.Metadata),
-			Status:   int(p.Status),
-			Steps:    p.Steps,
+			Metadata:        convertStepEventMetadata(p.Metadata),
+			Status:          int(p.Status),
+			Steps:           p.Steps,
+			DurationSeconds: p.Duration.Seconds(),
 		}
 
 	default:
@@ -88,10 +88,10 @@ func stateForJSONOutput(s *resource.State, opts Options) *resource.State {
 		s.PropertyDependencies, s.PendingReplacement, s.AdditionalSecretOutputs, s.Aliases, &s.CustomTimeouts)
 }
 
-// ShowJSONEvents renders engine events from a preview into a well-formed JSON document. Note that this does not
-// emit events incrementally so that it can guarantee anything emitted to stdout is well-formed. This means that,
-// if used interactively, the experience will lead to potentially very long pauses. If run in CI, it is up to the
-// end user to ensure that output is periodically printed to prevent tools from thinking preview has hung.
+// ShowJSONEvents renders engine events from a preview or a refresh into a well-formed JSON document. Note that this
+// does not emit events incrementally so that it can guarantee anything emitted to stdout is well-formed. This means
+// that, if used interactively, the experience will lead to potentially very long pauses. If run in CI, it is up to
+// the end user to ensure that output is periodically printed to prevent tools from thinking preview has hung.
 func ShowJSONEvents(op string, action apitype.UpdateKind, events <-chan engine.Event, done chan<- bool, opts Options) {
 	// Ensure we close the done channel before exiting.
 	defer func() { close(done) }()
@@ -130,54 +130,29 @@ func ShowJSONEvents(op string, action apitype.UpdateKind, events <-chan engine.E
 				Severity: diag.Info,
 			})
 		case engine.ResourcePreEvent:
+			// Refresh steps are recorded once their results are known; see below.
+			if action == apitype.RefreshUpdate {
+				break
+			}
+
 			// Create the detailed metadata for this step and the initial state of its resource. Later,
 			// if new outputs arrive, we'll search for and swap in those new values.
 			if m := e.Payload.(engine.ResourcePreEventPayload).Metadata; shouldShow(m, opts) || isRootStack(m) {
-				var detailedDiff map[string]propertyDiff
-				if m.DetailedDiff != nil {
-					detailedDiff = make(map[string]propertyDiff)
-					for k, v := range m.DetailedDiff {
-						detailedDiff[k] = propertyDiff{
-							Kind:      v.Kind.String(),
-							InputDiff: v.InputDiff,
-						}
-					}
-				}
-
-				step := &previewStep{
-					Op:             m.Op,
-					URN:            m.URN,
-					Provider:       m.Provider,
-					DiffReasons:    m.Diffs,
-					ReplaceReasons: m.Keys,
-					DetailedDiff:   detailedDiff,
-				}
-
-				if m.Old != nil {
-					oldState := stateForJSONOutput(m.Old.State, opts)
-					res, err := stack.SerializeResource(oldState, config.NewPanicCrypter())
-					if err == nil {
-						step.OldState = &res
-					} else {
-						logging.V(7).Infof("not adding old state as there was an error serialzing: %s", err)
-					}
-				}
-				if m.New != nil {
-					newState := stateForJSONOutput(m.New.State, opts)
-					res, err := stack.SerializeResource(newState, config.NewPanicCrypter())
-					if err == nil {
-						step.NewState = &res
-					} else {
-						logging.V(7).Infof("not adding new state as there was an error serialzing: %s", err)
-					}
+				digest.Steps = append(digest.Steps, previewStepForJSONOutput(m, opts))
+			}
+		case engine.ResourceOutputsEvent:
+			// The outputs of a refresh step describe any drift that the refresh discovered: the step's op is
+			// the result of the refresh, and its old and new states are the states before and after the refresh.
+			// Previews, on the other hand, have nothing further to record once their steps are known.
+			if action == apitype.RefreshUpdate {
+				if m := e.Payload.(engine.ResourceOutputsEventPayload).Metadata; shouldShow(m, opts) {
+					digest.Steps = append(digest.Steps, refreshStepForJSONOutput(m, opts))
 				}
-
-				digest.Steps = append(digest.Steps, step)
 			}
-		case engine.ResourceOutputsEvent, engine.ResourceOperationFailed:
-			// Because we are only JSON serializing previews, we don't need to worry about outputs
-			// resolving or operations failing. In the future, if we serialize actual deployments, we will
-			// need to come up with a scheme for matching the failure to the associated step.
+		case engine.ResourceOperationFailed:
+			// Because we are only JSON serializing previews and refreshes, we don't need to worry about
+			// operations failing. In the future, if we serialize actual deployments, we will need to come up
+			// with a scheme for matching the failure to the associated step.
 
 		// Events ocurring late:
 		case engine.SummaryEvent:
@@ -197,6 +172,86 @@ func ShowJSONEvents(op string, action apitype.UpdateKind, events <-chan engine.E
 	fmt.Println(string(out))
 }
 
+// previewStepForJSONOutput creates the JSON representation of a step that the engine intends to take, including the
+// properties that cause it to update or replace its resource.
+func previewStepForJSONOutput(m engine.StepEventMetadata, opts Options) *previewStep {
+	var detailedDiff map[string]propertyDiff
+	if m.DetailedDiff != nil {
+		detailedDiff = make(map[string]propertyDiff)
+		for k, v := range m.DetailedDiff {
+			detailedDiff[k] = propertyDiff{
+				Kind:      v.Kind.String(),
+				InputDiff: v.InputDiff,
+			}
+		}
+	}
+
+	step := &previewStep{
+		Op:             m.Op,
+		URN:            m.URN,
+		Provider:       m.Provider,
+		DiffReasons:    m.Diffs,
+		ReplaceReasons: m.Keys,
+		DetailedDiff:   detailedDiff,
+	}
+
+	if m.Old != nil {
+		oldState := stateForJSONOutput(m.Old.State, opts)
+		res, err := stack.SerializeResource(oldState, config.NewPanicCrypter())
+		if err == nil {
+			step.OldState = &res
+		} else {
+			logging.V(7).Infof("not adding old state as there was an error serialzing: %s", err)
+		}
+	}
+	if m.New != nil {
+		newState := stateForJSONOutput(m.New.State, opts)
+		res, err := stack.SerializeResource(newState, config.NewPanicCrypter())
+		if err == nil {
+			step.NewState = &res
+		} else {
+			logging.V(7).Infof("not adding new state as there was an error serialzing: %s", err)
+		}
+	}
+	return step
+}
+
+// refreshStepForJSONOutput creates the JSON representation of a completed refresh step.  The step's diff reasons are
+// the outputs that changed as a result of the refresh.
+func refreshStepForJSONOutput(m engine.StepEventMetadata, opts Options) *previewStep {
+	step := &previewStep{
+		Op:       m.Op,
+		URN:      m.URN,
+		Provider: m.Provider,
+	}
+	if m.Old != nil {
+		res, err := stack.SerializeResource(stateForJSONOutput(m.Old.State, opts), config.NewPanicCrypter())
+		if err == nil {
+			step.OldState = &res
+		} else {
+			logging.V(7).Infof("not adding old state as there was an error serialzing: %s", err)
+		}
+	}
+	if m.New != nil {
+		res, err := stack.SerializeResource(stateForJSONOutput(m.New.State, opts), config.NewPanicCrypter())
+		if err == nil {
+			step.NewState = &res
+		} else {
+			logging.V(7).Infof("not adding new state as there was an error serialzing: %s", err)
+		}
+	}
+	if m.Old != nil && m.New != nil {
+		if diff := m.Old.State.Outputs.Diff(m.New.State.Outputs); diff != nil {
+			for _, k := range diff.Keys() {
+				if diff.Changed(k) {
+					step.DiffReasons = append(step.DiffReasons, k)
+				}
+			}
+		}
+	}
+	return step
+}
+
 // previewDigest is a JSON-serializable overview of a preview operation.
 type previewDigest struct {
 	// Config contains a map of configuration keys/values used during the preview. Any secrets will be blinded.
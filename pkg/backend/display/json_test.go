@@ -0,0 +1,119 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+func TestPreviewStepForJSONOutput(t *testing.T) {
+	urn := resource.NewURN("dev", "proj", "", "aws:s3/bucket:Bucket", "b")
+	state := func(inputs resource.PropertyMap) *engine.StepEventStateMetadata {
+		return &engine.StepEventStateMetadata{State: &resource.State{
+			URN: urn, Type: urn.Type(), Custom: true, ID: "b-1", Inputs: inputs,
+		}}
+	}
+
+	// A replacement reports the properties that cause it, and the detailed diff of each, with secrets masked.
+	step := previewStepForJSONOutput(engine.StepEventMetadata{
+		Op:       deploy.OpReplace,
+		URN:      urn,
+		Provider: "urn:pulumi:dev::proj::pulumi:providers:aws::default::id",
+		Old: state(resource.PropertyMap{
+			"bucket": resource.NewStringProperty("a"),
+			"token":  resource.MakeSecret(resource.NewStringProperty("a")),
+		}),
+		New: state(resource.PropertyMap{
+			"bucket": resource.NewStringProperty("b"),
+			"token":  resource.MakeSecret(resource.NewStringProperty("b")),
+		}),
+		Keys:  []resource.PropertyKey{"bucket"},
+		Diffs: []resource.PropertyKey{"bucket", "token"},
+		DetailedDiff: map[string]plugin.PropertyDiff{
+			"bucket": {Kind: plugin.DiffUpdateReplace, InputDiff: true},
+			"token":  {Kind: plugin.DiffUpdate},
+		},
+	}, Options{})
+	assert.Equal(t, deploy.OpReplace, step.Op)
+	assert.Equal(t, urn, step.URN)
+	assert.Equal(t, "urn:pulumi:dev::proj::pulumi:providers:aws::default::id", step.Provider)
+	assert.Equal(t, []resource.PropertyKey{"bucket"}, step.ReplaceReasons)
+	assert.Equal(t, []resource.PropertyKey{"bucket", "token"}, step.DiffReasons)
+	assert.Equal(t, map[string]propertyDiff{
+		"bucket": {Kind: "update-replace", InputDiff: true},
+		"token":  {Kind: "update"},
+	}, step.DetailedDiff)
+	assert.Equal(t, "a", step.OldState.Inputs["bucket"])
+	assert.Equal(t, "b", step.NewState.Inputs["bucket"])
+	assert.Equal(t, "[secret]", step.NewState.Inputs["token"])
+
+	// A create has no old state and no detailed diff.
+	step = previewStepForJSONOutput(engine.StepEventMetadata{
+		Op:  deploy.OpCreate,
+		URN: urn,
+		New: state(resource.PropertyMap{"bucket": resource.NewStringProperty("b")}),
+	}, Options{})
+	assert.Equal(t, deploy.OpCreate, step.Op)
+	assert.Nil(t, step.OldState)
+	assert.NotNil(t, step.NewState)
+	assert.Nil(t, step.DetailedDiff)
+}
+
+func TestRefreshStepForJSONOutput(t *testing.T) {
+	urn := resource.NewURN("dev", "proj", "", "aws:s3/bucket:Bucket", "b")
+	state := func(outputs resource.PropertyMap) *engine.StepEventStateMetadata {
+		return &engine.StepEventStateMetadata{State: &resource.State{
+			URN: urn, Type: urn.Type(), Custom: true, ID: "b-1", Outputs: outputs,
+		}}
+	}
+
+	// A resource whose properties drifted reports the changed properties, with secrets masked.
+	step := refreshStepForJSONOutput(engine.StepEventMetadata{
+		Op:  deploy.OpUpdate,
+		URN: urn,
+		Old: state(resource.PropertyMap{
+			"acl":   resource.NewStringProperty("private"),
+			"tags":  resource.NewObjectProperty(resource.PropertyMap{}),
+			"token": resource.MakeSecret(resource.NewStringProperty("a")),
+		}),
+		New: state(resource.PropertyMap{
+			"acl":   resource.NewStringProperty("public-read"),
+			"tags":  resource.NewObjectProperty(resource.PropertyMap{}),
+			"token": resource.MakeSecret(resource.NewStringProperty("b")),
+		}),
+	}, Options{})
+	assert.Equal(t, deploy.OpUpdate, step.Op)
+	assert.Equal(t, []resource.PropertyKey{"acl", "token"}, step.DiffReasons)
+	assert.Equal(t, "public-read", step.NewState.Outputs["acl"])
+	assert.Equal(t, "[secret]", step.NewState.Outputs["token"])
+
+	// A resource that no longer exists has no new state.
+	step = refreshStepForJSONOutput(engine.StepEventMetadata{
+		Op:  deploy.OpDelete,
+		URN: urn,
+		Old: state(resource.PropertyMap{"acl": resource.NewStringProperty("private")}),
+	}, Options{})
+	assert.Equal(t, deploy.OpDelete, step.Op)
+	assert.NotNil(t, step.OldState)
+	assert.Nil(t, step.NewState)
+	assert.Empty(t, step.DiffReasons)
+}
@@ -2,6 +2,8 @@ package backend
 
 import (
 	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
 // ConflictingUpdateError represents an error which occurred while starting an update/destroy operation.
@@ -10,6 +12,11 @@ type ConflictingUpdateError struct {
 	Err error // The error that occurred while starting the operation.
 }
 
+// ExitCode fails the command that returns the error with cmdutil.ExitCodeConflict.
+func (c ConflictingUpdateError) ExitCode() int {
+	return cmdutil.ExitCodeConflict
+}
+
 func (c ConflictingUpdateError) Error() string {
 	return fmt.Sprintf("%s\nTo learn more about possible reasons and resolution, visit "+
 		"https://www.pulumi.com/docs/troubleshooting/#conflict", c.Err.Error())
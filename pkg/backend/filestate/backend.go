@@ -57,7 +57,14 @@ import (
 // Backend extends the base backend interface with specific information about local backends.
 type Backend interface {
 	backend.Backend
-	local() // at the moment, no local specific info, so just use a marker function.
+	local() // a marker function, distinguishing local backends from others.
+
+	// PruneHistory removes the update history entries and checkpoint backups of the given stack that the policy does
+	// not keep, returning the number of history entries and backups that were removed.
+	PruneHistory(ctx context.Context, stackRef backend.StackReference, policy RetentionPolicy) (int, int, error)
+
+	// CancelCurrentUpdate asks the update that is running on the given stack, if any, to cancel.
+	CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error
 }
 
 type localBackend struct {
@@ -106,7 +113,12 @@ func New(d diag.Sink, originalURL string) (Backend, error) {
 		return nil, err
 	}
 
-	bucket, err := blob.OpenBucket(context.TODO(), u)
+	bucketURL, sse, err := s3BucketURL(u)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid backend URL %s", originalURL)
+	}
+
+	bucket, err := blob.OpenBucket(context.TODO(), bucketURL)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to open bucket %s", u)
 	}
@@ -130,7 +142,7 @@ func New(d diag.Sink, originalURL string) (Backend, error) {
 		d:           d,
 		originalURL: originalURL,
 		url:         u,
-		bucket:      &wrappedBucket{bucket: bucket},
+		bucket:      &wrappedBucket{bucket: bucket, sse: sse},
 	}, nil
 }
 
@@ -312,6 +324,17 @@ func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, for
 
 func (b *localBackend) RenameStack(ctx context.Context, stack backend.Stack, newName tokens.QName) error {
 	stackName := stack.Ref().Name()
+
+	// An update that is running would go on saving its checkpoint under the old name.  An update that crashed on this
+	// machine is not running, so it does not prevent the rename.
+	update, err := b.currentUpdate(ctx, stackName)
+	if err != nil {
+		return err
+	} else if update != nil {
+		return errors.Errorf("stack '%s' has an update in progress, run by process %d on %s; "+
+			"wait for it to finish, or cancel it", stackName, update.PID, update.Hostname)
+	}
+
 	snap, _, err := b.getStack(stackName)
 	if err != nil {
 		return err
@@ -425,6 +448,15 @@ func (b *localBackend) apply(
 		return nil, result.FromError(err)
 	}
 
+	// Record the running update, so that `pulumi cancel` can ask it to cancel.
+	if !opts.DryRun {
+		endUpdate, err := b.beginUpdate(stackName, kind)
+		if err != nil {
+			return nil, result.FromError(err)
+		}
+		defer endUpdate()
+	}
+
 	// Spawn a display loop to show events on the CLI.
 	displayEvents := make(chan engine.Event)
 	displayDone := make(chan bool)
@@ -436,6 +468,10 @@ func (b *localBackend) apply(
 	engineEvents := make(chan engine.Event)
 
 	scope := op.Scopes.NewScope(engineEvents, opts.DryRun)
+	cancelCtx, stopWatching := scope.Context(), func() {}
+	if !opts.DryRun {
+		cancelCtx, stopWatching = b.watchForCancel(stackName, cancelCtx)
+	}
 	eventsDone := make(chan bool)
 	go func() {
 		// Pull in all events from the engine and send them to the two listeners.
@@ -455,7 +491,7 @@ func (b *localBackend) apply(
 	persister := b.newSnapshotPersister(stackName, op.SecretsManager)
 	manager := backend.NewSnapshotManager(persister, update.GetTarget().Snapshot)
 	engineCtx := &engine.Context{
-		Cancel:          scope.Context(),
+		Cancel:          cancelCtx,
 		Events:          engineEvents,
 		SnapshotManager: manager,
 		BackendClient:   backend.NewBackendClient(b),
@@ -478,6 +514,7 @@ func (b *localBackend) apply(
 		contract.Failf("Unrecognized update kind: %s", kind)
 	}
 	end := time.Now().Unix()
+	stopWatching()
 
 	// Wait for the display to finish showing all the events.
 	<-displayDone
@@ -513,6 +550,9 @@ func (b *localBackend) apply(
 	if !opts.DryRun {
 		saveErr = b.addToHistory(stackName, info)
 		backupErr = b.backupStack(stackName)
+		if saveErr == nil && backupErr == nil {
+			b.applyRetentionPolicy(stackName)
+		}
 	}
 
 	if updateRes != nil {
@@ -629,6 +669,29 @@ func (b *localBackend) ExportDeployment(ctx context.Context,
 	}, nil
 }
 
+func (b *localBackend) ExportDeploymentVersion(ctx context.Context, stk backend.Stack,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	chk, err := b.getHistoryCheckpoint(stk.Ref().Name(), version)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := chk.Latest
+	if deployment == nil {
+		deployment = &apitype.DeploymentV3{}
+	}
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
 func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	deployment *apitype.UntypedDeployment) error {
 
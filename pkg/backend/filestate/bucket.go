@@ -27,12 +27,15 @@ type Bucket interface {
 // are appropriately normalized to use forward slashes as required by it.  Without this, we may use
 // filepath.join which can make paths like `c:\temp\etc`.  gocloud's fileblob then converts those
 // backslashes to the hex string __0x5c__, breaking things on windows completely.
+//
+// For S3 buckets, it also requests the configured server-side encryption for every object it writes.
 type wrappedBucket struct {
 	bucket *blob.Bucket
+	sse    *serverSideEncryption
 }
 
 func (b *wrappedBucket) Copy(ctx context.Context, dstKey, srcKey string, opts *blob.CopyOptions) (err error) {
-	return b.bucket.Copy(ctx, filepath.ToSlash(dstKey), filepath.ToSlash(srcKey), opts)
+	return b.bucket.Copy(ctx, filepath.ToSlash(dstKey), filepath.ToSlash(srcKey), b.sse.copyOptions(opts))
 }
 
 func (b *wrappedBucket) Delete(ctx context.Context, key string) (err error) {
@@ -52,7 +55,7 @@ func (b *wrappedBucket) ReadAll(ctx context.Context, key string) (_ []byte, err
 }
 
 func (b *wrappedBucket) WriteAll(ctx context.Context, key string, p []byte, opts *blob.WriterOptions) (err error) {
-	return b.bucket.WriteAll(ctx, filepath.ToSlash(key), p, opts)
+	return b.bucket.WriteAll(ctx, filepath.ToSlash(key), p, b.sse.writerOptions(opts))
 }
 
 func (b *wrappedBucket) Exists(ctx context.Context, key string) (bool, error) {
@@ -0,0 +1,171 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cancel"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/fsutil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// cancelPollInterval is how often a running update checks whether it has been asked to cancel.
+var cancelPollInterval = time.Second
+
+// runningUpdate is the record that an update keeps in the state directory while it runs on a stack.
+type runningUpdate struct {
+	Kind      apitype.UpdateKind `json:"kind"`
+	StartTime int64              `json:"startTime"`
+	Hostname  string             `json:"hostname,omitempty"`
+	PID       int                `json:"pid"`
+}
+
+func (b *localBackend) updatePath(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return filepath.Join(b.StateDir(), workspace.UpdateDir, fsutil.QnamePath(stack)+".json")
+}
+
+func (b *localBackend) cancelPath(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return filepath.Join(b.StateDir(), workspace.UpdateDir, fsutil.QnamePath(stack)+".cancel")
+}
+
+// CancelCurrentUpdate asks the update that is running on the given stack to cancel.  The update stops starting new
+// resource operations, waits for those that are in flight, and then saves the stack's checkpoint, just as it does when
+// it is interrupted.  The request is stored in the state directory, so it reaches updates run on other machines.
+func (b *localBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
+	stackName := stackRef.Name()
+	update, err := b.currentUpdate(ctx, stackName)
+	if err != nil {
+		return err
+	} else if update == nil {
+		return errors.Errorf("stack '%s' has no update in progress", stackName)
+	}
+	return errors.Wrap(b.bucket.WriteAll(ctx, b.cancelPath(stackName), []byte("{}"), nil), "requesting cancellation")
+}
+
+// currentUpdate returns the record of the update that is running on the given stack, or nil if there is none.  A
+// record left behind by an update that ran on this machine and whose process has since exited, e.g. because it
+// crashed before it could remove the record, is removed along with any request to cancel the update.
+func (b *localBackend) currentUpdate(ctx context.Context, stackName tokens.QName) (*runningUpdate, error) {
+	updatePath := b.updatePath(stackName)
+	byts, err := b.bucket.ReadAll(ctx, updatePath)
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "checking for a running update")
+	}
+	var update runningUpdate
+	if err = json.Unmarshal(byts, &update); err != nil {
+		return nil, errors.Wrap(err, "reading the record of the running update")
+	}
+
+	if hostname, err := os.Hostname(); err == nil && update.Hostname == hostname && !processExists(update.PID) {
+		logging.V(5).Infof("removing the record of the update of %s by process %d, which is no longer running",
+			stackName, update.PID)
+		if err = b.deleteIfExists(b.cancelPath(stackName)); err != nil {
+			return nil, err
+		}
+		if err = b.deleteIfExists(updatePath); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return &update, nil
+}
+
+// beginUpdate records that an update is running on the given stack, clearing any request to cancel an earlier update,
+// e.g. one that exited before it could see the request.  The returned function must be called once the update has
+// finished.
+func (b *localBackend) beginUpdate(stackName tokens.QName, kind apitype.UpdateKind) (func(), error) {
+	cancelPath := b.cancelPath(stackName)
+	if err := b.deleteIfExists(cancelPath); err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	contract.IgnoreError(err)
+	byts, err := json.MarshalIndent(runningUpdate{
+		Kind:      kind,
+		StartTime: time.Now().Unix(),
+		Hostname:  hostname,
+		PID:       os.Getpid(),
+	}, "", "    ")
+	contract.AssertNoError(err)
+	updatePath := b.updatePath(stackName)
+	if err = b.bucket.WriteAll(context.TODO(), updatePath, byts, nil); err != nil {
+		return nil, errors.Wrap(err, "recording running update")
+	}
+
+	return func() {
+		contract.IgnoreError(b.deleteIfExists(cancelPath))
+		contract.IgnoreError(b.deleteIfExists(updatePath))
+	}, nil
+}
+
+// watchForCancel returns a cancellation context for the update that is running on the given stack.  It is canceled or
+// terminated along with parent, and is also canceled if CancelCurrentUpdate is called for the stack.  The returned
+// function stops watching for requests to cancel.
+func (b *localBackend) watchForCancel(stackName tokens.QName, parent *cancel.Context) (*cancel.Context, func()) {
+	cancelPath := b.cancelPath(stackName)
+	updateCtx, updateSource := cancel.NewContext(context.Background())
+	done, exited := make(chan bool), make(chan bool)
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(cancelPollInterval)
+		defer ticker.Stop()
+
+		canceled := parent.Canceled()
+		for {
+			select {
+			case <-done:
+				return
+			case <-parent.Terminated():
+				updateSource.Terminate()
+				return
+			case <-canceled:
+				updateSource.Cancel()
+				canceled = nil // keep waiting for termination.
+			case <-ticker.C:
+				requested, err := b.bucket.Exists(context.TODO(), cancelPath)
+				if err != nil {
+					logging.V(5).Infof("checking for a request to cancel the update failed: %v", err)
+				} else if requested {
+					logging.V(5).Infof("cancellation of the update of %s was requested", stackName)
+					updateSource.Cancel()
+				}
+			}
+		}
+	}()
+
+	return updateCtx, func() {
+		close(done)
+		<-exited
+	}
+}
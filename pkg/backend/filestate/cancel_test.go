@@ -0,0 +1,155 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cancel"
+)
+
+func TestCancelCurrentUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(dir)) }()
+
+	be, err := New(diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{}), FilePathPrefix+dir)
+	assert.NoError(t, err)
+	b := be.(*localBackend)
+
+	defer func(interval time.Duration) { cancelPollInterval = interval }(cancelPollInterval)
+	cancelPollInterval = 10 * time.Millisecond
+
+	ctx := context.Background()
+	ref := localBackendReference{name: "dev"}
+
+	// A stack with no running update cannot be canceled.
+	assert.Error(t, b.CancelCurrentUpdate(ctx, ref))
+
+	// A running update is canceled, but not terminated, when asked to.
+	endUpdate, err := b.beginUpdate(ref.name, apitype.UpdateUpdate)
+	assert.NoError(t, err)
+	parent, parentSource := cancel.NewContext(ctx)
+	updateCtx, stopWatching := b.watchForCancel(ref.name, parent)
+	assert.NoError(t, b.CancelCurrentUpdate(ctx, ref))
+	select {
+	case <-updateCtx.Canceled():
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "the update was not canceled")
+	}
+	assert.NoError(t, updateCtx.TerminateErr())
+	stopWatching()
+	endUpdate()
+
+	// Once the update has finished, neither its record nor the request to cancel it remains.
+	assert.Error(t, b.CancelCurrentUpdate(ctx, ref))
+	exists, err := b.bucket.Exists(ctx, b.cancelPath(ref.name))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Terminating the update's parent context terminates the update.
+	updateCtx, stopWatching = b.watchForCancel(ref.name, parent)
+	parentSource.Terminate()
+	select {
+	case <-updateCtx.Terminated():
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "the update was not terminated")
+	}
+	stopWatching()
+}
+
+// recordCrashedUpdate records an update on the given stack by a process on this machine that has since exited.
+func recordCrashedUpdate(t *testing.T, b *localBackend, stackName tokens.QName) {
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	assert.NoError(t, cmd.Run())
+
+	hostname, err := os.Hostname()
+	assert.NoError(t, err)
+	byts, err := json.Marshal(runningUpdate{
+		Kind:      apitype.UpdateUpdate,
+		StartTime: time.Now().Unix(),
+		Hostname:  hostname,
+		PID:       cmd.Process.Pid,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, b.bucket.WriteAll(context.Background(), b.updatePath(stackName), byts, nil))
+}
+
+func TestCancelCrashedUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(dir)) }()
+
+	be, err := New(diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{}), FilePathPrefix+dir)
+	assert.NoError(t, err)
+	b := be.(*localBackend)
+
+	ctx := context.Background()
+	ref := localBackendReference{name: "dev"}
+
+	// An update whose process has exited without removing its record is not running, so it cannot be canceled, and
+	// its record is removed.
+	recordCrashedUpdate(t, b, ref.name)
+	assert.Error(t, b.CancelCurrentUpdate(ctx, ref))
+	exists, err := b.bucket.Exists(ctx, b.updatePath(ref.name))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = b.bucket.Exists(ctx, b.cancelPath(ref.name))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestRenameStackDuringUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(dir)) }()
+
+	be, err := New(diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{}), FilePathPrefix+dir)
+	assert.NoError(t, err)
+	b := be.(*localBackend)
+
+	ctx := context.Background()
+	s, err := b.CreateStack(ctx, localBackendReference{name: "dev"}, nil)
+	assert.NoError(t, err)
+
+	// A stack cannot be renamed while it is being updated.
+	endUpdate, err := b.beginUpdate("dev", apitype.UpdateUpdate)
+	assert.NoError(t, err)
+	assert.Error(t, b.RenameStack(ctx, s, "prod"))
+
+	endUpdate()
+	assert.NoError(t, b.RenameStack(ctx, s, "prod"))
+	renamed, err := b.GetStack(ctx, localBackendReference{name: "prod"})
+	assert.NoError(t, err)
+	assert.NotNil(t, renamed)
+
+	// An update that crashed without removing its record does not prevent the stack from being renamed.
+	recordCrashedUpdate(t, b, "prod")
+	assert.NoError(t, b.RenameStack(ctx, renamed, "staging"))
+	renamed, err = b.GetStack(ctx, localBackendReference{name: "staging"})
+	assert.NoError(t, err)
+	assert.NotNil(t, renamed)
+}
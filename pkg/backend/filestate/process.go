@@ -0,0 +1,34 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package filestate
+
+import (
+	"os"
+	"syscall"
+)
+
+// processExists returns true if a process with the given ID is running on this machine.
+func processExists(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// Signal 0 checks that the process exists without disturbing it.  EPERM means that it exists, but belongs to
+	// another user.
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || err == syscall.EPERM
+}
@@ -0,0 +1,209 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+const (
+	// RetainHistoryCountEnvVar is the name of an environment variable that, if set, limits the number of update
+	// history entries and checkpoint backups that are kept for each stack.
+	RetainHistoryCountEnvVar = "PULUMI_RETAIN_HISTORY_COUNT"
+	// RetainHistoryAgeEnvVar is the name of an environment variable that, if set, causes update history entries and
+	// checkpoint backups older than the given duration (e.g. "720h" or "30d") to be removed.
+	RetainHistoryAgeEnvVar = "PULUMI_RETAIN_HISTORY_AGE"
+)
+
+// RetentionPolicy describes which update history entries and checkpoint backups are kept for a stack.  The most
+// recent entry is always kept.  The zero value keeps everything.
+type RetentionPolicy struct {
+	// Count, if positive, is the number of most recent entries to keep.
+	Count int
+	// MaxAge, if positive, is the age beyond which entries are removed.
+	MaxAge time.Duration
+}
+
+// IsZero returns true if the policy keeps everything.
+func (p RetentionPolicy) IsZero() bool {
+	return p.Count <= 0 && p.MaxAge <= 0
+}
+
+// keep returns true if the entry at the given index (oldest first) of n entries, recorded at time t, should be kept.
+func (p RetentionPolicy) keep(index, n int, t time.Time, now time.Time) bool {
+	if index == n-1 {
+		return true
+	}
+	if p.Count > 0 && index < n-p.Count {
+		return false
+	}
+	if p.MaxAge > 0 && !t.IsZero() && now.Sub(t) > p.MaxAge {
+		return false
+	}
+	return true
+}
+
+// ParseRetentionAge parses an age for a retention policy.  In addition to the units understood by
+// time.ParseDuration, a suffix of "d" may be used to specify a number of days.
+func ParseRetentionAge(s string) (time.Duration, error) {
+	if days := strings.TrimSuffix(s, "d"); days != s {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, errors.Errorf("invalid age '%s'", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errors.Errorf("invalid age '%s'", s)
+	}
+	return d, nil
+}
+
+// RetentionPolicyFromEnv returns the retention policy configured by the RetainHistoryCountEnvVar and
+// RetainHistoryAgeEnvVar environment variables.
+func RetentionPolicyFromEnv() (RetentionPolicy, error) {
+	var policy RetentionPolicy
+	if count := os.Getenv(RetainHistoryCountEnvVar); count != "" {
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 1 {
+			return policy, errors.Errorf("%s must be a positive integer", RetainHistoryCountEnvVar)
+		}
+		policy.Count = n
+	}
+	if age := os.Getenv(RetainHistoryAgeEnvVar); age != "" {
+		d, err := ParseRetentionAge(age)
+		if err != nil {
+			return policy, errors.Wrapf(err, "parsing %s", RetainHistoryAgeEnvVar)
+		}
+		policy.MaxAge = d
+	}
+	return policy, nil
+}
+
+func (b *localBackend) PruneHistory(ctx context.Context, stackRef backend.StackReference,
+	policy RetentionPolicy) (int, int, error) {
+
+	return b.pruneHistory(stackRef.Name(), policy)
+}
+
+// pruneHistory removes the update history entries, along with their checkpoints, and the checkpoint backups of the
+// given stack that the policy does not keep.  It returns the number of history entries and backups removed.
+func (b *localBackend) pruneHistory(name tokens.QName, policy RetentionPolicy) (int, int, error) {
+	if policy.IsZero() {
+		return 0, 0, nil
+	}
+	now := time.Now()
+
+	entries, err := b.getHistoryEntries(name)
+	if err != nil {
+		return 0, 0, err
+	}
+	removedHistory := 0
+	for i, entry := range entries {
+		if policy.keep(i, len(entries), fileTimestamp(entry.key, "-", ".history.json"), now) {
+			// Make sure that any history we keep remembers its version, as it can no longer be inferred from the
+			// entries before it once those are gone.
+			if !entry.stored {
+				byts, err := json.MarshalIndent(&entry.update, "", "    ")
+				if err != nil {
+					return removedHistory, 0, err
+				}
+				if err = b.bucket.WriteAll(context.TODO(), entry.key, byts, nil); err != nil {
+					return removedHistory, 0, err
+				}
+			}
+			continue
+		}
+
+		if err := b.deleteIfExists(entry.checkpointKey()); err != nil {
+			return removedHistory, 0, errors.Wrap(err, "deleting checkpoint file")
+		}
+		if err := b.bucket.Delete(context.TODO(), entry.key); err != nil {
+			return removedHistory, 0, errors.Wrap(err, "deleting history file")
+		}
+		removedHistory++
+	}
+
+	removedBackups := 0
+	backups, err := listBucket(b.bucket, b.backupDirectory(name))
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return removedHistory, 0, nil
+		}
+		return removedHistory, 0, err
+	}
+	for i, backup := range backups {
+		if policy.keep(i, len(backups), fileTimestamp(backup.Key, ".", ".json"), now) {
+			continue
+		}
+		if err := b.bucket.Delete(context.TODO(), backup.Key); err != nil {
+			return removedHistory, removedBackups, errors.Wrap(err, "deleting checkpoint backup")
+		}
+		removedBackups++
+	}
+
+	return removedHistory, removedBackups, nil
+}
+
+// deleteIfExists deletes the object with the given key, if there is one.
+func (b *localBackend) deleteIfExists(key string) error {
+	err := b.bucket.Delete(context.TODO(), key)
+	if err != nil && gcerrors.Code(errors.Cause(err)) != gcerrors.NotFound {
+		return err
+	}
+	return nil
+}
+
+// fileTimestamp extracts the time embedded in the name of a history or backup file.  History files are named
+// <stack>-<nanoseconds>.history.json and backups are named <stack>.<nanoseconds>.json.  If no time can be found,
+// the zero time is returned.
+func fileTimestamp(key, sep, suffix string) time.Time {
+	name := strings.TrimSuffix(path.Base(key), suffix)
+	idx := strings.LastIndex(name, sep)
+	if idx == -1 {
+		return time.Time{}
+	}
+	nanos, err := strconv.ParseInt(name[idx+1:], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// applyRetentionPolicy prunes the stack's history according to the policy configured in the environment, if any.
+// Failures are reported as warnings, since they do not affect the update that has just completed.
+func (b *localBackend) applyRetentionPolicy(name tokens.QName) {
+	policy, err := RetentionPolicyFromEnv()
+	if err == nil {
+		_, _, err = b.pruneHistory(name, policy)
+	}
+	if err != nil {
+		b.d.Warningf(diag.Message("" /*urn*/, "failed to prune update history: %v"), err)
+	}
+}
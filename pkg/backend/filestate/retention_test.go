@@ -0,0 +1,94 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestParseRetentionAge(t *testing.T) {
+	d, err := ParseRetentionAge("30d")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, d)
+
+	d, err = ParseRetentionAge("90m")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+
+	_, err = ParseRetentionAge("xd")
+	assert.Error(t, err)
+}
+
+func TestPruneHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(dir)) }()
+
+	be, err := New(diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{}), FilePathPrefix+dir)
+	assert.NoError(t, err)
+	b := be.(*localBackend)
+
+	// Write out five updates' worth of history, an hour apart, in the format that predates recorded versions.
+	name := tokens.QName("dev")
+	now := time.Now()
+	for i := 5; i > 0; i-- {
+		timestamp := now.Add(-time.Duration(i) * time.Hour).UnixNano()
+		prefix := path.Join(b.historyDirectory(name), fmt.Sprintf("%s-%d", name, timestamp))
+		assert.NoError(t, b.bucket.WriteAll(context.Background(), prefix+".history.json", []byte(`{"kind":"update"}`), nil))
+		assert.NoError(t, b.bucket.WriteAll(context.Background(), prefix+".checkpoint.json", []byte(`{}`), nil))
+	}
+
+	versions := func() []int {
+		updates, err := b.getHistory(name)
+		assert.NoError(t, err)
+		var vs []int
+		for _, u := range updates {
+			vs = append(vs, u.Version)
+		}
+		return vs
+	}
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, versions())
+
+	// Keeping the three most recent entries preserves their versions.
+	history, _, err := b.pruneHistory(name, RetentionPolicy{Count: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, history)
+	assert.Equal(t, []int{5, 4, 3}, versions())
+	files, err := listBucket(b.bucket, b.historyDirectory(name))
+	assert.NoError(t, err)
+	assert.Len(t, files, 6)
+
+	// New updates continue numbering from the most recent version.
+	next, err := b.nextHistoryVersion(name)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, next)
+
+	// Age-based pruning never removes the most recent entry.
+	history, _, err = b.pruneHistory(name, RetentionPolicy{MaxAge: 30 * time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, history)
+	assert.Equal(t, []int{5}, versions())
+}
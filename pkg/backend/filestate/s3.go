@@ -0,0 +1,173 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+	"gocloud.dev/blob"
+)
+
+const (
+	// S3EndpointEnvVar is the name of an environment variable that, if set, overrides the endpoint used by s3://
+	// backends, for example to use an S3-compatible store such as MinIO.
+	S3EndpointEnvVar = "PULUMI_BACKEND_S3_ENDPOINT"
+	// S3RegionEnvVar is the name of an environment variable that, if set, overrides the region of s3:// backends.
+	S3RegionEnvVar = "PULUMI_BACKEND_S3_REGION"
+	// S3ForcePathStyleEnvVar is the name of an environment variable that, if true, causes s3:// backends to address
+	// buckets by path rather than by virtual host, as many S3-compatible stores require.
+	S3ForcePathStyleEnvVar = "PULUMI_BACKEND_S3_FORCE_PATH_STYLE"
+	// S3SSEEnvVar is the name of an environment variable that, if set, is the server-side encryption algorithm (either
+	// "AES256" or "aws:kms") used for the objects written by s3:// backends.
+	S3SSEEnvVar = "PULUMI_BACKEND_S3_SSE"
+	// S3SSEKMSKeyIDEnvVar is the name of an environment variable that, if set, is the ID of the KMS key used to
+	// encrypt the objects written by s3:// backends.
+	S3SSEKMSKeyIDEnvVar = "PULUMI_BACKEND_S3_SSE_KMS_KEY_ID"
+)
+
+// s3QueryParams maps the query parameters of s3:// backend URLs that may also be set by environment variables to
+// those variables.  Parameters in the URL take precedence over the environment.
+var s3QueryParams = map[string]string{
+	"endpoint":         S3EndpointEnvVar,
+	"region":           S3RegionEnvVar,
+	"s3ForcePathStyle": S3ForcePathStyleEnvVar,
+	"sse":              S3SSEEnvVar,
+	"sseKmsKeyId":      S3SSEKMSKeyIDEnvVar,
+}
+
+// serverSideEncryption describes how the objects written to an S3 bucket are encrypted at rest.
+type serverSideEncryption struct {
+	Algorithm string // the encryption algorithm, either "AES256" or "aws:kms".
+	KMSKeyID  string // the KMS key to use with "aws:kms", or empty to use the default key.
+}
+
+// s3BucketURL applies the settings in the environment to an s3:// backend URL, and removes the query parameters that
+// configure server-side encryption, which are not understood by the S3 driver.  It returns the URL to open the bucket
+// with along with the server-side encryption settings, if any.  Other URLs are returned unchanged.
+//
+// In addition to the parameters that configure encryption, s3:// URLs accept the region, endpoint, disableSSL and
+// s3ForcePathStyle parameters understood by the S3 driver.
+func s3BucketURL(u string) (string, *serverSideEncryption, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", nil, err
+	}
+	if parsed.Scheme != "s3" {
+		return u, nil, nil
+	}
+
+	q := parsed.Query()
+	for param, envVar := range s3QueryParams {
+		if v := os.Getenv(envVar); v != "" && q.Get(param) == "" {
+			q.Set(param, v)
+		}
+	}
+	if v := q.Get("s3ForcePathStyle"); v != "" {
+		if _, err = strconv.ParseBool(v); err != nil {
+			return "", nil, errors.Errorf("invalid value '%s' for s3ForcePathStyle", v)
+		}
+	}
+
+	var sse *serverSideEncryption
+	algorithm, keyID := q.Get("sse"), q.Get("sseKmsKeyId")
+	q.Del("sse")
+	q.Del("sseKmsKeyId")
+	if algorithm == "" && keyID != "" {
+		algorithm = s3.ServerSideEncryptionAwsKms
+	}
+	switch algorithm {
+	case "":
+	case s3.ServerSideEncryptionAes256, s3.ServerSideEncryptionAwsKms:
+		if keyID != "" && algorithm != s3.ServerSideEncryptionAwsKms {
+			return "", nil, errors.Errorf("a KMS key may only be used with %s encryption",
+				s3.ServerSideEncryptionAwsKms)
+		}
+		sse = &serverSideEncryption{Algorithm: algorithm, KMSKeyID: keyID}
+	default:
+		return "", nil, errors.Errorf("unsupported server-side encryption algorithm '%s'; expected %s or %s",
+			algorithm, s3.ServerSideEncryptionAes256, s3.ServerSideEncryptionAwsKms)
+	}
+
+	parsed.RawQuery = q.Encode()
+	return parsed.String(), sse, nil
+}
+
+// beforeWrite returns a callback that requests server-side encryption for an object written to an S3 bucket.
+func (sse *serverSideEncryption) beforeWrite(
+	next func(func(interface{}) bool) error) func(func(interface{}) bool) error {
+	return func(as func(interface{}) bool) error {
+		var input *s3manager.UploadInput
+		if as(&input) {
+			input.ServerSideEncryption = aws.String(sse.Algorithm)
+			if sse.KMSKeyID != "" {
+				input.SSEKMSKeyId = aws.String(sse.KMSKeyID)
+			}
+		}
+		if next != nil {
+			return next(as)
+		}
+		return nil
+	}
+}
+
+// beforeCopy returns a callback that requests server-side encryption for an object copied within an S3 bucket.
+func (sse *serverSideEncryption) beforeCopy(
+	next func(func(interface{}) bool) error) func(func(interface{}) bool) error {
+	return func(as func(interface{}) bool) error {
+		var input *s3.CopyObjectInput
+		if as(&input) {
+			input.ServerSideEncryption = aws.String(sse.Algorithm)
+			if sse.KMSKeyID != "" {
+				input.SSEKMSKeyId = aws.String(sse.KMSKeyID)
+			}
+		}
+		if next != nil {
+			return next(as)
+		}
+		return nil
+	}
+}
+
+// writerOptions returns the given writer options, amended to request server-side encryption.
+func (sse *serverSideEncryption) writerOptions(opts *blob.WriterOptions) *blob.WriterOptions {
+	if sse == nil {
+		return opts
+	}
+	var result blob.WriterOptions
+	if opts != nil {
+		result = *opts
+	}
+	result.BeforeWrite = sse.beforeWrite(result.BeforeWrite)
+	return &result
+}
+
+// copyOptions returns the given copy options, amended to request server-side encryption.
+func (sse *serverSideEncryption) copyOptions(opts *blob.CopyOptions) *blob.CopyOptions {
+	if sse == nil {
+		return opts
+	}
+	var result blob.CopyOptions
+	if opts != nil {
+		result = *opts
+	}
+	result.BeforeCopy = sse.beforeCopy(result.BeforeCopy)
+	return &result
+}
@@ -0,0 +1,94 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3BucketURL(t *testing.T) {
+	// Non-S3 URLs are left alone.
+	u, sse, err := s3BucketURL("gs://bucket/dir?sse=AES256")
+	assert.NoError(t, err)
+	assert.Equal(t, "gs://bucket/dir?sse=AES256", u)
+	assert.Nil(t, sse)
+
+	// Encryption parameters are removed, and the driver's own parameters are kept.
+	u, sse, err = s3BucketURL("s3://bucket/dir?region=us-west-2&sseKmsKeyId=alias/state")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://bucket/dir?region=us-west-2", u)
+	assert.Equal(t, &serverSideEncryption{Algorithm: "aws:kms", KMSKeyID: "alias/state"}, sse)
+
+	_, _, err = s3BucketURL("s3://bucket?sse=AES256&sseKmsKeyId=alias/state")
+	assert.Error(t, err)
+	_, _, err = s3BucketURL("s3://bucket?sse=rot13")
+	assert.Error(t, err)
+
+	// Settings may come from the environment, but the URL takes precedence.
+	defer func() {
+		for _, envVar := range []string{S3EndpointEnvVar, S3ForcePathStyleEnvVar, S3RegionEnvVar, S3SSEEnvVar} {
+			assert.NoError(t, os.Unsetenv(envVar))
+		}
+	}()
+	assert.NoError(t, os.Setenv(S3EndpointEnvVar, "http://localhost:9000"))
+	assert.NoError(t, os.Setenv(S3ForcePathStyleEnvVar, "true"))
+	assert.NoError(t, os.Setenv(S3RegionEnvVar, "us-east-1"))
+	assert.NoError(t, os.Setenv(S3SSEEnvVar, "AES256"))
+	u, sse, err = s3BucketURL("s3://bucket?region=eu-west-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://bucket?endpoint=http%3A%2F%2Flocalhost%3A9000&region=eu-west-1&s3ForcePathStyle=true", u)
+	assert.Equal(t, &serverSideEncryption{Algorithm: "AES256"}, sse)
+
+	assert.NoError(t, os.Setenv(S3ForcePathStyleEnvVar, "maybe"))
+	_, _, err = s3BucketURL("s3://bucket")
+	assert.Error(t, err)
+}
+
+func TestServerSideEncryptionOptions(t *testing.T) {
+	var nilSSE *serverSideEncryption
+	assert.Nil(t, nilSSE.writerOptions(nil))
+	assert.Nil(t, nilSSE.copyOptions(nil))
+
+	sse := &serverSideEncryption{Algorithm: "aws:kms", KMSKeyID: "key"}
+
+	upload := &s3manager.UploadInput{}
+	err := sse.writerOptions(nil).BeforeWrite(func(i interface{}) bool {
+		if p, ok := i.(**s3manager.UploadInput); ok {
+			*p = upload
+			return true
+		}
+		return false
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "aws:kms", *upload.ServerSideEncryption)
+	assert.Equal(t, "key", *upload.SSEKMSKeyId)
+
+	copyInput := &s3.CopyObjectInput{}
+	err = sse.copyOptions(nil).BeforeCopy(func(i interface{}) bool {
+		if p, ok := i.(**s3.CopyObjectInput); ok {
+			*p = copyInput
+			return true
+		}
+		return false
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "aws:kms", *copyInput.ServerSideEncryption)
+	assert.Equal(t, "key", *copyInput.SSEKMSKeyId)
+}
@@ -280,9 +280,20 @@ func (b *localBackend) backupDirectory(stack tokens.QName) string {
 	return filepath.Join(b.StateDir(), workspace.BackupDir, fsutil.QnamePath(stack))
 }
 
-// getHistory returns locally stored update history. The first element of the result will be
-// the most recent update record.
-func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, error) {
+// historyEntry is a single record of an update in the stack's history.
+type historyEntry struct {
+	key    string             // the key of the history file.
+	update backend.UpdateInfo // the update, with its version resolved.
+	stored bool               // true if the version was recorded in the history file itself.
+}
+
+// checkpointKey returns the key of the checkpoint that resulted from the update.
+func (e historyEntry) checkpointKey() string {
+	return strings.TrimSuffix(e.key, ".history.json") + ".checkpoint.json"
+}
+
+// historyFiles returns the keys of the history files for the given stack, oldest first.
+func (b *localBackend) historyFiles(name tokens.QName) ([]string, error) {
 	contract.Require(name != "", "name")
 
 	dir := b.historyDirectory(name)
@@ -295,35 +306,115 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 		return nil, err
 	}
 
-	var updates []backend.UpdateInfo
+	// listBucket returns the array sorted by file name, and because of how we name files, older updates come before
+	// newer ones.
+	var files []string
+	for _, file := range allFiles {
+		// Keep all of the history files, ignoring the checkpoints.
+		if strings.HasSuffix(file.Key, ".history.json") {
+			files = append(files, file.Key)
+		}
+	}
+	return files, nil
+}
 
-	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
-	// newer ones. Loop backwards so we added the newest updates to the array we will return first.
-	for i := len(allFiles) - 1; i >= 0; i-- {
-		file := allFiles[i]
-		filepath := file.Key
+// readHistoryFile reads a single update history record.
+func (b *localBackend) readHistoryFile(key string) (backend.UpdateInfo, error) {
+	var update backend.UpdateInfo
+	byts, err := b.bucket.ReadAll(context.TODO(), key)
+	if err != nil {
+		return update, errors.Wrapf(err, "reading history file %s", key)
+	}
+	if err = json.Unmarshal(byts, &update); err != nil {
+		return update, errors.Wrapf(err, "reading history file %s", key)
+	}
+	return update, nil
+}
 
-		// Open all of the history files, ignoring the checkpoints.
-		if !strings.HasSuffix(filepath, ".history.json") {
-			continue
-		}
+// getHistoryEntries returns the stack's update history, oldest first.  Older history files do not record their
+// version, so any such entry is numbered one after the entry that precedes it.
+func (b *localBackend) getHistoryEntries(name tokens.QName) ([]historyEntry, error) {
+	files, err := b.historyFiles(name)
+	if err != nil {
+		return nil, err
+	}
 
-		var update backend.UpdateInfo
-		b, err := b.bucket.ReadAll(context.TODO(), filepath)
+	entries := make([]historyEntry, len(files))
+	for i, key := range files {
+		update, err := b.readHistoryFile(key)
 		if err != nil {
-			return nil, errors.Wrapf(err, "reading history file %s", filepath)
+			return nil, err
 		}
-		err = json.Unmarshal(b, &update)
-		if err != nil {
-			return nil, errors.Wrapf(err, "reading history file %s", filepath)
+		stored := update.Version != 0
+		if !stored {
+			update.Version = 1
+			if i > 0 {
+				update.Version = entries[i-1].update.Version + 1
+			}
 		}
+		entries[i] = historyEntry{key: key, update: update, stored: stored}
+	}
+	return entries, nil
+}
 
-		updates = append(updates, update)
+// getHistory returns locally stored update history. The first element of the result will be
+// the most recent update record.
+func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, error) {
+	entries, err := b.getHistoryEntries(name)
+	if err != nil {
+		return nil, err
 	}
 
+	// Loop backwards so we add the newest updates to the array we will return first.
+	var updates []backend.UpdateInfo
+	for i := len(entries) - 1; i >= 0; i-- {
+		updates = append(updates, entries[i].update)
+	}
 	return updates, nil
 }
 
+// getHistoryCheckpoint returns the checkpoint that resulted from the given version of the stack's history.
+func (b *localBackend) getHistoryCheckpoint(name tokens.QName, version int) (*apitype.CheckpointV3, error) {
+	entries, err := b.getHistoryEntries(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.update.Version == version {
+			key := entry.checkpointKey()
+			byts, err := b.bucket.ReadAll(context.TODO(), key)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading checkpoint file %s", key)
+			}
+			return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(byts)
+		}
+	}
+	return nil, errors.Errorf("stack '%s' has no version %d", name, version)
+}
+
+// nextHistoryVersion returns the version to record for the stack's next update.
+func (b *localBackend) nextHistoryVersion(name tokens.QName) (int, error) {
+	files, err := b.historyFiles(name)
+	if err != nil || len(files) == 0 {
+		return 1, err
+	}
+
+	// Only the most recent history file needs to be read, unless it predates the recording of versions.
+	last, err := b.readHistoryFile(files[len(files)-1])
+	if err != nil {
+		return 0, err
+	}
+	if last.Version != 0 {
+		return last.Version + 1, nil
+	}
+	entries, err := b.getHistoryEntries(name)
+	if err != nil {
+		return 0, err
+	}
+	return entries[len(entries)-1].update.Version + 1, nil
+}
+
 func (b *localBackend) renameHistory(oldName tokens.QName, newName tokens.QName) error {
 	contract.Require(oldName != "", "oldName")
 	contract.Require(newName != "", "newName")
@@ -369,6 +460,13 @@ func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo
 	// Prefix for the update and checkpoint files.
 	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", name, time.Now().UnixNano()))
 
+	// Record the update's version so that it remains stable even if older history is later pruned.
+	version, err := b.nextHistoryVersion(name)
+	if err != nil {
+		return err
+	}
+	update.Version = version
+
 	// Save the history file.
 	byts, err := json.MarshalIndent(&update, "", "    ")
 	if err != nil {
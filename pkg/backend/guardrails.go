@@ -0,0 +1,117 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+// ChangeGuardrails limit the number of resources that an update may delete or replace without additional
+// confirmation.  The zero value imposes no limits.
+type ChangeGuardrails struct {
+	// MaxDeletes, if positive, is the largest number of resources an update may delete or replace.
+	MaxDeletes int
+	// MaxDeletePercent, if positive, is the largest percentage of the stack's resources an update may delete or
+	// replace.
+	MaxDeletePercent float64
+}
+
+// IsZero returns true if the guardrails impose no limits.
+func (g ChangeGuardrails) IsZero() bool {
+	return g.MaxDeletes <= 0 && g.MaxDeletePercent <= 0
+}
+
+// Check returns an error describing the limit exceeded by the given changes to a stack that currently contains total
+// resources, or nil if the changes are within the limits.
+func (g ChangeGuardrails) Check(changes engine.ResourceChanges, total int) error {
+	deletes := changes[deploy.OpDelete] + changes[deploy.OpReplace]
+	if g.MaxDeletes > 0 && deletes > g.MaxDeletes {
+		return errors.Errorf("this update would delete or replace %d resources, more than the limit of %d",
+			deletes, g.MaxDeletes)
+	}
+	if g.MaxDeletePercent > 0 && total > 0 {
+		if percent := float64(deletes) * 100 / float64(total); percent > g.MaxDeletePercent {
+			return errors.Errorf("this update would delete or replace %.0f%% of the stack's %d resources, "+
+				"more than the limit of %g%%", percent, total, g.MaxDeletePercent)
+		}
+	}
+	return nil
+}
+
+// countResources returns the number of live resources in the stack, not counting the stack itself or providers.
+func countResources(snap *deploy.Snapshot) int {
+	if snap == nil {
+		return 0
+	}
+	count := 0
+	for _, res := range snap.Resources {
+		if !res.Delete && !providers.IsProviderType(res.Type) && res.Type != resource.RootStackType {
+			count++
+		}
+	}
+	return count
+}
+
+// checkGuardrails checks the changes made by a previewed update against the guardrails in the given options.  If a
+// limit is exceeded, the update is aborted, unless the user explicitly confirms it by entering the name of the stack.
+// Updates that would otherwise be approved automatically are always aborted.
+func checkGuardrails(ctx context.Context, stack Stack, changes engine.ResourceChanges,
+	opts UpdateOptions) result.Result {
+
+	if opts.Guardrails.IsZero() {
+		return nil
+	}
+	snap, err := stack.Snapshot(ctx)
+	if err != nil {
+		return result.FromError(err)
+	}
+	violation := opts.Guardrails.Check(changes, countResources(snap))
+	if violation == nil {
+		return nil
+	}
+	if opts.AutoApprove || !opts.Display.IsInteractive {
+		return result.FromError(errors.Wrap(violation, "aborting the update"))
+	}
+
+	name := string(stack.Ref().Name())
+	fmt.Println(opts.Display.Color.Colorize(colors.SpecWarning + "Warning: " + violation.Error() + colors.Reset))
+	surveycore.DisableColor = true
+	surveycore.QuestionIcon = ""
+	var response string
+	if err := survey.AskOne(&survey.Input{
+		Message: "\b" + opts.Display.Color.Colorize(
+			colors.SpecPrompt+fmt.Sprintf("To proceed anyway, type the name of the stack (%s):", name)+
+				colors.Reset),
+	}, &response, nil); err != nil {
+		return result.FromError(errors.Wrap(err, "confirmation cancelled, not proceeding with the update"))
+	}
+	if response != name {
+		fmt.Println("confirmation declined, not proceeding with the update")
+		return result.Bail()
+	}
+	return nil
+}
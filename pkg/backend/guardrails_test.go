@@ -0,0 +1,40 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestChangeGuardrails(t *testing.T) {
+	changes := engine.ResourceChanges{deploy.OpDelete: 3, deploy.OpReplace: 2, deploy.OpUpdate: 10}
+
+	assert.True(t, ChangeGuardrails{}.IsZero())
+	assert.NoError(t, ChangeGuardrails{}.Check(changes, 10))
+
+	assert.NoError(t, ChangeGuardrails{MaxDeletes: 5}.Check(changes, 10))
+	assert.Error(t, ChangeGuardrails{MaxDeletes: 4}.Check(changes, 10))
+
+	assert.NoError(t, ChangeGuardrails{MaxDeletePercent: 50}.Check(changes, 10))
+	assert.Error(t, ChangeGuardrails{MaxDeletePercent: 40}.Check(changes, 10))
+
+	// Percentages are not checked for empty stacks.
+	assert.NoError(t, ChangeGuardrails{MaxDeletePercent: 40}.Check(changes, 0))
+}
@@ -267,8 +267,9 @@ func Login(ctx context.Context, d diag.Sink, cloudURL string, opts display.Optio
 	} else if !cmdutil.Interactive() {
 		// If interactive mode isn't enabled, the only way to specify a token is through the environment variable.
 		// Fail the attempt to login.
-		return nil, errors.Errorf(
-			"%s must be set for login during non-interactive CLI sessions", AccessTokenEnvVar)
+		return nil, cmdutil.ErrorWithExitCode(errors.Errorf(
+			"%s must be set for login during non-interactive CLI sessions", AccessTokenEnvVar),
+			cmdutil.ExitCodeAuthentication)
 	} else {
 		// If no access token is available from the environment, and we are interactive, prompt and offer to
 		// open a browser to make it easy to generate and use a fresh token.
@@ -331,7 +332,7 @@ func Login(ctx context.Context, d diag.Sink, cloudURL string, opts display.Optio
 	if err != nil {
 		return nil, err
 	} else if !valid {
-		return nil, errors.Errorf("invalid access token")
+		return nil, cmdutil.ErrorWithExitCode(errors.Errorf("invalid access token"), cmdutil.ExitCodeAuthentication)
 	}
 
 	// Save them.
@@ -985,6 +986,7 @@ func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 			StartTime:       update.StartTime,
 			EndTime:         update.EndTime,
 			ResourceChanges: convertResourceChanges(update.ResourceChanges),
+			Version:         update.Version,
 		})
 	}
 
@@ -1056,17 +1058,23 @@ func (b *cloudBackend) GetLogs(ctx context.Context, stack backend.Stack, cfg bac
 
 func (b *cloudBackend) ExportDeployment(ctx context.Context,
 	stack backend.Stack) (*apitype.UntypedDeployment, error) {
-	return b.exportDeployment(ctx, stack.Ref())
+	return b.exportDeployment(ctx, stack.Ref(), nil)
+}
+
+func (b *cloudBackend) ExportDeploymentVersion(ctx context.Context, stack backend.Stack,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	return b.exportDeployment(ctx, stack.Ref(), &version)
 }
 
 func (b *cloudBackend) exportDeployment(ctx context.Context,
-	stackRef backend.StackReference) (*apitype.UntypedDeployment, error) {
+	stackRef backend.StackReference, version *int) (*apitype.UntypedDeployment, error) {
 	stack, err := b.getCloudStackIdentifier(stackRef)
 	if err != nil {
 		return nil, err
 	}
 
-	deployment, err := b.client.ExportStackDeployment(ctx, stack)
+	deployment, err := b.client.ExportStackDeployment(ctx, stack, version)
 	if err != nil {
 		return nil, err
 	}
@@ -34,6 +34,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/httputil"
 	"github.com/pulumi/pulumi/pkg/util/logging"
@@ -228,7 +229,9 @@ func pulumiAPICall(ctx context.Context, d diag.Sink, cloudAPI, method, path stri
 
 		// Provide a better error if using an authenticated call without having logged in first.
 		if resp.StatusCode == 401 && tok.Kind() == accessTokenKindAPIToken && tok.String() == "" {
-			return "", nil, errors.New("this command requires logging in; try running 'pulumi login' first")
+			return "", nil, cmdutil.ErrorWithExitCode(
+				errors.New("this command requires logging in; try running 'pulumi login' first"),
+				cmdutil.ExitCodeAuthentication)
 		}
 
 		var errResp apitype.ErrorResponse
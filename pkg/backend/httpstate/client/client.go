@@ -350,12 +350,18 @@ func (pc *Client) GetStackUpdates(ctx context.Context, stack StackIdentifier) ([
 	return response.Updates, nil
 }
 
-// ExportStackDeployment exports the indicated stack's deployment as a raw JSON message.
+// ExportStackDeployment exports the indicated stack's deployment as a raw JSON message.  If version is non-nil, the
+// deployment that resulted from that version of the stack is exported rather than the latest.
 func (pc *Client) ExportStackDeployment(ctx context.Context,
-	stack StackIdentifier) (apitype.UntypedDeployment, error) {
+	stack StackIdentifier, version *int) (apitype.UntypedDeployment, error) {
+
+	path := getStackPath(stack, "export")
+	if version != nil {
+		path += fmt.Sprintf("/%d", *version)
+	}
 
 	var resp apitype.ExportStackResponse
-	if err := pc.restCall(ctx, "GET", getStackPath(stack, "export"), nil, nil, &resp); err != nil {
+	if err := pc.restCall(ctx, "GET", path, nil, nil, &resp); err != nil {
 		return apitype.UntypedDeployment{}, err
 	}
 
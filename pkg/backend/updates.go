@@ -103,4 +103,7 @@ type UpdateInfo struct {
 	Result          UpdateResult           `json:"result"`
 	EndTime         int64                  `json:"endTime"`
 	ResourceChanges engine.ResourceChanges `json:"resourceChanges,omitempty"`
+
+	// Version is the stack's version after this update.  Versions start at 1 and increase with each update.
+	Version int `json:"version,omitempty"`
 }
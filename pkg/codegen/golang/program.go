@@ -0,0 +1,338 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package golang generates Go programs that use the Pulumi Go SDK.
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// GenerateProgram writes a Go program that declares the resources in the given snapshot, using their current inputs
+// as literal values.  Custom resources are declared with their current IDs as import IDs, so that running the program
+// against a new stack adopts the existing resources rather than creating new ones.  If any input is secret, an error
+// is returned unless showSecrets is true, in which case the secret's plaintext is written to the program, wrapped in
+// a call to pulumi.ToSecret.
+//
+// The result is a starting point rather than a finished program: references between resources are written as the
+// literal values they resolved to when the snapshot was taken.
+func GenerateProgram(w io.Writer, snap *deploy.Snapshot, showSecrets bool) error {
+	g := &programGenerator{
+		names:       make(map[resource.URN]string),
+		used:        make(map[string]bool),
+		showSecrets: showSecrets,
+	}
+	return g.generate(w, snap)
+}
+
+type programGenerator struct {
+	names      map[resource.URN]string // the variable names of the declared resources.
+	used       map[string]bool         // the variable names in use.
+	referenced map[resource.URN]bool   // the resources referenced by other resources.
+	usesAssets bool                    // true if the program needs to import the asset package.
+
+	showSecrets bool // true if the plaintext of secret values may be written to the program.
+}
+
+func (g *programGenerator) generate(w io.Writer, snap *deploy.Snapshot) error {
+	// Determine which resources to declare.  The stack itself is implicit, and default providers and resources that
+	// are pending deletion or merely read by the program are omitted.
+	var resources []*resource.State
+	if snap != nil {
+		for _, res := range snap.Resources {
+			if res.Type == resource.RootStackType || res.Delete || res.External || providers.IsDefaultProvider(res.URN) {
+				continue
+			}
+			resources = append(resources, res)
+			g.names[res.URN] = g.variableName(string(res.URN.Name()))
+		}
+	}
+
+	// Only resources that are referenced by others need to be bound to variables.
+	g.referenced = make(map[resource.URN]bool)
+	for _, res := range resources {
+		g.referenced[res.Parent] = true
+		for _, dep := range res.Dependencies {
+			g.referenced[dep] = true
+		}
+		if ref, err := providers.ParseReference(res.Provider); err == nil {
+			g.referenced[ref.URN()] = true
+		}
+	}
+
+	var body bytes.Buffer
+	for _, res := range resources {
+		if err := g.genResource(&body, res); err != nil {
+			return errors.Wrapf(err, "generating code for %s", res.URN)
+		}
+	}
+
+	var program bytes.Buffer
+	fmt.Fprintf(&program, "package main\n\n")
+	fmt.Fprintf(&program, "import (\n")
+	if g.usesAssets {
+		fmt.Fprintf(&program, "\t\"github.com/pulumi/pulumi/sdk/go/pulumi/asset\"\n")
+	}
+	fmt.Fprintf(&program, "\t\"github.com/pulumi/pulumi/sdk/go/pulumi\"\n")
+	fmt.Fprintf(&program, ")\n\n")
+	fmt.Fprintf(&program, "func main() {\n")
+	fmt.Fprintf(&program, "\tpulumi.Run(func(ctx *pulumi.Context) error {\n")
+	if len(resources) > 0 {
+		fmt.Fprintf(&program, "\t\tvar err error\n")
+	}
+	_, err := body.WriteTo(&program)
+	contract.IgnoreError(err)
+	fmt.Fprintf(&program, "\t\treturn nil\n")
+	fmt.Fprintf(&program, "\t})\n")
+	fmt.Fprintf(&program, "}\n")
+
+	formatted, err := format.Source(program.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "formatting generated program")
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+// variableName returns a unique Go identifier derived from the given resource name.
+func (g *programGenerator) variableName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, c := range name {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			if b.Len() == 0 && unicode.IsDigit(c) {
+				b.WriteRune('r')
+			}
+			if upper && b.Len() > 0 {
+				c = unicode.ToUpper(c)
+			} else if b.Len() == 0 {
+				c = unicode.ToLower(c)
+			}
+			b.WriteRune(c)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	base := b.String()
+	if base == "" || isReserved(base) {
+		base = "res" + strings.Title(base)
+	}
+
+	result := base
+	for i := 2; g.used[result]; i++ {
+		result = base + strconv.Itoa(i)
+	}
+	g.used[result] = true
+	return result
+}
+
+// isReserved returns true if name is a Go keyword or an identifier that the generated program already uses.
+func isReserved(name string) bool {
+	switch name {
+	case "break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func",
+		"go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct", "switch",
+		"type", "var", "ctx", "err", "pulumi", "asset", "nil", "true", "false":
+		return true
+	}
+	return false
+}
+
+// genResource writes the declaration of a single resource.
+func (g *programGenerator) genResource(w io.Writer, res *resource.State) error {
+	lhs := "_, err ="
+	if g.referenced[res.URN] {
+		lhs = g.names[res.URN] + ", err :="
+	}
+
+	var inputs bytes.Buffer
+	if err := g.genObject(&inputs, res.Inputs, 2); err != nil {
+		return err
+	}
+
+	var opts []string
+	if name, has := g.names[res.Parent]; has {
+		opts = append(opts, "Parent: "+name)
+	}
+	if len(res.Dependencies) > 0 {
+		var deps []string
+		for _, dep := range res.Dependencies {
+			if name, has := g.names[dep]; has {
+				deps = append(deps, name)
+			}
+		}
+		if len(deps) > 0 {
+			opts = append(opts, "DependsOn: []pulumi.Resource{"+strings.Join(deps, ", ")+"}")
+		}
+	}
+	if res.Protect {
+		opts = append(opts, "Protect: true")
+	}
+	if ref, err := providers.ParseReference(res.Provider); err == nil {
+		if name, has := g.names[ref.URN()]; has {
+			opts = append(opts, "Provider: "+name)
+		}
+	}
+	if res.Custom && res.ID != "" && !providers.IsProviderType(res.Type) {
+		opts = append(opts, "Import: "+strconv.Quote(string(res.ID)))
+	}
+	optsText := ""
+	if len(opts) > 0 {
+		optsText = ", pulumi.ResourceOpt{" + strings.Join(opts, ", ") + "}"
+	}
+
+	fmt.Fprintf(w, "\t\t%s ctx.RegisterResource(%q, %q, %v, %s%s)\n",
+		lhs, res.Type, res.URN.Name(), res.Custom, inputs.String(), optsText)
+	fmt.Fprintf(w, "\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n")
+	return nil
+}
+
+// genObject writes a property map as a Go map literal.
+func (g *programGenerator) genObject(w *bytes.Buffer, obj resource.PropertyMap, indent int) error {
+	if len(obj) == 0 {
+		w.WriteString("nil")
+		return nil
+	}
+	w.WriteString("map[string]interface{}{\n")
+	for _, k := range obj.StableKeys() {
+		w.WriteString(strings.Repeat("\t", indent+1))
+		fmt.Fprintf(w, "%q: ", string(k))
+		if err := g.genValue(w, obj[k], indent+1); err != nil {
+			return errors.Wrapf(err, "property %q", string(k))
+		}
+		w.WriteString(",\n")
+	}
+	w.WriteString(strings.Repeat("\t", indent) + "}")
+	return nil
+}
+
+// genValue writes a property value as a Go expression.
+func (g *programGenerator) genValue(w *bytes.Buffer, v resource.PropertyValue, indent int) error {
+	switch {
+	case v.IsNull(), v.IsComputed(), v.IsOutput():
+		w.WriteString("nil")
+	case v.IsSecret():
+		if !g.showSecrets {
+			return errors.New("secret values are only written to the program, in plaintext, if requested")
+		}
+		w.WriteString("pulumi.ToSecret(")
+		if err := g.genValue(w, v.SecretValue().Element, indent); err != nil {
+			return err
+		}
+		w.WriteString(") /* WARNING: plaintext secret */")
+	case v.IsBool():
+		fmt.Fprintf(w, "%v", v.BoolValue())
+	case v.IsNumber():
+		w.WriteString(strconv.FormatFloat(v.NumberValue(), 'g', -1, 64))
+	case v.IsString():
+		w.WriteString(strconv.Quote(v.StringValue()))
+	case v.IsArray():
+		arr := v.ArrayValue()
+		if len(arr) == 0 {
+			w.WriteString("[]interface{}{}")
+			return nil
+		}
+		w.WriteString("[]interface{}{\n")
+		for _, e := range arr {
+			w.WriteString(strings.Repeat("\t", indent+1))
+			if err := g.genValue(w, e, indent+1); err != nil {
+				return err
+			}
+			w.WriteString(",\n")
+		}
+		w.WriteString(strings.Repeat("\t", indent) + "}")
+	case v.IsObject():
+		if len(v.ObjectValue()) == 0 {
+			w.WriteString("map[string]interface{}{}")
+			return nil
+		}
+		return g.genObject(w, v.ObjectValue(), indent)
+	case v.IsAsset():
+		return g.genAsset(w, v.AssetValue())
+	case v.IsArchive():
+		return g.genArchive(w, v.ArchiveValue(), indent)
+	default:
+		return errors.Errorf("unexpected property value %v", v)
+	}
+	return nil
+}
+
+func (g *programGenerator) genAsset(w *bytes.Buffer, a *resource.Asset) error {
+	g.usesAssets = true
+	switch {
+	case a.IsPath():
+		fmt.Fprintf(w, "asset.NewFileAsset(%q)", a.Path)
+	case a.IsURI():
+		fmt.Fprintf(w, "asset.NewRemoteAsset(%q)", a.URI)
+	default:
+		fmt.Fprintf(w, "asset.NewStringAsset(%q)", a.Text)
+	}
+	return nil
+}
+
+func (g *programGenerator) genArchive(w *bytes.Buffer, a *resource.Archive, indent int) error {
+	g.usesAssets = true
+	switch {
+	case a.IsPath():
+		fmt.Fprintf(w, "asset.NewFileArchive(%q)", a.Path)
+	case a.IsURI():
+		fmt.Fprintf(w, "asset.NewRemoteArchive(%q)", a.URI)
+	default:
+		var keys []string
+		for k := range a.Assets {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		w.WriteString("asset.NewAssetArchive(map[string]interface{}{\n")
+		for _, k := range keys {
+			w.WriteString(strings.Repeat("\t", indent+1))
+			fmt.Fprintf(w, "%q: ", k)
+			switch e := a.Assets[k].(type) {
+			case *resource.Asset:
+				contract.IgnoreError(g.genAsset(w, e))
+			case resource.Asset:
+				contract.IgnoreError(g.genAsset(w, &e))
+			case *resource.Archive:
+				if err := g.genArchive(w, e, indent+1); err != nil {
+					return err
+				}
+			case resource.Archive:
+				if err := g.genArchive(w, &e, indent+1); err != nil {
+					return err
+				}
+			default:
+				return errors.Errorf("unexpected archive element %v", e)
+			}
+			w.WriteString(",\n")
+		}
+		w.WriteString(strings.Repeat("\t", indent) + "})")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestGenerateProgram(t *testing.T) {
+	urn := func(typ, name string) resource.URN {
+		return resource.NewURN("dev", "proj", "", tokens.Type(typ), tokens.QName(name))
+	}
+	stack := urn("pulumi:pulumi:Stack", "proj-dev")
+	defaultProvider := urn("pulumi:providers:aws", "default")
+	provider := urn("pulumi:providers:aws", "west")
+	component := urn("my:index:Component", "comp")
+	bucket := urn("aws:s3/bucket:Bucket", "my-bucket")
+	object := urn("aws:s3/bucketObject:BucketObject", "index")
+
+	snap := &deploy.Snapshot{Resources: []*resource.State{
+		{URN: stack, Type: "pulumi:pulumi:Stack"},
+		{URN: defaultProvider, Type: "pulumi:providers:aws", Custom: true, ID: "0", Parent: stack},
+		{URN: provider, Type: "pulumi:providers:aws", Custom: true, ID: "1", Parent: stack,
+			Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{"region": "us-west-2"})},
+		{URN: component, Type: "my:index:Component", Parent: stack},
+		{URN: bucket, Type: "aws:s3/bucket:Bucket", Custom: true, ID: "my-bucket-1234", Parent: component,
+			Provider: string(provider) + "::1", Protect: true,
+			Inputs: resource.PropertyMap{
+				"acl":  resource.NewStringProperty("private"),
+				"tags": resource.NewObjectProperty(resource.PropertyMap{"env": resource.NewStringProperty("dev")}),
+				"versioning": resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewObjectProperty(resource.PropertyMap{"enabled": resource.NewBoolProperty(true)}),
+				}),
+			}},
+		{URN: object, Type: "aws:s3/bucketObject:BucketObject", Custom: true, ID: "index.html",
+			Provider: string(defaultProvider) + "::0", Dependencies: []resource.URN{bucket},
+			Inputs: resource.PropertyMap{
+				"bucket":  resource.NewStringProperty("my-bucket-1234"),
+				"source":  resource.NewAssetProperty(&resource.Asset{Path: "index.html"}),
+				"key":     resource.MakeSecret(resource.NewStringProperty("hunter2")),
+				"retries": resource.NewNumberProperty(3),
+			}},
+	}}
+
+	// Secrets are only written to the program if requested.
+	var buf bytes.Buffer
+	err := GenerateProgram(&buf, snap, false)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `property "key"`)
+		assert.NotContains(t, err.Error(), "hunter2")
+	}
+	assert.NoError(t, GenerateProgram(&buf, snap, true))
+	program := buf.String()
+
+	assert.Contains(t, program,
+		`west, err := ctx.RegisterResource("pulumi:providers:aws", "west", true, map[string]interface{}{`)
+	assert.Contains(t, program, `comp, err := ctx.RegisterResource("my:index:Component", "comp", false, nil)`)
+	assert.Contains(t, program, `myBucket, err := ctx.RegisterResource("aws:s3/bucket:Bucket", "my-bucket", true,`)
+	assert.Contains(t, program,
+		`pulumi.ResourceOpt{Parent: comp, Protect: true, Provider: west, Import: "my-bucket-1234"})`)
+	assert.Contains(t, program, `pulumi.ResourceOpt{DependsOn: []pulumi.Resource{myBucket}, Import: "index.html"})`)
+	assert.Contains(t, program, `"source":  asset.NewFileAsset("index.html"),`)
+	assert.Contains(t, program, `"retries": 3,`)
+	assert.Contains(t, program, `"github.com/pulumi/pulumi/sdk/go/pulumi/asset"`)
+	assert.Contains(t, program, `"key":     pulumi.ToSecret("hunter2"), /* WARNING: plaintext secret */`)
+	assert.NotContains(t, program, `"default"`)
+	assert.NotContains(t, program, `"proj-dev"`)
+}
+
+func TestVariableName(t *testing.T) {
+	g := &programGenerator{used: make(map[string]bool)}
+	assert.Equal(t, "myBucket", g.variableName("my-bucket"))
+	assert.Equal(t, "myBucket2", g.variableName("my_bucket"))
+	assert.Equal(t, "r1stBucket", g.variableName("1st-bucket"))
+	assert.Equal(t, "resFunc", g.variableName("func"))
+	assert.Equal(t, "res", g.variableName("---"))
+}
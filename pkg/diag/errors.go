@@ -80,3 +80,9 @@ func GetResourceWillBeDestroyedButWasNotSpecifiedInTargetList(urn resource.URN)
 	return newError(urn, 2014, `Resource '%v' will be destroyed but was not specified in --target list.
 Either include resource in --target list or pass --target-dependents to proceed.`)
 }
+
+func GetResourcePartiallyInitializedWarning(urn resource.URN) *Diag {
+	return newError(urn, 2015, `Resource '%v' was saved to the stack's state, but failed to initialize.
+Run 'pulumi up' to retry its initialization, 'pulumi up --replace %v' to replace it, or 'pulumi state delete %v' to `+
+		`remove it from the stack's state.`)
+}
@@ -485,7 +485,7 @@ func printPropertyValue(
 			write(b, op, "asset(file:%s) { %s }", shortHash(a.Hash), path)
 		} else {
 			contract.Assert(a.IsURI())
-			write(b, op, "asset(uri:%s) { %s }", shortHash(a.Hash), a.URI)
+			write(b, op, "asset(uri:%s) { %s }", shortHash(a.Hash), resource.RedactURI(a.URI))
 		}
 	} else if v.IsArchive() {
 		a := v.ArchiveValue()
@@ -504,7 +504,7 @@ func printPropertyValue(
 			write(b, op, "archive(file:%s) { %s }", shortHash(a.Hash), path)
 		} else {
 			contract.Assert(a.IsURI())
-			write(b, op, "archive(uri:%s) { %v }", shortHash(a.Hash), a.URI)
+			write(b, op, "archive(uri:%s) { %v }", shortHash(a.Hash), resource.RedactURI(a.URI))
 		}
 	} else {
 		contract.Assert(v.IsObject())
@@ -747,7 +747,8 @@ func printArchiveDiff(
 	} else if oldURI, has := oldArchive.GetURI(); has {
 		if newURI, has := newArchive.GetURI(); has {
 			titleFunc(op, true)
-			write(b, op, "archive(uri:%s) { %s }\n", hashChange, getTextChangeString(oldURI, newURI))
+			write(b, op, "archive(uri:%s) { %s }\n", hashChange,
+				getTextChangeString(resource.RedactURI(oldURI), resource.RedactURI(newURI)))
 			return
 		}
 	} else {
@@ -938,7 +939,8 @@ func printAssetDiff(
 		oldURI, _ := oldAsset.GetURI()
 		if newURI, has := newAsset.GetURI(); has {
 			titleFunc(deploy.OpUpdate, true)
-			write(b, op, "asset(uri:%s) { %s }\n", hashChange, getTextChangeString(oldURI, newURI))
+			write(b, op, "asset(uri:%s) { %s }\n", hashChange,
+				getTextChangeString(resource.RedactURI(oldURI), resource.RedactURI(newURI)))
 			return
 		}
 	}
@@ -96,16 +96,27 @@ type SummaryEventPayload struct {
 	Duration        time.Duration     // the duration of the entire update operation (zero values for previews)
 	ResourceChanges ResourceChanges   // count of changed resources, useful for reporting
 	PolicyPacks     map[string]string // {policy-pack: version} for each policy pack applied
+	ResourceTimings []ResourceTiming  // the duration of each resource operation (empty for previews)
+}
+
+// ResourceTiming records the time taken by a resource operation.
+type ResourceTiming struct {
+	URN      resource.URN  // the URN of the resource.
+	Type     tokens.Type   // the type of the resource.
+	Op       deploy.StepOp // the operation performed.
+	Duration time.Duration // the time taken by the operation.
 }
 
 type ResourceOperationFailedPayload struct {
 	Metadata StepEventMetadata
+	Duration time.Duration // the time taken by the operation before it failed.
 	Status   resource.Status
 	Steps    int
 }
 
 type ResourceOutputsEventPayload struct {
 	Metadata StepEventMetadata
+	Duration time.Duration // the time taken by the operation, if it was performed.
 	Planning bool
 	Debug    bool
 }
@@ -358,14 +369,14 @@ func filterPropertyMap(propertyMap resource.PropertyMap, debug bool) resource.Pr
 				Hash: t.Hash,
 				Text: text,
 				Path: t.Path,
-				URI:  t.URI,
+				URI:  resource.RedactURI(t.URI),
 			}
 		case *resource.Archive:
 			return &resource.Archive{
 				Sig:    t.Sig,
 				Hash:   t.Hash,
 				Path:   t.Path,
-				URI:    t.URI,
+				URI:    resource.RedactURI(t.URI),
 				Assets: filterValue(t.Assets).(map[string]interface{}),
 			}
 		case resource.Secret:
@@ -425,7 +436,7 @@ func (e *eventEmitter) Close() {
 }
 
 func (e *eventEmitter) resourceOperationFailedEvent(
-	step deploy.Step, status resource.Status, steps int, debug bool) {
+	step deploy.Step, status resource.Status, steps int, duration time.Duration, debug bool) {
 
 	contract.Requiref(e != nil, "e", "!= nil")
 
@@ -433,19 +444,23 @@ func (e *eventEmitter) resourceOperationFailedEvent(
 		Type: ResourceOperationFailed,
 		Payload: ResourceOperationFailedPayload{
 			Metadata: makeStepEventMetadata(step.Op(), step, debug),
+			Duration: duration,
 			Status:   status,
 			Steps:    steps,
 		},
 	}
 }
 
-func (e *eventEmitter) resourceOutputsEvent(op deploy.StepOp, step deploy.Step, planning bool, debug bool) {
+func (e *eventEmitter) resourceOutputsEvent(op deploy.StepOp, step deploy.Step, duration time.Duration, planning bool,
+	debug bool) {
+
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- Event{
 		Type: ResourceOutputsEvent,
 		Payload: ResourceOutputsEventPayload{
 			Metadata: makeStepEventMetadata(op, step, debug),
+			Duration: duration,
 			Planning: planning,
 			Debug:    debug,
 		},
@@ -502,8 +517,8 @@ func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, poli
 	}
 }
 
-func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
-	duration time.Duration, resourceChanges ResourceChanges, policyPacks map[string]string) {
+func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool, duration time.Duration,
+	resourceChanges ResourceChanges, policyPacks map[string]string, timings []ResourceTiming) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- Event{
@@ -514,6 +529,7 @@ func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
 			Duration:        duration,
 			ResourceChanges: resourceChanges,
 			PolicyPacks:     policyPacks,
+			ResourceTimings: timings,
 		},
 	}
 }
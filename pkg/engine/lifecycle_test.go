@@ -2396,6 +2396,19 @@ func TestUpdatePartialFailure(t *testing.T) {
 				}
 			}
 
+			// The partially updated resource is reported, along with how to deal with it.
+			sawWarning := false
+			for _, evt := range evts {
+				if evt.Type == DiagEvent {
+					e := evt.Payload.(DiagEventPayload)
+					if e.Severity == diag.Warning && e.URN == resURN &&
+						strings.Contains(e.Message, "failed to initialize") {
+						sawWarning = true
+					}
+				}
+			}
+			assert.True(t, sawWarning)
+
 			return res
 		},
 	}}
@@ -4994,6 +5007,177 @@ func TestDependencyChangeDBR(t *testing.T) {
 	p.Run(t, snap)
 }
 
+func TestDeleteBeforeReplaceWithoutPropertyDependencies(t *testing.T) {
+	p := &TestPlan{}
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+
+					if !olds["A"].DeepEquals(news["A"]) {
+						return plugin.DiffResult{
+							ReplaceKeys:         []resource.PropertyKey{"A"},
+							DeleteBeforeReplace: true,
+						}, nil
+					}
+					return plugin.DiffResult{}, nil
+				},
+			}, nil
+		}),
+	}
+
+	const resType = "pkgA:index:typ"
+
+	inputsA := resource.NewPropertyMapFromMap(map[string]interface{}{"A": "foo"})
+	inputsB := resource.NewPropertyMapFromMap(map[string]interface{}{"A": "foo"})
+
+	var urnA, urnB resource.URN
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		var err error
+		urnA, _, _, err = monitor.RegisterResource(resType, "resA", true, deploytest.ResourceOptions{
+			Inputs: inputsA,
+		})
+		assert.NoError(t, err)
+
+		urnB, _, _, err = monitor.RegisterResource(resType, "resB", true, deploytest.ResourceOptions{
+			Inputs:       inputsB,
+			Dependencies: []resource.URN{urnA},
+		})
+		assert.NoError(t, err)
+
+		return nil
+	})
+
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+
+	// Simulate a checkpoint written before property dependencies were recorded.
+	for _, res := range snap.Resources {
+		res.PropertyDependencies = nil
+	}
+
+	// Changing A requires that it be deleted before it is replaced, which in turn requires that B, whose input may
+	// depend on A, be deleted first.
+	inputsA["A"] = resource.NewStringProperty("bar")
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			evts []Event, res result.Result) result.Result {
+
+			assert.Nil(t, res)
+
+			deleted := make(map[resource.URN]bool)
+			for _, entry := range j.Entries {
+				if entry.Step.Op() == deploy.OpDeleteReplaced {
+					deleted[entry.Step.URN()] = true
+				}
+			}
+			assert.Equal(t, map[resource.URN]bool{urnA: true, urnB: true}, deleted)
+
+			found := false
+			for _, e := range evts {
+				if e.Type == DiagEvent {
+					p := e.Payload.(DiagEventPayload)
+					if p.URN == urnA && p.Severity == diag.Info &&
+						strings.Contains(p.Message, "first deletes 1 dependent resource(s): resB") {
+						found = true
+					}
+				}
+			}
+			assert.True(t, found)
+
+			return res
+		},
+	}}
+	p.Run(t, snap)
+}
+
+func TestUnchangedInputsSkipDiff(t *testing.T) {
+	p := &TestPlan{}
+
+	checks, diffs := 0, 0
+	version := semver.MustParse("1.0.0")
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				Version: version,
+				CheckF: func(urn resource.URN,
+					olds, news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+					checks++
+					return news, nil, nil
+				},
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+
+					diffs++
+					if !olds["A"].DeepEquals(news["A"]) {
+						return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+					}
+					return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+				},
+			}, nil
+		}),
+	}
+
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"A": "foo"})
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: inputs,
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true}}
+	snap := p.Run(t, nil)
+	assert.Equal(t, 1, checks)
+	for _, res := range snap.Resources {
+		if res.Type == "pkgA:m:typA" {
+			assert.NotEmpty(t, res.InputHash)
+		}
+	}
+
+	// Neither the inputs nor the provider have changed, so the provider need not be consulted.
+	checks = 0
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true}}
+	snap = p.Run(t, snap)
+	assert.Equal(t, 0, checks)
+	assert.Equal(t, 0, diffs)
+
+	// Once the inputs change, the provider must check and diff them.
+	inputs["A"] = resource.NewStringProperty("bar")
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true}}
+	snap = p.Run(t, snap)
+	assert.Equal(t, 1, checks)
+	assert.Equal(t, 1, diffs)
+
+	// A different version of the provider's plugin may make different decisions.
+	checks, diffs = 0, 0
+	version = semver.MustParse("1.1.0")
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true}}
+	snap = p.Run(t, snap)
+	assert.Equal(t, 1, checks)
+	assert.Equal(t, 1, diffs)
+
+	// A refresh may have found drift, so the next update must diff the resource again.
+	checks, diffs = 0, 0
+	p.Steps = []TestStep{{Op: Refresh, SkipPreview: true}}
+	snap = p.Run(t, snap)
+	for _, res := range snap.Resources {
+		if res.Type == "pkgA:m:typA" {
+			assert.Empty(t, res.InputHash)
+		}
+	}
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true}}
+	p.Run(t, snap)
+	assert.Equal(t, 1, checks)
+	assert.Equal(t, 1, diffs)
+}
+
 func TestReplaceSpecificTargets(t *testing.T) {
 	//             A
 	//    _________|_________
@@ -5138,6 +5322,266 @@ func TestPreviewInputPropagation(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestResourceHooks(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID,
+					olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+
+					if !olds["A"].DeepEquals(news["A"]) {
+						return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+					}
+					return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+				},
+			}, nil
+		}),
+	}
+
+	var ran []string
+	var failing string
+	hook := func(name string) deploytest.ResourceHookFunc {
+		return func(urn resource.URN, id resource.ID, newInputs, oldInputs, newOutputs,
+			oldOutputs resource.PropertyMap) error {
+
+			assert.Equal(t, "resA", string(urn.Name()))
+			ran = append(ran, name)
+			if name == failing {
+				return errors.New("hook failed")
+			}
+			return nil
+		}
+	}
+
+	register := true
+	inputs := resource.NewPropertyMapFromMap(map[string]interface{}{"A": "foo"})
+	program := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		names := []string{"beforeCreate", "afterCreate", "beforeUpdate", "afterUpdate", "beforeDelete", "afterDelete"}
+		for _, name := range names {
+			err := monitor.RegisterResourceHook(name, hook(name))
+			assert.NoError(t, err)
+		}
+
+		if register {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+				Inputs: inputs,
+				Hooks: &resource.Hooks{
+					BeforeCreate: []string{"beforeCreate"},
+					AfterCreate:  []string{"afterCreate"},
+					BeforeUpdate: []string{"beforeUpdate"},
+					AfterUpdate:  []string{"afterUpdate"},
+					BeforeDelete: []string{"beforeDelete"},
+					AfterDelete:  []string{"afterDelete"},
+				},
+			})
+			if failing != "" {
+				return err
+			}
+			assert.NoError(t, err)
+		}
+
+		// Keep serving the hooks until the engine has deleted any resources that are no longer registered.
+		if !info.DryRun {
+			return monitor.SignalAndWaitForShutdown()
+		}
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+
+	// Hooks are not run by previews, so only the update runs the create hooks.
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Equal(t, []string{"beforeCreate", "afterCreate"}, ran)
+	assert.Equal(t, []string{"beforeDelete"}, snap.Resources[1].Hooks.BeforeDelete)
+
+	// Changing the resource's inputs runs the update hooks.
+	ran = nil
+	inputs["A"] = resource.NewStringProperty("bar")
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true}}
+	snap = p.Run(t, snap)
+	assert.Equal(t, []string{"beforeUpdate", "afterUpdate"}, ran)
+
+	// A failing hook halts the update before the resource is updated.
+	ran, failing = nil, "beforeUpdate"
+	inputs["A"] = resource.NewStringProperty("baz")
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true, ExpectFailure: true}}
+	p.Run(t, snap)
+	assert.Equal(t, []string{"beforeUpdate"}, ran)
+
+	// Deleting the resource runs the delete hooks that were recorded in its state.
+	ran, failing = nil, ""
+	register = false
+	p.Steps = []TestStep{{Op: Update, SkipPreview: true}}
+	snap = p.Run(t, snap)
+	assert.Equal(t, []string{"beforeDelete", "afterDelete"}, ran)
+	assert.Len(t, snap.Resources, 0)
+}
+
+func TestDestroyResourceHooks(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	ran := false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		err := monitor.RegisterResourceHook("beforeDelete", func(urn resource.URN, id resource.ID, newInputs,
+			oldInputs, newOutputs, oldOutputs resource.PropertyMap) error {
+
+			ran = true
+			return nil
+		})
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Hooks: &resource.Hooks{BeforeDelete: []string{"beforeDelete"}},
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 2)
+
+	// Destroy does not run the program, so it cannot run the resource's delete hook and refuses to delete it.
+	p.Steps = []TestStep{{Op: Destroy, ExpectFailure: true}}
+	snap = p.Run(t, snap)
+	assert.False(t, ran)
+	assert.Len(t, snap.Resources, 2)
+
+	// Told to skip the hook, destroy deletes the resource without running it.
+	p.Options.SkipResourceHooks = true
+	p.Steps = []TestStep{{Op: Destroy}}
+	snap = p.Run(t, snap)
+	assert.False(t, ran)
+	assert.Len(t, snap.Resources, 0)
+}
+
+func TestSavedPlan(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	registerB := false
+	inputsA := resource.PropertyMap{}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: inputsA,
+		})
+		if err != nil {
+			return err
+		}
+		if registerB {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	project := p.GetProject()
+	urnA := p.NewURN("pkgA:m:typA", "resA", "")
+	urnB := p.NewURN("pkgA:m:typA", "resB", "")
+
+	// A preview records the steps that it proposes in the plan.
+	plan := deploy.NewSavedPlan()
+	opts := p.Options
+	opts.SavePlan = plan
+	_, res := TestOp(Update).Run(project, p.GetTarget(nil), opts, true, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.True(t, plan.Has(urnA, deploy.OpCreate))
+	assert.False(t, plan.Has(urnB, deploy.OpCreate))
+
+	// An update whose steps match the plan succeeds.
+	p.Options.ApplyPlan = plan
+	p.Steps = []TestStep{{Op: Update}}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 2)
+
+	// Resources that the plan does not change may be left alone, but an update that diverges from the plan fails
+	// before it performs the step that diverges.
+	registerB = true
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, events []Event,
+			res result.Result) result.Result {
+
+			for _, entry := range j.Entries {
+				assert.NotEqual(t, urnB, entry.Step.URN())
+			}
+			diverged := false
+			for _, e := range events {
+				if e.Type == DiagEvent {
+					diverged = diverged || strings.Contains(e.Payload.(DiagEventPayload).Message, "is not in the plan")
+				}
+			}
+			assert.True(t, diverged)
+			return res
+		},
+	}}
+	p.Run(t, snap)
+	registerB = false
+
+	// An update that performs the planned operation but changes properties that the plan does not also fails.
+	plan = deploy.NewSavedPlan()
+	opts.SavePlan = plan
+	inputsA["A"] = resource.NewStringProperty("foo")
+	_, res = TestOp(Update).Run(project, p.GetTarget(snap), opts, true, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.True(t, plan.Has(urnA, deploy.OpUpdate))
+
+	inputsA["B"] = resource.NewStringProperty("bar")
+	p.Options.ApplyPlan = plan
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal, events []Event,
+			res result.Result) result.Result {
+
+			assert.Empty(t, j.Entries)
+			diverged := false
+			for _, e := range events {
+				if e.Type == DiagEvent {
+					diverged = diverged || strings.Contains(e.Payload.(DiagEventPayload).Message,
+						"changes properties that are not in the plan: [B]")
+				}
+			}
+			assert.True(t, diverged)
+			return res
+		},
+	}}
+	p.Run(t, snap)
+
+	// The planned update succeeds once the program makes only the planned changes.
+	delete(inputsA, "B")
+	p.Steps = []TestStep{{Op: Update}}
+	p.Run(t, snap)
+
+	// A plan may only be applied to the stack that it was saved for.
+	plan.Stack = "other"
+	p.Steps = []TestStep{{Op: Update, ExpectFailure: true}}
+	p.Run(t, snap)
+}
+
 func TestSingleResourceDefaultProviderGolangLifecycle(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
 		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
@@ -179,6 +179,8 @@ func (planResult *planResult) Walk(cancelCtx *Context, events deploy.Events, pre
 		opts := deploy.Options{
 			Events:            events,
 			Parallel:          planResult.Options.Parallel,
+			ProviderParallel:  planResult.Options.ProviderParallel,
+			Naming:            planResult.Options.Naming,
 			Refresh:           planResult.Options.Refresh,
 			RefreshOnly:       planResult.Options.isRefresh,
 			RefreshTargets:    planResult.Options.RefreshTargets,
@@ -188,6 +190,9 @@ func (planResult *planResult) Walk(cancelCtx *Context, events deploy.Events, pre
 			TargetDependents:  planResult.Options.TargetDependents,
 			TrustDependencies: planResult.Options.trustDependencies,
 			UseLegacyDiff:     planResult.Options.UseLegacyDiff,
+			SavePlan:          planResult.Options.SavePlan,
+			ApplyPlan:         planResult.Options.ApplyPlan,
+			SkipResourceHooks: planResult.Options.SkipResourceHooks,
 		}
 		walkResult = planResult.Plan.Execute(ctx, opts, preview)
 		close(done)
@@ -309,7 +314,7 @@ func (acts *planActions) OnResourceStepPost(ctx interface{},
 			acts.MapLock.Unlock()
 		}
 
-		acts.Opts.Events.resourceOutputsEvent(op, step, true /*planning*/, acts.Opts.Debug)
+		acts.Opts.Events.resourceOutputsEvent(op, step, 0, true /*planning*/, acts.Opts.Debug)
 	}
 
 	return nil
@@ -339,7 +344,7 @@ func (acts *planActions) OnResourceOutputs(step deploy.Step) error {
 	}
 
 	// Print the resource outputs separately.
-	acts.Opts.Events.resourceOutputsEvent(step.Op(), step, true /*planning*/, acts.Opts.Debug)
+	acts.Opts.Events.resourceOutputsEvent(step.Op(), step, 0, true /*planning*/, acts.Opts.Debug)
 
 	return nil
 }
@@ -15,7 +15,6 @@
 package engine
 
 import (
-	"fmt"
 	"sort"
 
 	"github.com/blang/semver"
@@ -25,7 +24,6 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/tokens"
-	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/workspace"
@@ -139,7 +137,7 @@ func ensurePluginsAreInstalled(plugins pluginSet) error {
 		installTasks.Go(func() error {
 			logging.V(preparePluginLog).Infof(
 				"ensurePluginsAreInstalled(): plugin %s %s not installed, doing install", info.Name, info.Version)
-			return installPlugin(info)
+			return plugin.InstallPlugin(info)
 		})
 	}
 
@@ -154,35 +152,6 @@ func ensurePluginsAreLoaded(plugctx *plugin.Context, plugins pluginSet, kinds pl
 	return plugctx.Host.EnsurePlugins(plugins.Values(), kinds)
 }
 
-// installPlugin installs a plugin from the given backend client.
-func installPlugin(plugin workspace.PluginInfo) error {
-	logging.V(preparePluginLog).Infof("installPlugin(%s, %s): beginning install", plugin.Name, plugin.Version)
-	if plugin.Kind == workspace.LanguagePlugin {
-		logging.V(preparePluginLog).Infof(
-			"installPlugin(%s, %s): is a language plugin, skipping install", plugin.Name, plugin.Version)
-		return nil
-	}
-
-	logging.V(preparePluginVerboseLog).Infof(
-		"installPlugin(%s, %s): initiating download", plugin.Name, plugin.Version)
-	stream, size, err := plugin.Download()
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("[%s plugin %s-%s] installing\n", plugin.Kind, plugin.Name, plugin.Version)
-	stream = workspace.ReadCloserProgressBar(stream, size, "Downloading plugin", cmdutil.GetGlobalColorization())
-
-	logging.V(preparePluginVerboseLog).Infof(
-		"installPlugin(%s, %s): extracting tarball to installation directory", plugin.Name, plugin.Version)
-	if err := plugin.Install(stream); err != nil {
-		return err
-	}
-
-	logging.V(7).Infof("installPlugin(%s, %s): successfully installed", plugin.Name, plugin.Version)
-	return nil
-}
-
 // computeDefaultProviderPlugins computes, for every resource plugin, a mapping from packages to semver versions
 // reflecting the version of a provider that should be used as the "default" resource when registering resources. This
 // function takes two sets of plugins: a set of plugins given to us from the language host and the full set of plugins.
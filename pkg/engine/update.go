@@ -56,6 +56,12 @@ type UpdateOptions struct {
 	// the degree of parallelism for resource operations (<=1 for serial).
 	Parallel int
 
+	// the maximum number of concurrent resource operations for each provider package, such as "aws".
+	ProviderParallel map[string]int
+
+	// an optional policy for naming resources that are not named explicitly.
+	Naming *deploy.NamingPolicy
+
 	// true if debugging output it enabled
 	Debug bool
 
@@ -81,6 +87,19 @@ type UpdateOptions struct {
 	// true if the engine should use legacy diffing behavior during an update.
 	UseLegacyDiff bool
 
+	// the operation timeouts for resources that do not specify their own.
+	DefaultTimeouts deploy.DefaultTimeouts
+
+	// an optional plan in which a preview records the steps that it proposes.
+	SavePlan *deploy.SavedPlan
+
+	// an optional plan that was saved by a preview, from which the steps of an update must not diverge.
+	ApplyPlan *deploy.SavedPlan
+
+	// true if resources whose delete hooks cannot run, because no program is running to serve them, may be deleted
+	// anyway.
+	SkipResourceHooks bool
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
@@ -237,6 +256,8 @@ func newUpdateSource(
 		Pwd:     pwd,
 		Program: main,
 		Target:  target,
+
+		DefaultTimeouts: opts.DefaultTimeouts,
 	}, defaultProviderVersions, dryRun), nil
 }
 
@@ -284,7 +305,7 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 
 				// Print out the total number of steps performed (and their kinds), the duration, and any summary info.
 				opts.Events.updateSummaryEvent(actions.MaybeCorrupt, time.Since(start),
-					resourceChanges, policies)
+					resourceChanges, policies, actions.Timings)
 			}
 		}
 	}
@@ -297,6 +318,8 @@ type updateActions struct {
 	Steps        int
 	Ops          map[deploy.StepOp]int
 	Seen         map[resource.URN]deploy.Step
+	Starts       map[deploy.Step]time.Time
+	Timings      []ResourceTiming
 	MapLock      sync.Mutex
 	MaybeCorrupt bool
 	Update       UpdateInfo
@@ -308,6 +331,7 @@ func newUpdateActions(context *Context, u UpdateInfo, opts planOptions) *updateA
 		Context: context,
 		Ops:     make(map[deploy.StepOp]int),
 		Seen:    make(map[resource.URN]deploy.Step),
+		Starts:  make(map[deploy.Step]time.Time),
 		Update:  u,
 		Opts:    opts,
 	}
@@ -317,6 +341,7 @@ func (acts *updateActions) OnResourceStepPre(step deploy.Step) (interface{}, err
 	// Ensure we've marked this step as observed.
 	acts.MapLock.Lock()
 	acts.Seen[step.URN()] = step
+	acts.Starts[step] = time.Now()
 	acts.MapLock.Unlock()
 
 	// Skip reporting if necessary.
@@ -334,6 +359,8 @@ func (acts *updateActions) OnResourceStepPost(
 
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
+	duration := time.Since(acts.Starts[step])
+	delete(acts.Starts, step)
 	acts.MapLock.Unlock()
 
 	// If we've already been terminated, exit without writing the checkpoint. We explicitly want to leave the
@@ -357,8 +384,15 @@ func (acts *updateActions) OnResourceStepPost(
 
 		// Issue a true, bonafide error.
 		acts.Opts.Diag.Errorf(diag.GetResourceOperationFailedError(errorURN), err)
+
+		// If the resource was only partially created or updated, it has been saved to the snapshot, so let the user
+		// know how to deal with it.
+		if status == resource.StatusPartialFailure && step.New() != nil {
+			urn := step.URN()
+			acts.Opts.Diag.Warningf(diag.GetResourcePartiallyInitializedWarning(errorURN), urn, urn, urn)
+		}
 		if reportStep {
-			acts.Opts.Events.resourceOperationFailedEvent(step, status, acts.Steps, acts.Opts.Debug)
+			acts.Opts.Events.resourceOperationFailedEvent(step, status, acts.Steps, duration, acts.Opts.Debug)
 		}
 	} else if reportStep {
 		op, record := step.Op(), step.Logical()
@@ -372,10 +406,18 @@ func (acts *updateActions) OnResourceStepPost(
 		}
 
 		if record {
-			// Increment the counters.
+			// Increment the counters, and record the time taken by operations that changed the resource.
 			acts.MapLock.Lock()
 			acts.Steps++
 			acts.Ops[op]++
+			if op != deploy.OpSame {
+				acts.Timings = append(acts.Timings, ResourceTiming{
+					URN:      step.URN(),
+					Type:     step.Type(),
+					Op:       op,
+					Duration: duration,
+				})
+			}
 			acts.MapLock.Unlock()
 		}
 
@@ -383,7 +425,7 @@ func (acts *updateActions) OnResourceStepPost(
 		// not show outputs for component resources at this point: any that exist must be from a previous execution of
 		// the Pulumi program, as component resources only report outputs via calls to RegisterResourceOutputs.
 		if step.Res().Custom || acts.Opts.Refresh && step.Op() == deploy.OpRefresh {
-			acts.Opts.Events.resourceOutputsEvent(op, step, false /*planning*/, acts.Opts.Debug)
+			acts.Opts.Events.resourceOutputsEvent(op, step, duration, false /*planning*/, acts.Opts.Debug)
 		}
 	}
 
@@ -425,7 +467,7 @@ func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 
 	// Skip reporting if necessary.
 	if shouldReportStep(step, acts.Opts) {
-		acts.Opts.Events.resourceOutputsEvent(step.Op(), step, false /*planning*/, acts.Opts.Debug)
+		acts.Opts.Events.resourceOutputsEvent(step.Op(), step, 0, false /*planning*/, acts.Opts.Debug)
 	}
 
 	// There's a chance there are new outputs that weren't written out last time.
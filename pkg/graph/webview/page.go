@@ -0,0 +1,207 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webview
+
+// page is the interactive viewer.  It has no external dependencies so that it works without network access: vertices
+// are laid out in layers by their depth in the graph and rendered as SVG.
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Pulumi Stack Graph</title>
+<style>
+  body { margin: 0; font-family: sans-serif; font-size: 12px; overflow: hidden; }
+  #toolbar { position: fixed; top: 0; left: 0; right: 0; padding: 6px; background: #f4f4f4;
+             border-bottom: 1px solid #ccc; z-index: 1; }
+  #toolbar input, #toolbar select { margin-right: 12px; }
+  #canvas { position: fixed; top: 36px; left: 0; right: 0; bottom: 0; cursor: move; }
+  .vertex rect { fill: #fff; stroke: #512668; stroke-width: 1px; }
+  .vertex text { pointer-events: none; }
+  .vertex.match rect { fill: #fff3b0; }
+  .vertex.selected rect { fill: #512668; }
+  .vertex.selected text { fill: #fff; }
+  .vertex.dependent rect { fill: #d9c7e3; }
+  .dim { opacity: 0.15; }
+  .hidden { display: none; }
+  line { stroke-width: 1px; }
+</style>
+</head>
+<body>
+<div id="toolbar">
+  Search: <input id="search" type="text" placeholder="name or URN">
+  Type: <select id="type"><option value="">(all)</option></select>
+  <span id="status"></span>
+</div>
+<svg id="canvas"><g id="viewport"><g id="edges"></g><g id="vertices"></g></g></svg>
+<script>
+(function() {
+  var SVG = "http://www.w3.org/2000/svg";
+  var W = 260, H = 24, DX = 320, DY = 34;
+  var view = { x: 20, y: 20, k: 1 };
+  var selected = null;
+
+  // URNs look like urn:pulumi:stack::project::parent$type::name.
+  function typeOf(urn) {
+    var parts = urn.split("::");
+    if (parts.length < 4) { return ""; }
+    var types = parts[2].split("$");
+    return types[types.length - 1];
+  }
+  function nameOf(urn) {
+    var parts = urn.split("::");
+    return parts[parts.length - 1];
+  }
+  function el(tag, attrs) {
+    var e = document.createElementNS(SVG, tag);
+    for (var k in attrs) { e.setAttribute(k, attrs[k]); }
+    return e;
+  }
+  function applyView() {
+    document.getElementById("viewport").setAttribute("transform",
+      "translate(" + view.x + "," + view.y + ") scale(" + view.k + ")");
+  }
+
+  fetch("graph.json").then(function(r) { return r.json(); }).then(function(g) {
+    var vs = g.vertices, es = g.edges;
+    var outs = vs.map(function() { return []; }), ins = vs.map(function() { return 0; });
+    var deps = vs.map(function() { return []; });
+    es.forEach(function(e) {
+      outs[e.from].push(e.to);
+      ins[e.to]++;
+      if (!e.parent) { deps[e.from].push(e.to); }
+    });
+
+    // Assign each vertex to a layer one deeper than the deepest vertex with an edge into it.
+    var depth = vs.map(function() { return 0; }), queue = [], pending = ins.slice();
+    vs.forEach(function(v) { if (pending[v.id] === 0) { queue.push(v.id); } });
+    while (queue.length) {
+      var id = queue.shift();
+      outs[id].forEach(function(to) {
+        depth[to] = Math.max(depth[to], depth[id] + 1);
+        if (--pending[to] === 0) { queue.push(to); }
+      });
+    }
+    var rows = {};
+    vs.forEach(function(v) {
+      v.type = typeOf(v.label);
+      v.name = nameOf(v.label);
+      var d = depth[v.id];
+      rows[d] = (rows[d] || 0) + 1;
+      v.x = d * DX;
+      v.y = (rows[d] - 1) * DY;
+    });
+
+    var edgeLayer = document.getElementById("edges"), vertexLayer = document.getElementById("vertices");
+    es.forEach(function(e) {
+      var a = vs[e.from], b = vs[e.to];
+      e.node = el("line", { x1: a.x + W, y1: a.y + H / 2, x2: b.x, y2: b.y + H / 2, stroke: e.color || "#999" });
+      if (e.label) { var t = el("title", {}); t.textContent = e.label; e.node.appendChild(t); }
+      edgeLayer.appendChild(e.node);
+    });
+    vs.forEach(function(v) {
+      v.node = el("g", { "class": "vertex", transform: "translate(" + v.x + "," + v.y + ")" });
+      v.node.appendChild(el("rect", { width: W, height: H, rx: 3 }));
+      var text = el("text", { x: 6, y: 16 });
+      text.textContent = v.name + " (" + v.type + ")";
+      v.node.appendChild(text);
+      var title = el("title", {});
+      title.textContent = v.label;
+      v.node.appendChild(title);
+      v.node.addEventListener("click", function(evt) { evt.stopPropagation(); select(v.id); });
+      vertexLayer.appendChild(v.node);
+    });
+
+    var types = {};
+    vs.forEach(function(v) { types[v.type] = true; });
+    var typeSelect = document.getElementById("type");
+    Object.keys(types).sort().forEach(function(t) {
+      var o = document.createElement("option");
+      o.value = o.textContent = t;
+      typeSelect.appendChild(o);
+    });
+
+    // Highlight the selected vertex and everything that depends on it.  Edges to parents are not dependencies.
+    function select(id) {
+      selected = id;
+      var reach = {};
+      if (id !== null) {
+        var stack = [id];
+        while (stack.length) {
+          var cur = stack.pop();
+          deps[cur].forEach(function(to) { if (!reach[to]) { reach[to] = true; stack.push(to); } });
+        }
+      }
+      vs.forEach(function(v) {
+        v.node.classList.toggle("selected", v.id === id);
+        v.node.classList.toggle("dependent", !!reach[v.id] && v.id !== id);
+        v.node.classList.toggle("dim", id !== null && v.id !== id && !reach[v.id]);
+      });
+      es.forEach(function(e) {
+        e.node.classList.toggle("dim", id !== null && (e.parent || !(e.from === id || reach[e.from])));
+      });
+      document.getElementById("status").textContent =
+        id === null ? vs.length + " resources" : Object.keys(reach).length + " dependents of " + vs[id].label;
+    }
+    function filter() {
+      var q = document.getElementById("search").value.toLowerCase(), t = typeSelect.value;
+      vs.forEach(function(v) {
+        v.visible = !t || v.type === t;
+        v.node.classList.toggle("hidden", !v.visible);
+        v.node.classList.toggle("match", q !== "" && v.label.toLowerCase().indexOf(q) >= 0);
+      });
+      es.forEach(function(e) { e.node.classList.toggle("hidden", !vs[e.from].visible || !vs[e.to].visible); });
+    }
+    document.getElementById("search").addEventListener("input", filter);
+    document.getElementById("search").addEventListener("keydown", function(evt) {
+      if (evt.key !== "Enter") { return; }
+      var q = this.value.toLowerCase();
+      for (var i = 0; i < vs.length; i++) {
+        if (vs[i].visible && vs[i].label.toLowerCase().indexOf(q) >= 0) {
+          view.x = 200 - vs[i].x * view.k;
+          view.y = 200 - vs[i].y * view.k;
+          applyView();
+          select(i);
+          return;
+        }
+      }
+    });
+    typeSelect.addEventListener("change", filter);
+    document.getElementById("canvas").addEventListener("click", function() { select(null); });
+    filter();
+    select(null);
+  });
+
+  // Pan by dragging and zoom with the mouse wheel.
+  var canvas = document.getElementById("canvas"), drag = null;
+  canvas.addEventListener("mousedown", function(evt) { drag = { x: evt.clientX - view.x, y: evt.clientY - view.y }; });
+  window.addEventListener("mouseup", function() { drag = null; });
+  window.addEventListener("mousemove", function(evt) {
+    if (drag) { view.x = evt.clientX - drag.x; view.y = evt.clientY - drag.y; applyView(); }
+  });
+  canvas.addEventListener("wheel", function(evt) {
+    evt.preventDefault();
+    var k = Math.min(4, Math.max(0.05, view.k * (evt.deltaY < 0 ? 1.1 : 1 / 1.1)));
+    var px = evt.clientX, py = evt.clientY - canvas.getBoundingClientRect().top;
+    view.x = px - (px - view.x) * k / view.k;
+    view.y = py - (py - view.y) * k / view.k;
+    view.k = k;
+    applyView();
+  });
+  applyView();
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,128 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webview serves a resource graph as a self-contained, interactive web page.  Unlike the DOT output produced
+// by the dotconv package, the page remains usable for graphs with thousands of vertices: it supports panning and
+// zooming, searching by label, filtering by resource type, and highlighting everything that depends on a vertex.
+package webview
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/pulumi/pulumi/pkg/graph"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// Vertex is the JSON representation of a graph vertex.
+type Vertex struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+}
+
+// Edge is the JSON representation of a graph edge.
+type Edge struct {
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Label  string `json:"label,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Parent bool   `json:"parent,omitempty"` // true if the edge connects a vertex to its parent.
+}
+
+// ParentEdge is implemented by graph edges that connect a vertex to its parent, rather than to a vertex that depends
+// on it.  The page draws them, but does not follow them when it highlights a vertex's dependents.
+type ParentEdge interface {
+	graph.Edge
+
+	// IsParentEdge returns true if the edge connects a vertex to its parent.
+	IsParentEdge() bool
+}
+
+// Data is the JSON representation of an entire graph, as consumed by the web page.
+type Data struct {
+	Vertices []Vertex `json:"vertices"`
+	Edges    []Edge   `json:"edges"`
+}
+
+// Convert flattens a graph into its JSON representation.  Vertices are numbered in breadth-first order from the
+// graph's roots, which are visited in order of their labels, so that the same graph always converts the same way.
+func Convert(g graph.Graph) *Data {
+	data := &Data{Vertices: []Vertex{}, Edges: []Edge{}}
+
+	ids := make(map[graph.Vertex]int)
+	var frontier []graph.Vertex
+	visit := func(v graph.Vertex) int {
+		if id, has := ids[v]; has {
+			return id
+		}
+		id := len(data.Vertices)
+		ids[v] = id
+		data.Vertices = append(data.Vertices, Vertex{ID: id, Label: v.Label()})
+		frontier = append(frontier, v)
+		return id
+	}
+
+	roots := g.Roots()
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].To().Label() < roots[j].To().Label() })
+	for _, root := range roots {
+		visit(root.To())
+	}
+	for len(frontier) > 0 {
+		v := frontier[0]
+		frontier = frontier[1:]
+		from := ids[v]
+		for _, out := range v.Outs() {
+			if out.To() == nil {
+				continue
+			}
+			parent, ok := out.(ParentEdge)
+			data.Edges = append(data.Edges, Edge{
+				From:   from,
+				To:     visit(out.To()),
+				Label:  out.Label(),
+				Color:  out.Color(),
+				Parent: ok && parent.IsParentEdge(),
+			})
+		}
+	}
+
+	return data
+}
+
+// NewHandler returns an HTTP handler that serves the interactive page at "/" and the graph's JSON representation at
+// "/graph.json".
+func NewHandler(g graph.Graph) (http.Handler, error) {
+	data, err := json.Marshal(Convert(g))
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graph.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write(data)
+		contract.IgnoreError(err)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, err := w.Write([]byte(page))
+		contract.IgnoreError(err)
+	})
+	return mux, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webview
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/graph"
+)
+
+type testGraph struct {
+	roots []graph.Edge
+}
+
+func (g *testGraph) Roots() []graph.Edge { return g.roots }
+
+type testVertex struct {
+	label string
+	outs  []graph.Edge
+}
+
+func (v *testVertex) Data() interface{}  { return nil }
+func (v *testVertex) Label() string      { return v.label }
+func (v *testVertex) Ins() []graph.Edge  { return nil }
+func (v *testVertex) Outs() []graph.Edge { return v.outs }
+
+type testEdge struct {
+	label string
+	color string
+	from  graph.Vertex
+	to    graph.Vertex
+}
+
+func (e *testEdge) Data() interface{}  { return nil }
+func (e *testEdge) Label() string      { return e.label }
+func (e *testEdge) To() graph.Vertex   { return e.to }
+func (e *testEdge) From() graph.Vertex { return e.from }
+func (e *testEdge) Color() string      { return e.color }
+
+type testParentEdge struct {
+	testEdge
+}
+
+func (e *testParentEdge) IsParentEdge() bool { return true }
+
+// newTestGraph builds a graph with the given root labels and edges between labels.  An edge whose target label is
+// prefixed with "^" is a parent edge.
+func newTestGraph(roots []string, edges [][2]string) graph.Graph {
+	vertices := map[string]*testVertex{}
+	vertex := func(label string) *testVertex {
+		if v, has := vertices[label]; has {
+			return v
+		}
+		v := &testVertex{label: label}
+		vertices[label] = v
+		return v
+	}
+
+	g := &testGraph{}
+	for _, root := range roots {
+		g.roots = append(g.roots, &testEdge{to: vertex(root)})
+	}
+	for _, e := range edges {
+		if strings.HasPrefix(e[1], "^") {
+			from, to := vertex(e[0]), vertex(e[1][1:])
+			from.outs = append(from.outs, &testParentEdge{testEdge{color: "#fff", from: from, to: to}})
+			continue
+		}
+		from, to := vertex(e[0]), vertex(e[1])
+		from.outs = append(from.outs, &testEdge{label: e[0] + "->" + e[1], color: "#000", from: from, to: to})
+	}
+	return g
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		roots    []string
+		edges    [][2]string
+		expected *Data
+	}{
+		{
+			name:     "empty",
+			expected: &Data{Vertices: []Vertex{}, Edges: []Edge{}},
+		},
+		{
+			name:  "chain",
+			roots: []string{"a"},
+			edges: [][2]string{{"a", "b"}, {"b", "c"}},
+			expected: &Data{
+				Vertices: []Vertex{{ID: 0, Label: "a"}, {ID: 1, Label: "b"}, {ID: 2, Label: "c"}},
+				Edges: []Edge{
+					{From: 0, To: 1, Label: "a->b", Color: "#000"},
+					{From: 1, To: 2, Label: "b->c", Color: "#000"},
+				},
+			},
+		},
+		{
+			// Shared vertices are numbered once, in breadth-first order from the roots.
+			name:  "diamond",
+			roots: []string{"a", "b"},
+			edges: [][2]string{{"a", "c"}, {"b", "c"}, {"c", "d"}},
+			expected: &Data{
+				Vertices: []Vertex{{ID: 0, Label: "a"}, {ID: 1, Label: "b"}, {ID: 2, Label: "c"}, {ID: 3, Label: "d"}},
+				Edges: []Edge{
+					{From: 0, To: 2, Label: "a->c", Color: "#000"},
+					{From: 1, To: 2, Label: "b->c", Color: "#000"},
+					{From: 2, To: 3, Label: "c->d", Color: "#000"},
+				},
+			},
+		},
+		{
+			// Roots are visited in order of their labels, whatever order the graph returns them in.
+			name:  "unordered roots",
+			roots: []string{"b", "a"},
+			edges: [][2]string{{"b", "d"}, {"a", "c"}},
+			expected: &Data{
+				Vertices: []Vertex{{ID: 0, Label: "a"}, {ID: 1, Label: "b"}, {ID: 2, Label: "c"}, {ID: 3, Label: "d"}},
+				Edges: []Edge{
+					{From: 0, To: 2, Label: "a->c", Color: "#000"},
+					{From: 1, To: 3, Label: "b->d", Color: "#000"},
+				},
+			},
+		},
+		{
+			// Parent edges are marked, so that the page does not treat them as dependencies.
+			name:  "parent",
+			roots: []string{"a"},
+			edges: [][2]string{{"a", "b"}, {"b", "^a"}},
+			expected: &Data{
+				Vertices: []Vertex{{ID: 0, Label: "a"}, {ID: 1, Label: "b"}},
+				Edges: []Edge{
+					{From: 0, To: 1, Label: "a->b", Color: "#000"},
+					{From: 1, To: 0, Color: "#fff", Parent: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Convert(newTestGraph(tt.roots, tt.edges)))
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler, err := NewHandler(newTestGraph([]string{"a"}, [][2]string{{"a", "b"}}))
+	assert.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	get := func(path string) (*http.Response, []byte) {
+		resp, err := http.Get(server.URL + path)
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, resp.Body.Close()) }()
+		body, err := ioutil.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		return resp, body
+	}
+
+	// The page is served at the root.
+	resp, body := get("/")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), "graph.json")
+
+	// The graph is served as JSON.
+	resp, body = get("/graph.json")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var data Data
+	assert.NoError(t, json.Unmarshal(body, &data))
+	assert.Equal(t, []Vertex{{ID: 0, Label: "a"}, {ID: 1, Label: "b"}}, data.Vertices)
+	assert.Equal(t, []Edge{{From: 0, To: 1, Label: "a->b", Color: "#000"}}, data.Edges)
+
+	// Anything else is not found.
+	resp, _ = get("/other")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
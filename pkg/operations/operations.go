@@ -16,11 +16,17 @@ package operations
 
 import (
 	"time"
+
+	"github.com/pulumi/pulumi/pkg/resource"
 )
 
 // LogEntry is a row in the logs for a running compute service
 type LogEntry struct {
 	ID string
+	// URN is the URN of the resource that produced the entry, if known.
+	URN resource.URN
+	// Stream is the name of the provider-specific log stream that contained the entry, if any.
+	Stream string
 	// Timestamp is a Unix timestamp, in milliseconds
 	Timestamp int64
 	Message   string
@@ -215,6 +215,7 @@ func (p *awsConnection) getLogsForLogGroupsConcurrently(
 		for _, event := range logEvents {
 			logs = append(logs, LogEntry{
 				ID:        names[i],
+				Stream:    aws.StringValue(event.LogStreamName),
 				Message:   aws.StringValue(event.Message),
 				Timestamp: aws.Int64Value(event.Timestamp),
 			})
@@ -131,6 +131,7 @@ func (ops *cloudOpsProvider) GetLogs(query LogQuery) (*[]LogEntry, error) {
 				// Extract out each individual log event and add them to our array of logs.
 				for _, logEvent := range logMessage.LogEvents {
 					if extracted := extractLambdaLogMessage(logEvent.Message, logName); extracted != nil {
+						extracted.Stream = logMessage.LogStream
 						logs = append(logs, *extracted)
 					}
 				}
@@ -157,6 +158,7 @@ func (ops *cloudOpsProvider) GetLogs(query LogQuery) (*[]LogEntry, error) {
 		for _, rawLog := range *rawLogs {
 			logs = append(logs, LogEntry{
 				ID:        name,
+				Stream:    rawLog.Stream,
 				Message:   rawLog.Message,
 				Timestamp: rawLog.Timestamp,
 			})
@@ -131,6 +131,7 @@ func (ops *gcpOpsProvider) getFunctionLogs(state *resource.State, query LogQuery
 
 		logs = append(logs, LogEntry{
 			ID:        name,
+			Stream:    entry.GetLogName(),
 			Message:   message,
 			Timestamp: entry.GetTimestamp().Seconds * 1000,
 		})
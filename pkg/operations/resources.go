@@ -157,6 +157,12 @@ func (ops *resourceOperations) GetLogs(query LogQuery) (*[]LogEntry, error) {
 				return logsResult, err
 			}
 			if logsResult != nil {
+				// Attribute any logs that the provider did not attribute itself to this resource.
+				for i := range *logsResult {
+					if (*logsResult)[i].URN == "" {
+						(*logsResult)[i].URN = ops.resource.State.URN
+					}
+				}
 				return logsResult, nil
 			}
 		}
@@ -19,8 +19,10 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -35,6 +37,11 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/azureblob" // driver for azblob://
+	_ "gocloud.dev/blob/gcsblob"   // driver for gs://
+	_ "gocloud.dev/blob/s3blob"    // driver for s3://
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
 
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/httputil"
@@ -44,6 +51,15 @@ const (
 	// BookkeepingDir is the name of our bookkeeping folder, we store state here (like .git for git).
 	// Copied from workspace.BookkeepingDir to break import cycle.
 	BookkeepingDir = ".pulumi"
+	// IgnoreFile is the name of a file, using gitignore syntax, that lists paths to exclude when a directory is
+	// expanded into an archive.  It may appear in any directory beneath the archive's root.
+	IgnoreFile = ".pulumiignore"
+
+	// RemoteAssetHeadersEnvVar is the name of an environment variable containing a JSON object that maps host names
+	// to additional HTTP headers (for example, `Authorization`) that are sent when fetching http:// and https:// assets
+	// and archives from that host.  Credentials may also be supplied as user info within the URI itself, although they
+	// are never persisted in checkpoints or displayed.
+	RemoteAssetHeadersEnvVar = "PULUMI_REMOTE_ASSET_HEADERS"
 )
 
 // Asset is a serialized asset reference.  It is a union: thus, only one of its fields will be non-nil.  Several helper
@@ -57,7 +73,8 @@ type Asset struct {
 	Text string `json:"text,omitempty" yaml:"text,omitempty"`
 	// Path will contain a non-empty path to the file on the current filesystem for file assets.
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
-	// URI will contain a non-empty URI (file://, http://, https://, or custom) for URI-backed assets.
+	// URI will contain a non-empty URI (file://, http://, https://, s3://, gs://, azblob://, or custom) for URI-backed
+	// assets.
 	URI string `json:"uri,omitempty" yaml:"uri,omitempty"`
 }
 
@@ -243,6 +260,16 @@ func (a *Asset) Serialize() map[string]interface{} {
 	return result
 }
 
+// Redacted returns this asset with any credentials removed from its URI, for persisting in checkpoints.
+func (a *Asset) Redacted() *Asset {
+	if uri := RedactURI(a.URI); uri != a.URI {
+		redacted := *a
+		redacted.URI = uri
+		return &redacted
+	}
+	return a
+}
+
 // DeserializeAsset checks to see if the map contains an asset, using its signature, and if so deserializes it.
 func DeserializeAsset(obj map[string]interface{}) (*Asset, bool, error) {
 	// If not an asset, return false immediately.
@@ -359,11 +386,17 @@ func (a *Asset) readURI() (*Blob, error) {
 	contract.Assertf(isurl, "Expected a URI-based asset")
 	switch s := url.Scheme; s {
 	case "http", "https":
-		resp, err := httputil.GetWithRetry(url.String(), http.DefaultClient)
+		resp, err := fetchHTTP(url)
 		if err != nil {
 			return nil, err
 		}
+		// If the server told us how large the asset is, stream it rather than reading it all into memory.
+		if resp.ContentLength >= 0 {
+			return &Blob{rd: resp.Body, sz: resp.ContentLength}, nil
+		}
 		return NewReadCloserBlob(resp.Body)
+	case "s3", "gs", "azblob":
+		return openBucketObject(url)
 	case "file":
 		contract.Assert(url.User == nil)
 		contract.Assert(url.RawQuery == "")
@@ -456,7 +489,7 @@ type Archive struct {
 	Assets map[string]interface{} `json:"assets,omitempty" yaml:"assets,omitempty"`
 	// Path is a non-empty string representing a path to a file on the current filesystem, for file archives.
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
-	// URI is a non-empty URI (file://, http://, https://, etc), for URI-backed archives.
+	// URI is a non-empty URI (file://, http://, https://, s3://, gs://, azblob://, etc), for URI-backed archives.
 	URI string `json:"uri,omitempty" yaml:"uri,omitempty"`
 }
 
@@ -585,6 +618,27 @@ func (a *Archive) Serialize() map[string]interface{} {
 	return result
 }
 
+// Redacted returns this archive with any credentials removed from its URI and those of the assets and archives that
+// it contains, for persisting in checkpoints.
+func (a *Archive) Redacted() *Archive {
+	redacted := *a
+	redacted.URI = RedactURI(a.URI)
+	if a.Assets != nil {
+		redacted.Assets = make(map[string]interface{})
+		for k, v := range a.Assets {
+			switch t := v.(type) {
+			case *Asset:
+				redacted.Assets[k] = t.Redacted()
+			case *Archive:
+				redacted.Assets[k] = t.Redacted()
+			default:
+				redacted.Assets[k] = v
+			}
+		}
+	}
+	return &redacted
+}
+
 // DeserializeArchive checks to see if the map contains an archive, using its signature, and if so deserializes it.
 func DeserializeArchive(obj map[string]interface{}) (*Archive, bool, error) {
 	// If not an archive, return false immediately.
@@ -769,10 +823,40 @@ func (a *Archive) readAssets() (ArchiveReader, error) {
 	return r, nil
 }
 
-// directoryArchiveReader is used to read an archive that is represented by a directory in the host filesystem.
+const (
+	// directoryPrefetchDepth is the maximum number of directory archive entries that are read ahead of the consumer.
+	directoryPrefetchDepth = 8
+	// directoryPrefetchMaxSize is the largest file that will be read ahead into memory; larger files are streamed.
+	directoryPrefetchMaxSize = 1 << 20
+)
+
+// directoryArchiveReader is used to read an archive that is represented by a directory in the host filesystem.  Small
+// files are read ahead by a bounded number of goroutines so that their I/O overlaps with consumption of prior entries.
 type directoryArchiveReader struct {
 	directoryPath string
 	assetPaths    []string
+	pending       []chan prefetchedFile // the in-flight reads for the entries at the front of assetPaths.
+}
+
+// prefetchedFile is the result of reading ahead a single directory archive entry.  If data is nil and err is nil, the
+// file was too large to buffer and must be streamed from disk instead.
+type prefetchedFile struct {
+	data []byte
+	err  error
+}
+
+func prefetchFile(path string) chan prefetchedFile {
+	result := make(chan prefetchedFile, 1)
+	go func() {
+		info, err := os.Stat(path)
+		if err != nil || info.Size() > directoryPrefetchMaxSize {
+			result <- prefetchedFile{}
+			return
+		}
+		data, err := ioutil.ReadFile(path)
+		result <- prefetchedFile{data: data, err: err}
+	}()
+	return result
 }
 
 func (r *directoryArchiveReader) Next() (string, *Blob, error) {
@@ -781,9 +865,15 @@ func (r *directoryArchiveReader) Next() (string, *Blob, error) {
 		return "", nil, io.EOF
 	}
 
+	// Keep the read-ahead window full, and then wait for the read of the next path in the archive to finish.
+	for len(r.pending) < len(r.assetPaths) && len(r.pending) < directoryPrefetchDepth {
+		r.pending = append(r.pending, prefetchFile(r.assetPaths[len(r.pending)]))
+	}
+	prefetched := <-r.pending[0]
+
 	// Fetch the next path in the archive and slice it off of the list.
 	assetPath := r.assetPaths[0]
-	r.assetPaths = r.assetPaths[1:]
+	r.assetPaths, r.pending = r.assetPaths[1:], r.pending[1:]
 
 	// Crop the asset's path s.t. it is relative to the directory path.
 	name, err := filepath.Rel(r.directoryPath, assetPath)
@@ -795,7 +885,12 @@ func (r *directoryArchiveReader) Next() (string, *Blob, error) {
 	// Replace Windows separators with Linux ones (ToSlash is a no-op on Linux)
 	name = filepath.ToSlash(name)
 
-	// Open and return the blob.
+	// If the contents were read ahead, hand them back directly; otherwise, open and return the blob.
+	if prefetched.err != nil {
+		return "", nil, errors.Wrapf(prefetched.err, "failed to read asset file '%v'", assetPath)
+	} else if prefetched.data != nil {
+		return name, NewByteBlob(prefetched.data), nil
+	}
 	blob, err := (&Asset{Path: assetPath}).Read()
 	if err != nil {
 		return "", nil, err
@@ -816,69 +911,128 @@ func (a *Archive) readPath() (ArchiveReader, error) {
 
 	if format == NotArchive {
 		// If not an archive, it could be a directory; if so, simply expand it out uncompressed as an archive.
-		info, err := os.Stat(path)
+		assetPaths, err := walkArchiveDirectory(path)
 		if err != nil {
-			return nil, errors.Wrapf(err, "couldn't read archive path '%v'", path)
-		} else if !info.IsDir() {
-			return nil, errors.Errorf("'%v' is neither a recognized archive type nor a directory", path)
+			return nil, err
+		}
+
+		r := &directoryArchiveReader{
+			directoryPath: path,
+			assetPaths:    assetPaths,
+		}
+		return r, nil
+	}
+
+	// Otherwise, it's an archive file, and we will go ahead and open it up and read it.
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return readArchive(file, format)
+}
+
+// walkArchiveDirectory returns the paths of the files that make up a directory archive, honoring any .pulumiignore
+// files.  The list is ordered deterministically by filepath.Walk.
+func walkArchiveDirectory(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't read archive path '%v'", path)
+	} else if !info.IsDir() {
+		return nil, errors.Errorf("'%v' is neither a recognized archive type nor a directory", path)
+	}
+
+	// Accumulate the list of asset paths.
+	assetPaths := []string{}
+	var ignores []gitignore.Pattern
+	if walkerr := filepath.Walk(path, func(filePath string, f os.FileInfo, fileerr error) error {
+		// If there was an error, exit.
+		if fileerr != nil {
+			return fileerr
 		}
 
-		// Accumulate the list of asset paths. This list is ordered deterministically by filepath.Walk.
-		assetPaths := []string{}
-		if walkerr := filepath.Walk(path, func(filePath string, f os.FileInfo, fileerr error) error {
-			// If there was an error, exit.
-			if fileerr != nil {
-				return fileerr
+		// If this is a .pulumi directory, we will skip this by default.
+		if f.Name() == BookkeepingDir {
+			if f.IsDir() {
+				return filepath.SkipDir
 			}
+			return nil
+		}
+
+		// .pulumiignore files only control what is archived, so they are not archived themselves.
+		if f.Name() == IgnoreFile && !f.IsDir() {
+			return nil
+		}
 
-			// If this is a .pulumi directory, we will skip this by default.
-			// TODO[pulumi/pulumi#122]: when we support .pulumiignore, this will be customizable.
-			if f.Name() == BookkeepingDir {
+		// Split the path relative to the archive root so that it can be matched against any ignore patterns
+		// that were declared by .pulumiignore files in this directory or its parents.
+		rel, err := filepath.Rel(path, filePath)
+		if err != nil {
+			return err
+		}
+		var relPath []string
+		if rel != "." {
+			relPath = strings.Split(filepath.ToSlash(rel), "/")
+			if len(ignores) > 0 && gitignore.NewMatcher(ignores).Match(relPath, f.IsDir()) {
 				if f.IsDir() {
 					return filepath.SkipDir
 				}
 				return nil
 			}
+		}
 
-			// If this was a directory, skip it.
-			if f.IsDir() {
-				return nil
+		// If this was a directory, pick up any ignore patterns it declares and then skip it.
+		if f.IsDir() {
+			patterns, err := readIgnorePatterns(filePath, relPath)
+			if err != nil {
+				return err
 			}
+			ignores = append(ignores, patterns...)
+			return nil
+		}
 
-			// If this is a symlink and it points at a directory, skip it. Otherwise continue along. This will mean
-			// that the file will be added to the list of files to archive. When you go to read this archive, you'll
-			// get a copy of the file (instead of a symlink) to some other file in the archive.
-			if f.Mode()&os.ModeSymlink != 0 {
-				fileInfo, statErr := os.Stat(filePath)
-				if statErr != nil {
-					return statErr
-				}
-
-				if fileInfo.IsDir() {
-					return nil
-				}
+		// If this is a symlink and it points at a directory, skip it. Otherwise continue along. This will mean
+		// that the file will be added to the list of files to archive. When you go to read this archive, you'll
+		// get a copy of the file (instead of a symlink) to some other file in the archive.
+		if f.Mode()&os.ModeSymlink != 0 {
+			fileInfo, statErr := os.Stat(filePath)
+			if statErr != nil {
+				return statErr
 			}
 
-			// Otherwise, add this asset to the list of paths and keep going.
-			assetPaths = append(assetPaths, filePath)
-			return nil
-		}); walkerr != nil {
-			return nil, walkerr
+			if fileInfo.IsDir() {
+				return nil
+			}
 		}
 
-		r := &directoryArchiveReader{
-			directoryPath: path,
-			assetPaths:    assetPaths,
-		}
-		return r, nil
+		// Otherwise, add this asset to the list of paths and keep going.
+		assetPaths = append(assetPaths, filePath)
+		return nil
+	}); walkerr != nil {
+		return nil, walkerr
 	}
+	return assetPaths, nil
+}
 
-	// Otherwise, it's an archive file, and we will go ahead and open it up and read it.
-	file, err := os.Open(path)
+// readIgnorePatterns reads the .pulumiignore file, if any, in the given directory.  The resulting patterns only apply
+// to paths beneath domain, which is the directory's path relative to the archive root.
+func readIgnorePatterns(dir string, domain []string) ([]gitignore.Pattern, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, IgnoreFile))
 	if err != nil {
-		return nil, err
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "reading %s", filepath.Join(dir, IgnoreFile))
 	}
-	return readArchive(file, format)
+
+	var patterns []gitignore.Pattern
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, domain))
+	}
+	return patterns, nil
 }
 
 func (a *Archive) readURI() (ArchiveReader, error) {
@@ -905,11 +1059,13 @@ func (a *Archive) readURI() (ArchiveReader, error) {
 func (a *Archive) openURLStream(url *url.URL) (io.ReadCloser, error) {
 	switch s := url.Scheme; s {
 	case "http", "https":
-		resp, err := httputil.GetWithRetry(url.String(), http.DefaultClient)
+		resp, err := fetchHTTP(url)
 		if err != nil {
 			return nil, err
 		}
 		return resp.Body, nil
+	case "s3", "gs", "azblob":
+		return openBucketObject(url)
 	case "file":
 		contract.Assert(url.Host == "")
 		contract.Assert(url.User == nil)
@@ -921,6 +1077,88 @@ func (a *Archive) openURLStream(url *url.URL) (io.ReadCloser, error) {
 	}
 }
 
+// fetchHTTP issues a GET request for the given http:// or https:// URL, attaching any headers configured for its host
+// by the RemoteAssetHeadersEnvVar environment variable.  User info in the URL is sent using basic authentication.
+func fetchHTTP(url *url.URL) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if user := url.User; user != nil {
+		password, _ := user.Password()
+		req.SetBasicAuth(user.Username(), password)
+	}
+	if headers := os.Getenv(RemoteAssetHeadersEnvVar); headers != "" {
+		var hostHeaders map[string]map[string]string
+		if err = json.Unmarshal([]byte(headers), &hostHeaders); err != nil {
+			return nil, errors.Wrapf(err, "could not parse %s", RemoteAssetHeadersEnvVar)
+		}
+		for k, v := range hostHeaders[url.Hostname()] {
+			req.Header.Set(k, v)
+		}
+	}
+
+	resp, err := httputil.DoWithRetry(req, http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		contract.IgnoreClose(resp.Body)
+		return nil, errors.Errorf("failed to fetch '%v': %v", RedactURI(url.String()), resp.Status)
+	}
+	return resp, nil
+}
+
+// RedactURI removes any credentials embedded as user info from the given URI, so that they are not persisted or
+// displayed.
+func RedactURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.User == nil {
+		return uri
+	}
+	u.User = nil
+	return u.String()
+}
+
+// bucketObjectReader reads a single object from an object store, closing the bucket when the object is closed.
+type bucketObjectReader struct {
+	*blob.Reader
+	bucket *blob.Bucket
+}
+
+func (r *bucketObjectReader) Close() error {
+	err := r.Reader.Close()
+	contract.IgnoreError(r.bucket.Close())
+	return err
+}
+
+// openBucketObject opens the object named by an s3://, gs://, or azblob:// URL.  The URL's host names the bucket and
+// its path names the object's key; credentials are picked up from the ambient environment, just as they are for the
+// corresponding state backends.
+func openBucketObject(url *url.URL) (*Blob, error) {
+	key := strings.TrimPrefix(url.Path, "/")
+	if url.Host == "" || key == "" {
+		return nil, errors.Errorf("'%v' must name both a bucket and an object key", url)
+	}
+
+	bucketURL := *url
+	bucketURL.Path = ""
+	ctx := context.Background()
+	bucket, err := blob.OpenBucket(ctx, bucketURL.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening bucket for '%v'", url)
+	}
+	r, err := bucket.NewReader(ctx, key, nil)
+	if err != nil {
+		contract.IgnoreClose(bucket)
+		return nil, errors.Wrapf(err, "reading '%v'", url)
+	}
+	return &Blob{
+		rd: &bucketObjectReader{Reader: r, bucket: bucket},
+		sz: r.Size(),
+	}, nil
+}
+
 // Bytes fetches the archive contents as a byte slices.  This is almost certainly the least efficient way to deal with
 // the underlying streaming capabilities offered by assets and archives, but can be used in a pinch to interact with
 // APIs that demand []bytes.
@@ -1093,35 +1331,54 @@ func (a *Archive) ReadSourceArchive() (ArchiveFormat, io.ReadCloser, error) {
 // EnsureHash computes the SHA256 hash of the archive's contents and stores it on the object.
 func (a *Archive) EnsureHash() error {
 	if a.Hash == "" {
-		hash := sha256.New()
-
-		// Attempt to compute the hash in the most efficient way.  First try to open the archive directly and copy it
-		// to the hash.  This avoids traversing any of the contents and just treats it as a byte stream.
-		f, r, err := a.ReadSourceArchive()
-		if err != nil {
-			return err
-		}
-		if f != NotArchive && r != nil {
-			defer contract.IgnoreClose(r)
-			_, err = io.Copy(hash, r)
-			if err != nil {
-				return err
-			}
-		} else {
-			// Otherwise, it's not an archive; we'll need to transform it into one.  Pick tar since it avoids
-			// any superfluous compression which doesn't actually help us in this situation.
-			err := a.Archive(TarArchive, hash)
+		// Directories can be arbitrarily large, so consult the cache before hashing their contents.
+		if path, ispath := a.GetPath(); ispath && detectArchiveFormat(path) == NotArchive {
+			hash, err := cachedDirectoryArchiveHash(path, a.computeHash)
 			if err != nil {
 				return err
 			}
+			a.Hash = hash
+			return nil
 		}
 
-		// Finally, encode the resulting hash as a string and we're done.
-		a.Hash = hex.EncodeToString(hash.Sum(nil))
+		hash, err := a.computeHash()
+		if err != nil {
+			return err
+		}
+		a.Hash = hash
 	}
 	return nil
 }
 
+// computeHash computes the SHA256 hash of the archive's contents.
+func (a *Archive) computeHash() (string, error) {
+	hash := sha256.New()
+
+	// Attempt to compute the hash in the most efficient way.  First try to open the archive directly and copy it
+	// to the hash.  This avoids traversing any of the contents and just treats it as a byte stream.
+	f, r, err := a.ReadSourceArchive()
+	if err != nil {
+		return "", err
+	}
+	if f != NotArchive && r != nil {
+		defer contract.IgnoreClose(r)
+		_, err = io.Copy(hash, r)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		// Otherwise, it's not an archive; we'll need to transform it into one.  Pick tar since it avoids
+		// any superfluous compression which doesn't actually help us in this situation.
+		err := a.Archive(TarArchive, hash)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Finally, encode the resulting hash as a string and we're done.
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // ArchiveFormat indicates what archive and/or compression format an archive uses.
 type ArchiveFormat int
 
@@ -0,0 +1,186 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+const (
+	// DisableArchiveHashCacheEnvVar is the name of an environment variable that, when set to a non-empty value,
+	// disables the on-disk cache of directory archive hashes.
+	DisableArchiveHashCacheEnvVar = "PULUMI_DISABLE_ARCHIVE_HASH_CACHE"
+
+	// pulumiHomeEnvVar overrides the location of the Pulumi home directory.
+	// Copied from workspace.PulumiHomeEnvVar to break import cycle.
+	pulumiHomeEnvVar = "PULUMI_HOME"
+
+	// archiveHashCacheVersion is mixed into every fingerprint so that changes to the archive format invalidate any
+	// previously cached hashes.
+	archiveHashCacheVersion = "1"
+)
+
+// archiveHashCacheMaxAge is how long an entry in the archive hash cache may go unused before it is evicted, so that
+// the cache does not keep growing as directories are hashed once and never again, e.g. in temporary build trees.
+var archiveHashCacheMaxAge = 30 * 24 * time.Hour
+
+// archiveHashCacheEntry is the on-disk record of a directory archive's hash.
+type archiveHashCacheEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Hash        string `json:"hash"`
+}
+
+// cachedDirectoryArchiveHash returns the hash of the directory archive rooted at path.  The hash is remembered
+// alongside a fingerprint of the names, sizes, and modification times of the files in the archive, so that repeated
+// operations against an unchanged directory need not read the contents of every file again.  If the fingerprint does
+// not match, compute is called to hash the archive from scratch.
+func cachedDirectoryArchiveHash(path string, compute func() (string, error)) (string, error) {
+	if os.Getenv(DisableArchiveHashCacheEnvVar) != "" {
+		return compute()
+	}
+
+	entryPath, err := archiveHashCachePath(path)
+	if err != nil {
+		logging.V(5).Infof("archive hash cache unavailable for '%v': %v", path, err)
+		return compute()
+	}
+	fingerprint, err := directoryArchiveFingerprint(path)
+	if err != nil {
+		return "", err
+	}
+
+	// If there is a matching entry, we're done.  Mark it as used, so that it is not evicted.
+	var entry archiveHashCacheEntry
+	if b, readErr := ioutil.ReadFile(entryPath); readErr == nil {
+		if jsonErr := json.Unmarshal(b, &entry); jsonErr == nil && entry.Fingerprint == fingerprint {
+			now := time.Now()
+			contract.IgnoreError(os.Chtimes(entryPath, now, now))
+			return entry.Hash, nil
+		}
+	}
+
+	hash, err := compute()
+	if err != nil {
+		return "", err
+	}
+
+	// Failing to record the hash only costs us time later, so just log any errors.
+	entry = archiveHashCacheEntry{Fingerprint: fingerprint, Hash: hash}
+	if err = writeArchiveHashCacheEntry(entryPath, entry); err != nil {
+		logging.V(5).Infof("failed to cache archive hash for '%v': %v", path, err)
+	}
+	if err = evictArchiveHashCacheEntries(filepath.Dir(entryPath)); err != nil {
+		logging.V(5).Infof("failed to evict unused archive hashes: %v", err)
+	}
+	return hash, nil
+}
+
+// evictArchiveHashCacheEntries removes the entries in the given cache directory, along with any temporary files left
+// behind by interrupted writes, that have not been used for longer than archiveHashCacheMaxAge.
+func evictArchiveHashCacheEntries(dir string) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-archiveHashCacheMaxAge)
+	for _, info := range infos {
+		if !info.IsDir() && info.ModTime().Before(cutoff) {
+			if err := os.Remove(filepath.Join(dir, info.Name())); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// archiveHashCachePath returns the path of the cache entry for the directory archive rooted at path.
+func archiveHashCachePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	home := os.Getenv(pulumiHomeEnvVar)
+	if home == "" {
+		u, err := user.Current()
+		if err != nil {
+			return "", errors.Wrapf(err, "getting current user")
+		}
+		home = filepath.Join(u.HomeDir, BookkeepingDir)
+	}
+
+	key := sha256.Sum256([]byte(abs))
+	return filepath.Join(home, "cache", "archives", hex.EncodeToString(key[:])+".json"), nil
+}
+
+// directoryArchiveFingerprint summarizes the name, size, mode, and modification time of each file in the directory
+// archive rooted at path.
+func directoryArchiveFingerprint(path string) (string, error) {
+	assetPaths, err := walkArchiveDirectory(path)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "v%s\n", archiveHashCacheVersion)
+	for _, assetPath := range assetPaths {
+		info, err := os.Stat(assetPath)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%v\x00%d\n", assetPath, info.Size(), info.Mode(), info.ModTime().UnixNano())
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeArchiveHashCacheEntry atomically writes a cache entry to the given path.
+func writeArchiveHashCacheEntry(entryPath string, entry archiveHashCacheEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(entryPath), 0700); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(entryPath), filepath.Base(entryPath))
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), entryPath)
+	}
+	if err != nil {
+		contract.IgnoreError(os.Remove(tmp.Name()))
+	}
+	return err
+}
@@ -902,6 +902,17 @@ func TestSetSuccess(t *testing.T) {
 				MustMakeKey("my", "servers"): NewObjectValue(`[{"host":"example","name":"foo"}]`),
 			},
 		},
+		{
+			Key:   `my:servers[0].port`,
+			Path:  true,
+			Value: NewValue("80"),
+			Config: Map{
+				MustMakeKey("my", "servers"): NewObjectValue(`[{"host":"example"}]`),
+			},
+			Expected: Map{
+				MustMakeKey("my", "servers"): NewObjectValue(`[{"host":"example","port":80}]`),
+			},
+		},
 		{
 			Key:   `my:name[0]`,
 			Path:  true,
@@ -72,6 +72,50 @@ func (c Value) Value(decrypter Decrypter) (string, error) {
 	return decrypter.DecryptValue(c.value)
 }
 
+// Reencrypt returns a copy of this value in which each secret has been decrypted using decrypter and encrypted again
+// using encrypter, e.g. to copy the value to a stack that uses a different secrets provider.
+func (c Value) Reencrypt(decrypter Decrypter, encrypter Encrypter) (Value, error) {
+	if !c.secure {
+		return c, nil
+	}
+
+	reencrypt := func(ciphertext string) (string, error) {
+		plaintext, err := decrypter.DecryptValue(ciphertext)
+		if err != nil {
+			return "", err
+		}
+		return encrypter.EncryptValue(plaintext)
+	}
+
+	if !c.object {
+		ciphertext, err := reencrypt(c.value)
+		if err != nil {
+			return Value{}, err
+		}
+		return NewSecureValue(ciphertext), nil
+	}
+
+	var obj interface{}
+	if err := json.Unmarshal([]byte(c.value), &obj); err != nil {
+		return Value{}, err
+	}
+	reencrypted, err := mapSecureValues(obj, func(ciphertext string) (interface{}, error) {
+		newCiphertext, err := reencrypt(ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"secure": newCiphertext}, nil
+	})
+	if err != nil {
+		return Value{}, err
+	}
+	b, err := json.Marshal(reencrypted)
+	if err != nil {
+		return Value{}, err
+	}
+	return NewSecureObjectValue(string(b)), nil
+}
+
 func (c Value) SecureValues(decrypter Decrypter) ([]string, error) {
 	d := NewTrackingDecrypter(decrypter)
 	if _, err := c.Value(d); err != nil {
@@ -242,32 +286,40 @@ func isSecureValue(v interface{}) (bool, string) {
 
 // decryptObject returns a new object with all secure values in the object converted to decrypted strings.
 func decryptObject(v interface{}, decrypter Decrypter) (interface{}, error) {
-	decryptIt := func(val interface{}) (interface{}, error) {
+	return mapSecureValues(v, func(ciphertext string) (interface{}, error) {
+		return decrypter.DecryptValue(ciphertext)
+	})
+}
+
+// mapSecureValues returns a new object with all secure values in the object replaced by the result of calling f with
+// their ciphertext.
+func mapSecureValues(v interface{}, f func(ciphertext string) (interface{}, error)) (interface{}, error) {
+	mapIt := func(val interface{}) (interface{}, error) {
 		if isSecure, secureVal := isSecureValue(val); isSecure {
-			return decrypter.DecryptValue(secureVal)
+			return f(secureVal)
 		}
-		return decryptObject(val, decrypter)
+		return mapSecureValues(val, f)
 	}
 
 	switch t := v.(type) {
 	case map[string]interface{}:
 		m := make(map[string]interface{})
 		for key, val := range t {
-			decrypted, err := decryptIt(val)
+			mapped, err := mapIt(val)
 			if err != nil {
 				return nil, err
 			}
-			m[key] = decrypted
+			m[key] = mapped
 		}
 		return m, nil
 	case []interface{}:
 		a := make([]interface{}, len(t))
 		for i, val := range t {
-			decrypted, err := decryptIt(val)
+			mapped, err := mapIt(val)
 			if err != nil {
 				return nil, err
 			}
-			a[i] = decrypted
+			a[i] = mapped
 		}
 		return a, nil
 	}
@@ -264,3 +264,35 @@ func roundtripValue(v Value, marshal func(v interface{}) ([]byte, error),
 	err = unmarshal(b, &newV)
 	return newV, err
 }
+
+func TestReencrypt(t *testing.T) {
+	from := NewSymmetricCrypter(make([]byte, SymmetricCrypterKeyBytes))
+	to := NewSymmetricCrypterFromPassphrase("password", []byte("salt"))
+
+	ciphertext, err := from.EncryptValue("secret")
+	assert.NoError(t, err)
+
+	plain := NewValue("value")
+	v, err := plain.Reencrypt(from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, plain, v)
+
+	v, err = NewSecureValue(ciphertext).Reencrypt(from, to)
+	assert.NoError(t, err)
+	assert.True(t, v.Secure())
+	plaintext, err := v.Value(to)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+
+	obj := fmt.Sprintf(`{"inner":{"secure":%q},"list":["a",{"secure":%q}]}`, ciphertext, ciphertext)
+	v, err = NewSecureObjectValue(obj).Reencrypt(from, to)
+	assert.NoError(t, err)
+	assert.True(t, v.Secure())
+	assert.True(t, v.Object())
+	plaintext, err = v.Value(to)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"inner":"secret","list":["a","secret"]}`, plaintext)
+
+	_, err = NewSecureValue(ciphertext).Reencrypt(to, from)
+	assert.Error(t, err)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
 	"github.com/pulumi/pulumi/pkg/workspace"
 	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
@@ -48,7 +49,7 @@ func (p *languageRuntime) GetRequiredPlugins(info plugin.ProgInfo) ([]workspace.
 
 func (p *languageRuntime) Run(info plugin.RunInfo) (string, bool, error) {
 	// Connect to the resource monitor and create an appropriate client.
-	conn, err := grpc.Dial(info.MonitorAddress, grpc.WithInsecure())
+	conn, err := grpc.Dial(info.MonitorAddress, grpc.WithInsecure(), rpcutil.WithLocalDialer())
 	if err != nil {
 		return "", false, errors.Wrapf(err, "could not connect to resource monitor")
 	}
@@ -58,9 +59,11 @@ func (p *languageRuntime) Run(info plugin.RunInfo) (string, bool, error) {
 	resmon := pulumirpc.NewResourceMonitorClient(conn)
 
 	// Run the program.
+	monitor := &ResourceMonitor{resmon: resmon}
+	defer func() { contract.IgnoreError(monitor.close()) }()
 	done := make(chan error)
 	go func() {
-		done <- p.program(info, &ResourceMonitor{resmon: resmon})
+		done <- p.program(info, monitor)
 	}()
 	if progerr := <-done; progerr != nil {
 		return progerr.Error(), false, nil
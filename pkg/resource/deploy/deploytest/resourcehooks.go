@@ -0,0 +1,103 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploytest
+
+import (
+	"context"
+	"sync"
+
+	pbstruct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+type ResourceHookFunc func(urn resource.URN, id resource.ID, newInputs, oldInputs, newOutputs,
+	oldOutputs resource.PropertyMap) error
+
+type resourceHookServer struct {
+	addr   string
+	cancel chan bool
+	done   chan error
+
+	lock  sync.Mutex
+	hooks map[string]ResourceHookFunc
+}
+
+func newResourceHookServer() (*resourceHookServer, error) {
+	server := &resourceHookServer{
+		cancel: make(chan bool),
+		hooks:  make(map[string]ResourceHookFunc),
+	}
+
+	addr, done, err := rpcutil.ServeLocal(server.cancel, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceHooksServer(srv, server)
+			return nil
+		},
+	}, nil, false /*pipes*/)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not start resource hook server")
+	}
+
+	server.addr = addr
+	server.done = done
+	return server, nil
+}
+
+func (s *resourceHookServer) add(name string, hook ResourceHookFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.hooks[name] = hook
+}
+
+func (s *resourceHookServer) Close() error {
+	close(s.cancel)
+	return <-s.done
+}
+
+func (s *resourceHookServer) RunHook(ctx context.Context,
+	req *pulumirpc.RunResourceHookRequest) (*pulumirpc.RunResourceHookResponse, error) {
+
+	s.lock.Lock()
+	hook, has := s.hooks[req.GetHook()]
+	s.lock.Unlock()
+	if !has {
+		return nil, errors.Errorf("unknown resource hook %q", req.GetHook())
+	}
+
+	// unmarshal properties
+	var props [4]resource.PropertyMap
+	for i, obj := range []*pbstruct.Struct{req.NewInputs, req.OldInputs, req.NewOutputs, req.OldOutputs} {
+		if obj == nil {
+			continue
+		}
+		m, err := plugin.UnmarshalProperties(obj, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+		if err != nil {
+			return nil, err
+		}
+		props[i] = m
+	}
+
+	if err := hook(resource.URN(req.GetUrn()), resource.ID(req.GetId()), props[0], props[1], props[2],
+		props[3]); err != nil {
+		return &pulumirpc.RunResourceHookResponse{Error: err.Error()}, nil
+	}
+	return &pulumirpc.RunResourceHookResponse{}, nil
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/tokens"
@@ -26,6 +28,7 @@ import (
 
 type ResourceMonitor struct {
 	resmon pulumirpc.ResourceMonitorClient
+	hooks  *resourceHookServer
 }
 
 type ResourceOptions struct {
@@ -42,6 +45,7 @@ type ResourceOptions struct {
 	ImportID              resource.ID
 	CustomTimeouts        *resource.CustomTimeouts
 	SupportsPartialValues *bool
+	Hooks                 *resource.Hooks
 }
 
 func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom bool,
@@ -99,6 +103,17 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 	if opts.SupportsPartialValues != nil {
 		supportsPartialValues = *opts.SupportsPartialValues
 	}
+	var hooks *pulumirpc.RegisterResourceRequest_ResourceHooksBinding
+	if opts.Hooks != nil {
+		hooks = &pulumirpc.RegisterResourceRequest_ResourceHooksBinding{
+			BeforeCreate: opts.Hooks.BeforeCreate,
+			AfterCreate:  opts.Hooks.AfterCreate,
+			BeforeUpdate: opts.Hooks.BeforeUpdate,
+			AfterUpdate:  opts.Hooks.AfterUpdate,
+			BeforeDelete: opts.Hooks.BeforeDelete,
+			AfterDelete:  opts.Hooks.AfterDelete,
+		}
+	}
 	requestInput := &pulumirpc.RegisterResourceRequest{
 		Type:                       string(t),
 		Name:                       name,
@@ -117,6 +132,7 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		ImportId:                   string(opts.ImportID),
 		CustomTimeouts:             &timeouts,
 		SupportsPartialValues:      supportsPartialValues,
+		Hooks:                      hooks,
 	}
 
 	// submit request
@@ -199,6 +215,36 @@ func (rm *ResourceMonitor) Invoke(tok tokens.ModuleMember, inputs resource.Prope
 	return outs, nil, nil
 }
 
+func (rm *ResourceMonitor) RegisterResourceHook(name string, hook ResourceHookFunc) error {
+	// start the hook server the first time that a hook is registered
+	if rm.hooks == nil {
+		hooks, err := newResourceHookServer()
+		if err != nil {
+			return err
+		}
+		rm.hooks = hooks
+	}
+	rm.hooks.add(name, hook)
+
+	_, err := rm.resmon.RegisterResourceHook(context.Background(), &pulumirpc.RegisterResourceHookRequest{
+		Name:    name,
+		Address: rm.hooks.addr,
+	})
+	return err
+}
+
+func (rm *ResourceMonitor) SignalAndWaitForShutdown() error {
+	_, err := rm.resmon.SignalAndWaitForShutdown(context.Background(), &pbempty.Empty{})
+	return err
+}
+
+func (rm *ResourceMonitor) close() error {
+	if rm.hooks == nil {
+		return nil
+	}
+	return rm.hooks.Close()
+}
+
 func prepareTestTimeout(timeout float64) string {
 	mins := int(timeout) / 60
 
@@ -0,0 +1,85 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+// ResourceHookArgs are the arguments passed to a resource hook. Properties that do not apply to the operation being
+// hooked (e.g. the old inputs of a resource that is being created) are nil.
+type ResourceHookArgs struct {
+	URN        resource.URN
+	ID         resource.ID
+	NewInputs  resource.PropertyMap
+	OldInputs  resource.PropertyMap
+	NewOutputs resource.PropertyMap
+	OldOutputs resource.PropertyMap
+}
+
+// ResourceHooks runs the hooks that a program has registered with the engine.
+type ResourceHooks interface {
+	// RunHook runs the named hook with the given arguments. It returns false if no hook by that name is registered.
+	RunHook(name string, args ResourceHookArgs) (bool, error)
+}
+
+// ResourceHookSource is implemented by source iterators whose programs may register resource hooks.
+type ResourceHookSource interface {
+	// ResourceHooks returns the hooks registered by the source's program.
+	ResourceHooks() ResourceHooks
+}
+
+// noResourceHooks stands in for the hooks of a plan that has no program to serve them, but that has been told to
+// perform the operations that they guard anyway.  Every hook that it is asked to run is skipped.
+type noResourceHooks struct{}
+
+func (noResourceHooks) RunHook(name string, args ResourceHookArgs) (bool, error) {
+	return false, nil
+}
+
+// checkDeleteHooks returns an error if the given resource has delete hooks but no program is running to serve them,
+// as is the case for a destroy, which does not run the program.  Deleting the resource without its hooks could skip
+// work that it depends on, such as draining its connections, so the plan must be told to skip them explicitly.
+func checkDeleteHooks(plan *Plan, old *resource.State) error {
+	if plan.hooks != nil || (len(old.Hooks.BeforeDelete) == 0 && len(old.Hooks.AfterDelete) == 0) {
+		return nil
+	}
+	return errors.Errorf("refusing to delete '%s' without running its delete hooks, because no program is running "+
+		"to serve them; pass --skip-resource-hooks to delete it anyway", old.URN)
+}
+
+// runResourceHooks runs each of the named hooks in order, stopping at the first one that fails. Hooks that are no
+// longer registered, e.g. because the program that registered them has exited, are skipped with a warning.
+func runResourceHooks(plan *Plan, names []string, kind string, args ResourceHookArgs) error {
+	for _, name := range names {
+		ran := false
+		if plan.hooks != nil {
+			var err error
+			if ran, err = plan.hooks.RunHook(name, args); err != nil {
+				return errors.Wrapf(err, "%s hook %q failed", kind, name)
+			}
+		}
+		if !ran {
+			msg := fmt.Sprintf("skipping %s hook %q: the hook is not registered by the program", kind, name)
+			plan.Diag().Warningf(diag.RawMessage(args.URN, msg))
+		}
+	}
+	return nil
+}
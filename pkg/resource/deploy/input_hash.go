@@ -0,0 +1,101 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// inputHash returns a hash of the inputs that the program supplied for a custom resource, along with the identity,
+// inputs and plugin version of the resource's provider.  If the hash matches the one recorded for the resource in the
+// last update, the provider would see exactly the same request as last time, so its checked inputs and diff may be
+// reused.  The plugin version is included because a provider that does not pin a version resolves to the newest
+// installed plugin, whose behavior may differ even though the provider's inputs have not changed.
+//
+// An empty hash is returned if the hash would not be trustworthy: if any of the inputs are unknown, if any of them
+// are secret (so that the hash never leaks information about a secret), if any of them are assets or archives (whose
+// contents may change without their inputs changing), or if the provider or its version cannot be identified.
+//
+// Refreshes do not record a hash, so the first update after a refresh always checks and diffs the resources whose
+// state the refresh read, and reconciles any drift that it found.
+func (sg *stepGenerator) inputHash(goal *resource.Goal, inputs resource.PropertyMap, prov plugin.Provider) string {
+	if !goal.Custom || providers.IsProviderType(goal.Type) || goal.Provider == "" || prov == nil {
+		return ""
+	}
+	if inputs.ContainsUnknowns() || inputs.ContainsSecrets() || containsAssets(resource.NewObjectProperty(inputs)) {
+		return ""
+	}
+
+	ref, err := providers.ParseReference(goal.Provider)
+	if err != nil {
+		return ""
+	}
+	provider, has := sg.providers[ref.URN()]
+	if !has || provider.Inputs.ContainsUnknowns() || provider.Inputs.ContainsSecrets() {
+		return ""
+	}
+
+	info, err := prov.GetPluginInfo()
+	if err != nil || info.Version == nil {
+		return ""
+	}
+
+	// encoding/json sorts map keys, so the encoding of the inputs is stable.
+	bytes, err := json.Marshal(struct {
+		Type            string                 `json:"type"`
+		Inputs          map[string]interface{} `json:"inputs"`
+		Provider        string                 `json:"provider"`
+		ProviderInputs  map[string]interface{} `json:"providerInputs"`
+		ProviderVersion string                 `json:"providerVersion"`
+	}{
+		Type:            string(goal.Type),
+		Inputs:          inputs.Mappable(),
+		Provider:        goal.Provider,
+		ProviderInputs:  provider.Inputs.Mappable(),
+		ProviderVersion: info.Version.String(),
+	})
+	contract.AssertNoError(err)
+
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:])
+}
+
+// containsAssets returns true if the given value contains any assets or archives.
+func containsAssets(v resource.PropertyValue) bool {
+	switch {
+	case v.IsAsset(), v.IsArchive():
+		return true
+	case v.IsArray():
+		for _, e := range v.ArrayValue() {
+			if containsAssets(e) {
+				return true
+			}
+		}
+	case v.IsObject():
+		for _, e := range v.ObjectValue() {
+			if containsAssets(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
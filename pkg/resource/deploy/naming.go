@@ -0,0 +1,131 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	cryptorand "crypto/rand"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// defaultNamingCharset is the set of characters from which random suffixes are chosen by default.
+const defaultNamingCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// NamingPolicy names the resources that a program does not give an explicit name.  Each name consists of a prefix,
+// the resource's logical name, and, optionally, a hyphen and a random suffix.
+type NamingPolicy struct {
+	// Prefix is prepended to each name.
+	Prefix string
+	// SuffixLength is the number of random characters appended to each name.
+	SuffixLength int
+	// Charset is the set of characters from which the random suffix is chosen.
+	Charset string
+	// Property is the input property that holds the name of a resource.
+	Property resource.PropertyKey
+	// Types are the patterns of the resource types to which the policy applies.  Because the engine cannot tell which
+	// resources take a name as an input, the types must be listed explicitly.
+	Types []string
+}
+
+// NewNamingPolicy creates a naming policy for the given stack of a project from the policy in its settings, which
+// may be nil, in which case nil is returned.
+func NewNamingPolicy(policy *workspace.NamingPolicy, project tokens.PackageName,
+	stack tokens.QName) (*NamingPolicy, error) {
+
+	if policy == nil {
+		return nil, nil
+	}
+	if policy.SuffixLength < 0 {
+		return nil, errors.New("the naming policy's suffix length must not be negative")
+	}
+	if len(policy.Types) == 0 {
+		return nil, errors.New("the naming policy must list the resource types to which it applies")
+	}
+
+	prefix, err := workspace.ExpandProjectVariables(policy.Prefix, project, stack)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid prefix")
+	}
+
+	result := &NamingPolicy{
+		Prefix:       prefix,
+		SuffixLength: policy.SuffixLength,
+		Charset:      policy.Charset,
+		Property:     resource.PropertyKey(policy.Property),
+		Types:        policy.Types,
+	}
+	if result.Charset == "" {
+		result.Charset = defaultNamingCharset
+	}
+	if result.Property == "" {
+		result.Property = "name"
+	}
+	return result, nil
+}
+
+// appliesTo returns true if the policy names resources of the given type.
+func (p *NamingPolicy) appliesTo(t tokens.Type) bool {
+	for _, pattern := range p.Types {
+		if matchTypePattern(pattern, string(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns the inputs of a custom resource with the given type and logical name, to which a name has been
+// added if the inputs do not already name the resource.  A name that the resource already has, as recorded in its old
+// inputs, is kept even if it does not comply with the policy, so that adopting or changing the policy does not
+// replace existing resources; otherwise, a new name is generated.
+func (p *NamingPolicy) Apply(t tokens.Type, name tokens.QName, inputs,
+	oldInputs resource.PropertyMap) resource.PropertyMap {
+
+	if !p.appliesTo(t) {
+		return inputs
+	}
+	if v, has := inputs[p.Property]; has && !v.IsNull() {
+		return inputs
+	}
+
+	value, has := oldInputs[p.Property]
+	if !has || !value.IsString() || value.StringValue() == "" {
+		value = resource.NewStringProperty(p.newName(name))
+	}
+
+	named := inputs.Copy()
+	named[p.Property] = value
+	return named
+}
+
+// newName generates a new name for a resource with the given logical name.
+func (p *NamingPolicy) newName(name tokens.QName) string {
+	charset := []rune(p.Charset)
+	suffix := make([]rune, p.SuffixLength)
+	for i := range suffix {
+		n, err := cryptorand.Int(cryptorand.Reader, big.NewInt(int64(len(charset))))
+		contract.AssertNoError(err)
+		suffix[i] = charset[n.Int64()]
+	}
+	if len(suffix) == 0 {
+		return p.Prefix + string(name)
+	}
+	return p.Prefix + string(name) + "-" + string(suffix)
+}
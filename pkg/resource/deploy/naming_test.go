@@ -0,0 +1,82 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func TestNewNamingPolicy(t *testing.T) {
+	p, err := NewNamingPolicy(nil, "proj", "dev")
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+
+	p, err = NewNamingPolicy(&workspace.NamingPolicy{
+		Prefix:       "${project}-${stack}-",
+		SuffixLength: 4,
+		Types:        []string{"aws:s3/*"},
+	}, "proj", "dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "proj-dev-", p.Prefix)
+	assert.Equal(t, defaultNamingCharset, p.Charset)
+	assert.Equal(t, resource.PropertyKey("name"), p.Property)
+
+	_, err = NewNamingPolicy(&workspace.NamingPolicy{Prefix: "${org}-", Types: []string{"aws:s3/*"}}, "proj", "dev")
+	assert.EqualError(t, err, "invalid prefix: unknown variable '${org}'; only ${project} and ${stack} may be used")
+
+	_, err = NewNamingPolicy(&workspace.NamingPolicy{SuffixLength: -1, Types: []string{"aws:s3/*"}}, "proj", "dev")
+	assert.Error(t, err)
+
+	// A policy that does not list the types it applies to would name resources that take no name.
+	_, err = NewNamingPolicy(&workspace.NamingPolicy{Prefix: "${stack}-"}, "proj", "dev")
+	assert.EqualError(t, err, "the naming policy must list the resource types to which it applies")
+}
+
+func TestNamingPolicyApply(t *testing.T) {
+	p, err := NewNamingPolicy(&workspace.NamingPolicy{
+		Prefix:       "${stack}-",
+		SuffixLength: 6,
+		Charset:      "ab",
+		Types:        []string{"aws:s3/*"},
+	}, "proj", "dev")
+	assert.NoError(t, err)
+
+	// Resources of other types are left alone.
+	inputs := resource.PropertyMap{}
+	assert.Equal(t, inputs, p.Apply("aws:ec2/instance:Instance", "web", inputs, nil))
+
+	// Explicit names are kept.
+	inputs = resource.NewPropertyMapFromMap(map[string]interface{}{"name": "explicit"})
+	assert.Equal(t, inputs, p.Apply("aws:s3/bucket:Bucket", "site", inputs, nil))
+
+	// Other resources are given a new name, without modifying their inputs.
+	inputs = resource.PropertyMap{}
+	named := p.Apply("aws:s3/bucket:Bucket", "site", inputs, nil)
+	assert.Empty(t, inputs)
+	assert.Regexp(t, regexp.MustCompile("^dev-site-[ab]{6}$"), named["name"].StringValue())
+
+	// Names that were generated previously are kept.
+	assert.Equal(t, named, p.Apply("aws:s3/bucket:Bucket", "site", inputs, named))
+
+	// So are existing names that do not comply with the policy, so that the resources are not replaced.
+	old := resource.NewPropertyMapFromMap(map[string]interface{}{"name": "site-1234567"})
+	assert.Equal(t, old, p.Apply("aws:s3/bucket:Bucket", "site", inputs, old))
+}
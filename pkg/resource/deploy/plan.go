@@ -49,6 +49,7 @@ type BackendClient interface {
 type Options struct {
 	Events            Events         // an optional events callback interface.
 	Parallel          int            // the degree of parallelism for resource operations (<=1 for serial).
+	ProviderParallel  map[string]int // the maximum number of concurrent resource operations for each provider package.
 	Refresh           bool           // whether or not to refresh before executing the plan.
 	RefreshOnly       bool           // whether or not to exit after refreshing.
 	RefreshTargets    []resource.URN // The specific resources to refresh during a refresh op.
@@ -58,6 +59,10 @@ type Options struct {
 	TargetDependents  bool           // true if we're allowing things to proceed, even with unspecified targets
 	TrustDependencies bool           // whether or not to trust the resource dependency graph.
 	UseLegacyDiff     bool           // whether or not to use legacy diffing behavior.
+	Naming            *NamingPolicy  // an optional policy for naming resources that are not named explicitly.
+	SavePlan          *SavedPlan     // an optional plan in which to record the steps that are performed.
+	ApplyPlan         *SavedPlan     // an optional plan that steps must match; any step that diverges fails.
+	SkipResourceHooks bool           // whether to delete resources whose hooks cannot run, e.g. during a destroy.
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -117,6 +122,7 @@ type Plan struct {
 	preview              bool                             // true if this plan is to be previewed rather than applied.
 	depGraph             *graph.DependencyGraph           // the dependency graph of the old snapshot
 	providers            *providers.Registry              // the provider registry for this plan.
+	hooks                ResourceHooks                    // the hooks registered by the plan's program, if any.
 }
 
 // addDefaultProviders adds any necessary default provider definitions and references to the given snapshot. Version
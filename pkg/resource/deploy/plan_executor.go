@@ -36,6 +36,8 @@ type planExecutor struct {
 
 	stepGen  *stepGenerator // step generator owned by this plan
 	stepExec *stepExecutor  // step executor owned by this plan
+
+	steps *SavedPlan // the steps that have been performed, to compare with the plan being applied, if any
 }
 
 // A set is returned of all the target URNs to facilitate later callers.  The set can be 'nil'
@@ -96,6 +98,27 @@ func (pe *planExecutor) checkTargets(targets []resource.URN, op StepOp) result.R
 	return nil
 }
 
+// planSteps records the given steps in the plan being saved, if any, and checks them against the plan being applied,
+// if any.  If a step diverges from the plan being applied, the error is reported and none of the steps are performed.
+func (pe *planExecutor) planSteps(steps []Step) result.Result {
+	opts := pe.stepGen.opts
+	for _, step := range steps {
+		if opts.ApplyPlan != nil {
+			if err := opts.ApplyPlan.check(step); err != nil {
+				pe.reportError(step.URN(), err)
+				return result.Bail()
+			}
+		}
+	}
+	for _, step := range steps {
+		pe.steps.record(step)
+		if opts.SavePlan != nil {
+			opts.SavePlan.record(step)
+		}
+	}
+	return nil
+}
+
 // reportExecResult issues an appropriate diagnostic depending on went wrong.
 func (pe *planExecutor) reportExecResult(message string, preview bool) {
 	kind := "update"
@@ -114,6 +137,18 @@ func (pe *planExecutor) reportError(urn resource.URN, err error) {
 // Execute executes a plan to completion, using the given cancellation context and running a preview
 // or update.
 func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview bool) result.Result {
+	// Tie a plan being saved to this plan's stack, and refuse to apply a plan that was saved for another stack.
+	project, stack := pe.plan.source.Project(), pe.plan.target.Name
+	if opts.SavePlan != nil {
+		opts.SavePlan.setTarget(project, stack)
+	}
+	if opts.ApplyPlan != nil {
+		if err := opts.ApplyPlan.checkTarget(project, stack); err != nil {
+			pe.reportError("", err)
+			return result.Bail()
+		}
+	}
+
 	// Set up a goroutine that will signal cancellation to the plan's plugins if the caller context is cancelled. We do
 	// not hang this off of the context we create below because we do not want the failure of a single step to cause
 	// other steps to fail.
@@ -166,8 +201,16 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 		return res
 	}
 
+	// If the source's program can register resource hooks, make them available to the plan's steps.
+	if hookSource, ok := src.(ResourceHookSource); ok {
+		pe.plan.hooks = hookSource.ResourceHooks()
+	} else if opts.SkipResourceHooks {
+		pe.plan.hooks = noResourceHooks{}
+	}
+
 	// Set up a step generator for this plan.
 	pe.stepGen = newStepGenerator(pe.plan, opts, updateTargetsOpt, replaceTargetsOpt)
+	pe.steps = NewSavedPlan()
 
 	// Retire any pending deletes that are currently present in this plan.
 	if res := pe.retirePendingDeletes(callerCtx, opts, preview); res != nil {
@@ -211,6 +254,7 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 	//     should bail.
 	//  3. The stepExecCancel cancel context gets canceled. This means some error occurred in the step executor
 	//     and we need to bail. This can also happen if the user hits Ctrl-C.
+	sourceDone := false
 	canceled, res := func() (bool, result.Result) {
 		logging.V(4).Infof("planExecutor.Execute(...): waiting for incoming events")
 		for {
@@ -229,6 +273,7 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 				}
 
 				if event.Event == nil {
+					sourceDone = true
 					return false, pe.performDeletes(ctx, updateTargetsOpt, destroyTargetsOpt)
 				}
 
@@ -253,6 +298,12 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 	pe.stepExec.WaitForCompletion()
 	logging.V(4).Infof("planExecutor.Execute(...): step executor has completed")
 
+	// If the source's program has registered all of its resources, it may be waiting to serve its resource hooks
+	// until the steps that run them have completed, so it can be released now.
+	if sourceDone {
+		contract.IgnoreClose(src)
+	}
+
 	// Now that we've performed all steps in the plan, ensure that the list of targets to update was
 	// valid.  We have to do this *after* performing the steps as the target list may have referred
 	// to a resource that was created in one of hte steps.
@@ -264,6 +315,14 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 		return res
 	}
 
+	// If the plan being applied proposed steps that were not performed, e.g. because values that were unknown during
+	// the preview turned out not to change, report them.
+	if res == nil && opts.ApplyPlan != nil && !pe.stepExec.Errored() {
+		for _, step := range opts.ApplyPlan.missing(pe.steps) {
+			pe.plan.Diag().Warningf(diag.RawMessage("", "the plan's "+step+" was not performed"))
+		}
+	}
+
 	// If the step generator and step executor were both successful, then we send all the resources
 	// observed to be analyzed. Otherwise, this step is skipped.
 	if res == nil && !pe.stepExec.Errored() {
@@ -318,6 +377,10 @@ func (pe *planExecutor) performDeletes(
 		return res
 	}
 
+	if res := pe.planSteps(deleteSteps); res != nil {
+		return res
+	}
+
 	deletes := pe.stepGen.ScheduleDeletes(deleteSteps)
 
 	// ScheduleDeletes gives us a list of lists of steps. Each list of steps can safely be executed
@@ -371,6 +434,9 @@ func (pe *planExecutor) handleSingleEvent(event SourceEvent) result.Result {
 	if res != nil {
 		return res
 	}
+	if res := pe.planSteps(steps); res != nil {
+		return res
+	}
 
 	pe.stepExec.ExecuteSerial(steps)
 	return nil
@@ -0,0 +1,208 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+// SavedPlan records the operations that a preview proposed for each resource, so that a later update can be checked
+// against it.  Resources that the preview left unchanged are not recorded.
+type SavedPlan struct {
+	// Project is the project whose stack the plan was saved for.
+	Project tokens.PackageName `json:"project"`
+	// Stack is the stack that the plan was saved for.  It may only be applied to the same stack.
+	Stack tokens.QName `json:"stack"`
+	// Steps maps each resource that the plan changes to the operations that it performs on the resource.
+	Steps map[resource.URN][]PlannedStep `json:"steps"`
+
+	lock sync.Mutex
+}
+
+// PlannedStep is an operation that a plan performs on a resource, along with the changes that it makes.  An update
+// that applies the plan may make fewer changes, but not more.
+type PlannedStep struct {
+	// Op is the operation that the step performs.
+	Op StepOp `json:"op"`
+	// Diffs are the properties that the step changes, including inputs whose values were unknown during the preview.
+	Diffs []resource.PropertyKey `json:"diffs,omitempty"`
+	// Keys are the properties whose changes cause the resource to be replaced.
+	Keys []resource.PropertyKey `json:"keys,omitempty"`
+}
+
+// NewSavedPlan returns an empty plan, ready to record the steps of a preview.
+func NewSavedPlan() *SavedPlan {
+	return &SavedPlan{Steps: make(map[resource.URN][]PlannedStep)}
+}
+
+// Has returns true if the plan performs the given operation on the given resource.
+func (p *SavedPlan) Has(urn resource.URN, op StepOp) bool {
+	_, has := p.get(urn, op)
+	return has
+}
+
+// get returns the step that the plan performs with the given operation on the given resource, if any.
+func (p *SavedPlan) get(urn resource.URN, op StepOp) (PlannedStep, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, planned := range p.Steps[urn] {
+		if planned.Op == op {
+			return planned, true
+		}
+	}
+	return PlannedStep{}, false
+}
+
+// setTarget records the stack that the plan is being saved for.
+func (p *SavedPlan) setTarget(project tokens.PackageName, stack tokens.QName) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.Project, p.Stack = project, stack
+}
+
+// checkTarget returns an error if the plan was saved for a different stack than the one it is being applied to.
+func (p *SavedPlan) checkTarget(project tokens.PackageName, stack tokens.QName) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.Project != project || p.Stack != stack {
+		return errors.Errorf("the plan was saved for stack %s of project %s, not stack %s of project %s",
+			p.Stack, p.Project, stack, project)
+	}
+	return nil
+}
+
+// record adds a step to the plan, unless it leaves its resource unchanged.
+func (p *SavedPlan) record(step Step) {
+	if step.Op() == OpSame || p.Has(step.URN(), step.Op()) {
+		return
+	}
+
+	diffs, keys := stepChanges(step)
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.Steps == nil {
+		p.Steps = make(map[resource.URN][]PlannedStep)
+	}
+	p.Steps[step.URN()] = append(p.Steps[step.URN()], PlannedStep{Op: step.Op(), Diffs: diffs, Keys: keys})
+}
+
+// check returns an error if the step changes its resource in a way that the plan does not: by performing an
+// operation that the plan does not, or by changing or replacing the resource because of properties that the planned
+// operation does not.  A step that leaves its resource unchanged is always allowed, as a preview may propose an update
+// that turns out to be unnecessary once the values that were unknown during the preview are known.
+func (p *SavedPlan) check(step Step) error {
+	if step.Op() == OpSame {
+		return nil
+	}
+	planned, has := p.get(step.URN(), step.Op())
+	if !has {
+		return errors.Errorf("%s of resource %s is not in the plan; run a new preview to update the plan",
+			step.Op(), step.URN())
+	}
+
+	diffs, keys := stepChanges(step)
+	if extra := missingKeys(planned.Diffs, diffs); len(extra) != 0 {
+		return errors.Errorf("%s of resource %s changes properties that are not in the plan: %v; "+
+			"run a new preview to update the plan", step.Op(), step.URN(), extra)
+	}
+	if extra := missingKeys(planned.Keys, keys); len(extra) != 0 {
+		return errors.Errorf("%s of resource %s is caused by properties that are not in the plan: %v; "+
+			"run a new preview to update the plan", step.Op(), step.URN(), extra)
+	}
+	return nil
+}
+
+// stepChanges returns the properties that the given step changes, sorted, along with the properties that cause it to
+// replace its resource, if any.  The changed properties are those that its provider reported as changed, along with
+// any inputs whose values differ from, or are not known to be the same as, the resource's old inputs.
+func stepChanges(step Step) ([]resource.PropertyKey, []resource.PropertyKey) {
+	changed := make(map[resource.PropertyKey]bool)
+	if d, ok := step.(interface{ Diffs() []resource.PropertyKey }); ok {
+		for _, k := range d.Diffs() {
+			changed[k] = true
+		}
+	}
+	if new := step.New(); new != nil {
+		var olds resource.PropertyMap
+		if old := step.Old(); old != nil {
+			olds = old.Inputs
+		}
+		for k, v := range new.Inputs {
+			if old, has := olds[k]; !has || v.ContainsUnknowns() || !old.DeepEquals(v) {
+				changed[k] = true
+			}
+		}
+		for k := range olds {
+			if _, has := new.Inputs[k]; !has {
+				changed[k] = true
+			}
+		}
+	}
+
+	var keys []resource.PropertyKey
+	if k, ok := step.(interface{ Keys() []resource.PropertyKey }); ok {
+		keys = append(keys, k.Keys()...)
+	}
+	return sortedKeys(changed), keys
+}
+
+// missingKeys returns the keys in actual that are not in planned.
+func missingKeys(planned, actual []resource.PropertyKey) []resource.PropertyKey {
+	has := make(map[resource.PropertyKey]bool)
+	for _, k := range planned {
+		has[k] = true
+	}
+	var result []resource.PropertyKey
+	for _, k := range actual {
+		if !has[k] {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+// sortedKeys returns the keys of the given set in sorted order.
+func sortedKeys(set map[resource.PropertyKey]bool) []resource.PropertyKey {
+	var result []resource.PropertyKey
+	for k := range set {
+		result = append(result, k)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// missing returns the operations in this plan that are not in the actual plan, sorted by resource.
+func (p *SavedPlan) missing(actual *SavedPlan) []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var result []string
+	for urn, steps := range p.Steps {
+		for _, step := range steps {
+			if !actual.Has(urn, step.Op) {
+				result = append(result, string(step.Op)+" of resource "+string(urn))
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -17,10 +17,12 @@ package deploy
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
+	pbstruct "github.com/golang/protobuf/ptypes/struct"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -46,6 +48,9 @@ type EvalRunInfo struct {
 	Program string             `json:"program" yaml:"program"`                   // the path to the program.
 	Args    []string           `json:"args,omitempty" yaml:"args,omitempty"`     // any arguments to pass to the package.
 	Target  *Target            `json:"target,omitempty" yaml:"target,omitempty"` // the target being deployed into.
+
+	// DefaultTimeouts are the operation timeouts for resources that do not specify their own.
+	DefaultTimeouts DefaultTimeouts `json:"defaultTimeouts,omitempty" yaml:"defaultTimeouts,omitempty"`
 }
 
 // NewEvalSource returns a planning source that fetches resources by evaluating a package with a set of args and
@@ -95,19 +100,22 @@ func (src *evalSource) Iterate(
 	regChan := make(chan *registerResourceEvent)
 	regOutChan := make(chan *registerResourceOutputsEvent)
 	regReadChan := make(chan *readResourceEvent)
-	mon, err := newResourceMonitor(src, providers, regChan, regOutChan, regReadChan, tracingSpan)
+	shutdownChan := make(chan bool)
+	mon, err := newResourceMonitor(src, providers, regChan, regOutChan, regReadChan, shutdownChan, tracingSpan)
 	if err != nil {
 		return nil, result.FromError(errors.Wrap(err, "failed to start resource monitor"))
 	}
 
 	// Create a new iterator with appropriate channels, and gear up to go!
 	iter := &evalSourceIterator{
-		mon:         mon,
-		src:         src,
-		regChan:     regChan,
-		regOutChan:  regOutChan,
-		regReadChan: regReadChan,
-		finChan:     make(chan result.Result),
+		mon:          mon,
+		src:          src,
+		hooks:        mon.hooks,
+		regChan:      regChan,
+		regOutChan:   regOutChan,
+		regReadChan:  regReadChan,
+		shutdownChan: shutdownChan,
+		finChan:      make(chan result.Result, 1),
 	}
 
 	// Now invoke Run in a goroutine.  All subsequent resource creation events will come in over the gRPC channel,
@@ -119,20 +127,29 @@ func (src *evalSource) Iterate(
 }
 
 type evalSourceIterator struct {
-	mon         SourceResourceMonitor              // the resource monitor, per iterator.
-	src         *evalSource                        // the owning eval source object.
-	regChan     chan *registerResourceEvent        // the channel that contains resource registrations.
-	regOutChan  chan *registerResourceOutputsEvent // the channel that contains resource completions.
-	regReadChan chan *readResourceEvent            // the channel that contains read resource requests.
-	finChan     chan result.Result                 // the channel that communicates completion.
-	done        bool                               // set to true when the evaluation is done.
+	mon          SourceResourceMonitor              // the resource monitor, per iterator.
+	src          *evalSource                        // the owning eval source object.
+	hooks        *resourceHooks                     // the resource hooks registered by the program.
+	regChan      chan *registerResourceEvent        // the channel that contains resource registrations.
+	regOutChan   chan *registerResourceOutputsEvent // the channel that contains resource completions.
+	regReadChan  chan *readResourceEvent            // the channel that contains read resource requests.
+	shutdownChan chan bool                          // the channel that signals the program is awaiting shutdown.
+	finChan      chan result.Result                 // the channel that communicates completion.
+	done         bool                               // set to true when the evaluation is done.
 }
 
+var _ ResourceHookSource = (*evalSourceIterator)(nil)
+
 func (iter *evalSourceIterator) Close() error {
 	// Cancel the monitor and reclaim any associated resources.
 	return iter.mon.Cancel()
 }
 
+// ResourceHooks returns the resource hooks registered by the program.
+func (iter *evalSourceIterator) ResourceHooks() ResourceHooks {
+	return iter.hooks
+}
+
 func (iter *evalSourceIterator) Next() (SourceEvent, result.Result) {
 	// If we are done, quit.
 	if iter.done {
@@ -156,10 +173,19 @@ func (iter *evalSourceIterator) Next() (SourceEvent, result.Result) {
 		contract.Assert(read != nil)
 		logging.V(5).Infoln("EvalSourceIterator produced a read")
 		return read, nil
+	case <-iter.shutdownChan:
+		// The program has registered all of its resources and is waiting to be shut down so that it can continue to
+		// serve its resource hooks while the engine finishes the plan.  There is nothing more to iterate.
+		iter.done = true
+		logging.V(5).Infof("EvalSourceIterator ended with a shutdown request")
+		return nil, nil
 	case res := <-iter.finChan:
 		// If we are finished, we can safely exit.  The contract with the language provider is that this implies
 		// that the language runtime has exited and so calling Close on the plugin is fine.
 		iter.done = true
+
+		// The program's resource hooks exited along with it, so any hooks that are run from now on are skipped.
+		iter.hooks.close()
 		if res != nil {
 			if res.IsBail() {
 				logging.V(5).Infof("EvalSourceIterator ended with bail.")
@@ -396,6 +422,12 @@ type resmon struct {
 	regChan          chan *registerResourceEvent        // the channel to send resource registrations to.
 	regOutChan       chan *registerResourceOutputsEvent // the channel to send resource output registrations to.
 	regReadChan      chan *readResourceEvent            // the channel to send resource reads to.
+	shutdownChan     chan bool                          // the channel to signal that the program awaits shutdown.
+	shutdownOnce     sync.Once                          // ensures that the shutdown is signaled only once.
+	hooks            *resourceHooks                     // the resource hooks registered by the program.
+	defaultTimeouts  DefaultTimeouts                    // the timeouts for resources that do not specify their own.
+	registrations    map[string]*registration           // the registrations awaiting delivery, by request ID.
+	registrationLock sync.Mutex                         // guards the registrations.
 	addr             string                             // the address the host is listening on.
 	cancel           chan bool                          // a channel that can cancel the server.
 	done             chan error                         // a channel that resolves when the server completes.
@@ -405,7 +437,7 @@ var _ SourceResourceMonitor = (*resmon)(nil)
 
 // newResourceMonitor creates a new resource monitor RPC server.
 func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *registerResourceEvent,
-	regOutChan chan *registerResourceOutputsEvent, regReadChan chan *readResourceEvent,
+	regOutChan chan *registerResourceOutputsEvent, regReadChan chan *readResourceEvent, shutdownChan chan bool,
 	tracingSpan opentracing.Span) (*resmon, error) {
 
 	// Create our cancellation channel.
@@ -428,10 +460,15 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 		regChan:          regChan,
 		regOutChan:       regOutChan,
 		regReadChan:      regReadChan,
+		shutdownChan:     shutdownChan,
+		hooks:            newResourceHooks(),
+		defaultTimeouts:  src.runinfo.DefaultTimeouts,
+		registrations:    make(map[string]*registration),
 		cancel:           cancel,
 	}
 
-	// Fire up a gRPC server and start listening for incomings.
+	// Fire up a gRPC server and start listening for incomings.  This always listens on a TCP port, even if plugins
+	// serve on local sockets, because programs and plugins that predate local sockets must be able to dial it.
 	port, done, err := rpcutil.Serve(0, resmon.cancel, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
 			pulumirpc.RegisterResourceMonitorServer(srv, resmon)
@@ -458,7 +495,9 @@ func (rm *resmon) Address() string {
 // Cancel signals that the engine should be terminated, awaits its termination, and returns any errors that result.
 func (rm *resmon) Cancel() error {
 	close(rm.cancel)
-	return <-rm.done
+	err := <-rm.done
+	rm.hooks.close()
+	return err
 }
 
 // getProviderReference fetches the provider reference for a resource, read, or invoke from the given package with the
@@ -520,7 +559,7 @@ func (rm *resmon) SupportsFeature(ctx context.Context,
 	hasSupport := false
 
 	switch req.Id {
-	case "secrets":
+	case "secrets", "idempotentRegistration":
 		hasSupport = true
 	}
 
@@ -674,10 +713,69 @@ func (rm *resmon) ReadResource(ctx context.Context,
 	}, nil
 }
 
+// registration is a resource registration that the monitor is processing on behalf of a request that its client
+// may retry, along with its response once it completes.
+type registration struct {
+	resp *pulumirpc.RegisterResourceResponse
+	err  error
+	done chan bool // closed once the registration completes.
+}
+
 // RegisterResource is invoked by a language process when a new resource has been allocated.
+//
+// A client that resends a registration after its connection to the monitor is reset tags the original and the retry
+// with the same request ID.  The retry is not registered again; it receives the response to the original request
+// instead.  Registrations without a request ID, including identical duplicates, are always registered, so that the
+// engine reports the duplicate.
 func (rm *resmon) RegisterResource(ctx context.Context,
 	req *pulumirpc.RegisterResourceRequest) (*pulumirpc.RegisterResourceResponse, error) {
 
+	id := rpcutil.RequestID(ctx)
+	if id == "" {
+		return rm.registerResource(ctx, req)
+	}
+
+	rm.registrationLock.Lock()
+	reg, has := rm.registrations[id]
+	if !has {
+		reg = &registration{done: make(chan bool)}
+		rm.registrations[id] = reg
+	}
+	rm.registrationLock.Unlock()
+
+	if has {
+		logging.V(5).Infof("ResourceMonitor.RegisterResource received a retried request, name=%s", req.GetName())
+		select {
+		case <-reg.done:
+		case <-rm.cancel:
+			return nil, rpcerror.New(codes.Unavailable, "resource monitor shut down while waiting on a registration")
+		}
+		if ctx.Err() == nil {
+			rm.forgetRegistration(id)
+		}
+		return reg.resp, reg.err
+	}
+
+	reg.resp, reg.err = rm.registerResource(ctx, req)
+	close(reg.done)
+
+	// Keep the response around for a retry only if the client went away before it could receive it.
+	if ctx.Err() == nil {
+		rm.forgetRegistration(id)
+	}
+	return reg.resp, reg.err
+}
+
+// forgetRegistration discards the registration with the given request ID once its response has been delivered.
+func (rm *resmon) forgetRegistration(id string) {
+	rm.registrationLock.Lock()
+	defer rm.registrationLock.Unlock()
+	delete(rm.registrations, id)
+}
+
+func (rm *resmon) registerResource(ctx context.Context,
+	req *pulumirpc.RegisterResourceRequest) (*pulumirpc.RegisterResourceResponse, error) {
+
 	// Communicate the type, name, and object information to the iterator that is awaiting us.
 	name := tokens.QName(req.GetName())
 	custom := req.GetCustom()
@@ -784,12 +882,30 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 			timeouts.Update = seconds
 		}
 	}
+	if custom {
+		rm.defaultTimeouts.Apply(t, &timeouts)
+	}
 
 	var deleteBeforeReplace *bool
 	if deleteBeforeReplaceValue || req.GetDeleteBeforeReplaceDefined() {
 		deleteBeforeReplace = &deleteBeforeReplaceValue
 	}
 
+	var hooks resource.Hooks
+	if binding := req.GetHooks(); binding != nil {
+		hooks = resource.Hooks{
+			BeforeCreate: binding.GetBeforeCreate(),
+			AfterCreate:  binding.GetAfterCreate(),
+			BeforeUpdate: binding.GetBeforeUpdate(),
+			AfterUpdate:  binding.GetAfterUpdate(),
+			BeforeDelete: binding.GetBeforeDelete(),
+			AfterDelete:  binding.GetAfterDelete(),
+		}
+		if err := rm.hooks.validate(hooks); err != nil {
+			return nil, rpcerror.New(codes.InvalidArgument, err.Error())
+		}
+	}
+
 	logging.V(5).Infof(
 		"ResourceMonitor.RegisterResource received: t=%v, name=%v, custom=%v, #props=%v, parent=%v, protect=%v, "+
 			"provider=%v, deps=%v, deleteBeforeReplace=%v, ignoreChanges=%v, aliases=%v, customTimeouts=%v",
@@ -802,6 +918,7 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 			propertyDependencies, deleteBeforeReplace, ignoreChanges, additionalSecretOutputs, aliases, id, &timeouts),
 		done: make(chan *RegisterResult),
 	}
+	step.goal.Hooks = hooks
 
 	select {
 	case rm.regChan <- step:
@@ -903,6 +1020,171 @@ func (rm *resmon) RegisterResourceOutputs(ctx context.Context,
 	return &pbempty.Empty{}, nil
 }
 
+// RegisterResourceHook is invoked by a language process to make a resource hook that it serves available to the
+// resources that it registers.
+func (rm *resmon) RegisterResourceHook(ctx context.Context,
+	req *pulumirpc.RegisterResourceHookRequest) (*pbempty.Empty, error) {
+
+	name, addr := req.GetName(), req.GetAddress()
+	if name == "" {
+		return nil, rpcerror.New(codes.InvalidArgument, "missing required hook name")
+	}
+	if addr == "" {
+		return nil, rpcerror.New(codes.InvalidArgument, "missing required hook address")
+	}
+	if err := rm.hooks.register(name, addr); err != nil {
+		return nil, err
+	}
+
+	logging.V(5).Infof("ResourceMonitor.RegisterResourceHook registered: name=%v, address=%v", name, addr)
+	return &pbempty.Empty{}, nil
+}
+
+// SignalAndWaitForShutdown is invoked by a language process that has registered all of its resources but must keep
+// running to serve its resource hooks.  It tells the engine that the program is done and blocks until the engine has
+// finished the plan and shuts the monitor down.
+func (rm *resmon) SignalAndWaitForShutdown(ctx context.Context, req *pbempty.Empty) (*pbempty.Empty, error) {
+	signal := false
+	rm.shutdownOnce.Do(func() { signal = true })
+	if signal {
+		select {
+		case rm.shutdownChan <- true:
+		case <-rm.cancel:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	logging.V(5).Infof("ResourceMonitor.SignalAndWaitForShutdown waiting for shutdown")
+	select {
+	case <-rm.cancel:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &pbempty.Empty{}, nil
+}
+
+// resourceHooks tracks the resource hooks registered by a program and runs them on the engine's behalf.
+type resourceHooks struct {
+	lock    sync.Mutex                               // guards the maps below.
+	clients map[string]pulumirpc.ResourceHooksClient // the registered hooks, keyed by name.
+	conns   map[string]*grpc.ClientConn              // the connections to the hook servers, keyed by address.
+}
+
+var _ ResourceHooks = (*resourceHooks)(nil)
+
+func newResourceHooks() *resourceHooks {
+	return &resourceHooks{
+		clients: make(map[string]pulumirpc.ResourceHooksClient),
+		conns:   make(map[string]*grpc.ClientConn),
+	}
+}
+
+// register connects to the hook server at the given address and records the named hook as served by it.
+func (h *resourceHooks) register(name, addr string) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if _, has := h.clients[name]; has {
+		return rpcerror.New(codes.AlreadyExists, fmt.Sprintf("a resource hook named %q is already registered", name))
+	}
+
+	conn, has := h.conns[addr]
+	if !has {
+		var err error
+		conn, err = grpc.Dial(addr, grpc.WithInsecure(), rpcutil.WithLocalDialer(), grpc.WithUnaryInterceptor(
+			rpcutil.OpenTracingClientInterceptor(),
+		))
+		if err != nil {
+			return errors.Wrapf(err, "could not connect to resource hook %q", name)
+		}
+		h.conns[addr] = conn
+	}
+	h.clients[name] = pulumirpc.NewResourceHooksClient(conn)
+	return nil
+}
+
+// validate returns an error if any of the given hooks has not been registered.
+func (h *resourceHooks) validate(hooks resource.Hooks) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for _, names := range [][]string{
+		hooks.BeforeCreate, hooks.AfterCreate,
+		hooks.BeforeUpdate, hooks.AfterUpdate,
+		hooks.BeforeDelete, hooks.AfterDelete,
+	} {
+		for _, name := range names {
+			if _, has := h.clients[name]; !has {
+				return errors.Errorf("unknown resource hook %q: hooks must be registered before they are used", name)
+			}
+		}
+	}
+	return nil
+}
+
+// RunHook runs the named hook, returning false if it is not registered.
+func (h *resourceHooks) RunHook(name string, args ResourceHookArgs) (bool, error) {
+	h.lock.Lock()
+	client, has := h.clients[name]
+	h.lock.Unlock()
+	if !has {
+		return false, nil
+	}
+
+	label := fmt.Sprintf("ResourceHooks.RunHook(%s, %s)", name, args.URN)
+	marshal := func(props resource.PropertyMap) (*pbstruct.Struct, error) {
+		if props == nil {
+			return nil, nil
+		}
+		return plugin.MarshalProperties(props, plugin.MarshalOptions{
+			Label:        label,
+			KeepUnknowns: true,
+			KeepSecrets:  true,
+		})
+	}
+	req := &pulumirpc.RunResourceHookRequest{
+		Hook: name,
+		Urn:  string(args.URN),
+		Id:   string(args.ID),
+	}
+	var err error
+	if req.NewInputs, err = marshal(args.NewInputs); err != nil {
+		return true, err
+	}
+	if req.OldInputs, err = marshal(args.OldInputs); err != nil {
+		return true, err
+	}
+	if req.NewOutputs, err = marshal(args.NewOutputs); err != nil {
+		return true, err
+	}
+	if req.OldOutputs, err = marshal(args.OldOutputs); err != nil {
+		return true, err
+	}
+
+	logging.V(5).Infof("%s executing", label)
+	resp, err := client.RunHook(context.TODO(), req)
+	if err != nil {
+		return true, rpcerror.Convert(err)
+	}
+	if msg := resp.GetError(); msg != "" {
+		return true, errors.New(msg)
+	}
+	return true, nil
+}
+
+// close forgets all registered hooks and closes the connections to their servers.
+func (h *resourceHooks) close() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for _, conn := range h.conns {
+		contract.IgnoreClose(conn)
+	}
+	h.clients = make(map[string]pulumirpc.ResourceHooksClient)
+	h.conns = make(map[string]*grpc.ClientConn)
+}
+
 type registerResourceEvent struct {
 	goal *resource.Goal       // the resource goal state produced by the iterator.
 	done chan *RegisterResult // the channel to communicate with after the resource state is available.
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy/deploytest"
@@ -29,7 +30,9 @@ import (
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
 	"github.com/pulumi/pulumi/pkg/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
 
 type testRegEvent struct {
@@ -680,3 +683,56 @@ func TestReadInvokeDefaultProviders(t *testing.T) {
 // 	assert.True(t, registered181)
 // 	assert.True(t, registered182)
 // }
+
+func TestRegisterResourceRetried(t *testing.T) {
+	regChan := make(chan *registerResourceEvent)
+	rm := &resmon{regChan: regChan, registrations: make(map[string]*registration), cancel: make(chan bool)}
+	defer close(rm.cancel)
+
+	// Complete each registration as the engine would, recording the names of the resources that are registered.
+	var names []string
+	go func() {
+		for {
+			select {
+			case event := <-regChan:
+				goal := event.Goal()
+				names = append(names, string(goal.Name))
+				urn := resource.NewURN("test", "test", "", goal.Type, goal.Name)
+				event.Done(&RegisterResult{
+					State: resource.NewState(goal.Type, urn, goal.Custom, false, "", goal.Properties,
+						resource.PropertyMap{}, goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider,
+						goal.PropertyDependencies, false, nil, nil, nil),
+				})
+			case <-rm.cancel:
+				return
+			}
+		}
+	}()
+
+	withRequestID := func(id string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(rpcutil.RequestIDMetadataKey, id))
+	}
+	register := func(ctx context.Context, name string) *pulumirpc.RegisterResourceResponse {
+		resp, err := rm.RegisterResource(ctx, &pulumirpc.RegisterResourceRequest{Type: "component", Name: name})
+		assert.NoError(t, err)
+		return resp
+	}
+
+	// The client goes away before it receives the response to a registration, so the response is kept for a retry,
+	// which receives it without registering the resource again.
+	gone, cancel := context.WithCancel(withRequestID("1"))
+	cancel()
+	original := register(gone, "a")
+	assert.Len(t, rm.registrations, 1)
+	assert.Equal(t, original, register(withRequestID("1"), "a"))
+	assert.Equal(t, []string{"a"}, names)
+	assert.Empty(t, rm.registrations)
+
+	// Responses that are delivered are not kept.
+	register(withRequestID("2"), "b")
+	assert.Empty(t, rm.registrations)
+
+	// A duplicate registration that is not a retry is registered again, so that the engine reports the duplicate.
+	register(context.Background(), "b")
+	assert.Equal(t, []string{"a", "b", "b"}, names)
+}
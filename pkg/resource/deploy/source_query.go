@@ -248,7 +248,8 @@ func newQueryResourceMonitor(
 		reg:              reg,
 	}
 
-	// Fire up a gRPC server and start listening for incomings.
+	// Fire up a gRPC server and start listening for incomings.  This always listens on a TCP port, even if plugins
+	// serve on local sockets, because programs and plugins that predate local sockets must be able to dial it.
 	port, done, err := rpcutil.Serve(0, queryResmon.cancel, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
 			pulumirpc.RegisterResourceMonitorServer(srv, queryResmon)
@@ -412,6 +413,18 @@ func (rm *queryResmon) RegisterResourceOutputs(ctx context.Context,
 	return nil, fmt.Errorf("Query mode does not support registering resource operations")
 }
 
+// RegisterResourceHook is invoked by a language process to register a resource hook.
+func (rm *queryResmon) RegisterResourceHook(ctx context.Context,
+	req *pulumirpc.RegisterResourceHookRequest) (*pbempty.Empty, error) {
+
+	return nil, fmt.Errorf("Query mode does not support resource hooks")
+}
+
+// SignalAndWaitForShutdown is invoked by a language process that must keep serving resource hooks.
+func (rm *queryResmon) SignalAndWaitForShutdown(ctx context.Context, req *pbempty.Empty) (*pbempty.Empty, error) {
+	return nil, fmt.Errorf("Query mode does not support resource hooks")
+}
+
 // SupportsFeature the query resmon is able to have secrets passed to it, which may be arguments to invoke calls.
 func (rm *queryResmon) SupportsFeature(ctx context.Context,
 	req *pulumirpc.SupportsFeatureRequest) (*pulumirpc.SupportsFeatureResponse, error) {
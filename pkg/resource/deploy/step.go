@@ -211,6 +211,13 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	var resourceError error
 	resourceStatus := resource.StatusOK
 	if !preview {
+		if err := runResourceHooks(s.plan, s.new.Hooks.BeforeCreate, "before-create", ResourceHookArgs{
+			URN:       s.URN(),
+			NewInputs: s.new.Inputs,
+		}); err != nil {
+			return resource.StatusOK, nil, err
+		}
+
 		if s.new.Custom {
 			// Invoke the Create RPC function for this provider:
 			prov, err := getProvider(s)
@@ -238,6 +245,19 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			s.new.ID = id
 			s.new.Outputs = outs
 		}
+
+		// The resource exists now, so a failing hook leaves it partially created rather than failing the create.
+		if resourceError == nil {
+			if err := runResourceHooks(s.plan, s.new.Hooks.AfterCreate, "after-create", ResourceHookArgs{
+				URN:        s.URN(),
+				ID:         s.new.ID,
+				NewInputs:  s.new.Inputs,
+				NewOutputs: s.new.Outputs,
+			}); err != nil {
+				resourceError = err
+				resourceStatus = resource.StatusPartialFailure
+			}
+		}
 	} else {
 		s.new.Outputs = s.new.Inputs
 	}
@@ -332,7 +352,22 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	}
 
 	// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle.
+	if !s.old.External {
+		// Check that the resource's delete hooks can run even when previewing, so that a preview fails up front.
+		if err := checkDeleteHooks(s.plan, s.old); err != nil {
+			return resource.StatusOK, nil, err
+		}
+	}
 	if !preview && !s.old.External {
+		if err := runResourceHooks(s.plan, s.old.Hooks.BeforeDelete, "before-delete", ResourceHookArgs{
+			URN:        s.URN(),
+			ID:         s.old.ID,
+			OldInputs:  s.old.Inputs,
+			OldOutputs: s.old.Outputs,
+		}); err != nil {
+			return resource.StatusOK, nil, err
+		}
+
 		if s.old.Custom {
 			// Invoke the Delete RPC function for this provider:
 			prov, err := getProvider(s)
@@ -344,6 +379,16 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 				return rst, nil, err
 			}
 		}
+
+		// The resource is already gone, so a failing hook cannot fail the delete; report it instead.
+		if err := runResourceHooks(s.plan, s.old.Hooks.AfterDelete, "after-delete", ResourceHookArgs{
+			URN:        s.URN(),
+			ID:         s.old.ID,
+			OldInputs:  s.old.Inputs,
+			OldOutputs: s.old.Outputs,
+		}); err != nil {
+			s.plan.Diag().Warningf(diag.RawMessage(s.URN(), err.Error()))
+		}
 	}
 
 	return resource.StatusOK, func() {}, nil
@@ -439,6 +484,16 @@ func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	var resourceError error
 	resourceStatus := resource.StatusOK
 	if !preview {
+		if err := runResourceHooks(s.plan, s.new.Hooks.BeforeUpdate, "before-update", ResourceHookArgs{
+			URN:        s.URN(),
+			ID:         s.old.ID,
+			NewInputs:  s.new.Inputs,
+			OldInputs:  s.old.Inputs,
+			OldOutputs: s.old.Outputs,
+		}); err != nil {
+			return resource.StatusOK, nil, err
+		}
+
 		if s.new.Custom {
 			// Invoke the Update RPC function for this provider:
 			prov, err := getProvider(s)
@@ -465,6 +520,21 @@ func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			// Now copy any output state back in case the update triggered cascading updates to other properties.
 			s.new.Outputs = outs
 		}
+
+		// The resource has been updated, so a failing hook leaves it partially updated rather than failing the update.
+		if resourceError == nil {
+			if err := runResourceHooks(s.plan, s.new.Hooks.AfterUpdate, "after-update", ResourceHookArgs{
+				URN:        s.URN(),
+				ID:         s.new.ID,
+				NewInputs:  s.new.Inputs,
+				OldInputs:  s.old.Inputs,
+				NewOutputs: s.new.Outputs,
+				OldOutputs: s.old.Outputs,
+			}); err != nil {
+				resourceError = err
+				resourceStatus = resource.StatusPartialFailure
+			}
+		}
 	} else {
 		s.new.Outputs = s.new.Inputs
 	}
@@ -758,10 +828,13 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 			resourceID = refreshed.ID
 		}
 
+		// The refreshed state deliberately records no input hash, so that the next update diffs the resource against
+		// what was read rather than trusting the decisions the provider made before the resource drifted.
 		s.new = resource.NewState(s.old.Type, s.old.URN, s.old.Custom, s.old.Delete, resourceID, inputs, outputs,
 			s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider,
 			s.old.PropertyDependencies, s.old.PendingReplacement, s.old.AdditionalSecretOutputs, s.old.Aliases,
 			&s.old.CustomTimeouts)
+		s.new.Hooks = s.old.Hooks
 	} else {
 		s.new = nil
 	}
@@ -17,12 +17,15 @@ package deploy
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 )
@@ -94,6 +97,8 @@ type stepExecutor struct {
 	workers        sync.WaitGroup     // WaitGroup tracking the worker goroutines that are owned by this step executor.
 	incomingChains chan incomingChain // Incoming chains that we are to execute
 
+	providerSlots map[tokens.Package]chan bool // Semaphores limiting the concurrent operations for each provider.
+
 	ctx      context.Context    // cancellation context for the current plan.
 	cancel   context.CancelFunc // CancelFunc that cancels the above context.
 	sawError atomic.Value       // atomic boolean indicating whether or not the step excecutor saw that there was an error.
@@ -256,6 +261,15 @@ func (se *stepExecutor) cancelDueToError() {
 // executeStep executes a single step, returning true if the step execution was successful and
 // false if it was not.
 func (se *stepExecutor) executeStep(workerID int, step Step) error {
+	// Wait for the step's provider to have capacity before announcing the step.  If the plan is canceled in the
+	// meantime, the step is abandoned, just as it would be had the cancellation arrived before the step was reached.
+	release, ok := se.acquireProviderSlot(workerID, step)
+	if !ok {
+		se.log(workerID, "step %v on %v canceled while waiting for provider capacity", step.Op(), step.URN())
+		return nil
+	}
+	defer release()
+
 	var payload interface{}
 	events := se.opts.Events
 	if events != nil {
@@ -314,6 +328,42 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	return nil
 }
 
+// acquireProviderSlot waits until the provider for the given step's resource has capacity for another operation, if
+// its concurrency is limited, and returns a function that releases the capacity again.  If the plan is canceled while
+// waiting, it returns false instead.
+func (se *stepExecutor) acquireProviderSlot(workerID int, step Step) (func(), bool) {
+	if len(se.providerSlots) == 0 {
+		return func() {}, true
+	}
+	slots, has := se.providerSlots[stepPackage(step)]
+	if !has {
+		return func() {}, true
+	}
+	se.log(workerID, "step %v on %v waiting for provider capacity", step.Op(), step.URN())
+	select {
+	case slots <- true:
+		return func() { <-slots }, true
+	case <-se.ctx.Done():
+		return nil, false
+	}
+}
+
+// stepPackage returns the package of the provider that performs the given step, or "" if the step's resource is not
+// managed by a provider.
+func stepPackage(step Step) tokens.Package {
+	state := step.New()
+	if state == nil {
+		state = step.Old()
+	}
+	if state != nil && !state.Custom {
+		return ""
+	}
+	if providers.IsProviderType(step.Type()) {
+		return providers.GetProviderPackage(step.Type())
+	}
+	return tokens.Package(strings.SplitN(string(step.Type()), tokens.TokenDelimiter, 2)[0])
+}
+
 // log is a simple logging helper for the step executor.
 func (se *stepExecutor) log(workerID int, msg string, args ...interface{}) {
 	if logging.V(stepExecutorLogLevel) {
@@ -394,6 +444,15 @@ func newStepExecutor(ctx context.Context, cancel context.CancelFunc, plan *Plan,
 
 	exec.sawError.Store(false)
 
+	for pkg, limit := range opts.ProviderParallel {
+		if limit > 0 {
+			if exec.providerSlots == nil {
+				exec.providerSlots = make(map[tokens.Package]chan bool)
+			}
+			exec.providerSlots[tokens.Package(pkg)] = make(chan bool, limit)
+		}
+	}
+
 	// If we're being asked to run as parallel as possible, spawn a single worker that launches chain executions
 	// asynchronously.
 	if opts.InfiniteParallelism() {
@@ -0,0 +1,95 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func newTestSameStep(t tokens.Type) Step {
+	urn := resource.NewURN("test", "test", "", t, "res")
+	state := resource.NewState(t, urn, true, false, "", resource.PropertyMap{}, nil, "", false, false, nil, nil,
+		"", nil, false, nil, nil, nil)
+	return &SameStep{old: state, new: state}
+}
+
+func TestStepPackage(t *testing.T) {
+	assert.Equal(t, tokens.Package("aws"), stepPackage(newTestSameStep("aws:s3/bucket:Bucket")))
+	assert.Equal(t, tokens.Package("aws"), stepPackage(newTestSameStep("pulumi:providers:aws")))
+
+	component := newTestSameStep("my:component")
+	component.New().Custom = false
+	assert.Equal(t, tokens.Package(""), stepPackage(component))
+}
+
+func TestProviderSlots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	se := newStepExecutor(ctx, cancel, nil, Options{ProviderParallel: map[string]int{"aws": 2, "gcp": 0}},
+		false, false)
+	defer func() {
+		se.SignalCompletion()
+		se.WaitForCompletion()
+	}()
+
+	// Providers without a positive limit are unconstrained.
+	assert.Len(t, se.providerSlots, 1)
+
+	aws := newTestSameStep("aws:s3/bucket:Bucket")
+	release1, ok := se.acquireProviderSlot(0, aws)
+	assert.True(t, ok)
+	release2, ok := se.acquireProviderSlot(0, aws)
+	assert.True(t, ok)
+	assert.Len(t, se.providerSlots["aws"], 2)
+
+	// A third operation must wait for one of the first two to finish.
+	acquired := make(chan bool)
+	go func() {
+		release, ok := se.acquireProviderSlot(1, aws)
+		acquired <- ok
+		release()
+	}()
+	select {
+	case <-acquired:
+		assert.Fail(t, "acquired more provider slots than the limit")
+	default:
+	}
+	release1()
+	assert.True(t, <-acquired)
+
+	// Other providers are not affected.
+	release, ok := se.acquireProviderSlot(0, newTestSameStep("gcp:storage/bucket:Bucket"))
+	assert.True(t, ok)
+	release()
+
+	// Operations that are waiting for capacity give up if the plan is canceled.
+	release3, ok := se.acquireProviderSlot(0, aws)
+	assert.True(t, ok)
+	go func() {
+		_, ok := se.acquireProviderSlot(1, aws)
+		acquired <- ok
+	}()
+	cancel()
+	assert.False(t, <-acquired)
+	release2()
+	release3()
+	assert.Len(t, se.providerSlots["aws"], 0)
+}
@@ -247,11 +247,17 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		inputs = processedInputs
 	}
 
+	// Give the resource a name that complies with the naming policy, if there is one and the program did not name it.
+	if sg.opts.Naming != nil && goal.Custom && !providers.IsProviderType(goal.Type) {
+		inputs = sg.opts.Naming.Apply(goal.Type, goal.Name, inputs, oldInputs)
+	}
+
 	// Produce a new state object that we'll build up as operations are performed.  Ultimately, this is what will
 	// get serialized into the checkpoint file.
 	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", inputs, nil, goal.Parent, goal.Protect, false,
 		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
 		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts)
+	new.Hooks = goal.Hooks
 
 	// Mark the URN/resource as having been seen. So we can run analyzers on all resources seen, as well as
 	// lookup providers for calculating replacement of resources that use the provider.
@@ -291,9 +297,19 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		return []Step{NewImportStep(sg.plan, event, new, goal.IgnoreChanges)}, nil
 	}
 
+	// If neither the resource's inputs nor its provider have changed since the last update, the provider would make
+	// the same decisions that it did then, so there is no need to ask it to check or diff the resource again.
+	new.InputHash = sg.inputHash(goal, inputs, prov)
+	unchanged := hasOld && new.InputHash != "" && new.InputHash == old.InputHash &&
+		!recreating && !wasExternal && !sg.isTargetedReplace(urn) && len(old.InitErrors) == 0
+	if unchanged {
+		logging.V(7).Infof("Planner skipped checking '%v' as its inputs are unchanged", urn)
+		new.Inputs, inputs = oldInputs, oldInputs
+	}
+
 	// Ensure the provider is okay with this resource and fetch the inputs to pass to subsequent methods.
 	var err error
-	if prov != nil {
+	if prov != nil && !unchanged {
 		var failures []plugin.CheckFailure
 
 		// If we are re-creating this resource because it was deleted earlier, the old inputs are now
@@ -301,7 +317,11 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		// don't consider those inputs since Pulumi does not own them. Finally, if the resource has been
 		// targeted for replacement, ignore its old state.
 		if recreating || wasExternal || sg.isTargetedReplace(urn) {
-			inputs, failures, err = prov.Check(urn, nil, goal.Properties, allowUnknowns)
+			fresh := goal.Properties
+			if sg.opts.Naming != nil && !providers.IsProviderType(goal.Type) {
+				fresh = sg.opts.Naming.Apply(goal.Type, goal.Name, fresh, nil)
+			}
+			inputs, failures, err = prov.Check(urn, nil, fresh, allowUnknowns)
 		} else {
 			inputs, failures, err = prov.Check(urn, oldInputs, inputs, allowUnknowns)
 		}
@@ -431,6 +451,8 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		if !sg.isTargetedForUpdate(urn) {
 			logging.V(7).Infof(
 				"Planner decided not to update '%v' due to not being in target group (same) (inputs=%v)", urn, new.Inputs)
+		} else if unchanged {
+			logging.V(7).Infof("Planner decided not to update '%v' as its inputs are unchanged (same)", urn)
 		} else {
 			updateSteps, res := sg.generateStepsFromDiff(
 				event, urn, old, new, oldInputs, oldOutputs, inputs, prov, goal)
@@ -538,7 +560,11 @@ func (sg *stepGenerator) generateStepsFromDiff(
 			// Note that if we're performing a targeted replace, we already have the correct inputs.
 			if prov != nil && !sg.isTargetedReplace(urn) {
 				var failures []plugin.CheckFailure
-				inputs, failures, err = prov.Check(urn, nil, goal.Properties, allowUnknowns)
+				fresh := goal.Properties
+				if sg.opts.Naming != nil && !providers.IsProviderType(goal.Type) {
+					fresh = sg.opts.Naming.Apply(goal.Type, goal.Name, fresh, nil)
+				}
+				inputs, failures, err = prov.Check(urn, nil, fresh, allowUnknowns)
 				if err != nil {
 					return nil, result.FromError(err)
 				} else if issueCheckErrors(sg.plan, new, urn, failures) {
@@ -590,6 +616,7 @@ func (sg *stepGenerator) generateStepsFromDiff(
 
 					// Deletions must occur in reverse dependency order, and `deps` is returned in dependency
 					// order, so we iterate in reverse.
+					var cascade []string
 					for i := len(toReplace) - 1; i >= 0; i-- {
 						dependentResource := toReplace[i].res
 
@@ -607,6 +634,15 @@ func (sg *stepGenerator) generateStepsFromDiff(
 						// Mark the condemned resource as deleted. We won't know until later in the plan whether
 						// or not we're going to be replacing this resource.
 						sg.deletes[dependentResource.URN] = true
+						cascade = append(cascade, string(dependentResource.URN.Name()))
+					}
+
+					// Let the user know which dependents must be deleted first, so that the cascade is visible in
+					// previews as well as updates.
+					if len(cascade) > 0 {
+						sg.plan.ctx.Diag.Infof(
+							diag.Message(urn, "replacing this resource first deletes %d dependent resource(s): %s"),
+							len(cascade), strings.Join(cascade, ", "))
 					}
 				}
 
@@ -1199,9 +1235,20 @@ func (sg *stepGenerator) calculateDependentReplacements(root *resource.State) ([
 
 		// Scan the properties of this resource in order to determine whether or not any of them depend on a resource
 		// that requires replacement and build a set of input properties for the provider diff.
+		//
+		// Resources from older checkpoints may not have recorded their property dependencies.  For these, we must
+		// conservatively assume that every property depends on every one of the resource's dependencies, rather than
+		// risk deleting a resource while it is still in use.
+		propertyDeps := r.PropertyDependencies
+		if len(propertyDeps) == 0 && len(r.Dependencies) > 0 {
+			propertyDeps = make(map[resource.PropertyKey][]resource.URN)
+			for pk := range r.Inputs {
+				propertyDeps[pk] = r.Dependencies
+			}
+		}
 		hasDependencyInReplaceSet, inputsForDiff := false, resource.PropertyMap{}
 		for pk, pv := range r.Inputs {
-			for _, propertyDep := range r.PropertyDependencies[pk] {
+			for _, propertyDep := range propertyDeps[pk] {
 				if replaceSet[propertyDep] {
 					hasDependencyInReplaceSet = true
 					pv = resource.MakeComputed(resource.NewStringProperty("<unknown>"))
@@ -0,0 +1,119 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// DefaultTimeout supplies the operation timeouts for resources whose types match a pattern.
+type DefaultTimeout struct {
+	// Pattern is a resource type token in which "*" matches any sequence of characters.
+	Pattern string `json:"pattern" yaml:"pattern"`
+	// Timeouts are the timeouts, in seconds, for matching resources.
+	Timeouts resource.CustomTimeouts `json:"timeouts" yaml:"timeouts"`
+}
+
+// DefaultTimeouts is a list of default timeouts, ordered from most to least specific pattern.
+type DefaultTimeouts []DefaultTimeout
+
+// NewDefaultTimeouts creates a list of default timeouts from the timeouts in a stack's settings, which map type
+// patterns to durations such as "45m".
+func NewDefaultTimeouts(timeouts map[string]workspace.StackTimeouts) (DefaultTimeouts, error) {
+	parse := func(pattern, op, s string) (float64, error) {
+		if s == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, errors.Errorf("invalid %s timeout '%s' for '%s'", op, s, pattern)
+		}
+		return d.Seconds(), nil
+	}
+
+	var result DefaultTimeouts
+	for pattern, t := range timeouts {
+		var d DefaultTimeout
+		var err error
+		d.Pattern = pattern
+		if d.Timeouts.Create, err = parse(pattern, "create", t.Create); err != nil {
+			return nil, err
+		}
+		if d.Timeouts.Update, err = parse(pattern, "update", t.Update); err != nil {
+			return nil, err
+		}
+		if d.Timeouts.Delete, err = parse(pattern, "delete", t.Delete); err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+
+	// Longer patterns are considered more specific.
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Pattern) != len(result[j].Pattern) {
+			return len(result[i].Pattern) > len(result[j].Pattern)
+		}
+		return result[i].Pattern < result[j].Pattern
+	})
+	return result, nil
+}
+
+// Apply fills in each timeout that is not already set for a resource of the given type from the most specific
+// matching pattern that sets it.
+func (d DefaultTimeouts) Apply(t tokens.Type, timeouts *resource.CustomTimeouts) {
+	for _, def := range d {
+		if !matchTypePattern(def.Pattern, string(t)) {
+			continue
+		}
+		if timeouts.Create == 0 {
+			timeouts.Create = def.Timeouts.Create
+		}
+		if timeouts.Update == 0 {
+			timeouts.Update = def.Timeouts.Update
+		}
+		if timeouts.Delete == 0 {
+			timeouts.Delete = def.Timeouts.Delete
+		}
+	}
+}
+
+// matchTypePattern returns true if the type token s matches the pattern, in which "*" matches any sequence of
+// characters.
+func matchTypePattern(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(s, part)
+		if idx == -1 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
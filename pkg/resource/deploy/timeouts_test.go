@@ -0,0 +1,59 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func TestMatchTypePattern(t *testing.T) {
+	assert.True(t, matchTypePattern("aws:rds/instance:Instance", "aws:rds/instance:Instance"))
+	assert.True(t, matchTypePattern("aws:rds/*", "aws:rds/instance:Instance"))
+	assert.True(t, matchTypePattern("aws:*", "aws:rds/instance:Instance"))
+	assert.True(t, matchTypePattern("*:Instance", "aws:rds/instance:Instance"))
+	assert.True(t, matchTypePattern("aws:*/instance:*", "aws:rds/instance:Instance"))
+	assert.False(t, matchTypePattern("aws:rds/*", "aws:ec2/instance:Instance"))
+	assert.False(t, matchTypePattern("aws:rds", "aws:rds/instance:Instance"))
+}
+
+func TestDefaultTimeouts(t *testing.T) {
+	defaults, err := NewDefaultTimeouts(map[string]workspace.StackTimeouts{
+		"aws:*":     {Create: "10m", Delete: "5m"},
+		"aws:rds/*": {Create: "45m"},
+	})
+	assert.NoError(t, err)
+
+	// The most specific pattern wins, with the remaining timeouts filled in from less specific ones.
+	var timeouts resource.CustomTimeouts
+	defaults.Apply("aws:rds/instance:Instance", &timeouts)
+	assert.Equal(t, resource.CustomTimeouts{Create: 45 * 60, Delete: 5 * 60}, timeouts)
+
+	// Timeouts specified by the program are left alone.
+	timeouts = resource.CustomTimeouts{Create: 60}
+	defaults.Apply("aws:s3/bucket:Bucket", &timeouts)
+	assert.Equal(t, resource.CustomTimeouts{Create: 60, Delete: 5 * 60}, timeouts)
+
+	timeouts = resource.CustomTimeouts{}
+	defaults.Apply("gcp:sql/databaseInstance:DatabaseInstance", &timeouts)
+	assert.Equal(t, resource.CustomTimeouts{}, timeouts)
+
+	_, err = NewDefaultTimeouts(map[string]workspace.StackTimeouts{"aws:*": {Create: "soon"}})
+	assert.Error(t, err)
+}
@@ -113,47 +113,109 @@ func RenameStack(snap *deploy.Snapshot, newName tokens.QName, newProject tokens.
 		return resource.NewURN(newName, project, "", u.QualifiedType(), u.Name())
 	}
 
-	rewriteState := func(res *resource.State) {
-		contract.Assert(res != nil)
+	if err := snap.VerifyIntegrity(); err != nil {
+		return errors.Wrap(err, "checkpoint is invalid")
+	}
 
+	for _, res := range snap.Resources {
 		res.URN = rewriteUrn(res.URN)
+		rewriteReferences(res, rewriteUrn)
+	}
 
-		if res.Parent != "" {
-			res.Parent = rewriteUrn(res.Parent)
-		}
+	for _, ops := range snap.PendingOperations {
+		ops.Resource.URN = rewriteUrn(ops.Resource.URN)
+		rewriteReferences(ops.Resource, rewriteUrn)
+	}
 
-		for depIdx, dep := range res.Dependencies {
-			res.Dependencies[depIdx] = rewriteUrn(dep)
+	return nil
+}
+
+// MoveResource changes the URN of a resource, e.g. to match a new name or type that the resource has been given in the
+// program, so that the next update does not replace it.  The references of every other resource to the resource are
+// changed to match.  The new URN must belong to the same stack and project, must not already be in use, and must keep
+// the resource's parent.  A provider cannot be given a type that is not a provider's, or vice versa, and a resource
+// with children cannot be given a new type, since their URNs include it.
+func MoveResource(snap *deploy.Snapshot, res *resource.State, newURN resource.URN) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(res != nil, "res")
+
+	oldURN := res.URN
+	switch {
+	case !newURN.IsValid():
+		return errors.Errorf("%q is not a valid URN", newURN)
+	case newURN.Stack() != oldURN.Stack() || newURN.Project() != oldURN.Project():
+		return errors.Errorf("%q must belong to stack %q of project %q", newURN, oldURN.Stack(), oldURN.Project())
+	case oldURN.Type() == resource.RootStackType:
+		return errors.New("the stack's root resource cannot be moved")
+	case providers.IsProviderType(oldURN.Type()) != providers.IsProviderType(newURN.Type()):
+		return errors.Errorf("%q and %q must both be providers, or both not be", oldURN, newURN)
+	case len(LocateResource(snap, newURN)) != 0:
+		return errors.Errorf("a resource with URN %q already exists", newURN)
+	}
+
+	if newURN.QualifiedType() != oldURN.QualifiedType() {
+		// The qualified type of a URN includes the qualified type of the resource's parent, unless it is the stack.
+		var parentType tokens.Type
+		if res.Parent != "" && res.Parent.Type() != resource.RootStackType {
+			parentType = res.Parent.QualifiedType()
+		}
+		expected := resource.NewURN(newURN.Stack(), newURN.Project(), parentType, newURN.Type(), newURN.Name())
+		if newURN != expected {
+			return errors.Errorf("%q does not match the resource's parent %q; expected %q", newURN, res.Parent, expected)
 		}
 
-		for _, propDeps := range res.PropertyDependencies {
-			for depIdx, dep := range propDeps {
-				propDeps[depIdx] = rewriteUrn(dep)
+		for _, other := range snap.Resources {
+			if other.Parent == oldURN {
+				return errors.Errorf("the type of %q cannot be changed, since it has children", oldURN)
 			}
 		}
+	}
 
-		if res.Provider != "" {
-			providerRef, err := providers.ParseReference(res.Provider)
-			contract.AssertNoErrorf(err, "failed to parse provider reference from validated checkpoint")
-
-			providerRef, err = providers.NewReference(rewriteUrn(providerRef.URN()), providerRef.ID())
-			contract.AssertNoErrorf(err, "failed to generate provider reference from valid reference")
+	rewriteUrn := func(u resource.URN) resource.URN {
+		if u == oldURN {
+			return newURN
+		}
+		return u
+	}
 
-			res.Provider = providerRef.String()
+	res.URN, res.Type = newURN, newURN.Type()
+	for _, other := range snap.Resources {
+		rewriteReferences(other, rewriteUrn)
+	}
+	for _, op := range snap.PendingOperations {
+		if op.Resource == res {
+			continue
 		}
+		rewriteReferences(op.Resource, rewriteUrn)
 	}
+	return nil
+}
 
-	if err := snap.VerifyIntegrity(); err != nil {
-		return errors.Wrap(err, "checkpoint is invalid")
+// rewriteReferences rewrites the URNs of the parent, dependencies and provider of a resource.
+func rewriteReferences(res *resource.State, rewriteUrn func(resource.URN) resource.URN) {
+	contract.Assert(res != nil)
+
+	if res.Parent != "" {
+		res.Parent = rewriteUrn(res.Parent)
 	}
 
-	for _, res := range snap.Resources {
-		rewriteState(res)
+	for depIdx, dep := range res.Dependencies {
+		res.Dependencies[depIdx] = rewriteUrn(dep)
 	}
 
-	for _, ops := range snap.PendingOperations {
-		rewriteState(ops.Resource)
+	for _, propDeps := range res.PropertyDependencies {
+		for depIdx, dep := range propDeps {
+			propDeps[depIdx] = rewriteUrn(dep)
+		}
 	}
 
-	return nil
+	if res.Provider != "" {
+		providerRef, err := providers.ParseReference(res.Provider)
+		contract.AssertNoErrorf(err, "failed to parse provider reference from validated checkpoint")
+
+		providerRef, err = providers.NewReference(rewriteUrn(providerRef.URN()), providerRef.ID())
+		contract.AssertNoErrorf(err, "failed to generate provider reference from valid reference")
+
+		res.Provider = providerRef.String()
+	}
 }
@@ -321,3 +321,65 @@ func TestRenameStack(t *testing.T) {
 		assert.Len(t, LocateResource(snap, updatedResourceURN), 1)
 	})
 }
+
+func TestMoveResource(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	b.Parent = a.URN
+	b.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"foo": {a.URN}}
+	c := NewResource("c", pA)
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		b,
+		c,
+	})
+
+	newURN := resource.NewURN("test", "test", "", a.Type, "a2")
+	err := MoveResource(snap, a, newURN)
+	assert.NoError(t, err)
+	assert.Equal(t, newURN, a.URN)
+	assert.Equal(t, newURN, b.Parent)
+	assert.Equal(t, []resource.URN{newURN}, b.Dependencies)
+	assert.Equal(t, []resource.URN{newURN}, b.PropertyDependencies["foo"])
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	// Moving a provider rewrites the references of the resources that use it.
+	newProviderURN := resource.NewURN("test", "test", "", pA.Type, "p2")
+	err = MoveResource(snap, pA, newProviderURN)
+	assert.NoError(t, err)
+	ref, err := providers.ParseReference(c.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, newProviderURN, ref.URN())
+	assert.Equal(t, resource.ID("0"), ref.ID())
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	// The new URN must be unused and belong to the same stack.
+	err = MoveResource(snap, c, b.URN)
+	assert.Error(t, err)
+	err = MoveResource(snap, c, resource.NewURN("other", "test", "", c.Type, "c"))
+	assert.Error(t, err)
+	err = MoveResource(snap, c, "not-a-urn")
+	assert.Error(t, err)
+
+	// Resources may change type, but not between providers and other resources.
+	newTypeURN := resource.NewURN("test", "test", "", "a:b:d", "c")
+	err = MoveResource(snap, c, newTypeURN)
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.Type("a:b:d"), c.Type)
+	err = MoveResource(snap, c, resource.NewURN("test", "test", "", pA.Type, "c"))
+	assert.Error(t, err)
+	err = MoveResource(snap, pA, resource.NewURN("test", "test", "", "a:b:c", "p3"))
+	assert.Error(t, err)
+
+	// The new URN must keep the resource's parent, whose type qualifies the types of its children's URNs.
+	err = MoveResource(snap, b, resource.NewURN("test", "test", "", "a:b:d", "b2"))
+	assert.Error(t, err)
+	newChildURN := resource.NewURN("test", "test", a.URN.QualifiedType(), b.Type, "b2")
+	err = MoveResource(snap, b, newChildURN)
+	assert.NoError(t, err)
+	err = MoveResource(snap, a, resource.NewURN("test", "test", "", "a:b:d", "a3"))
+	assert.Error(t, err)
+	assert.NoError(t, snap.VerifyIntegrity())
+}
@@ -0,0 +1,32 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// Hooks are the names of the hooks, registered by a program, that the engine runs around a resource's
+// operations.
+type Hooks struct {
+	BeforeCreate []string `json:"beforeCreate,omitempty" yaml:"beforeCreate,omitempty"`
+	AfterCreate  []string `json:"afterCreate,omitempty" yaml:"afterCreate,omitempty"`
+	BeforeUpdate []string `json:"beforeUpdate,omitempty" yaml:"beforeUpdate,omitempty"`
+	AfterUpdate  []string `json:"afterUpdate,omitempty" yaml:"afterUpdate,omitempty"`
+	BeforeDelete []string `json:"beforeDelete,omitempty" yaml:"beforeDelete,omitempty"`
+	AfterDelete  []string `json:"afterDelete,omitempty" yaml:"afterDelete,omitempty"`
+}
+
+// IsNotEmpty returns true if any hooks are named for any of the resource's operations.
+func (h *Hooks) IsNotEmpty() bool {
+	return len(h.BeforeCreate) != 0 || len(h.AfterCreate) != 0 || len(h.BeforeUpdate) != 0 ||
+		len(h.AfterUpdate) != 0 || len(h.BeforeDelete) != 0 || len(h.AfterDelete) != 0
+}
@@ -16,6 +16,7 @@ package plugin
 
 import (
 	"os"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/hashicorp/go-multierror"
@@ -248,8 +249,15 @@ func (host *defaultHost) ListAnalyzers() []Analyzer {
 
 func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (Provider, error) {
 	plugin, err := host.loadPlugin(func() (interface{}, error) {
-		// Try to load and bind to a plugin.
+		// Try to load and bind to a plugin.  If the plugin is not installed, but we know which version is required,
+		// install it and try again.
 		plug, err := NewProvider(host, host.ctx, pkg, version)
+		if _, missing := err.(*workspace.MissingError); missing && version != nil {
+			if err = host.installProvider(pkg, version); err != nil {
+				return nil, err
+			}
+			plug, err = NewProvider(host, host.ctx, pkg, version)
+		}
 		if err == nil && plug != nil {
 			info, infoerr := plug.GetPluginInfo()
 			if infoerr != nil {
@@ -293,6 +301,23 @@ func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (
 	return plugin.(Provider), nil
 }
 
+// installProvider downloads and installs the given version of the resource plugin for a package.
+func (host *defaultHost) installProvider(pkg tokens.Package, version *semver.Version) error {
+	info := workspace.PluginInfo{
+		Kind:    workspace.ResourcePlugin,
+		Name:    strings.Replace(string(pkg), tokens.QNameDelimiter, "_", -1),
+		Version: version,
+	}
+	host.ctx.Diag.Infoerrf(diag.Message("", /*urn*/
+		"resource plugin %s-v%s is not installed; downloading and installing it"), info.Name, version)
+	if err := InstallPlugin(info); err != nil {
+		return errors.Wrapf(err, "installing resource plugin %s-v%s; "+
+			"it can be installed manually using `pulumi plugin install resource %s v%s`",
+			info.Name, version, info.Name, version)
+	}
+	return nil
+}
+
 func (host *defaultHost) LanguageRuntime(runtime string) (LanguageRuntime, error) {
 	plugin, err := host.loadPlugin(func() (interface{}, error) {
 		// First see if we already loaded this plugin.
@@ -50,7 +50,8 @@ func newHostServer(host Host, ctx *Context) (*hostServer, error) {
 		cancel: make(chan bool),
 	}
 
-	// Fire up a gRPC server and start listening for incomings.
+	// Fire up a gRPC server and start listening for incomings.  This always listens on a TCP port, even if plugins
+	// serve on local sockets, because programs and plugins that predate local sockets must be able to dial it.
 	port, done, err := rpcutil.Serve(0, engine.cancel, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
 			lumirpc.RegisterEngineServer(srv, engine)
@@ -0,0 +1,50 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	lumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func TestHostServerDialableWithoutLocalDialer(t *testing.T) {
+	defer os.Setenv(rpcutil.UnixSocketsEnvVar, os.Getenv(rpcutil.UnixSocketsEnvVar))
+	assert.NoError(t, os.Setenv(rpcutil.UnixSocketsEnvVar, "true"))
+
+	server, err := newHostServer(nil, &Context{})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, server.Cancel()) }()
+	server.rootUrn.Store("urn:pulumi:stack::project::pulumi:pulumi:Stack::project-stack")
+
+	// Plugins and programs that predate local sockets dial the engine with a plain grpc.Dial, which only understands
+	// TCP addresses.
+	conn, err := grpc.Dial(server.Address(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, conn.Close()) }()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := lumirpc.NewEngineClient(conn).GetRootResource(ctx, &lumirpc.GetRootResourceRequest{},
+		grpc.WaitForReady(true))
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:pulumi:stack::project::pulumi:pulumi:Stack::project-stack", resp.GetUrn())
+}
@@ -0,0 +1,79 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// InstallPlugin downloads the given plugin and installs it into the plugin cache, showing a progress bar while it
+// downloads.  The download is verified as described by DownloadPlugin.  Language plugins are not installed, as they
+// are distributed with the CLI.
+func InstallPlugin(info workspace.PluginInfo) error {
+	logging.V(7).Infof("InstallPlugin(%s, %s): beginning install", info.Name, info.Version)
+	if info.Kind == workspace.LanguagePlugin {
+		logging.V(7).Infof("InstallPlugin(%s, %s): is a language plugin, skipping install", info.Name, info.Version)
+		return nil
+	}
+
+	logging.V(8).Infof("InstallPlugin(%s, %s): initiating download", info.Name, info.Version)
+	stream, size, err := DownloadPlugin(info)
+	if err != nil {
+		return err
+	}
+
+	// Report progress on stderr, so that it does not corrupt the output of commands run with --json.
+	cmdutil.Diag().Infoerrf(diag.Message("", "[%s plugin %s-%s] installing"), info.Kind, info.Name, info.Version)
+	stream = workspace.ReadCloserProgressBar(stream, size, "Downloading plugin", cmdutil.GetGlobalColorization())
+
+	logging.V(8).Infof("InstallPlugin(%s, %s): extracting tarball to installation directory", info.Name, info.Version)
+	if err := info.Install(stream); err != nil {
+		return err
+	}
+
+	logging.V(7).Infof("InstallPlugin(%s, %s): successfully installed", info.Name, info.Version)
+	return nil
+}
+
+// DownloadPlugin fetches the tarball of the given plugin and returns it along with its size (if known).  If the
+// plugin's server publishes a checksum alongside the tarball, reading the tarball to the end fails unless its contents
+// match.  Otherwise, a warning is issued and the tarball is not verified.
+func DownloadPlugin(info workspace.PluginInfo) (io.ReadCloser, int64, error) {
+	stream, size, err := info.Download()
+	if err != nil {
+		return nil, -1, err
+	}
+
+	// Only look for a checksum once the tarball itself is reachable, so that an unreachable server does not cost us
+	// a second round of retries.
+	label := fmt.Sprintf("%s plugin %s-%s", info.Kind, info.Name, info.Version)
+	logging.V(8).Infof("DownloadPlugin(%s, %s): fetching checksum", info.Name, info.Version)
+	checksum, err := info.DownloadChecksum()
+	if err != nil {
+		cmdutil.Diag().Warningf(diag.Message("", "could not fetch the checksum of %s, so it will not be verified: %v"),
+			label, err)
+	} else if checksum == "" {
+		cmdutil.Diag().Warningf(diag.Message("", "no checksum is published for %s, so it will not be verified"), label)
+	} else {
+		stream = workspace.NewChecksumVerifier(stream, checksum)
+	}
+	return stream, size, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func TestDownloadPlugin(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("plugins are only published for amd64")
+	}
+
+	sum := sha256.Sum256([]byte("tarball"))
+	checksum := hex.EncodeToString(sum[:])
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/pulumi-resource-aws-") && strings.HasSuffix(r.URL.Path, ".sha256"):
+			_, err = w.Write([]byte(checksum + "  pulumi-resource-aws.tar.gz\n"))
+		case strings.HasPrefix(r.URL.Path, "/pulumi-resource-gcp-") && strings.HasSuffix(r.URL.Path, ".sha256"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, ".sha256"):
+			w.WriteHeader(http.StatusForbidden)
+		case strings.HasPrefix(r.URL.Path, "/pulumi-resource-aws-"):
+			_, err = w.Write([]byte("evil"))
+		default:
+			_, err = w.Write([]byte("tarball"))
+		}
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	old := os.Getenv(workspace.PluginDownloadURLEnvVar)
+	defer func() { assert.NoError(t, os.Setenv(workspace.PluginDownloadURLEnvVar, old)) }()
+	assert.NoError(t, os.Setenv(workspace.PluginDownloadURLEnvVar, server.URL))
+
+	download := func(name string) ([]byte, error) {
+		v := semver.MustParse("1.2.3")
+		stream, _, err := DownloadPlugin(workspace.PluginInfo{Kind: workspace.ResourcePlugin, Name: name, Version: &v})
+		if err != nil {
+			return nil, err
+		}
+		defer func() { assert.NoError(t, stream.Close()) }()
+		return ioutil.ReadAll(stream)
+	}
+
+	// Tarballs that do not match their published checksum fail to read.
+	_, err := download("aws")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "plugin checksum mismatch")
+	}
+
+	// Tarballs without a checksum, or whose checksum cannot be fetched, are downloaded without being verified.
+	body, err := download("gcp")
+	assert.NoError(t, err)
+	assert.Equal(t, "tarball", string(body))
+	body, err = download("azure")
+	assert.NoError(t, err)
+	assert.Equal(t, "tarball", string(body))
+}
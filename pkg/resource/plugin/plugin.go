@@ -45,6 +45,7 @@ type plugin struct {
 
 	Bin    string
 	Args   []string
+	Addr   string
 	Conn   *grpc.ClientConn
 	Proc   *os.Process
 	Stdin  io.WriteCloser
@@ -160,12 +161,17 @@ func newPlugin(ctx *Context, pwd, bin, prefix string, args []string) (*plugin, e
 		port += string(b[:n])
 	}
 
-	// Parse the output line (minus the '\n') to ensure it's a numeric port.
-	if _, err = strconv.Atoi(port); err != nil {
-		killerr := plug.Proc.Kill()
-		contract.IgnoreError(killerr) // ignoring the error because the existing one trumps it.
-		return nil, errors.Wrapf(
-			err, "%v plugin [%v] wrote a non-numeric port to stdout ('%v')", prefix, bin, port)
+	// Parse the output line (minus the '\n') to ensure it's a numeric port or, if the plugin is listening on a Unix
+	// domain socket or named pipe, the address of the socket.
+	addr := port
+	if !rpcutil.IsLocalSocketAddress(port) {
+		if _, err = strconv.Atoi(port); err != nil {
+			killerr := plug.Proc.Kill()
+			contract.IgnoreError(killerr) // ignoring the error because the existing one trumps it.
+			return nil, errors.Wrapf(
+				err, "%v plugin [%v] wrote a non-numeric port to stdout ('%v')", prefix, bin, port)
+		}
+		addr = "127.0.0.1:" + port
 	}
 
 	// After reading the port number, set up a tracer on stdout just so other output doesn't disappear.
@@ -176,8 +182,8 @@ func newPlugin(ctx *Context, pwd, bin, prefix string, args []string) (*plugin, e
 	// We want to increase the default message size as per pulumi/pulumi#2319
 	messageSizeOpts := grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(pluginRPCMaxMessageSize))
 
-	// Now that we have the port, go ahead and create a gRPC client connection to it.
-	conn, err := grpc.Dial("127.0.0.1:"+port, grpc.WithInsecure(), grpc.WithUnaryInterceptor(
+	// Now that we have the address, go ahead and create a gRPC client connection to it.
+	conn, err := grpc.Dial(addr, grpc.WithInsecure(), rpcutil.WithLocalDialer(), grpc.WithUnaryInterceptor(
 		rpcutil.OpenTracingClientInterceptor(),
 	), messageSizeOpts)
 	if err != nil {
@@ -227,6 +233,7 @@ func newPlugin(ctx *Context, pwd, bin, prefix string, args []string) (*plugin, e
 	}
 
 	// Done; store the connection and return the plugin info.
+	plug.Addr = addr
 	plug.Conn = conn
 	return plug, nil
 }
@@ -287,6 +294,11 @@ func (p *plugin) Close() error {
 		result = multierror.Append(result, err)
 	}
 
+	// The plugin had no chance to remove its socket, if it was listening on one, so do it on its behalf.
+	if err := rpcutil.RemoveLocalSocket(p.Addr); err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	// Wait for stdout and stderr to drain.
 	if p.stdoutDone != nil {
 		<-p.stdoutDone
@@ -51,8 +51,8 @@ func Main(name string, provMaker func(*HostClient) (pulumirpc.ResourceProviderSe
 		return errors.Errorf("fatal: could not connect to host RPC: %v", err)
 	}
 
-	// Fire up a gRPC server, letting the kernel choose a free port for us.
-	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+	// Fire up a gRPC server, letting the kernel choose a free port or socket for us.
+	addr, done, err := rpcutil.ServeLocal(nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
 			prov, proverr := provMaker(host)
 			if proverr != nil {
@@ -61,13 +61,13 @@ func Main(name string, provMaker func(*HostClient) (pulumirpc.ResourceProviderSe
 			pulumirpc.RegisterResourceProviderServer(srv, prov)
 			return nil
 		},
-	}, nil)
+	}, nil, true /*pipes*/)
 	if err != nil {
 		return errors.Errorf("fatal: %v", err)
 	}
 
-	// The resource provider protocol requires that we now write out the port we have chosen to listen on.
-	fmt.Printf("%d\n", port)
+	// The resource provider protocol requires that we now write out the port or socket we have chosen to listen on.
+	fmt.Printf("%s\n", rpcutil.HandshakeAddress(addr))
 
 	// Finally, wait for the server to stop serving.
 	if err := <-done; err != nil {
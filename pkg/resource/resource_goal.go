@@ -37,6 +37,7 @@ type Goal struct {
 	Aliases                 []URN                 // additional URNs that should be aliased to this resource.
 	ID                      ID                    // the expected ID of the resource, if any.
 	CustomTimeouts          CustomTimeouts        // an optional config object for resource options
+	Hooks                   Hooks                 // the hooks to run around the resource's operations.
 }
 
 // NewGoal allocates a new resource goal state.
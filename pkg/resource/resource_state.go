@@ -42,6 +42,8 @@ type State struct {
 	AdditionalSecretOutputs []PropertyKey         // an additional set of outputs that should be treated as secrets.
 	Aliases                 []URN                 // TODO
 	CustomTimeouts          CustomTimeouts        // A config block that will be used to configure timeouts for CRUD operations
+	InputHash               string                // a hash of the inputs and provider that produced the resource's inputs.
+	Hooks                   Hooks                 // the hooks to run around the resource's operations.
 }
 
 // NewState creates a new resource value from existing resource state information.
@@ -57,6 +57,19 @@ var (
 
 // SerializeDeployment serializes an entire snapshot as a deploy record.
 func SerializeDeployment(snap *deploy.Snapshot, sm secrets.Manager) (*apitype.DeploymentV3, error) {
+	return serializeDeployment(snap, sm, false)
+}
+
+// SerializeDeploymentWithPlaintextSecrets serializes an entire snapshot as a deploy record, writing secret values in
+// plaintext rather than encrypting them.  The secrets manager is still recorded, so that the secrets can be encrypted
+// again when the deployment is imported.
+func SerializeDeploymentWithPlaintextSecrets(snap *deploy.Snapshot,
+	sm secrets.Manager) (*apitype.DeploymentV3, error) {
+
+	return serializeDeployment(snap, sm, true)
+}
+
+func serializeDeployment(snap *deploy.Snapshot, sm secrets.Manager, showSecrets bool) (*apitype.DeploymentV3, error) {
 	contract.Require(snap != nil, "snap")
 
 	// Capture the version information into a manifest.
@@ -84,13 +97,16 @@ func SerializeDeployment(snap *deploy.Snapshot, sm secrets.Manager) (*apitype.De
 	}
 
 	var enc config.Encrypter
-	if sm != nil {
+	switch {
+	case showSecrets:
+		enc = plaintextEncrypter{}
+	case sm != nil:
 		e, err := sm.Encrypter()
 		if err != nil {
 			return nil, errors.Wrap(err, "getting encrypter for deployment")
 		}
 		enc = e
-	} else {
+	default:
 		enc = config.NewPanicCrypter()
 	}
 
@@ -286,11 +302,15 @@ func SerializeResource(res *resource.State, enc config.Encrypter) (apitype.Resou
 		PendingReplacement:      res.PendingReplacement,
 		AdditionalSecretOutputs: res.AdditionalSecretOutputs,
 		Aliases:                 res.Aliases,
+		InputHash:               res.InputHash,
 	}
 
 	if res.CustomTimeouts.IsNotEmpty() {
 		v3Resource.CustomTimeouts = &res.CustomTimeouts
 	}
+	if res.Hooks.IsNotEmpty() {
+		v3Resource.Hooks = &res.Hooks
+	}
 
 	return v3Resource, nil
 }
@@ -354,9 +374,9 @@ func SerializePropertyValue(prop resource.PropertyValue, enc config.Encrypter) (
 
 	// For assets, we need to serialize them a little carefully, so we can recover them afterwards.
 	if prop.IsAsset() {
-		return prop.AssetValue().Serialize(), nil
+		return prop.AssetValue().Redacted().Serialize(), nil
 	} else if prop.IsArchive() {
-		return prop.ArchiveValue().Serialize(), nil
+		return prop.ArchiveValue().Redacted().Serialize(), nil
 	}
 
 	if prop.IsSecret() {
@@ -372,6 +392,12 @@ func SerializePropertyValue(prop resource.PropertyValue, enc config.Encrypter) (
 			return nil, errors.Wrap(err, "encoding serialized property value")
 		}
 		plaintext := string(bytes)
+		if _, ok := enc.(plaintextEncrypter); ok {
+			return apitype.SecretV1{
+				Sig:       resource.SecretSig,
+				Plaintext: plaintext,
+			}, nil
+		}
 
 		// If the encrypter is a cachingCrypter, call through its encryptSecret method, which will look for a matching
 		// *resource.Secret + plaintext in its cache in order to avoid re-encrypting the value.
@@ -407,10 +433,15 @@ func DeserializeResource(res apitype.ResourceV3, dec config.Decrypter) (*resourc
 		return nil, err
 	}
 
-	return resource.NewState(
+	state := resource.NewState(
 		res.Type, res.URN, res.Custom, res.Delete, res.ID,
 		inputs, outputs, res.Parent, res.Protect, res.External, res.Dependencies, res.InitErrors, res.Provider,
-		res.PropertyDependencies, res.PendingReplacement, res.AdditionalSecretOutputs, res.Aliases, res.CustomTimeouts), nil
+		res.PropertyDependencies, res.PendingReplacement, res.AdditionalSecretOutputs, res.Aliases, res.CustomTimeouts)
+	state.InputHash = res.InputHash
+	if res.Hooks != nil {
+		state.Hooks = *res.Hooks
+	}
+	return state, nil
 }
 
 func DeserializeOperation(op apitype.OperationV2, dec config.Decrypter) (resource.Operation, error) {
@@ -482,15 +513,18 @@ func DeserializePropertyValue(v interface{}, dec config.Decrypter) (resource.Pro
 					contract.Assert(isarchive)
 					return resource.NewArchiveProperty(archive), nil
 				case resource.SecretSig:
-					ciphertext, ok := objmap["ciphertext"].(string)
-					if !ok {
-						return resource.PropertyValue{}, errors.New("malformed secret value: missing ciphertext")
+					// Secrets in deployments exported with their secrets decrypted carry their plaintext instead.
+					ciphertext, hasCiphertext := objmap["ciphertext"].(string)
+					plaintext, hasPlaintext := objmap["plaintext"].(string)
+					if !hasPlaintext {
+						if !hasCiphertext {
+							return resource.PropertyValue{}, errors.New("malformed secret value: missing ciphertext")
+						}
+						if plaintext, err = dec.DecryptValue(ciphertext); err != nil {
+							return resource.PropertyValue{}, errors.Wrap(err, "decrypting secret value")
+						}
 					}
 					var elem interface{}
-					plaintext, err := dec.DecryptValue(ciphertext)
-					if err != nil {
-						return resource.PropertyValue{}, errors.Wrap(err, "decrypting secret value")
-					}
 					if err := json.Unmarshal([]byte(plaintext), &elem); err != nil {
 						return resource.PropertyValue{}, err
 					}
@@ -501,7 +535,7 @@ func DeserializePropertyValue(v interface{}, dec config.Decrypter) (resource.Pro
 					prop := resource.MakeSecret(ev)
 					// If the decrypter is a cachingCrypter, insert the plain- and ciphertext into the cache with the
 					// new *resource.Secret as the key.
-					if cachingCrypter, ok := dec.(*cachingCrypter); ok {
+					if cachingCrypter, ok := dec.(*cachingCrypter); ok && !hasPlaintext {
 						cachingCrypter.insert(prop.SecretValue(), plaintext, ciphertext)
 					}
 					return prop, nil
@@ -519,3 +553,11 @@ func DeserializePropertyValue(v interface{}, dec config.Decrypter) (resource.Pro
 
 	return resource.NewNullProperty(), nil
 }
+
+// plaintextEncrypter is the encrypter used to serialize deployments whose secrets are written in plaintext.  It is
+// never asked to encrypt anything: SerializePropertyValue recognizes it and records the plaintext directly.
+type plaintextEncrypter struct{}
+
+func (plaintextEncrypter) EncryptValue(plaintext string) (string, error) {
+	return "", errors.New("secrets are not encrypted in this deployment")
+}
@@ -173,6 +173,10 @@ type ProgramTestOptions struct {
 	// ExpectRefreshChanges may be set to true if a test is expected to have changes yielded by an immediate refresh.
 	// This could occur, for example, is a resource's state is constantly changing outside of Pulumi (e.g., timestamps).
 	ExpectRefreshChanges bool
+	// CheckIdempotency may be set to true to run an additional preview after each successful update, failing the test
+	// if the preview proposes any changes.  This catches resources and programs that are not idempotent, even for
+	// tests that are run in quick mode.  The check is skipped if AllowEmptyPreviewChanges is true.
+	CheckIdempotency bool
 	// RetryFailedSteps indicates that failed updates, refreshes, and destroys should be retried after a brief
 	// intermission. A maximum of 3 retries will be attempted.
 	RetryFailedSteps bool
@@ -357,6 +361,9 @@ func (opts ProgramTestOptions) With(overrides ProgramTestOptions) ProgramTestOpt
 	if overrides.ExpectRefreshChanges {
 		opts.ExpectRefreshChanges = overrides.ExpectRefreshChanges
 	}
+	if overrides.CheckIdempotency {
+		opts.CheckIdempotency = overrides.CheckIdempotency
+	}
 	if overrides.SkipRefresh {
 		opts.SkipRefresh = overrides.SkipRefresh
 	}
@@ -508,6 +515,7 @@ func GetLogs(
 //   pulumi config set --secret <each opts.Secrets>
 //   pulumi preview
 //   pulumi up
+//   (~) pulumi preview (expected to be empty)
 //   pulumi stack export --file stack.json
 //   pulumi stack import --file stack.json
 //   pulumi preview (expected to be empty)
@@ -517,6 +525,7 @@ func GetLogs(
 //
 //   (*) Only if PULUMI_ACCESS_TOKEN is set.
 //   (+) Only if `opts.RunBuild` is true.
+//   (~) Only if `opts.CheckIdempotency` is true.
 //
 // All commands must return success return codes for the test to succeed, unless ExpectFailure is true.
 func ProgramTest(t *testing.T, opts *ProgramTestOptions) {
@@ -1120,6 +1129,21 @@ func (pt *programTester) previewAndUpdate(dir string, name string, shouldFail, e
 		return errors.New("expected this step to fail, but it succeeded")
 	}
 
+	// If requested, make sure that a subsequent preview does not propose any changes.  If the update itself was
+	// expected to be a no-op, it has already checked this.
+	if pt.opts.CheckIdempotency && !pt.opts.AllowEmptyPreviewChanges && !expectNopUpdate {
+		idempotency := []string{"preview", "--non-interactive", "--expect-no-changes"}
+		if pt.opts.GetDebugUpdates() {
+			idempotency = append(idempotency, "-d")
+		}
+		if pt.opts.PreviewCommandlineFlags != nil {
+			idempotency = append(idempotency, pt.opts.PreviewCommandlineFlags...)
+		}
+		if err := pt.runPulumiCommand("pulumi-preview-"+name+"-idempotency", idempotency, dir, false); err != nil {
+			return errors.Wrap(err, "a preview after the update proposed changes; the program is not idempotent")
+		}
+	}
+
 	return nil
 }
 
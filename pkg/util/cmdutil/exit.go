@@ -28,6 +28,80 @@ import (
 	"github.com/pulumi/pulumi/pkg/util/result"
 )
 
+// The exit codes of failed commands, which tell automation why a command failed.  They are part of the CLI's
+// interface, so existing codes must not change.
+const (
+	// ExitCodeError is the exit code of failures that are not described by another code.
+	ExitCodeError = -1
+	// ExitCodeChanges is the exit code of a command run with --expect-no-changes that found changes.
+	ExitCodeChanges = 2
+	// ExitCodeUpdateFailed is the exit code of an update, refresh or destroy that failed after it had begun operating
+	// on the stack's resources, so that it may have changed some of them.
+	ExitCodeUpdateFailed = 3
+	// ExitCodeConflict is the exit code of a command that failed because another update of its stack is in progress.
+	ExitCodeConflict = 4
+	// ExitCodeAuthentication is the exit code of a command that failed because the user is not logged in, or their
+	// credentials are invalid.
+	ExitCodeAuthentication = 5
+)
+
+// ExitCoder is implemented by errors and results that determine the exit code of the command that fails with them.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+type exitCodeError struct {
+	error
+	code int
+}
+
+func (e exitCodeError) Cause() error  { return e.error }
+func (e exitCodeError) ExitCode() int { return e.code }
+
+// ErrorWithExitCode returns an error that fails the command that returns it with the given exit code.
+func ErrorWithExitCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return exitCodeError{error: err, code: code}
+}
+
+type exitCodeResult struct {
+	result.Result
+	code int
+}
+
+func (r exitCodeResult) ExitCode() int { return r.code }
+
+// ResultWithExitCode returns a result that fails the command that returns it with the given exit code, unless its
+// error determines a code of its own.  This allows a failure, including a bail, to be classified by the code that
+// knows what failed.
+func ResultWithExitCode(res result.Result, code int) result.Result {
+	if res == nil {
+		return nil
+	}
+	return exitCodeResult{Result: res, code: code}
+}
+
+// exitCode returns the exit code of a command that failed with the given result: that of the first error in the
+// result's chain of causes that determines one, or else that of the result itself, or else ExitCodeError.
+func exitCode(res result.Result) int {
+	for err := res.Error(); err != nil; {
+		if coder, ok := err.(ExitCoder); ok {
+			return coder.ExitCode()
+		}
+		causer, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = causer.Cause()
+	}
+	if coder, ok := res.(ExitCoder); ok {
+		return coder.ExitCode()
+	}
+	return ExitCodeError
+}
+
 // DetailedError extracts a detailed error message, including stack trace, if there is one.
 func DetailedError(err error) string {
 	msg := errorMessage(err)
@@ -120,7 +194,7 @@ func RunResultFunc(run func(cmd *cobra.Command, args []string) result.Result) fu
 			// to quit at this point (with an error code so no one thinks we succeeded).  Bailing
 			// always indicates a failure, just one we don't need to print a message for.
 			if res.IsBail() {
-				os.Exit(-1)
+				os.Exit(exitCode(res))
 				return
 			}
 
@@ -135,7 +209,9 @@ func RunResultFunc(run func(cmd *cobra.Command, args []string) result.Result) fu
 				logging.V(3).Infof(DetailedError(err))
 			}
 
-			ExitError(msg)
+			// Escape percent sign before passing the message as a format string (e.g., msg could contain %PATH% on
+			// Windows).
+			exitErrorCodef(exitCode(res), strings.Replace(msg, "%", "%%", -1))
 		}
 	}
 }
@@ -0,0 +1,45 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func TestExitCode(t *testing.T) {
+	err := errors.New("failed")
+	assert.Equal(t, ExitCodeError, exitCode(result.FromError(err)))
+	assert.Equal(t, ExitCodeError, exitCode(result.Bail()))
+	assert.Nil(t, ErrorWithExitCode(nil, ExitCodeChanges))
+	assert.Nil(t, ResultWithExitCode(nil, ExitCodeUpdateFailed))
+
+	// Codes are found through wrapped errors.
+	authErr := ErrorWithExitCode(err, ExitCodeAuthentication)
+	assert.Equal(t, "failed", authErr.Error())
+	assert.Equal(t, ExitCodeAuthentication, exitCode(result.FromError(authErr)))
+	assert.Equal(t, ExitCodeAuthentication, exitCode(result.FromError(errors.Wrap(authErr, "logging in"))))
+
+	// A result's code applies to bails, and to errors that do not have a code of their own.
+	assert.Equal(t, ExitCodeUpdateFailed, exitCode(ResultWithExitCode(result.Bail(), ExitCodeUpdateFailed)))
+	assert.Equal(t, ExitCodeUpdateFailed, exitCode(ResultWithExitCode(result.FromError(err), ExitCodeUpdateFailed)))
+	assert.Equal(t, ExitCodeAuthentication,
+		exitCode(ResultWithExitCode(result.FromError(authErr), ExitCodeUpdateFailed)))
+	assert.True(t, ResultWithExitCode(result.Bail(), ExitCodeUpdateFailed).IsBail())
+}
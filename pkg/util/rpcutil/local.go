@@ -0,0 +1,137 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcutil
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// UnixSocketsEnvVar is the name of an environment variable that, if true, causes plugins to serve RPCs on Unix domain
+// sockets (or named pipes on Windows) rather than on TCP ports of the loopback interface.  This avoids exhausting
+// ports, and prompts from firewalls, when many updates run concurrently on one machine.  A plugin tells the engine the
+// address of its socket when it starts, so only engines that understand it are ever asked to dial one; plugins that
+// predate the variable keep using TCP ports.  The servers that the engine hands to plugins and programs, such as the
+// resource monitor, always listen on TCP ports, since plugins and programs that predate the variable cannot dial
+// sockets.
+const UnixSocketsEnvVar = "PULUMI_RPC_UNIX_SOCKETS"
+
+const (
+	unixAddressPrefix  = "unix:"
+	npipeAddressPrefix = "npipe:"
+)
+
+// UseUnixSockets returns true if servers should listen on Unix domain sockets or named pipes.
+func UseUnixSockets() bool {
+	v, err := strconv.ParseBool(os.Getenv(UnixSocketsEnvVar))
+	return err == nil && v
+}
+
+// IsLocalSocketAddress returns true if the address is that of a Unix domain socket or named pipe, as returned by
+// ServeLocal.
+func IsLocalSocketAddress(addr string) bool {
+	return strings.HasPrefix(addr, unixAddressPrefix) || strings.HasPrefix(addr, npipeAddressPrefix)
+}
+
+// ServeLocal is like Serve, but chooses the transport: if UseUnixSockets is true, the server listens on a Unix domain
+// socket or, on Windows, a named pipe, and otherwise on a free TCP port of the loopback interface.  It returns the
+// address of the server, which is one of "unix:<path>", "npipe:<path>", or "127.0.0.1:<port>".  Clients must dial the
+// address with the WithLocalDialer option.
+//
+// Only clients written in Go can dial named pipes, so unless pipes is true, the server listens on a TCP port on
+// Windows.  Unix domain sockets are understood by the gRPC libraries of all of the language SDKs.
+func ServeLocal(cancel chan bool, registers []func(*grpc.Server) error, parentSpan opentracing.Span,
+	pipes bool) (string, chan error, error) {
+
+	if !UseUnixSockets() || (runtime.GOOS == "windows" && !pipes) {
+		port, done, err := Serve(0, cancel, registers, parentSpan)
+		if err != nil {
+			return "", nil, err
+		}
+		return "127.0.0.1:" + strconv.Itoa(port), done, nil
+	}
+
+	lis, addr, cleanup, err := listenLocal()
+	if err != nil {
+		return "", nil, errors.Errorf("failed to listen on a local socket: %v", err)
+	}
+	done, err := serveListener(lis, cancel, registers, parentSpan, cleanup)
+	if err != nil {
+		contract.IgnoreClose(lis)
+		cleanup()
+		return "", nil, err
+	}
+	return addr, done, nil
+}
+
+// HandshakeAddress returns what a plugin writes to stdout to tell the engine where it is listening, given the address
+// returned by ServeLocal: the port alone for TCP addresses, which is all that older engines understand, and the
+// address itself for sockets.
+func HandshakeAddress(addr string) string {
+	if IsLocalSocketAddress(addr) {
+		return addr
+	}
+	if _, port, err := net.SplitHostPort(addr); err == nil {
+		return port
+	}
+	return addr
+}
+
+// RemoveLocalSocket removes a Unix domain socket returned by ServeLocal, along with the directory created for it.
+// This is for clients of servers that may be killed before they can clean up after themselves, such as plugins.  It
+// does nothing for other addresses.
+func RemoveLocalSocket(addr string) error {
+	if !strings.HasPrefix(addr, unixAddressPrefix) {
+		return nil
+	}
+	path := strings.TrimPrefix(addr, unixAddressPrefix)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	// Remove only succeeds for empty directories, so this never removes anything but the socket's own directory.
+	if err := os.Remove(filepath.Dir(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// WithLocalDialer returns a dial option that allows a client to dial any of the addresses returned by ServeLocal.
+func WithLocalDialer() grpc.DialOption {
+	return grpc.WithContextDialer(dialLocal)
+}
+
+func dialLocal(ctx context.Context, addr string) (net.Conn, error) {
+	switch {
+	case strings.HasPrefix(addr, unixAddressPrefix):
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", strings.TrimPrefix(addr, unixAddressPrefix))
+	case strings.HasPrefix(addr, npipeAddressPrefix):
+		return dialPipe(ctx, strings.TrimPrefix(addr, npipeAddressPrefix))
+	default:
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", addr)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcutil
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandshakeAddress(t *testing.T) {
+	assert.Equal(t, "1234", HandshakeAddress("127.0.0.1:1234"))
+	assert.Equal(t, "unix:/tmp/pulumi-rpc-1/rpc.sock", HandshakeAddress("unix:/tmp/pulumi-rpc-1/rpc.sock"))
+	assert.Equal(t, `npipe:\\.\pipe\pulumi-rpc-1-1`, HandshakeAddress(`npipe:\\.\pipe\pulumi-rpc-1-1`))
+}
+
+func TestServeLocal(t *testing.T) {
+	defer os.Setenv(UnixSocketsEnvVar, os.Getenv(UnixSocketsEnvVar))
+
+	for _, sockets := range []string{"false", "true"} {
+		t.Run(sockets, func(t *testing.T) {
+			assert.NoError(t, os.Setenv(UnixSocketsEnvVar, sockets))
+
+			cancel := make(chan bool)
+			addr, done, err := ServeLocal(cancel, nil, nil, true /*pipes*/)
+			assert.NoError(t, err)
+			if sockets == "true" {
+				assert.True(t, IsLocalSocketAddress(addr))
+			} else {
+				assert.True(t, strings.HasPrefix(addr, "127.0.0.1:"))
+			}
+
+			// The server has no services, so a successful round trip ends with an Unimplemented error.
+			conn, err := grpc.Dial(addr, grpc.WithInsecure(), WithLocalDialer())
+			assert.NoError(t, err)
+			err = grpc.Invoke(context.Background(), "/pulumirpc.Test/Ping", nil, nil, conn, grpc.WaitForReady(true))
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+			assert.NoError(t, conn.Close())
+
+			close(cancel)
+			assert.NoError(t, <-done)
+			assert.NoError(t, RemoveLocalSocket(addr))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcutil
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDMetadataKey is the gRPC metadata key under which a client may send an ID that it generates for a request.
+// A client that resends the request after a failure sends the same ID again, so that the server can recognize the
+// retry.
+const RequestIDMetadataKey = "pulumi-request-id"
+
+// WithRequestID returns a context that sends the given request ID with the outgoing calls made with it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, id)
+}
+
+// RequestID returns the request ID that the client sent with an incoming call, or "" if it did not send one.
+func RequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if ids := md.Get(RequestIDMetadataKey); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
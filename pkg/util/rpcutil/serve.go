@@ -46,22 +46,35 @@ func Serve(port int, cancel chan bool, registers []func(*grpc.Server) error,
 		return port, nil, errors.Errorf("failed to listen on TCP port ':%v': %v", port, err)
 	}
 
+	// If the port was 0, look up what port the kernel chosen, by accessing the underlying TCP listener/address.
+	if port == 0 {
+		tcpl := lis.(*net.TCPListener)
+		tcpa := tcpl.Addr().(*net.TCPAddr)
+		port = tcpa.Port
+	}
+
+	done, err := serveListener(lis, cancel, registers, parentSpan, nil)
+	if err != nil {
+		return port, nil, err
+	}
+	return port, done, nil
+}
+
+// serveListener creates a new gRPC server, calls out to the supplied registration functions to bind interfaces, and
+// then serves on the supplied listener.  It returns a channel that waits until the server is finished.  If cleanup is
+// non-nil, it is called once the server has stopped.
+func serveListener(lis net.Listener, cancel chan bool, registers []func(*grpc.Server) error,
+	parentSpan opentracing.Span, cleanup func()) (chan error, error) {
+
 	// Now new up a gRPC server and register any RPC interfaces the caller wants.
 	srv := grpc.NewServer(grpc.UnaryInterceptor(OpenTracingServerInterceptor(parentSpan)))
 	for _, register := range registers {
 		if err := register(srv); err != nil {
-			return port, nil, errors.Errorf("failed to register RPC handler: %v", err)
+			return nil, errors.Errorf("failed to register RPC handler: %v", err)
 		}
 	}
 	reflection.Register(srv) // enable reflection.
 
-	// If the port was 0, look up what port the kernel chosen, by accessing the underlying TCP listener/address.
-	if port == 0 {
-		tcpl := lis.(*net.TCPListener)
-		tcpa := tcpl.Addr().(*net.TCPAddr)
-		port = tcpa.Port
-	}
-
 	// If the caller provided a cancellation channel, start a goroutine that will gracefully terminate the gRPC server when
 	// that channel is closed or receives a `true` value.
 	if cancel != nil {
@@ -76,7 +89,11 @@ func Serve(port int, cancel chan bool, registers []func(*grpc.Server) error,
 	// Finally, serve; this returns only once the server shuts down (e.g., due to a signal).
 	done := make(chan error)
 	go func() {
-		if err := srv.Serve(lis); err != nil && !IsBenignCloseErr(err) {
+		err := srv.Serve(lis)
+		if cleanup != nil {
+			cleanup()
+		}
+		if err != nil && !IsBenignCloseErr(err) {
 			done <- errors.Errorf("stopped serving: %v", err)
 		} else {
 			done <- nil // send a signal so caller knows we're done, even though it's nil.
@@ -84,5 +101,5 @@ func Serve(port int, cancel chan bool, registers []func(*grpc.Server) error,
 		close(done)
 	}()
 
-	return port, done, nil
+	return done, nil
 }
@@ -0,0 +1,51 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package rpcutil
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// listenLocal listens on a Unix domain socket in a new temporary directory, which only the current user may access.
+// It returns the listener, its address, and a function that removes the directory once the listener is closed.
+func listenLocal() (net.Listener, string, func(), error) {
+	dir, err := ioutil.TempDir("", "pulumi-rpc-")
+	if err != nil {
+		return nil, "", nil, err
+	}
+	cleanup := func() { contract.IgnoreError(os.RemoveAll(dir)) }
+
+	path := filepath.Join(dir, "rpc.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		cleanup()
+		return nil, "", nil, err
+	}
+	return lis, unixAddressPrefix + path, cleanup, nil
+}
+
+func dialPipe(ctx context.Context, path string) (net.Conn, error) {
+	return nil, errors.Errorf("cannot dial named pipe %s: named pipes are only supported on Windows", path)
+}
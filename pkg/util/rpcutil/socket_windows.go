@@ -0,0 +1,45 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build windows
+
+package rpcutil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+
+	winio "github.com/Microsoft/go-winio"
+)
+
+// nextPipeID makes the names of the named pipes created by this process unique.
+var nextPipeID int32
+
+// listenLocal listens on a new named pipe.  It returns the listener, its address, and a function to call once the
+// listener is closed, which has nothing to do because named pipes disappear with their listeners.
+func listenLocal() (net.Listener, string, func(), error) {
+	path := fmt.Sprintf(`\\.\pipe\pulumi-rpc-%d-%d`, os.Getpid(), atomic.AddInt32(&nextPipeID, 1))
+	lis, err := winio.ListenPipe(path, nil)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return lis, npipeAddressPrefix + path, func() {}, nil
+}
+
+func dialPipe(ctx context.Context, path string) (net.Conn, error) {
+	return winio.DialPipeContext(ctx, path)
+}
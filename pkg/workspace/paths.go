@@ -49,6 +49,8 @@ const (
 	TemplateDir = "templates"
 	// TemplatePolicyDir is the name of the directory containing templates for Policy Packs.
 	TemplatePolicyDir = "templates-policy"
+	// UpdateDir is the name of the directory that records the updates that are running on stacks.
+	UpdateDir = "updates"
 	// WorkspaceDir is the name of the directory that holds workspace information for projects.
 	WorkspaceDir = "workspaces"
 
@@ -89,15 +91,34 @@ func DetectProjectPath() (string, error) {
 }
 
 // DetectProjectStackPath returns the name of the file to store stack specific project settings in. We place stack
-// specific settings next to the Pulumi.yaml file, named like: Pulumi.<stack-name>.yaml
+// specific settings next to the Pulumi.yaml file, named like: Pulumi.<stack-name>.yaml.  An existing settings file in
+// any other supported format, such as Pulumi.<stack-name>.json, is used instead if there is one.
 func DetectProjectStackPath(stackName tokens.QName) (string, error) {
 	proj, projPath, err := DetectProjectAndPath()
 	if err != nil {
 		return "", err
 	}
 
-	return filepath.Join(filepath.Dir(projPath), proj.Config, fmt.Sprintf("%s.%s%s", ProjectFile, qnameFileName(stackName),
-		filepath.Ext(projPath))), nil
+	return ProjectStackPath(proj, projPath, stackName), nil
+}
+
+// ProjectStackPath returns the name of the file that stores stack specific settings for the project at projPath.  If
+// no settings file exists yet, the path uses the same format as the project file.
+func ProjectStackPath(proj *Project, projPath string, stackName tokens.QName) string {
+	dir := filepath.Join(filepath.Dir(projPath), proj.Config)
+	base := fmt.Sprintf("%s.%s", ProjectFile, qnameFileName(stackName))
+	for _, ext := range append([]string{filepath.Ext(projPath)}, encoding.Exts...) {
+		if path := filepath.Join(dir, base+ext); fileExists(path) {
+			return path
+		}
+	}
+	return filepath.Join(dir, base+filepath.Ext(projPath))
+}
+
+// fileExists returns true if path names an existing file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
 }
 
 // DetectProjectPathFrom locates the closest project from the given path, searching "upwards" in the directory
@@ -15,7 +15,10 @@
 package workspace
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -26,6 +29,7 @@ import (
 	"regexp"
 	"runtime"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/util/archive"
@@ -44,6 +48,10 @@ import (
 
 const (
 	windowsGOOS = "windows"
+
+	// PluginDownloadURLEnvVar is the name of an environment variable that, if set, overrides the server from which
+	// plugins that do not name a server of their own are downloaded, e.g. to use a mirror.
+	PluginDownloadURLEnvVar = "PULUMI_PLUGIN_DOWNLOAD_URL"
 )
 
 var (
@@ -171,48 +179,135 @@ func (info *PluginInfo) SetFileMetadata(path string) error {
 
 // Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
 func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
+	endpoint, err := info.downloadURL()
+	if err != nil {
+		return nil, -1, err
+	}
+	resp, err := getPluginFile(endpoint)
+	if err != nil {
+		return nil, -1, err
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// DownloadChecksum fetches the SHA256 checksum of the plugin's tarball, which is published alongside the tarball on
+// the plugin's server (or its mirror) in a file with the extension ".sha256", in the format written by sha256sum.  Not
+// every server publishes checksums, so if the server does not have one, the empty string is returned.
+func (info PluginInfo) DownloadChecksum() (string, error) {
+	endpoint, err := info.downloadURL()
+	if err != nil {
+		return "", err
+	}
+	resp, err := requestPluginFile(endpoint + ".sha256")
+	if err != nil {
+		return "", err
+	}
+	defer contract.IgnoreClose(resp.Body)
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", errors.Errorf("%d HTTP error fetching plugin checksum from %s.sha256", resp.StatusCode, endpoint)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 || !sha256Regexp.MatchString(fields[0]) {
+		return "", errors.Errorf("malformed checksum for plugin %s-%s", info.Name, info.Version)
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+var sha256Regexp = regexp.MustCompile("^[0-9a-fA-F]{64}$")
+
+// downloadURL returns the URL of the plugin's tarball for the current OS and architecture.
+func (info PluginInfo) downloadURL() (string, error) {
 	// Figure out the OS/ARCH pair for the download URL.
 	var os string
 	switch runtime.GOOS {
 	case "darwin", "linux", "windows":
 		os = runtime.GOOS
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
+		return "", errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
 	}
 	var arch string
 	switch runtime.GOARCH {
 	case "amd64":
 		arch = runtime.GOARCH
 	default:
-		return nil, -1, errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
+		return "", errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
 	}
 
 	// If the plugin has a server, associated with it, download from there.  Otherwise use the "default" location, which
-	// is hosted by Pulumi.
+	// is hosted by Pulumi unless a mirror has been configured.
 	serverURL := info.ServerURL
 	if serverURL == "" {
-		serverURL = "https://api.pulumi.com/releases/plugins"
+		serverURL = defaultPluginServerURL()
 	}
 
-	endpoint := fmt.Sprintf("%s/pulumi-%s-%s-v%s-%s-%s.tar.gz", serverURL, info.Kind, info.Name, info.Version, os, arch)
+	return fmt.Sprintf("%s/pulumi-%s-%s-v%s-%s-%s.tar.gz", serverURL, info.Kind, info.Name, info.Version, os, arch), nil
+}
+
+// getPluginFile issues a GET request for a file on a plugin server, failing if the server does not return it.
+func getPluginFile(endpoint string) (*http.Response, error) {
+	resp, err := requestPluginFile(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		contract.IgnoreClose(resp.Body)
+		return nil, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
+	}
+	return resp, nil
+}
+
+// requestPluginFile issues a GET request for a file on a plugin server, returning the server's response whatever its
+// status.
+func requestPluginFile(endpoint string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", endpoint, nil)
 	if err != nil {
-		return nil, -1, err
+		return nil, err
 	}
 
 	userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
 	req.Header.Set("User-Agent", userAgent)
 
-	resp, err := httputil.DoWithRetry(req, http.DefaultClient)
-	if err != nil {
-		return nil, -1, err
-	}
+	return httputil.DoWithRetry(req, http.DefaultClient)
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, -1, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
+// NewChecksumVerifier wraps a plugin tarball so that reading it to the end fails if the contents do not have the
+// given SHA256 checksum.
+func NewChecksumVerifier(tarball io.ReadCloser, checksum string) io.ReadCloser {
+	return &checksumVerifier{ReadCloser: tarball, hash: sha256.New(), expected: strings.ToLower(checksum)}
+}
+
+type checksumVerifier struct {
+	io.ReadCloser
+	hash     hash.Hash
+	expected string
+}
+
+func (v *checksumVerifier) Read(p []byte) (int, error) {
+	n, err := v.ReadCloser.Read(p)
+	_, hashErr := v.hash.Write(p[:n])
+	contract.IgnoreError(hashErr)
+	if err == io.EOF {
+		if actual := hex.EncodeToString(v.hash.Sum(nil)); actual != v.expected {
+			return n, errors.Errorf("plugin checksum mismatch: expected %s, got %s", v.expected, actual)
+		}
 	}
+	return n, err
+}
 
-	return resp.Body, resp.ContentLength, nil
+// defaultPluginServerURL returns the server from which plugins that do not name a server are downloaded.
+func defaultPluginServerURL() string {
+	if url := os.Getenv(PluginDownloadURLEnvVar); url != "" {
+		return strings.TrimSuffix(url, "/")
+	}
+	return "https://api.pulumi.com/releases/plugins"
 }
 
 // Install installs a plugin's tarball into the cache.  It validates that plugin names are in the expected format.
@@ -258,7 +353,7 @@ func (info PluginInfo) Install(tarball io.ReadCloser) error {
 	// If two calls to `plugin install` for the same plugin are racing, the second one will be unable to rename
 	// the directory. That's OK, just ignore the error. The temp directory created as part of the install will be
 	// cleaned up when we exit by the defer above.
-	fmt.Print("Moving plugin...")
+	fmt.Fprint(os.Stderr, "Moving plugin...")
 	if err := os.Rename(tempDir, finalDir); err != nil && !os.IsExist(err) {
 		switch err.(type) {
 		case *os.LinkError:
@@ -271,7 +366,7 @@ func (info PluginInfo) Install(tarball io.ReadCloser) error {
 			return errors.Wrap(err, "moving plugin")
 		}
 	}
-	fmt.Println(" done.")
+	fmt.Fprintln(os.Stderr, " done.")
 
 	return nil
 }
@@ -591,7 +686,8 @@ func SelectCompatiblePlugin(
 	return bestMatch, nil
 }
 
-// ReadCloserProgressBar displays a progress bar for the given closer and returns a wrapper closer to manipulate it.
+// ReadCloserProgressBar displays a progress bar on stderr for the given closer and returns a wrapper closer to
+// manipulate it.
 func ReadCloserProgressBar(
 	closer io.ReadCloser, size int64, message string, colorization colors.Colorization) io.ReadCloser {
 	if size == -1 {
@@ -604,6 +700,7 @@ func ReadCloserProgressBar(
 	bar.Postfix(colorization.Colorize(colors.Reset))
 	bar.SetMaxWidth(80)
 	bar.SetUnits(pb.U_BYTES)
+	bar.Output = os.Stderr
 	bar.Start()
 
 	return &barCloser{
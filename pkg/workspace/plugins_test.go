@@ -15,6 +15,15 @@
 package workspace
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/blang/semver"
@@ -234,3 +243,79 @@ func TestPluginSelection_EmptyVersionWithAlternatives(t *testing.T) {
 	assert.Equal(t, "myplugin", result.Name)
 	assert.Equal(t, "0.2.0", result.Version.String())
 }
+
+func TestPluginDownloadMirror(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("plugins are only published for amd64")
+	}
+
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		_, err := w.Write([]byte("tarball"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	old := os.Getenv(PluginDownloadURLEnvVar)
+	defer func() { assert.NoError(t, os.Setenv(PluginDownloadURLEnvVar, old)) }()
+	assert.NoError(t, os.Setenv(PluginDownloadURLEnvVar, server.URL+"/mirror/"))
+
+	v := semver.MustParse("1.2.3")
+	stream, _, err := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v}.Download()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { assert.NoError(t, stream.Close()) }()
+	body, err := ioutil.ReadAll(stream)
+	assert.NoError(t, err)
+	assert.Equal(t, "tarball", string(body))
+	assert.Equal(t, "/mirror/pulumi-resource-aws-v1.2.3-"+runtime.GOOS+"-amd64.tar.gz", requested)
+}
+
+func TestPluginDownloadChecksum(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("plugins are only published for amd64")
+	}
+
+	tarball := []byte("tarball")
+	sum := sha256.Sum256(tarball)
+	checksum := hex.EncodeToString(sum[:])
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/pulumi-resource-aws-") && strings.HasSuffix(r.URL.Path, ".sha256"):
+			_, err = w.Write([]byte(checksum + "  pulumi-resource-aws.tar.gz\n"))
+		case strings.HasSuffix(r.URL.Path, ".sha256"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_, err = w.Write(tarball)
+		}
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	old := os.Getenv(PluginDownloadURLEnvVar)
+	defer func() { assert.NoError(t, os.Setenv(PluginDownloadURLEnvVar, old)) }()
+	assert.NoError(t, os.Setenv(PluginDownloadURLEnvVar, server.URL))
+
+	// Published checksums are fetched from alongside the tarball.
+	v := semver.MustParse("1.2.3")
+	actual, err := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v}.DownloadChecksum()
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, actual)
+
+	// Servers need not publish checksums.
+	actual, err = PluginInfo{Kind: ResourcePlugin, Name: "gcp", Version: &v}.DownloadChecksum()
+	assert.NoError(t, err)
+	assert.Equal(t, "", actual)
+
+	// Tarballs whose contents match the checksum read successfully, and others fail.
+	body, err := ioutil.ReadAll(NewChecksumVerifier(ioutil.NopCloser(bytes.NewReader(tarball)), checksum))
+	assert.NoError(t, err)
+	assert.Equal(t, tarball, body)
+	_, err = ioutil.ReadAll(NewChecksumVerifier(ioutil.NopCloser(bytes.NewReader([]byte("evil"))), checksum))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "plugin checksum mismatch")
+	}
+}
@@ -16,9 +16,12 @@ package workspace
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -85,6 +88,7 @@ type Project struct {
 	License *string `json:"license,omitempty" yaml:"license,omitempty"`
 
 	// Config indicates where to store the Pulumi.<stack-name>.yaml files, combined with the folder Pulumi.yaml is in.
+	// All commands read and write stack settings in this directory, which is created as needed.
 	Config string `json:"config,omitempty" yaml:"config,omitempty"`
 
 	// Template is an optional template manifest, if this project is a template.
@@ -92,6 +96,29 @@ type Project struct {
 
 	// Backend is an optional backend configuration
 	Backend *ProjectBackend `json:"backend,omitempty" yaml:"backend,omitempty"`
+
+	// Naming is an optional policy for the names of resources that are not given an explicit name.  A stack may
+	// override it with its own policy.
+	Naming *NamingPolicy `json:"naming,omitempty" yaml:"naming,omitempty"`
+
+	// SecretsProvider is the optional secrets provider used by new stacks of this project that are not given one
+	// explicitly, such as "awskms://alias/${project}-${stack}?region=us-east-1".  It may refer to the project and
+	// stack as ${project} and ${stack}.
+	SecretsProvider string `json:"secretsProvider,omitempty" yaml:"secretsProvider,omitempty"`
+
+	// ConfigEnv maps configuration keys to the environment variables from which their values are read when a stack's
+	// configuration does not set them, e.g. so that CI systems can supply values without writing the stack's settings
+	// file.  Keys without a namespace, such as "dbPassword", are in the project's namespace.
+	ConfigEnv map[string]ConfigEnvVar `json:"configEnv,omitempty" yaml:"configEnv,omitempty"`
+
+	// ConfigDefaults are configuration values that all of the project's stacks inherit, unless they set the same keys
+	// themselves.  Keys without a namespace are in the project's namespace.  Secrets are encrypted per stack, so they
+	// cannot be given here.
+	ConfigDefaults map[string]config.Value `json:"configDefaults,omitempty" yaml:"configDefaults,omitempty"`
+
+	// Parallel is the optional default for the largest number of resource operations that updates of the project's
+	// stacks run at once, when --parallel is not given.
+	Parallel int `json:"parallel,omitempty" yaml:"parallel,omitempty"`
 }
 
 func (proj *Project) Validate() error {
@@ -101,10 +128,125 @@ func (proj *Project) Validate() error {
 	if proj.Runtime.Name() == "" {
 		return errors.New("project is missing a 'runtime' attribute")
 	}
+	if filepath.IsAbs(proj.Config) {
+		return errors.Errorf("project 'config' directory '%s' must be relative to the project's directory", proj.Config)
+	}
+	for k, env := range proj.ConfigEnv {
+		if _, err := proj.configKey(k); err != nil {
+			return errors.Wrapf(err, "invalid project 'configEnv' key '%s'", k)
+		}
+		if env.Env == "" {
+			return errors.Errorf("project 'configEnv' key '%s' is missing an environment variable", k)
+		}
+	}
+	for k, v := range proj.ConfigDefaults {
+		if _, err := proj.configKey(k); err != nil {
+			return errors.Wrapf(err, "invalid project 'configDefaults' key '%s'", k)
+		}
+		if v.Secure() {
+			return errors.Errorf("project 'configDefaults' key '%s' cannot be a secret; set it for each stack", k)
+		}
+	}
+	if proj.Parallel < 0 {
+		return errors.Errorf("project 'parallel' must not be negative, not %d", proj.Parallel)
+	}
 
 	return nil
 }
 
+// StackSecretsProvider returns the secrets provider for a new stack of this project that is not given one explicitly,
+// or the empty string if the project does not declare one.
+func (proj *Project) StackSecretsProvider(stack tokens.QName) (string, error) {
+	provider, err := ExpandProjectVariables(proj.SecretsProvider, proj.Name, stack)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid project 'secretsProvider'")
+	}
+	return provider, nil
+}
+
+// EnvironmentConfig returns the configuration values that are read from the environment variables in ConfigEnv.  Keys
+// whose environment variables are not set are omitted.  Values that are marked as secrets are encrypted with the
+// given encrypter, which is not used otherwise.
+func (proj *Project) EnvironmentConfig(encrypter config.Encrypter) (config.Map, error) {
+	result := make(config.Map)
+	for k, env := range proj.ConfigEnv {
+		key, err := proj.configKey(k)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid project 'configEnv' key '%s'", k)
+		}
+		v, ok := os.LookupEnv(env.Env)
+		if !ok {
+			continue
+		}
+		if !env.Secret {
+			result[key] = config.NewValue(v)
+			continue
+		}
+		ciphertext, err := encrypter.EncryptValue(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "encrypting the value of '%s' from $%s", key, env.Env)
+		}
+		result[key] = config.NewSecureValue(ciphertext)
+	}
+	return result, nil
+}
+
+// HasSecretConfigEnv returns true if any of the configuration values read from the environment are secrets.
+func (proj *Project) HasSecretConfigEnv() bool {
+	for _, env := range proj.ConfigEnv {
+		if env.Secret {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultConfig returns the configuration values in ConfigDefaults.
+func (proj *Project) DefaultConfig() (config.Map, error) {
+	result := make(config.Map)
+	for k, v := range proj.ConfigDefaults {
+		key, err := proj.configKey(k)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid project 'configDefaults' key '%s'", k)
+		}
+		result[key] = v
+	}
+	return result, nil
+}
+
+// configKey parses a configuration key of the project, treating keys without a namespace as if they are in the
+// project's.
+func (proj *Project) configKey(k string) (config.Key, error) {
+	if !strings.Contains(k, tokens.TokenDelimiter) {
+		k = fmt.Sprintf("%s:%s", proj.Name, k)
+	}
+	return config.ParseKey(k)
+}
+
+// projectVariableRegexp matches references to variables such as ${project}.
+var projectVariableRegexp = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// ExpandProjectVariables replaces each reference to ${project} or ${stack} in s with the name of the given project or
+// stack.  It is an error for s to refer to any other variable.
+func ExpandProjectVariables(s string, project tokens.PackageName, stack tokens.QName) (string, error) {
+	var unknown string
+	result := projectVariableRegexp.ReplaceAllStringFunc(s, func(v string) string {
+		switch name := v[2 : len(v)-1]; name {
+		case "project":
+			return string(project)
+		case "stack":
+			return string(stack)
+		default:
+			unknown = name
+			return v
+		}
+	})
+	if unknown != "" {
+		return "", errors.Errorf("unknown variable '${%s}'; only ${project} and ${stack} may be used", unknown)
+	}
+	return result, nil
+}
+
 // TrustResourceDependencies returns whether or not this project's runtime can be trusted to accurately report
 // dependencies. All languages supported by Pulumi today do this correctly. This option remains useful when bringing
 // up new Pulumi languages.
@@ -167,6 +309,133 @@ type ProjectStack struct {
 	EncryptionSalt string `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`
 	// Config is an optional config bag.
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
+	// DependsOn is an optional list of stacks whose outputs this stack consumes.
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	// Dependents is an optional list of stacks that consume this stack's outputs.
+	Dependents []StackDependent `json:"dependents,omitempty" yaml:"dependents,omitempty"`
+	// Guardrails is an optional set of limits on the resources an update may delete or replace.
+	Guardrails *StackGuardrails `json:"guardrails,omitempty" yaml:"guardrails,omitempty"`
+	// Timeouts optionally maps resource type patterns, such as "aws:rds/*", to the operation timeouts to use for
+	// matching resources that do not specify their own.
+	Timeouts map[string]StackTimeouts `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
+	// ProviderParallel optionally maps provider packages, such as "aws", to the largest number of resource
+	// operations that may run against them at once, independent of the overall degree of parallelism.
+	ProviderParallel map[string]int `json:"providerParallel,omitempty" yaml:"providerParallel,omitempty"`
+	// Naming is an optional policy for the names of resources that are not given an explicit name.  If set, it
+	// replaces the project's policy.
+	Naming *NamingPolicy `json:"naming,omitempty" yaml:"naming,omitempty"`
+}
+
+// ConfigEnvVar names the environment variable from which a configuration value is read.  In a project file, it is
+// either the name of the variable or an object with env and secret attributes.
+type ConfigEnvVar struct {
+	// Env is the name of the environment variable.
+	Env string
+	// Secret is true if the value is a secret, and so is encrypted like other secret configuration values.
+	Secret bool
+}
+
+func (env ConfigEnvVar) MarshalYAML() (interface{}, error) {
+	if !env.Secret {
+		return env.Env, nil
+	}
+
+	return map[string]interface{}{
+		"env":    env.Env,
+		"secret": env.Secret,
+	}, nil
+}
+
+func (env ConfigEnvVar) MarshalJSON() ([]byte, error) {
+	if !env.Secret {
+		return json.Marshal(env.Env)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"env":    env.Env,
+		"secret": env.Secret,
+	})
+}
+
+func (env *ConfigEnvVar) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &env.Env); err == nil {
+		return nil
+	}
+
+	var payload struct {
+		Env    string `json:"env"`
+		Secret bool   `json:"secret"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err == nil {
+		env.Env = payload.Env
+		env.Secret = payload.Secret
+		return nil
+	}
+
+	return errors.New("configEnv values must be a string or an object with env and secret attributes")
+}
+
+func (env *ConfigEnvVar) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&env.Env); err == nil {
+		return nil
+	}
+
+	var payload struct {
+		Env    string `yaml:"env"`
+		Secret bool   `yaml:"secret"`
+	}
+
+	if err := unmarshal(&payload); err == nil {
+		env.Env = payload.Env
+		env.Secret = payload.Secret
+		return nil
+	}
+
+	return errors.New("configEnv values must be a string or an object with env and secret attributes")
+}
+
+// NamingPolicy describes the names that the engine gives to resources whose programs do not set an explicit name.
+// Such a resource is named by the policy's prefix, followed by its logical name and, optionally, a hyphen and a random
+// suffix.
+type NamingPolicy struct {
+	// Prefix is prepended to each name.  It may refer to the project and stack as ${project} and ${stack}.
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// SuffixLength is the number of random characters appended to each name.
+	SuffixLength int `json:"suffixLength,omitempty" yaml:"suffixLength,omitempty"`
+	// Charset is the set of characters from which the random suffix is chosen.  It defaults to lowercase letters
+	// and digits.
+	Charset string `json:"charset,omitempty" yaml:"charset,omitempty"`
+	// Property is the name of the input property that holds the name of a resource.  It defaults to "name".
+	Property string `json:"property,omitempty" yaml:"property,omitempty"`
+	// Types are the patterns, such as "aws:s3/*", of the resource types to which the policy applies.  At least one
+	// is required, as the policy adds its property to every resource that matches.
+	Types []string `json:"types,omitempty" yaml:"types,omitempty"`
+}
+
+// StackTimeouts are the default operation timeouts for a set of resources, expressed as durations such as "45m".
+type StackTimeouts struct {
+	Create string `json:"create,omitempty" yaml:"create,omitempty"`
+	Update string `json:"update,omitempty" yaml:"update,omitempty"`
+	Delete string `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+// StackGuardrails limit the resources an update to a stack may delete or replace without additional confirmation.
+type StackGuardrails struct {
+	// MaxDeletes, if positive, is the largest number of resources an update may delete or replace.
+	MaxDeletes int `json:"maxDeletes,omitempty" yaml:"maxDeletes,omitempty"`
+	// MaxDeletePercent, if positive, is the largest percentage of the stack's resources an update may delete or
+	// replace.
+	MaxDeletePercent float64 `json:"maxDeletePercent,omitempty" yaml:"maxDeletePercent,omitempty"`
+}
+
+// StackDependent is a stack that consumes the outputs of another stack.
+type StackDependent struct {
+	// Stack is the name of the dependent stack.  If empty, the dependent stack has the same name as the stack that
+	// declares it.
+	Stack string `json:"stack,omitempty" yaml:"stack,omitempty"`
+	// Path is the directory containing the dependent stack's project, relative to the declaring project's directory.
+	Path string `json:"path" yaml:"path"`
 }
 
 // Save writes a project definition to a file.
@@ -2,10 +2,15 @@ package workspace
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
 )
 
 func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
@@ -36,3 +41,154 @@ func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
 	doTest(yaml.Marshal, yaml.Unmarshal)
 	doTest(json.Marshal, json.Unmarshal)
 }
+
+func TestProjectStackPathJSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "project-stack")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	proj := &Project{Name: "proj"}
+	projPath := filepath.Join(dir, "Pulumi.yaml")
+
+	// Without an existing settings file, the project's format is used.
+	assert.Equal(t, filepath.Join(dir, "Pulumi.dev.yaml"), ProjectStackPath(proj, projPath, "dev"))
+
+	// An existing JSON settings file is read and written in place.
+	jsonPath := filepath.Join(dir, "Pulumi.dev.json")
+	err = ioutil.WriteFile(jsonPath, []byte(`{"config": {"proj:foo": "bar"}}`), 0600)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonPath, ProjectStackPath(proj, projPath, "dev"))
+
+	ps, err := LoadProjectStack(jsonPath)
+	assert.NoError(t, err)
+	assert.Equal(t, config.NewValue("bar"), ps.Config[config.MustMakeKey("proj", "foo")])
+
+	ps.Config[config.MustMakeKey("proj", "baz")] = config.NewValue("qux")
+	assert.NoError(t, ps.Save(jsonPath))
+	var saved map[string]interface{}
+	b, err := ioutil.ReadFile(jsonPath)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(b, &saved))
+	assert.Equal(t, map[string]interface{}{"proj:foo": "bar", "proj:baz": "qux"}, saved["config"])
+}
+
+func TestProjectStackPathConfigDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "project-stack")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	proj := &Project{Name: "proj", Runtime: NewProjectRuntimeInfo("go", nil), Config: "config"}
+	assert.NoError(t, proj.Validate())
+	projPath := filepath.Join(dir, "Pulumi.yaml")
+
+	path := ProjectStackPath(proj, projPath, "dev")
+	assert.Equal(t, filepath.Join(dir, "config", "Pulumi.dev.yaml"), path)
+
+	// Saving the settings creates the directory.
+	ps := &ProjectStack{Config: config.Map{config.MustMakeKey("proj", "foo"): config.NewValue("bar")}}
+	assert.NoError(t, ps.Save(path))
+	loaded, err := LoadProjectStack(ProjectStackPath(proj, projPath, "dev"))
+	assert.NoError(t, err)
+	assert.Equal(t, ps.Config, loaded.Config)
+
+	proj.Config = filepath.Join(dir, "config")
+	assert.Error(t, proj.Validate())
+}
+
+func TestStackSecretsProvider(t *testing.T) {
+	proj := &Project{Name: "proj"}
+	provider, err := proj.StackSecretsProvider("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "", provider)
+
+	proj.SecretsProvider = "awskms://alias/${project}-${stack}?region=us-east-1"
+	provider, err = proj.StackSecretsProvider("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "awskms://alias/proj-dev?region=us-east-1", provider)
+
+	proj.SecretsProvider = "awskms://alias/${org}"
+	_, err = proj.StackSecretsProvider("dev")
+	assert.EqualError(t, err,
+		"invalid project 'secretsProvider': unknown variable '${org}'; only ${project} and ${stack} may be used")
+}
+
+func TestProjectEnvironmentConfig(t *testing.T) {
+	var proj Project
+	err := yaml.Unmarshal([]byte(`name: proj
+runtime: go
+configEnv:
+  dbPassword:
+    env: PULUMI_TEST_DB_PASSWORD
+    secret: true
+  aws:region: PULUMI_TEST_AWS_REGION
+  unset: PULUMI_TEST_UNSET
+`), &proj)
+	assert.NoError(t, err)
+	assert.NoError(t, proj.Validate())
+	assert.Equal(t, map[string]ConfigEnvVar{
+		"dbPassword": {Env: "PULUMI_TEST_DB_PASSWORD", Secret: true},
+		"aws:region": {Env: "PULUMI_TEST_AWS_REGION"},
+		"unset":      {Env: "PULUMI_TEST_UNSET"},
+	}, proj.ConfigEnv)
+	assert.True(t, proj.HasSecretConfigEnv())
+
+	os.Setenv("PULUMI_TEST_DB_PASSWORD", "hunter2")
+	defer os.Unsetenv("PULUMI_TEST_DB_PASSWORD")
+	os.Setenv("PULUMI_TEST_AWS_REGION", "us-west-2")
+	defer os.Unsetenv("PULUMI_TEST_AWS_REGION")
+	os.Unsetenv("PULUMI_TEST_UNSET")
+
+	// Secret values are encrypted.
+	cfg, err := proj.EnvironmentConfig(config.NopEncrypter)
+	assert.NoError(t, err)
+	assert.Equal(t, config.Map{
+		config.MustMakeKey("proj", "dbPassword"): config.NewSecureValue("hunter2"),
+		config.MustMakeKey("aws", "region"):      config.NewValue("us-west-2"),
+	}, cfg)
+
+	// The entries round trip, with plain variables written as strings.
+	b, err := yaml.Marshal(proj.ConfigEnv)
+	assert.NoError(t, err)
+	var roundtrip map[string]ConfigEnvVar
+	assert.NoError(t, yaml.Unmarshal(b, &roundtrip))
+	assert.Equal(t, proj.ConfigEnv, roundtrip)
+	assert.Contains(t, string(b), "aws:region: PULUMI_TEST_AWS_REGION")
+	j, err := json.Marshal(proj.ConfigEnv)
+	assert.NoError(t, err)
+	roundtrip = nil
+	assert.NoError(t, json.Unmarshal(j, &roundtrip))
+	assert.Equal(t, proj.ConfigEnv, roundtrip)
+
+	proj.ConfigEnv = map[string]ConfigEnvVar{"a:b:c:d": {Env: "FOO"}}
+	assert.Error(t, proj.Validate())
+	proj.ConfigEnv = map[string]ConfigEnvVar{"dbPassword": {}}
+	assert.Error(t, proj.Validate())
+	assert.False(t, proj.HasSecretConfigEnv())
+}
+
+func TestProjectDefaultConfig(t *testing.T) {
+	var proj Project
+	err := yaml.Unmarshal([]byte(`name: proj
+runtime: go
+configDefaults:
+  instanceType: t2.micro
+  aws:region: us-west-2
+  servers:
+  - host: example
+    port: 80
+`), &proj)
+	assert.NoError(t, err)
+	assert.NoError(t, proj.Validate())
+
+	cfg, err := proj.DefaultConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, config.Map{
+		config.MustMakeKey("proj", "instanceType"): config.NewValue("t2.micro"),
+		config.MustMakeKey("aws", "region"):        config.NewValue("us-west-2"),
+		config.MustMakeKey("proj", "servers"):      config.NewObjectValue(`[{"host":"example","port":80}]`),
+	}, cfg)
+
+	// Secrets cannot be shared between stacks.
+	proj.ConfigDefaults["password"] = config.NewSecureValue("ciphertext")
+	assert.Error(t, proj.Validate())
+}
@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/texttheater/golang-levenshtein/levenshtein"
@@ -28,6 +29,8 @@ import (
 	"gopkg.in/src-d/go-git.v4/plumbing"
 
 	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/gitutil"
 )
@@ -402,6 +405,70 @@ func LoadTemplate(path string) (Template, error) {
 	return template, nil
 }
 
+// ValidateTemplate checks that the template in a directory can be used to create a project, without creating one:
+// that its Pulumi.yaml has a valid template section, that the keys of its config are valid, and that its files can be
+// scaffolded into a project with a valid manifest.  It returns every problem it finds.
+func ValidateTemplate(path string) []error {
+	proj, err := LoadProject(filepath.Join(path, "Pulumi.yaml"))
+	if err != nil {
+		return []error{errors.Wrap(err, "loading Pulumi.yaml")}
+	}
+
+	var errs []error
+	if proj.Template == nil {
+		errs = append(errs, errors.New("Pulumi.yaml is missing a 'template' section"))
+	} else {
+		if err = ValidateProjectDescription(proj.Template.Description); err != nil {
+			errs = append(errs, errors.Wrap(err, "invalid template description"))
+		}
+
+		keys := make([]string, 0, len(proj.Template.Config))
+		for k := range proj.Template.Config {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			// Keys without a namespace belong to the project, so any name will do to check them.
+			key := k
+			if !strings.Contains(key, tokens.TokenDelimiter) {
+				key = defaultProjectName + tokens.TokenDelimiter + key
+			}
+			if _, err = config.ParseKey(key); err != nil {
+				errs = append(errs, errors.Wrapf(err, "invalid config key '%s'", k))
+			}
+		}
+	}
+
+	// Scaffold the template into a temporary directory to make sure the result is a valid project.
+	temp, err := ioutil.TempDir("", "pulumi-template-validate-")
+	if err != nil {
+		return append(errs, err)
+	}
+	defer func() { contract.IgnoreError(os.RemoveAll(temp)) }()
+
+	dest := filepath.Join(temp, defaultProjectName)
+	if err = os.Mkdir(dest, 0700); err != nil {
+		return append(errs, err)
+	}
+	if err = CopyTemplateFiles(path, dest, true, defaultProjectName, "A Pulumi project."); err != nil {
+		return append(errs, errors.Wrap(err, "scaffolding a project"))
+	}
+	scaffolded, err := LoadProject(filepath.Join(dest, "Pulumi.yaml"))
+	if err != nil {
+		return append(errs, errors.Wrap(err, "loading the scaffolded Pulumi.yaml"))
+	}
+	if err = ValidateProjectName(scaffolded.Name.String()); err != nil {
+		errs = append(errs, errors.Wrap(err, "invalid scaffolded project name"))
+	}
+	if scaffolded.Description != nil {
+		if err = ValidateProjectDescription(*scaffolded.Description); err != nil {
+			errs = append(errs, errors.Wrap(err, "invalid scaffolded project description"))
+		}
+	}
+
+	return errs
+}
+
 // CopyTemplateFilesDryRun does a dry run of copying a template to a destination directory,
 // to ensure it won't overwrite any files.
 func CopyTemplateFilesDryRun(sourceDir, destDir string) error {
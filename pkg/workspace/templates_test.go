@@ -15,6 +15,7 @@
 package workspace
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -252,3 +253,28 @@ func TestRetrieveFileTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-template-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(contents string) {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "Pulumi.yaml"), []byte(contents), 0600))
+	}
+
+	// A template whose name and description are filled in when it is scaffolded is valid.
+	write("name: ${PROJECT}\ndescription: ${DESCRIPTION}\nruntime: nodejs\n" +
+		"template:\n  description: A template\n  config:\n    aws:region: {}\n    count: {}\n")
+	assert.Empty(t, ValidateTemplate(dir))
+
+	// Problems with the manifest, its config and the scaffolded project are all reported.
+	write("name: not valid!\nruntime: nodejs\ntemplate:\n  config:\n    a:b:c: {}\n")
+	assert.Len(t, ValidateTemplate(dir), 2)
+
+	write("name: ${PROJECT}\nruntime: nodejs\n")
+	assert.Len(t, ValidateTemplate(dir), 1)
+
+	write("name: ${PROJECT}\n")
+	assert.Len(t, ValidateTemplate(dir), 1)
+}
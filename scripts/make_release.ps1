@@ -32,6 +32,7 @@ RunGoBuild "github.com/pulumi/pulumi/sdk/nodejs/cmd/pulumi-language-nodejs"
 RunGoBuild "github.com/pulumi/pulumi/sdk/python/cmd/pulumi-language-python"
 RunGoBuild "github.com/pulumi/pulumi/sdk/dotnet/cmd/pulumi-language-dotnet"
 RunGoBuild "github.com/pulumi/pulumi/sdk/go/pulumi-language-go"
+RunGoBuild "github.com/pulumi/pulumi/sdk/go/pulumi-resource-pulumi-go"
 CopyPackage "$Root\sdk\nodejs\bin" "pulumi"
 
 Copy-Item "$Root\sdk\nodejs\dist\pulumi-resource-pulumi-nodejs.cmd" "$PublishDir\bin"
@@ -51,6 +51,7 @@ run_go_build "${ROOT}/sdk/nodejs/cmd/pulumi-language-nodejs"
 run_go_build "${ROOT}/sdk/python/cmd/pulumi-language-python"
 run_go_build "${ROOT}/sdk/dotnet/cmd/pulumi-language-dotnet"
 run_go_build "${ROOT}/sdk/go/pulumi-language-go"
+run_go_build "${ROOT}/sdk/go/pulumi-resource-pulumi-go"
 
 # Copy over the language and dynamic resource providers.
 cp "${ROOT}/sdk/nodejs/dist/pulumi-resource-pulumi-nodejs" "${PUBDIR}/bin/"
@@ -79,20 +79,20 @@ func main() {
 		engineAddress = args[0]
 	}
 
-	// Fire up a gRPC server, letting the kernel choose a free port.
-	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+	// Fire up a gRPC server, letting the kernel choose a free port or socket.
+	addr, done, err := rpcutil.ServeLocal(nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
 			host := newLanguageHost(dotnetExec, engineAddress, tracing)
 			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
 			return nil
 		},
-	}, nil)
+	}, nil, true /*pipes*/)
 	if err != nil {
 		cmdutil.Exit(errors.Wrapf(err, "could not start language host RPC server"))
 	}
 
-	// Otherwise, print out the port so that the spawner knows how to reach us.
-	fmt.Printf("%d\n", port)
+	// Otherwise, print out the address so that the spawner knows how to reach us.
+	fmt.Printf("%s\n", rpcutil.HandshakeAddress(addr))
 
 	// And finally wait for the server to stop serving.
 	if err := <-done; err != nil {
@@ -125,7 +125,7 @@ func (host *dotnetLanguageHost) GetRequiredPlugins(
 	logging.V(5).Infof("GetRequiredPlugins: %v", req.GetProgram())
 
 	// Make a connection to the real engine that we will log messages to.
-	conn, err := grpc.Dial(host.engineAddress, grpc.WithInsecure())
+	conn, err := grpc.Dial(host.engineAddress, grpc.WithInsecure(), rpcutil.WithLocalDialer())
 	if err != nil {
 		return nil, errors.Wrapf(err, "language host could not make connection to engine")
 	}
@@ -0,0 +1,95 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package program finds the executables of Pulumi programs written in Go, for the plugins that run them.
+package program
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+const unableToFindProgramTemplate = "unable to find program: %s"
+
+// Command returns a command that runs the program of the given project.  The program to execute is simply the name of
+// the project.  This ensures good Go toolability, whereby you can simply run `go install .` to build a Pulumi program
+// prior to running it, among other benefits.  For ease of use, if we don't find a pre-built program, the command
+// invokes it via 'go run' on behalf of the user.
+func Command(project string) (*exec.Cmd, error) {
+	program, err := find(project)
+	if err == nil {
+		return exec.Command(program), nil
+	} else if err.Error() != fmt.Sprintf(unableToFindProgramTemplate, project) {
+		return nil, err
+	}
+
+	logging.V(5).Infof("Unable to find program %s in $PATH, attempting invocation via 'go run'", project)
+	program, err = find("go")
+	if err != nil {
+		return nil, err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get current working directory")
+	}
+
+	goFileSearchPattern := filepath.Join(cwd, "*.go")
+	if matches, err := filepath.Glob(goFileSearchPattern); err != nil || len(matches) == 0 {
+		return nil, errors.Errorf("Failed to find go files for 'go run' matching %s", goFileSearchPattern)
+	}
+
+	// go run $cwd
+	return exec.Command(program, "run", cwd), nil
+}
+
+// find attempts to find the needed program in various locations on the
+// filesystem, eventually resorting to searching in $PATH.
+func find(program string) (string, error) {
+
+	// look in the same directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get current working directory")
+	}
+
+	cwdProgram := filepath.Join(cwd, program)
+	if fileInfo, err := os.Stat(cwdProgram); !os.IsNotExist(err) && !fileInfo.Mode().IsDir() {
+		logging.V(5).Infof("program %s found in CWD", program)
+		return cwdProgram, nil
+	}
+
+	// look in $GOPATH/bin
+	if goPath := os.Getenv("GOPATH"); len(goPath) > 0 {
+		goPathProgram := filepath.Join(goPath, "bin", program)
+		if fileInfo, err := os.Stat(goPathProgram); !os.IsNotExist(err) && !fileInfo.Mode().IsDir() {
+			logging.V(5).Infof("program %s found in $GOPATH/bin", program)
+			return goPathProgram, nil
+		}
+	}
+
+	// look in the $PATH somewhere
+	if fullPath, err := exec.LookPath(program); err == nil {
+		logging.V(5).Infof("program %s found in $PATH", program)
+		return fullPath, nil
+	}
+
+	return "", errors.Errorf(unableToFindProgramTemplate, program)
+}
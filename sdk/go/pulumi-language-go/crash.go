@@ -0,0 +1,79 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// crashCapture forwards a program's stderr, line by line, until the Go runtime starts to report a panic or a fatal
+// error.  From then on, the output is captured instead, so that the crash can be reported as the program's error
+// rather than as a stream of unrelated lines.
+type crashCapture struct {
+	w       io.Writer    // the writer to forward output to.
+	partial []byte       // an incomplete line that has not been forwarded yet.
+	crashed bool         // true once a crash has been detected.
+	report  bytes.Buffer // the captured crash report.
+}
+
+func (c *crashCapture) Write(p []byte) (int, error) {
+	if c.crashed {
+		return c.report.Write(p)
+	}
+
+	c.partial = append(c.partial, p...)
+	for {
+		idx := bytes.IndexByte(c.partial, '\n')
+		if idx == -1 {
+			return len(p), nil
+		}
+		line := c.partial[:idx+1]
+		if bytes.HasPrefix(line, []byte("panic: ")) || bytes.HasPrefix(line, []byte("fatal error: ")) {
+			c.crashed = true
+			c.report.Write(c.partial)
+			c.partial = nil
+			return len(p), nil
+		}
+		if _, err := c.w.Write(line); err != nil {
+			return 0, err
+		}
+		c.partial = c.partial[idx+1:]
+	}
+}
+
+// Flush forwards any incomplete line that remains.
+func (c *crashCapture) Flush() error {
+	if c.crashed || len(c.partial) == 0 {
+		return nil
+	}
+	_, err := c.w.Write(c.partial)
+	c.partial = nil
+	return err
+}
+
+// Crash returns a description of the crash, if one was detected, along with the stack traces reported by the Go
+// runtime.
+func (c *crashCapture) Crash() (string, bool) {
+	if !c.crashed {
+		return "", false
+	}
+	report := strings.TrimSpace(c.report.String())
+	if strings.HasPrefix(report, "panic: ") {
+		return "program panicked: " + strings.TrimPrefix(report, "panic: "), true
+	}
+	return "program crashed with a " + report, true
+}
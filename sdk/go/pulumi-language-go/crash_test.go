@@ -0,0 +1,55 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrashCapture(t *testing.T) {
+	var out bytes.Buffer
+	c := &crashCapture{w: &out}
+	for _, chunk := range []string{
+		"creating bucket\nwar", "ning: slow\n",
+		"panic: runtime error: index out of range\n\ngoroutine 1 [running]:\n",
+		"main.main()\n\t/src/main.go:10 +0x1d\n",
+	} {
+		n, err := c.Write([]byte(chunk))
+		assert.NoError(t, err)
+		assert.Equal(t, len(chunk), n)
+	}
+	assert.NoError(t, c.Flush())
+
+	assert.Equal(t, "creating bucket\nwarning: slow\n", out.String())
+	crash, crashed := c.Crash()
+	assert.True(t, crashed)
+	assert.Equal(t, "program panicked: runtime error: index out of range\n\ngoroutine 1 [running]:\n"+
+		"main.main()\n\t/src/main.go:10 +0x1d", crash)
+}
+
+func TestCrashCaptureNoCrash(t *testing.T) {
+	var out bytes.Buffer
+	c := &crashCapture{w: &out}
+	_, err := c.Write([]byte("error: program failed: boom\nno newline"))
+	assert.NoError(t, err)
+	assert.NoError(t, c.Flush())
+
+	assert.Equal(t, "error: program failed: boom\nno newline", out.String())
+	_, crashed := c.Crash()
+	assert.False(t, crashed)
+}
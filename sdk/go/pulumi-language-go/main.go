@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"syscall"
 
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -29,9 +28,11 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/rpcutil"
 	"github.com/pulumi/pulumi/pkg/version"
+	"github.com/pulumi/pulumi/sdk/go/internal/program"
 	"github.com/pulumi/pulumi/sdk/go/pulumi"
 	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
@@ -52,20 +53,20 @@ func main() {
 	}
 	engineAddress := args[0]
 
-	// Fire up a gRPC server, letting the kernel choose a free port.
-	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+	// Fire up a gRPC server, letting the kernel choose a free port or socket.
+	addr, done, err := rpcutil.ServeLocal(nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
 			host := newLanguageHost(engineAddress, tracing)
 			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
 			return nil
 		},
-	}, nil)
+	}, nil, true /*pipes*/)
 	if err != nil {
 		cmdutil.Exit(errors.Wrapf(err, "could not start language host RPC server"))
 	}
 
-	// Otherwise, print out the port so that the spawner knows how to reach us.
-	fmt.Printf("%d\n", port)
+	// Otherwise, print out the address so that the spawner knows how to reach us.
+	fmt.Printf("%s\n", rpcutil.HandshakeAddress(addr))
 
 	// And finally wait for the server to stop serving.
 	if err := <-done; err != nil {
@@ -92,42 +93,6 @@ func (host *goLanguageHost) GetRequiredPlugins(ctx context.Context,
 	return &pulumirpc.GetRequiredPluginsResponse{}, nil
 }
 
-const unableToFindProgramTemplate = "unable to find program: %s"
-
-// findProgram attempts to find the needed program in various locations on the
-// filesystem, eventually resorting to searching in $PATH.
-func findProgram(program string) (string, error) {
-
-	// look in the same directory
-	cwd, err := os.Getwd()
-	if err != nil {
-		return "", errors.Wrap(err, "unable to get current working directory")
-	}
-
-	cwdProgram := filepath.Join(cwd, program)
-	if fileInfo, err := os.Stat(cwdProgram); !os.IsNotExist(err) && !fileInfo.Mode().IsDir() {
-		logging.V(5).Infof("program %s found in CWD", program)
-		return cwdProgram, nil
-	}
-
-	// look in $GOPATH/bin
-	if goPath := os.Getenv("GOPATH"); len(goPath) > 0 {
-		goPathProgram := filepath.Join(goPath, "bin", program)
-		if fileInfo, err := os.Stat(goPathProgram); !os.IsNotExist(err) && !fileInfo.Mode().IsDir() {
-			logging.V(5).Infof("program %s found in $GOPATH/bin", program)
-			return goPathProgram, nil
-		}
-	}
-
-	// look in the $PATH somewhere
-	if fullPath, err := exec.LookPath(program); err == nil {
-		logging.V(5).Infof("program %s found in $PATH", program)
-		return fullPath, nil
-	}
-
-	return "", errors.Errorf(unableToFindProgramTemplate, program)
-}
-
 // RPC endpoint for LanguageRuntimeServer::Run
 func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest) (*pulumirpc.RunResponse, error) {
 	// Create the environment we'll use to run the process.  This is how we pass the RunInfo to the actual
@@ -137,59 +102,45 @@ func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest)
 		return nil, errors.Wrap(err, "failed to prepare environment")
 	}
 
-	// by default we try to run a named executable on the path, but we will fallback to 'go run' style execution
-	goRunInvoke := false
-
-	// The program to execute is simply the name of the project.  This ensures good Go toolability, whereby
-	// you can simply run `go install .` to build a Pulumi program prior to running it, among other benefits.
-	// For ease of use, if we don't find a pre-built program, we attempt to invoke via 'go run' on behalf of the user.
-	program, err := findProgram(req.GetProject())
+	// Find the program, falling back to 'go run' style execution if it has not been built.
+	cmd, err := program.Command(req.GetProject())
 	if err != nil {
-		const message = "problem executing program (could not run language executor)"
-		if err.Error() == fmt.Sprintf(unableToFindProgramTemplate, req.GetProject()) {
-			logging.V(5).Infof("Unable to find program %s in $PATH, attempting invocation via 'go run'", program)
-			program, err = findProgram("go")
-			if err != nil {
-				return nil, errors.Wrap(err, message)
-			}
-			goRunInvoke = true
-		} else {
-			return nil, errors.Wrap(err, message)
-		}
+		return nil, errors.Wrap(err, "problem executing program (could not run language executor)")
 	}
 
-	logging.V(5).Infof("language host launching process: %s", program)
+	logging.V(5).Infof("language host launching process: %s", cmd.Path)
 
 	// Now simply spawn a process to execute the requested program, wiring up stdout/stderr directly.
 	var errResult string
-	var cmd *exec.Cmd
-
-	if goRunInvoke {
-		cwd, err := os.Getwd()
-		if err != nil {
-			return nil, errors.Wrap(err, "unable to get current working directory")
-		}
-
-		goFileSearchPattern := filepath.Join(cwd, "*.go")
-		if matches, err := filepath.Glob(goFileSearchPattern); err != nil || len(matches) == 0 {
-			return nil, errors.Errorf("Failed to find go files for 'go run' matching %s", goFileSearchPattern)
-		}
-
-		args := []string{"run", cwd}
-		// go run $cwd
-		cmd = exec.Command(program, args...)
-	} else {
-		cmd = exec.Command(program)
-	}
 
+	// Capture the program's stderr if it crashes, so that the crash, along with its stack traces, can be reported as
+	// the program's error rather than as a generic exit status.
+	stderr := &crashCapture{w: os.Stderr}
 	cmd.Env = env
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	cmd.Stderr = stderr
+	if err = cmd.Start(); err == nil {
+		// If the engine cancels the run, for example because it is shutting down, interrupt the program so that it can
+		// stop early.  The Go SDK cancels the program's context.Context when it is interrupted.
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				contract.IgnoreError(cmd.Process.Signal(os.Interrupt))
+			case <-done:
+			}
+		}()
+		err = cmd.Wait()
+		close(done)
+	}
+	contract.IgnoreError(stderr.Flush())
+	if err != nil {
 		if exiterr, ok := err.(*exec.ExitError); ok {
 			// If the program ran, but exited with a non-zero error code.  This will happen often, since user
 			// errors will trigger this.  So, the error message should look as nice as possible.
-			if status, stok := exiterr.Sys().(syscall.WaitStatus); stok {
+			if crash, crashed := stderr.Crash(); crashed {
+				err = errors.New(crash)
+			} else if status, stok := exiterr.Sys().(syscall.WaitStatus); stok {
 				err = errors.Errorf("program exited with non-zero exit code: %d", status.ExitStatus())
 			} else {
 				err = errors.Wrapf(exiterr, "program exited unexpectedly")
@@ -0,0 +1,60 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+	"github.com/pulumi/pulumi/sdk/go/internal/program"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// Launches the resource provider for the dynamic resources of Go programs.  Their providers are defined by the
+// programs themselves, so this runs the program of the project in the current working directory, which serves its
+// providers in place of this plugin, writing out the address that it listens on, rather than running its body.
+func main() {
+	logging.InitLogging(false, 0, false)
+
+	project, err := workspace.DetectProject()
+	if err != nil {
+		cmdutil.Exit(errors.Wrap(err, "finding the project whose dynamic providers to serve"))
+	}
+	cmd, err := program.Command(string(project.Name))
+	if err != nil {
+		cmdutil.Exit(errors.Wrap(err, "problem executing program (could not run dynamic providers)"))
+	}
+
+	logging.V(5).Infof("dynamic provider launching process: %s", cmd.Path)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=true", pulumi.EnvDynamicProvider))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		// Exit with the program's own exit code, if it ran, as it will already have reported the reason.
+		if exiterr, ok := err.(*exec.ExitError); ok {
+			if status, stok := exiterr.Sys().(syscall.WaitStatus); stok {
+				os.Exit(status.ExitStatus())
+			}
+		}
+		cmdutil.Exit(errors.Wrap(err, "problem executing program (could not run dynamic providers)"))
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// AutoNaming customizes the physical names of custom resources that their programs do not name themselves, which
+// their providers otherwise derive from their logical names by appending a random hex suffix.
+type AutoNaming struct {
+	// Property is the input property that holds the physical names of the resources, such as "name".
+	Property string
+	// Namer chooses the physical name of each resource whose Property is not set.
+	Namer AutoNamer
+}
+
+// AutoNamer returns the physical name of a resource, or "" to leave the naming of the resource to its provider, for
+// example because resources of its type have no Property.
+type AutoNamer func(args *AutoNameArgs) (string, error)
+
+// AutoNameArgs describes the resource that an AutoNamer names.
+type AutoNameArgs struct {
+	// Project is the name of the program's project.
+	Project string
+	// Stack is the name of the stack that is being deployed.
+	Stack string
+	// Type is the resource's type token.
+	Type string
+	// Name is the resource's logical name.
+	Name string
+}
+
+// SuffixNamer returns an AutoNamer that names resources by their logical names followed by the separator and a suffix
+// of the given length, whose characters are drawn from charset.  Unlike those of providers, the suffix is not random,
+// but derived from the project, stack, type, and logical name of the resource, so that it is the same in every update
+// of the stack and does not cause the resource to be replaced.
+func SuffixNamer(separator string, length int, charset string) AutoNamer {
+	return func(args *AutoNameArgs) (string, error) {
+		if length < 0 || charset == "" {
+			return "", errors.Errorf("invalid suffix of length %d drawn from %q", length, charset)
+		}
+
+		seed := sha256.New()
+		for _, s := range []string{args.Project, args.Stack, args.Type, args.Name} {
+			_, err := seed.Write(append([]byte(s), 0))
+			contract.AssertNoError(err)
+		}
+
+		// Draw each character of the suffix from the hash of the seed and the character's index.
+		suffix := make([]rune, length)
+		chars := []rune(charset)
+		for i := range suffix {
+			h := sha256.New()
+			_, err := h.Write(seed.Sum(nil))
+			contract.AssertNoError(err)
+			contract.AssertNoError(binary.Write(h, binary.LittleEndian, uint64(i)))
+			suffix[i] = chars[binary.LittleEndian.Uint64(h.Sum(nil))%uint64(len(chars))]
+		}
+		return args.Name + separator + string(suffix), nil
+	}
+}
+
+// autoNamingFor returns the auto-naming that applies to a resource with the given options: that of the first of them
+// to set one, or else that of the context, if any.
+func (ctx *Context) autoNamingFor(opts []ResourceOpt) *AutoNaming {
+	for _, opt := range opts {
+		if opt.AutoNaming != nil {
+			return opt.AutoNaming
+		}
+	}
+	return ctx.autoNaming
+}
+
+// autoName sets the physical name of a custom resource with the given type, name, and options, if auto-naming applies
+// to it and its properties do not name it already.
+func (ctx *Context) autoName(t, name string, props map[string]interface{},
+	opts []ResourceOpt) (map[string]interface{}, error) {
+
+	naming := ctx.autoNamingFor(opts)
+	if naming == nil || naming.Namer == nil || naming.Property == "" || props[naming.Property] != nil {
+		return props, nil
+	}
+
+	physicalName, err := naming.Namer(&AutoNameArgs{Project: ctx.Project(), Stack: ctx.Stack(), Type: t, Name: name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "naming resource %s (%s)", name, t)
+	} else if physicalName == "" {
+		return props, nil
+	}
+
+	named := map[string]interface{}{naming.Property: physicalName}
+	for k, v := range props {
+		if k != naming.Property {
+			named[k] = v
+		}
+	}
+	return named, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuffixNamer(t *testing.T) {
+	namer := SuffixNamer("-", 6, "abc")
+	args := &AutoNameArgs{Project: "proj", Stack: "dev", Type: "aws:s3/bucket:Bucket", Name: "logs"}
+
+	// Suffixes are drawn from the charset, and are the same every time that the same resource is named.
+	name, err := namer(args)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(name, "logs-"))
+	assert.Len(t, name, len("logs-")+6)
+	assert.Empty(t, strings.Trim(name[len("logs-"):], "abc"))
+	again, err := namer(args)
+	assert.NoError(t, err)
+	assert.Equal(t, name, again)
+
+	// Resources of other stacks get other suffixes.
+	other, err := SuffixNamer("-", 6, "abcdefghijklmnopqrstuvwxyz")(&AutoNameArgs{Project: "proj", Stack: "prod",
+		Type: "aws:s3/bucket:Bucket", Name: "logs"})
+	assert.NoError(t, err)
+	mine, err := SuffixNamer("-", 6, "abcdefghijklmnopqrstuvwxyz")(args)
+	assert.NoError(t, err)
+	assert.NotEqual(t, mine, other)
+
+	_, err = SuffixNamer("-", 6, "")(args)
+	assert.Error(t, err)
+}
+
+func TestAutoNaming(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+	ctx.autoNaming = &AutoNaming{Property: "name", Namer: func(args *AutoNameArgs) (string, error) {
+		if args.Type == "test:index:Unnamed" {
+			return "", nil
+		}
+		return args.Stack + "-" + args.Name, nil
+	}}
+
+	// Resources that are not named are named by the context's namer, or that of their options, which are inherited
+	// by the children of components.
+	named, err := ctx.RegisterResource("test:index:Resource", "a", true, map[string]interface{}{"size": 1})
+	assert.NoError(t, err)
+	_, err = ctx.RegisterResource("test:index:Resource", "b", true, map[string]interface{}{"name": "explicit"})
+	assert.NoError(t, err)
+	_, err = ctx.RegisterResource("test:index:Unnamed", "c", true, nil)
+	assert.NoError(t, err)
+	upper := AutoName(&AutoNaming{Property: "title", Namer: func(args *AutoNameArgs) (string, error) {
+		return strings.ToUpper(args.Name), nil
+	}})
+	comp, err := NewComponentResource(ctx, "test:index:Component", "comp", upper)
+	assert.NoError(t, err)
+	_, err = comp.Context().RegisterResource("test:index:Resource", "d", true, nil)
+	assert.NoError(t, err)
+
+	// Failures to name resources are reported.
+	_, err = ctx.RegisterResource("test:index:Resource", "e", true, nil, AutoName(&AutoNaming{Property: "name",
+		Namer: func(args *AutoNameArgs) (string, error) {
+			return "", errors.New("no names left")
+		}}))
+	assert.EqualError(t, err, "naming resource e (test:index:Resource): no names left")
+	ctx.waitForRPCs()
+
+	v, _, err := named.State["name"].s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "dev-a", v)
+	assert.Equal(t, map[string]interface{}{"name": "dev-a", "size": 1.0}, monitor.inputs["test:index:Resource::a"])
+	assert.Equal(t, map[string]interface{}{"name": "explicit"}, monitor.inputs["test:index:Resource::b"])
+	assert.Equal(t, map[string]interface{}{}, monitor.inputs["test:index:Unnamed::c"])
+	assert.Equal(t, map[string]interface{}{}, monitor.inputs["test:index:Component::comp"])
+	assert.Equal(t, map[string]interface{}{"title": "D"}, monitor.inputs["test:index:Resource::d"])
+}
@@ -0,0 +1,152 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// as returns an output of the output's value converted to the given type.  Unlike the Apply methods of the typed
+// outputs, which panic if the value is of the wrong type, the result is rejected with a descriptive error if the value
+// cannot be converted without loss: strings and bools only convert from values of the same kind, and numbers only
+// convert from numbers whose value is unchanged by the conversion.
+func (out Output) as(to reflect.Type) Output {
+	return out.Apply(func(v interface{}) (interface{}, error) {
+		return castValue(v, to)
+	})
+}
+
+func castValue(v interface{}, to reflect.Type) (interface{}, error) {
+	if v == nil {
+		return nil, errors.Errorf("expected a value of type %s, got nil", to)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case isNumberKind(to.Kind()):
+		if isNumberKind(rv.Kind()) {
+			// Only accept the conversion if converting back yields the same number, so that fractions and values
+			// that overflow the type are rejected rather than truncated.
+			converted := rv.Convert(to)
+			if converted.Convert(rv.Type()).Interface() == rv.Interface() {
+				return converted.Interface(), nil
+			}
+			return nil, errors.Errorf("cannot represent %v as a value of type %s", v, to)
+		}
+	case to.Kind() == reflect.String || to.Kind() == reflect.Bool:
+		if rv.Kind() == to.Kind() {
+			return rv.Convert(to).Interface(), nil
+		}
+	case rv.Type().ConvertibleTo(to):
+		return rv.Convert(to).Interface(), nil
+	}
+	return nil, errors.Errorf("expected a value of type %s, got %T", to, v)
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64 && k != reflect.Uintptr
+}
+
+// AsArchiveOutput returns the output as an ArchiveOutput.  The result is rejected if the value is not an archive.
+func (out Output) AsArchiveOutput() ArchiveOutput {
+	return ArchiveOutput(out.as(archiveType))
+}
+
+// AsArrayOutput returns the output as an ArrayOutput.  The result is rejected if the value is not an array.
+func (out Output) AsArrayOutput() ArrayOutput {
+	return ArrayOutput(out.as(arrayType))
+}
+
+// AsAssetOutput returns the output as an AssetOutput.  The result is rejected if the value is not an asset.
+func (out Output) AsAssetOutput() AssetOutput {
+	return AssetOutput(out.as(assetType))
+}
+
+// AsBoolOutput returns the output as a BoolOutput.  The result is rejected if the value is not a bool.
+func (out Output) AsBoolOutput() BoolOutput {
+	return BoolOutput(out.as(boolType))
+}
+
+// AsFloat32Output returns the output as a Float32Output.  The result is rejected if the value is not a float32.
+func (out Output) AsFloat32Output() Float32Output {
+	return Float32Output(out.as(float32Type))
+}
+
+// AsFloat64Output returns the output as a Float64Output.  The result is rejected if the value is not a float64.
+func (out Output) AsFloat64Output() Float64Output {
+	return Float64Output(out.as(float64Type))
+}
+
+// AsIntOutput returns the output as an IntOutput.  The result is rejected if the value is not an int.
+func (out Output) AsIntOutput() IntOutput {
+	return IntOutput(out.as(intType))
+}
+
+// AsInt8Output returns the output as an Int8Output.  The result is rejected if the value is not an int8.
+func (out Output) AsInt8Output() Int8Output {
+	return Int8Output(out.as(int8Type))
+}
+
+// AsInt16Output returns the output as an Int16Output.  The result is rejected if the value is not an int16.
+func (out Output) AsInt16Output() Int16Output {
+	return Int16Output(out.as(int16Type))
+}
+
+// AsInt32Output returns the output as an Int32Output.  The result is rejected if the value is not an int32.
+func (out Output) AsInt32Output() Int32Output {
+	return Int32Output(out.as(int32Type))
+}
+
+// AsInt64Output returns the output as an Int64Output.  The result is rejected if the value is not an int64.
+func (out Output) AsInt64Output() Int64Output {
+	return Int64Output(out.as(int64Type))
+}
+
+// AsMapOutput returns the output as a MapOutput.  The result is rejected if the value is not a map.
+func (out Output) AsMapOutput() MapOutput {
+	return MapOutput(out.as(mapType))
+}
+
+// AsStringOutput returns the output as a StringOutput.  The result is rejected if the value is not a string.
+func (out Output) AsStringOutput() StringOutput {
+	return StringOutput(out.as(stringType))
+}
+
+// AsUintOutput returns the output as a UintOutput.  The result is rejected if the value is not a uint.
+func (out Output) AsUintOutput() UintOutput {
+	return UintOutput(out.as(uintType))
+}
+
+// AsUint8Output returns the output as a Uint8Output.  The result is rejected if the value is not a uint8.
+func (out Output) AsUint8Output() Uint8Output {
+	return Uint8Output(out.as(uint8Type))
+}
+
+// AsUint16Output returns the output as a Uint16Output.  The result is rejected if the value is not a uint16.
+func (out Output) AsUint16Output() Uint16Output {
+	return Uint16Output(out.as(uint16Type))
+}
+
+// AsUint32Output returns the output as a Uint32Output.  The result is rejected if the value is not a uint32.
+func (out Output) AsUint32Output() Uint32Output {
+	return Uint32Output(out.as(uint32Type))
+}
+
+// AsUint64Output returns the output as a Uint64Output.  The result is rejected if the value is not a uint64.
+func (out Output) AsUint64Output() Uint64Output {
+	return Uint64Output(out.as(uint64Type))
+}
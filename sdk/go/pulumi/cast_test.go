@@ -0,0 +1,79 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/go/pulumi/asset"
+)
+
+func resolvedOutput(v interface{}) Output {
+	out := newOutput()
+	out.s.resolve(v, true)
+	return out
+}
+
+func TestCasts(t *testing.T) {
+	// Values of the right kind are converted, including numbers that arrive as float64s over RPC.
+	v, known, err := resolvedOutput(float64(42)).AsIntOutput().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, 42, v)
+
+	v, _, err = resolvedOutput(uint8(200)).AsUint64Output().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(200), v)
+
+	v, _, err = resolvedOutput("hello").AsStringOutput().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", v)
+
+	v, _, err = resolvedOutput(map[string]interface{}{"a": 1}).AsMapOutput().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1}, v)
+
+	v, _, err = resolvedOutput(asset.NewStringAsset("x")).AsAssetOutput().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "x", v.(asset.Asset).Text())
+
+	// Values of the wrong kind, and numbers that would change, are rejected rather than panicking.
+	_, _, err = resolvedOutput(42).AsStringOutput().s.await(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "expected a value of type string, got int")
+	}
+	_, _, err = resolvedOutput("42").AsIntOutput().s.await(context.Background())
+	assert.Error(t, err)
+	_, _, err = resolvedOutput(1.5).AsIntOutput().s.await(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot represent 1.5 as a value of type int")
+	}
+	_, _, err = resolvedOutput(300).AsUint8Output().s.await(context.Background())
+	assert.Error(t, err)
+	_, _, err = resolvedOutput(nil).AsBoolOutput().s.await(context.Background())
+	assert.Error(t, err)
+	_, _, err = resolvedOutput("x").AsArchiveOutput().s.await(context.Background())
+	assert.Error(t, err)
+
+	// Unknown values stay unknown.
+	unknown := newOutput()
+	unknown.s.resolve(nil, false)
+	_, known, err = unknown.AsIntOutput().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, known)
+}
@@ -0,0 +1,77 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+// ComponentResourceState is the state of a component resource.  A Go component is a struct that embeds a
+// *ComponentResourceState, which makes the struct a ComponentResource, alongside the outputs that it exposes:
+//
+//	type VPC struct {
+//	    *pulumi.ComponentResourceState
+//	    SubnetIDs pulumi.ArrayOutput
+//	}
+//
+// Its constructor registers the component with NewComponentResource, registers the component's children with the
+// component's context, and then registers the component's outputs with RegisterComponentOutputs.
+type ComponentResourceState struct {
+	urn URNOutput
+	ctx *Context
+}
+
+// NewComponentResource registers a component resource with the given type token and name.  Like RegisterResource, it
+// returns without waiting for the registration to complete.
+func NewComponentResource(ctx *Context, t, name string, opts ...ResourceOpt) (*ComponentResourceState, error) {
+	res, err := ctx.RegisterResource(t, name, false, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The component's children are transformed by the same transformations as the component itself, and use the same
+	// providers and auto-naming.
+	state := &ComponentResourceState{urn: res.urn}
+	state.ctx = &Context{
+		contextState:    ctx.contextState,
+		parent:          state,
+		transformations: ctx.transformationsFor(opts),
+		providers:       ctx.providersFor(opts),
+		autoNaming:      ctx.autoNamingFor(opts),
+	}
+	return state, nil
+}
+
+// URN will resolve to the component's URN after registration has completed.
+func (state *ComponentResourceState) URN() URNOutput {
+	return state.urn
+}
+
+// Context returns a context for registering the component's children.  Resources registered with it are parented to
+// the component, unless their options specify another parent, so that they are grouped under it and are named
+// relative to it.  Components registered with it may register their own children in turn.
+func (state *ComponentResourceState) Context() *Context {
+	return state.ctx
+}
+
+// RegisterComponentOutputs completes the registration of a component resource, attaching the outputs that it exposes.
+// It waits for the component's URN, and for the outputs among the given values, to be resolved.
+func (ctx *Context) RegisterComponentOutputs(component ComponentResource, outs map[string]interface{}) error {
+	awaitCtx, cancel := ctx.awaitContext()
+	defer cancel()
+	urn, _, err := component.URN().await(awaitCtx)
+	if err != nil {
+		return wrapErrorf(err, "awaiting component URN")
+	}
+	return ctx.RegisterResourceOutputs(urn, outs)
+}
+
+var _ ComponentResource = (*ComponentResourceState)(nil)
@@ -0,0 +1,206 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// fakeMonitor is a resource monitor that registers resources without creating them, recording their registration
+// requests, their parents, their inputs, their providers, and the outputs registered for them.  Custom resources are
+// given IDs derived from their names, and resources that are read have the state registered for their IDs, unless their
+// IDs are unknown.  Invokes record their arguments as inputs, and return the results registered for their tokens.
+// Resource hooks record their addresses, and the program's signal that it awaits shutdown returns immediately.
+type fakeMonitor struct {
+	pulumirpc.ResourceMonitorClient
+
+	mutex     sync.Mutex
+	requests  map[string]*pulumirpc.RegisterResourceRequest
+	parents   map[string]string
+	inputs    map[string]map[string]interface{}
+	providers map[string]string
+	outputs   map[string]map[string]interface{}
+	reads     map[string]*structpb.Struct
+	results   map[string]*structpb.Struct
+
+	readRequests map[string]*pulumirpc.ReadResourceRequest
+
+	hooks    map[string]string // the addresses of registered resource hooks, keyed by name.
+	shutdown bool              // true if the program has signaled that it awaits shutdown.
+}
+
+func newFakeMonitor() *fakeMonitor {
+	return &fakeMonitor{
+		requests:  map[string]*pulumirpc.RegisterResourceRequest{},
+		parents:   map[string]string{},
+		inputs:    map[string]map[string]interface{}{},
+		providers: map[string]string{},
+		outputs:   map[string]map[string]interface{}{},
+		reads:     map[string]*structpb.Struct{},
+		results:   map[string]*structpb.Struct{},
+
+		readRequests: map[string]*pulumirpc.ReadResourceRequest{},
+
+		hooks: map[string]string{},
+	}
+}
+
+func (m *fakeMonitor) SupportsFeature(ctx context.Context, in *pulumirpc.SupportsFeatureRequest,
+	opts ...grpc.CallOption) (*pulumirpc.SupportsFeatureResponse, error) {
+	return &pulumirpc.SupportsFeatureResponse{}, nil
+}
+
+func (m *fakeMonitor) RegisterResource(ctx context.Context, in *pulumirpc.RegisterResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.RegisterResourceResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	inputs, _, err := unmarshalOutputs(in.GetObject())
+	if err != nil {
+		return nil, err
+	}
+
+	urn := in.GetType() + "::" + in.GetName()
+	m.requests[urn] = in
+	m.parents[urn] = in.GetParent()
+	m.inputs[urn] = inputs
+	m.providers[urn] = in.GetProvider()
+
+	var id string
+	if in.GetCustom() {
+		id = in.GetName() + "-id"
+	}
+	return &pulumirpc.RegisterResourceResponse{Urn: urn, Id: id, Object: in.GetObject()}, nil
+}
+
+func (m *fakeMonitor) ReadResource(ctx context.Context, in *pulumirpc.ReadResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.ReadResourceResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	urn := in.GetType() + "::" + in.GetName()
+	m.readRequests[urn] = in
+	if in.GetId() == rpcTokenUnknownValue {
+		return &pulumirpc.ReadResourceResponse{Urn: urn}, nil
+	}
+
+	state, has := m.reads[in.GetId()]
+	if !has {
+		return nil, errors.Errorf("resource %s not found", in.GetId())
+	}
+	return &pulumirpc.ReadResourceResponse{Urn: urn, Properties: state}, nil
+}
+
+func (m *fakeMonitor) Invoke(ctx context.Context, in *pulumirpc.InvokeRequest,
+	opts ...grpc.CallOption) (*pulumirpc.InvokeResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	args, _, err := unmarshalOutputs(in.GetArgs())
+	if err != nil {
+		return nil, err
+	}
+
+	m.inputs[in.GetTok()] = args
+	m.providers[in.GetTok()] = in.GetProvider()
+	return &pulumirpc.InvokeResponse{Return: m.results[in.GetTok()]}, nil
+}
+
+func (m *fakeMonitor) RegisterResourceHook(ctx context.Context, in *pulumirpc.RegisterResourceHookRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.hooks[in.GetName()] = in.GetAddress()
+	return &empty.Empty{}, nil
+}
+
+func (m *fakeMonitor) SignalAndWaitForShutdown(ctx context.Context, in *empty.Empty,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.shutdown = true
+	return &empty.Empty{}, nil
+}
+
+func (m *fakeMonitor) RegisterResourceOutputs(ctx context.Context, in *pulumirpc.RegisterResourceOutputsRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	outs, _, err := unmarshalOutputs(in.GetOutputs())
+	if err != nil {
+		return nil, err
+	}
+	m.outputs[in.GetUrn()] = outs
+	return &empty.Empty{}, nil
+}
+
+type testComponent struct {
+	*ComponentResourceState
+	Name StringOutput
+}
+
+func TestComponentResources(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	// Register a component, with a child, a nested component, and a child with an explicit parent.
+	comp, err := NewComponentResource(ctx, "test:index:Component", "comp")
+	assert.NoError(t, err)
+	component := &testComponent{ComponentResourceState: comp}
+
+	child, err := comp.Context().RegisterResource("test:index:Child", "child", true,
+		map[string]interface{}{"name": String("child")})
+	assert.NoError(t, err)
+	component.Name = StringOutput(child.State["name"])
+
+	nested, err := NewComponentResource(comp.Context(), "test:index:Component", "nested")
+	assert.NoError(t, err)
+	_, err = nested.Context().RegisterResource("test:index:Child", "grandchild", true, nil)
+	assert.NoError(t, err)
+	_, err = comp.Context().RegisterResource("test:index:Child", "other", true, nil, ResourceOpt{Parent: child})
+	assert.NoError(t, err)
+
+	assert.NoError(t, ctx.RegisterComponentOutputs(nested, nil))
+	assert.NoError(t, ctx.RegisterComponentOutputs(component, map[string]interface{}{"name": component.Name}))
+	ctx.waitForRPCs()
+
+	// Children are parented to their components, and resources registered with the root context to the stack.
+	assert.Equal(t, map[string]string{
+		"test:index:Component::comp":   "stack",
+		"test:index:Child::child":      "test:index:Component::comp",
+		"test:index:Component::nested": "test:index:Component::comp",
+		"test:index:Child::grandchild": "test:index:Component::nested",
+		"test:index:Child::other":      "test:index:Child::child",
+	}, monitor.parents)
+
+	// Outputs are registered for the components.
+	assert.Equal(t, map[string]interface{}{"name": "child"}, monitor.outputs["test:index:Component::comp"])
+	assert.Contains(t, monitor.outputs, "test:index:Component::nested")
+}
@@ -45,6 +45,12 @@ func (c *Config) Get(key string) string {
 	return Get(c.ctx, c.fullKey(key))
 }
 
+// GetWithEnv loads an optional configuration value by its key, or, if it doesn't exist, the value of the first of the
+// given environment variables that is set.  It returns "" if neither the key nor any of the variables is set.
+func (c *Config) GetWithEnv(key string, envVars ...string) string {
+	return GetWithEnv(c.ctx, c.fullKey(key), envVars...)
+}
+
 // GetBool loads an optional bool configuration value by its key, or returns false if it doesn't exist.
 func (c *Config) GetBool(key string) bool {
 	return GetBool(c.ctx, c.fullKey(key))
@@ -91,6 +97,12 @@ func (c *Config) GetObject(key string, output interface{}) error {
 	return GetObject(c.ctx, c.fullKey(key), output)
 }
 
+// GetSecret loads an optional configuration value by its key, as a secret output, or returns an output of "" if it
+// doesn't exist.
+func (c *Config) GetSecret(key string) pulumi.StringOutput {
+	return GetSecret(c.ctx, c.fullKey(key))
+}
+
 // GetUint loads an optional uint configuration value by its key, or returns 0 if it doesn't exist.
 func (c *Config) GetUint(key string) uint {
 	return GetUint(c.ctx, c.fullKey(key))
@@ -121,42 +133,42 @@ func (c *Config) Require(key string) string {
 	return Require(c.ctx, c.fullKey(key))
 }
 
-// RequireBool loads a bool configuration value by its key, or panics if it doesn't exist.
+// RequireBool loads a bool configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireBool(key string) bool {
 	return RequireBool(c.ctx, c.fullKey(key))
 }
 
-// RequireFloat32 loads a float32 configuration value by its key, or panics if it doesn't exist.
+// RequireFloat32 loads a float32 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireFloat32(key string) float32 {
 	return RequireFloat32(c.ctx, c.fullKey(key))
 }
 
-// RequireFloat64 loads a float64 configuration value by its key, or panics if it doesn't exist.
+// RequireFloat64 loads a float64 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireFloat64(key string) float64 {
 	return RequireFloat64(c.ctx, c.fullKey(key))
 }
 
-// RequireInt loads a int configuration value by its key, or panics if it doesn't exist.
+// RequireInt loads a int configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireInt(key string) int {
 	return RequireInt(c.ctx, c.fullKey(key))
 }
 
-// RequireInt8 loads a int8 configuration value by its key, or panics if it doesn't exist.
+// RequireInt8 loads a int8 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireInt8(key string) int8 {
 	return RequireInt8(c.ctx, c.fullKey(key))
 }
 
-// RequireInt16 loads a int16 configuration value by its key, or panics if it doesn't exist.
+// RequireInt16 loads a int16 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireInt16(key string) int16 {
 	return RequireInt16(c.ctx, c.fullKey(key))
 }
 
-// RequireInt32 loads a int32 configuration value by its key, or panics if it doesn't exist.
+// RequireInt32 loads a int32 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireInt32(key string) int32 {
 	return RequireInt32(c.ctx, c.fullKey(key))
 }
 
-// RequireInt64 loads a int64 configuration value by its key, or panics if it doesn't exist.
+// RequireInt64 loads a int64 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireInt64(key string) int64 {
 	return RequireInt64(c.ctx, c.fullKey(key))
 }
@@ -167,27 +179,32 @@ func (c *Config) RequireObject(key string, output interface{}) {
 	RequireObject(c.ctx, c.fullKey(key), output)
 }
 
-// RequireUint loads a uint configuration value by its key, or panics if it doesn't exist.
+// RequireSecret loads a configuration value by its key, as a secret output, or panics if it doesn't exist.
+func (c *Config) RequireSecret(key string) pulumi.StringOutput {
+	return RequireSecret(c.ctx, c.fullKey(key))
+}
+
+// RequireUint loads a uint configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireUint(key string) uint {
 	return RequireUint(c.ctx, c.fullKey(key))
 }
 
-// RequireUint8 loads a uint8 configuration value by its key, or panics if it doesn't exist.
+// RequireUint8 loads a uint8 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireUint8(key string) uint8 {
 	return RequireUint8(c.ctx, c.fullKey(key))
 }
 
-// RequireUint16 loads a uint16 configuration value by its key, or panics if it doesn't exist.
+// RequireUint16 loads a uint16 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireUint16(key string) uint16 {
 	return RequireUint16(c.ctx, c.fullKey(key))
 }
 
-// RequireUint32 loads a uint32 configuration value by its key, or panics if it doesn't exist.
+// RequireUint32 loads a uint32 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireUint32(key string) uint32 {
 	return RequireUint32(c.ctx, c.fullKey(key))
 }
 
-// RequireUint64 loads a uint64 configuration value by its key, or panics if it doesn't exist.
+// RequireUint64 loads a uint64 configuration value by its key, or panics if it is missing or invalid.
 func (c *Config) RequireUint64(key string) uint64 {
 	return RequireUint64(c.ctx, c.fullKey(key))
 }
@@ -197,42 +214,42 @@ func (c *Config) Try(key string) (string, error) {
 	return Try(c.ctx, c.fullKey(key))
 }
 
-// TryBool loads an optional bool configuration value by its key, or returns an error if it doesn't exist.
+// TryBool loads an optional bool configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryBool(key string) (bool, error) {
 	return TryBool(c.ctx, c.fullKey(key))
 }
 
-// TryFloat32 loads an optional float32 configuration value by its key, or returns an error if it doesn't exist.
+// TryFloat32 loads an optional float32 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryFloat32(key string) (float32, error) {
 	return TryFloat32(c.ctx, c.fullKey(key))
 }
 
-// TryFloat64 loads an optional float64 configuration value by its key, or returns an error if it doesn't exist.
+// TryFloat64 loads an optional float64 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryFloat64(key string) (float64, error) {
 	return TryFloat64(c.ctx, c.fullKey(key))
 }
 
-// TryInt loads an optional int configuration value by its key, or returns an error if it doesn't exist.
+// TryInt loads an optional int configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryInt(key string) (int, error) {
 	return TryInt(c.ctx, c.fullKey(key))
 }
 
-// TryInt8 loads an optional int8 configuration value by its key, or returns an error if it doesn't exist.
+// TryInt8 loads an optional int8 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryInt8(key string) (int8, error) {
 	return TryInt8(c.ctx, c.fullKey(key))
 }
 
-// TryInt16 loads an optional int16 configuration value by its key, or returns an error if it doesn't exist.
+// TryInt16 loads an optional int16 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryInt16(key string) (int16, error) {
 	return TryInt16(c.ctx, c.fullKey(key))
 }
 
-// TryInt32 loads an optional int32 configuration value by its key, or returns an error if it doesn't exist.
+// TryInt32 loads an optional int32 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryInt32(key string) (int32, error) {
 	return TryInt32(c.ctx, c.fullKey(key))
 }
 
-// TryInt64 loads an optional int64 configuration value by its key, or returns an error if it doesn't exist.
+// TryInt64 loads an optional int64 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryInt64(key string) (int64, error) {
 	return TryInt64(c.ctx, c.fullKey(key))
 }
@@ -243,27 +260,32 @@ func (c *Config) TryObject(key string, output interface{}) error {
 	return TryObject(c.ctx, c.fullKey(key), output)
 }
 
-// TryUint loads an optional uint configuration value by its key, or returns an error if it doesn't exist.
+// TrySecret loads a configuration value by its key, as a secret output, or returns an error if it doesn't exist.
+func (c *Config) TrySecret(key string) (pulumi.StringOutput, error) {
+	return TrySecret(c.ctx, c.fullKey(key))
+}
+
+// TryUint loads an optional uint configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryUint(key string) (uint, error) {
 	return TryUint(c.ctx, c.fullKey(key))
 }
 
-// TryUint8 loads an optional uint8 configuration value by its key, or returns an error if it doesn't exist.
+// TryUint8 loads an optional uint8 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryUint8(key string) (uint8, error) {
 	return TryUint8(c.ctx, c.fullKey(key))
 }
 
-// TryUint16 loads an optional uint16 configuration value by its key, or returns an error if it doesn't exist.
+// TryUint16 loads an optional uint16 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryUint16(key string) (uint16, error) {
 	return TryUint16(c.ctx, c.fullKey(key))
 }
 
-// TryUint32 loads an optional uint32 configuration value by its key, or returns an error if it doesn't exist.
+// TryUint32 loads an optional uint32 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryUint32(key string) (uint32, error) {
 	return TryUint32(c.ctx, c.fullKey(key))
 }
 
-// TryUint64 loads an optional uint64 configuration value by its key, or returns an error if it doesn't exist.
+// TryUint64 loads an optional uint64 configuration value by its key, or returns an error if it is missing or invalid.
 func (c *Config) TryUint64(key string) (uint64, error) {
 	return TryUint64(c.ctx, c.fullKey(key))
 }
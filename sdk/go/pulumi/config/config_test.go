@@ -16,11 +16,14 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/pulumi/pulumi/sdk/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/go/pulumi/pulumitest"
 )
 
 type TestStruct struct {
@@ -148,4 +151,127 @@ func TestConfig(t *testing.T) {
 	testStruct = TestStruct{}
 	_, err = cfg.Try("missing")
 	assert.NotNil(t, err)
+
+	// Typed Try and Require variants reject values that are not of their type.
+	_, err = cfg.TryInt("sss")
+	assert.EqualError(t, err,
+		"configuration variable 'testpkg:sss' has value \"a string value\", which is not a valid int")
+	_, err = cfg.TryBool("intint")
+	assert.EqualError(t, err, "configuration variable 'testpkg:intint' has value \"42\", which is not a valid bool")
+	_, err = cfg.TryUint8("fpfpfp")
+	assert.NotNil(t, err)
+	_, err = cfg.TryFloat32("missing")
+	assert.EqualError(t, err, "missing required configuration variable 'testpkg:missing'; run `pulumi config` to set")
+	assert.Panics(t, func() { cfg.RequireInt64("sss") })
+}
+
+// TestSecretConfig tests that secret config values are returned as secret outputs.
+func TestSecretConfig(t *testing.T) {
+	ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+		Config: map[string]string{
+			"testpkg:password": "hunter2",
+		},
+	})
+	assert.Nil(t, err)
+
+	cfg := New(ctx, "testpkg")
+
+	assertSecret := func(expected string, out pulumi.StringOutput) {
+		assert.Equal(t, expected, pulumitest.MustAwait(t, out))
+		secret, err := pulumitest.IsSecret(context.Background(), out)
+		assert.Nil(t, err)
+		assert.True(t, secret)
+	}
+
+	assertSecret("hunter2", cfg.GetSecret("password"))
+	assertSecret("", cfg.GetSecret("missing"))
+	assertSecret("hunter2", cfg.RequireSecret("password"))
+	assert.Panics(t, func() { cfg.RequireSecret("missing") })
+
+	out, err := cfg.TrySecret("password")
+	assert.Nil(t, err)
+	assertSecret("hunter2", out)
+	_, err = cfg.TrySecret("missing")
+	assert.NotNil(t, err)
+}
+
+type server struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type servers struct {
+	Servers []server `json:"servers"`
+}
+
+func (s *servers) Validate() error {
+	for i, srv := range s.Servers {
+		if srv.Host == "" {
+			return fmt.Errorf("servers[%d].host is required", i)
+		}
+	}
+	return nil
+}
+
+// TestObjectConfig tests that structured config values are decoded into structs.
+func TestObjectConfig(t *testing.T) {
+	ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+		Config: map[string]string{
+			"testpkg:json":     `{"servers":[{"host":"a","port":80},{"host":"b","port":8080}]}`,
+			"testpkg:yaml":     "servers:\n- host: a\n  port: 80\n- host: b\n  port: 8080\n",
+			"testpkg:badType":  `{"servers":[{"host":"a","port":"eighty"}]}`,
+			"testpkg:badValue": `{"servers":[{"port":80}]}`,
+			"testpkg:badYAML":  "servers: [",
+		},
+	})
+	assert.Nil(t, err)
+
+	cfg := New(ctx, "testpkg")
+	expected := servers{Servers: []server{{Host: "a", Port: 80}, {Host: "b", Port: 8080}}}
+
+	// Both JSON and YAML values are decoded.
+	var fromJSON, fromYAML servers
+	assert.Nil(t, cfg.GetObject("json", &fromJSON))
+	assert.Equal(t, expected, fromJSON)
+	assert.Nil(t, cfg.TryObject("yaml", &fromYAML))
+	assert.Equal(t, expected, fromYAML)
+
+	// Errors point at the offending key.
+	err = cfg.GetObject("badType", &servers{})
+	if assert.Error(t, err) {
+		assert.Regexp(t, "^configuration variable 'testpkg:badType' has an invalid value at 'servers(\\.0)?\\.port': "+
+			"expected int, not string$", err.Error())
+	}
+	assert.EqualError(t, cfg.TryObject("badValue", &servers{}),
+		"configuration variable 'testpkg:badValue' is invalid: servers[0].host is required")
+	err = cfg.GetObject("badYAML", &servers{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "configuration variable 'testpkg:badYAML' is neither valid JSON nor YAML")
+	}
+	assert.Panics(t, func() { cfg.RequireObject("badValue", &servers{}) })
+}
+
+func TestConfigWithEnv(t *testing.T) {
+	ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+		Config: map[string]string{
+			"testpkg:region": "us-east-1",
+		},
+	})
+	assert.Nil(t, err)
+
+	os.Setenv("PULUMI_TEST_REGION", "us-west-2")
+	defer os.Unsetenv("PULUMI_TEST_REGION")
+	os.Setenv("PULUMI_TEST_TOKEN", "abc")
+	defer os.Unsetenv("PULUMI_TEST_TOKEN")
+	os.Unsetenv("PULUMI_TEST_UNSET")
+
+	cfg := New(ctx, "testpkg")
+
+	// Values in the stack's configuration take precedence over the environment.
+	assert.Equal(t, "us-east-1", cfg.GetWithEnv("region", "PULUMI_TEST_REGION"))
+
+	// Otherwise, the first environment variable that is set is used.
+	assert.Equal(t, "abc", cfg.GetWithEnv("token", "PULUMI_TEST_UNSET", "PULUMI_TEST_TOKEN"))
+	assert.Equal(t, "abc", GetWithEnv(ctx, "testpkg:token", "PULUMI_TEST_TOKEN"))
+	assert.Equal(t, "", cfg.GetWithEnv("missing", "PULUMI_TEST_UNSET"))
 }
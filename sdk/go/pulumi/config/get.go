@@ -15,7 +15,7 @@
 package config
 
 import (
-	"encoding/json"
+	"os"
 
 	"github.com/spf13/cast"
 
@@ -28,6 +28,20 @@ func Get(ctx *pulumi.Context, key string) string {
 	return v
 }
 
+// GetWithEnv loads an optional configuration value by its key, or, if it doesn't exist, the value of the first of the
+// given environment variables that is set.  It returns "" if neither the key nor any of the variables is set.
+func GetWithEnv(ctx *pulumi.Context, key string, envVars ...string) string {
+	if v, ok := ctx.GetConfig(key); ok {
+		return v
+	}
+	for _, env := range envVars {
+		if v, ok := os.LookupEnv(env); ok {
+			return v
+		}
+	}
+	return ""
+}
+
 // GetBool loads an optional configuration value by its key, as a bool, or returns false if it doesn't exist.
 func GetBool(ctx *pulumi.Context, key string) bool {
 	if v, ok := ctx.GetConfig(key); ok {
@@ -93,16 +107,22 @@ func GetInt64(ctx *pulumi.Context, key string) int64 {
 }
 
 // GetObject attempts to load an optional configuration value by its key into the specified output variable.
+// The value may be JSON, as set by `pulumi config set --path`, or YAML, and is decoded using the output's JSON field
+// tags.  If the output implements Validator, the decoded value is validated too.
 func GetObject(ctx *pulumi.Context, key string, output interface{}) error {
 	if v, ok := ctx.GetConfig(key); ok {
-		if err := json.Unmarshal([]byte(v), output); err != nil {
-			return err
-		}
+		return unmarshalObject(key, v, output)
 	}
 
 	return nil
 }
 
+// GetSecret loads an optional configuration value by its key, as a secret output, or returns an output of "" if it
+// doesn't exist.  The output's value is encrypted in the stack's checkpoint and masked in the CLI's output.
+func GetSecret(ctx *pulumi.Context, key string) pulumi.StringOutput {
+	return pulumi.StringOutput(pulumi.ToSecret(Get(ctx, key)))
+}
+
 // GetUint loads an optional configuration value by its key, as a uint, or returns 0 if it doesn't exist.
 func GetUint(ctx *pulumi.Context, key string) uint {
 	if v, ok := ctx.GetConfig(key); ok {
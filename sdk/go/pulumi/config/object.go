@@ -0,0 +1,80 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Validator may be implemented by the outputs of GetObject, RequireObject and TryObject to check the values that they
+// are decoded into, such as that required fields are set.
+type Validator interface {
+	Validate() error
+}
+
+// unmarshalObject decodes a structured configuration value, which may be JSON, as it is when set with `pulumi config
+// set --path`, or YAML, into output, using output's JSON field tags.  Errors name the configuration key and the path
+// of the offending field within it.
+func unmarshalObject(key, v string, output interface{}) error {
+	err := json.Unmarshal([]byte(v), output)
+	if _, isSyntaxErr := err.(*json.SyntaxError); isSyntaxErr {
+		var obj interface{}
+		if yamlErr := yaml.Unmarshal([]byte(v), &obj); yamlErr != nil {
+			return errors.Errorf("configuration variable '%s' is neither valid JSON nor YAML: %v", key, err)
+		}
+		b, marshalErr := json.Marshal(yamlToJSON(obj))
+		if marshalErr != nil {
+			return errors.Wrapf(marshalErr, "configuration variable '%s' cannot be decoded", key)
+		}
+		err = json.Unmarshal(b, output)
+	}
+	if typeErr, isTypeErr := err.(*json.UnmarshalTypeError); isTypeErr && typeErr.Field != "" {
+		return errors.Errorf("configuration variable '%s' has an invalid value at '%s': expected %s, not %s",
+			key, typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "configuration variable '%s' cannot be decoded", key)
+	}
+
+	if validator, ok := output.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return errors.Wrapf(err, "configuration variable '%s' is invalid", key)
+		}
+	}
+	return nil
+}
+
+// yamlToJSON converts the maps that YAML decodes, whose keys may be of any type, into maps that JSON can encode.
+func yamlToJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprintf("%v", k)] = yamlToJSON(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = yamlToJSON(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -15,10 +15,6 @@
 package config
 
 import (
-	"encoding/json"
-
-	"github.com/spf13/cast"
-
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/sdk/go/pulumi"
 )
@@ -32,89 +28,134 @@ func Require(ctx *pulumi.Context, key string) string {
 	return v
 }
 
-// RequireBool loads an optional configuration value by its key, as a bool, or panics if it doesn't exist.
+// RequireBool loads a configuration value by its key, as a bool, or panics if it is missing or invalid.
 func RequireBool(ctx *pulumi.Context, key string) bool {
-	v := Require(ctx, key)
-	return cast.ToBool(v)
+	v, err := TryBool(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireFloat32 loads an optional configuration value by its key, as a float32, or panics if it doesn't exist.
+// RequireFloat32 loads a configuration value by its key, as a float32, or panics if it is missing or invalid.
 func RequireFloat32(ctx *pulumi.Context, key string) float32 {
-	v := Require(ctx, key)
-	return cast.ToFloat32(v)
+	v, err := TryFloat32(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireFloat64 loads an optional configuration value by its key, as a float64, or panics if it doesn't exist.
+// RequireFloat64 loads a configuration value by its key, as a float64, or panics if it is missing or invalid.
 func RequireFloat64(ctx *pulumi.Context, key string) float64 {
-	v := Require(ctx, key)
-	return cast.ToFloat64(v)
+	v, err := TryFloat64(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireInt loads an optional configuration value by its key, as a int, or panics if it doesn't exist.
+// RequireInt loads a configuration value by its key, as a int, or panics if it is missing or invalid.
 func RequireInt(ctx *pulumi.Context, key string) int {
-	v := Require(ctx, key)
-	return cast.ToInt(v)
+	v, err := TryInt(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireInt8 loads an optional configuration value by its key, as a int8, or panics if it doesn't exist.
+// RequireInt8 loads a configuration value by its key, as a int8, or panics if it is missing or invalid.
 func RequireInt8(ctx *pulumi.Context, key string) int8 {
-	v := Require(ctx, key)
-	return cast.ToInt8(v)
+	v, err := TryInt8(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireInt16 loads an optional configuration value by its key, as a int16, or panics if it doesn't exist.
+// RequireInt16 loads a configuration value by its key, as a int16, or panics if it is missing or invalid.
 func RequireInt16(ctx *pulumi.Context, key string) int16 {
-	v := Require(ctx, key)
-	return cast.ToInt16(v)
+	v, err := TryInt16(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireInt32 loads an optional configuration value by its key, as a int32, or panics if it doesn't exist.
+// RequireInt32 loads a configuration value by its key, as a int32, or panics if it is missing or invalid.
 func RequireInt32(ctx *pulumi.Context, key string) int32 {
-	v := Require(ctx, key)
-	return cast.ToInt32(v)
+	v, err := TryInt32(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireInt64 loads an optional configuration value by its key, as a int64, or panics if it doesn't exist.
+// RequireInt64 loads a configuration value by its key, as a int64, or panics if it is missing or invalid.
 func RequireInt64(ctx *pulumi.Context, key string) int64 {
-	v := Require(ctx, key)
-	return cast.ToInt64(v)
+	v, err := TryInt64(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
 // RequireObject loads an optional configuration value by its key into the output variable,
 // or panics if unable to do so.
 func RequireObject(ctx *pulumi.Context, key string, output interface{}) {
 	v := Require(ctx, key)
-	if err := json.Unmarshal([]byte(v), output); err != nil {
-		contract.Failf("unable to unmarshall required configuration variable '%s'; %s", key, err.Error())
+	if err := unmarshalObject(key, v, output); err != nil {
+		contract.Failf("%v", err)
 	}
 }
 
-// RequireUint loads an optional configuration value by its key, as a uint, or panics if it doesn't exist.
+// RequireSecret loads a configuration value by its key, as a secret output, or panics if it doesn't exist.  The
+// output's value is encrypted in the stack's checkpoint and masked in the CLI's output.
+func RequireSecret(ctx *pulumi.Context, key string) pulumi.StringOutput {
+	return pulumi.StringOutput(pulumi.ToSecret(Require(ctx, key)))
+}
+
+// RequireUint loads a configuration value by its key, as a uint, or panics if it is missing or invalid.
 func RequireUint(ctx *pulumi.Context, key string) uint {
-	v := Require(ctx, key)
-	return cast.ToUint(v)
+	v, err := TryUint(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireUint8 loads an optional configuration value by its key, as a uint8, or panics if it doesn't exist.
+// RequireUint8 loads a configuration value by its key, as a uint8, or panics if it is missing or invalid.
 func RequireUint8(ctx *pulumi.Context, key string) uint8 {
-	v := Require(ctx, key)
-	return cast.ToUint8(v)
+	v, err := TryUint8(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireUint16 loads an optional configuration value by its key, as a uint16, or panics if it doesn't exist.
+// RequireUint16 loads a configuration value by its key, as a uint16, or panics if it is missing or invalid.
 func RequireUint16(ctx *pulumi.Context, key string) uint16 {
-	v := Require(ctx, key)
-	return cast.ToUint16(v)
+	v, err := TryUint16(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireUint32 loads an optional configuration value by its key, as a uint32, or panics if it doesn't exist.
+// RequireUint32 loads a configuration value by its key, as a uint32, or panics if it is missing or invalid.
 func RequireUint32(ctx *pulumi.Context, key string) uint32 {
-	v := Require(ctx, key)
-	return cast.ToUint32(v)
+	v, err := TryUint32(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
 
-// RequireUint64 loads an optional configuration value by its key, as a uint64, or panics if it doesn't exist.
+// RequireUint64 loads a configuration value by its key, as a uint64, or panics if it is missing or invalid.
 func RequireUint64(ctx *pulumi.Context, key string) uint64 {
-	v := Require(ctx, key)
-	return cast.ToUint64(v)
+	v, err := TryUint64(ctx, key)
+	if err != nil {
+		contract.Failf("%v", err)
+	}
+	return v
 }
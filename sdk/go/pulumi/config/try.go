@@ -15,14 +15,17 @@
 package config
 
 import (
-	"encoding/json"
-
 	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 
 	"github.com/pulumi/pulumi/sdk/go/pulumi"
 )
 
+// invalidValueError returns the error for a configuration value that cannot be converted to the expected type.
+func invalidValueError(key, typ, v string) error {
+	return errors.Errorf("configuration variable '%s' has value %q, which is not a valid %s", key, v, typ)
+}
+
 // Try loads a configuration value by its key, returning a non-nil error if it doesn't exist.
 func Try(ctx *pulumi.Context, key string) (string, error) {
 	v, ok := ctx.GetConfig(key)
@@ -33,76 +36,108 @@ func Try(ctx *pulumi.Context, key string) (string, error) {
 	return v, nil
 }
 
-// TryBool loads an optional configuration value by its key, as a bool, or returns an error if it doesn't exist.
+// TryBool loads a configuration value by its key, as a bool, or returns an error if it is missing or invalid.
 func TryBool(ctx *pulumi.Context, key string) (bool, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return false, err
 	}
-	return cast.ToBool(v), nil
+	result, err := cast.ToBoolE(v)
+	if err != nil {
+		return false, invalidValueError(key, "bool", v)
+	}
+	return result, nil
 }
 
-// TryFloat32 loads an optional configuration value by its key, as a float32, or returns an error if it doesn't exist.
+// TryFloat32 loads a configuration value by its key, as a float32, or returns an error if it is missing or invalid.
 func TryFloat32(ctx *pulumi.Context, key string) (float32, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToFloat32(v), nil
+	result, err := cast.ToFloat32E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "float32", v)
+	}
+	return result, nil
 }
 
-// TryFloat64 loads an optional configuration value by its key, as a float64, or returns an error if it doesn't exist.
+// TryFloat64 loads a configuration value by its key, as a float64, or returns an error if it is missing or invalid.
 func TryFloat64(ctx *pulumi.Context, key string) (float64, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToFloat64(v), nil
+	result, err := cast.ToFloat64E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "float64", v)
+	}
+	return result, nil
 }
 
-// TryInt loads an optional configuration value by its key, as a int, or returns an error if it doesn't exist.
+// TryInt loads a configuration value by its key, as a int, or returns an error if it is missing or invalid.
 func TryInt(ctx *pulumi.Context, key string) (int, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToInt(v), nil
+	result, err := cast.ToIntE(v)
+	if err != nil {
+		return 0, invalidValueError(key, "int", v)
+	}
+	return result, nil
 }
 
-// TryInt8 loads an optional configuration value by its key, as a int8, or returns an error if it doesn't exist.
+// TryInt8 loads a configuration value by its key, as a int8, or returns an error if it is missing or invalid.
 func TryInt8(ctx *pulumi.Context, key string) (int8, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToInt8(v), nil
+	result, err := cast.ToInt8E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "int8", v)
+	}
+	return result, nil
 }
 
-// TryInt16 loads an optional configuration value by its key, as a int16, or returns an error if it doesn't exist.
+// TryInt16 loads a configuration value by its key, as a int16, or returns an error if it is missing or invalid.
 func TryInt16(ctx *pulumi.Context, key string) (int16, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToInt16(v), nil
+	result, err := cast.ToInt16E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "int16", v)
+	}
+	return result, nil
 }
 
-// TryInt32 loads an optional configuration value by its key, as a int32, or returns an error if it doesn't exist.
+// TryInt32 loads a configuration value by its key, as a int32, or returns an error if it is missing or invalid.
 func TryInt32(ctx *pulumi.Context, key string) (int32, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToInt32(v), nil
+	result, err := cast.ToInt32E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "int32", v)
+	}
+	return result, nil
 }
 
-// TryInt64 loads an optional configuration value by its key, as a int64, or returns an error if it doesn't exist.
+// TryInt64 loads a configuration value by its key, as a int64, or returns an error if it is missing or invalid.
 func TryInt64(ctx *pulumi.Context, key string) (int64, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToInt64(v), nil
+	result, err := cast.ToInt64E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "int64", v)
+	}
+	return result, nil
 }
 
 // TryObject loads an optional configuration value by its key into the output variable,
@@ -112,50 +147,80 @@ func TryObject(ctx *pulumi.Context, key string, output interface{}) error {
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal([]byte(v), output)
+	return unmarshalObject(key, v, output)
 }
 
-// TryUint loads an optional configuration value by its key, as a uint, or returns an error if it doesn't exist.
+// TrySecret loads a configuration value by its key, as a secret output, or returns an error if it doesn't exist.  The
+// output's value is encrypted in the stack's checkpoint and masked in the CLI's output.
+func TrySecret(ctx *pulumi.Context, key string) (pulumi.StringOutput, error) {
+	v, err := Try(ctx, key)
+	if err != nil {
+		return pulumi.StringOutput{}, err
+	}
+	return pulumi.StringOutput(pulumi.ToSecret(v)), nil
+}
+
+// TryUint loads a configuration value by its key, as a uint, or returns an error if it is missing or invalid.
 func TryUint(ctx *pulumi.Context, key string) (uint, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToUint(v), nil
+	result, err := cast.ToUintE(v)
+	if err != nil {
+		return 0, invalidValueError(key, "uint", v)
+	}
+	return result, nil
 }
 
-// TryUint8 loads an optional configuration value by its key, as a uint8, or returns an error if it doesn't exist.
+// TryUint8 loads a configuration value by its key, as a uint8, or returns an error if it is missing or invalid.
 func TryUint8(ctx *pulumi.Context, key string) (uint8, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToUint8(v), nil
+	result, err := cast.ToUint8E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "uint8", v)
+	}
+	return result, nil
 }
 
-// TryUint16 loads an optional configuration value by its key, as a uint16, or returns an error if it doesn't exist.
+// TryUint16 loads a configuration value by its key, as a uint16, or returns an error if it is missing or invalid.
 func TryUint16(ctx *pulumi.Context, key string) (uint16, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToUint16(v), nil
+	result, err := cast.ToUint16E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "uint16", v)
+	}
+	return result, nil
 }
 
-// TryUint32 loads an optional configuration value by its key, as a uint32, or returns an error if it doesn't exist.
+// TryUint32 loads a configuration value by its key, as a uint32, or returns an error if it is missing or invalid.
 func TryUint32(ctx *pulumi.Context, key string) (uint32, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToUint32(v), nil
+	result, err := cast.ToUint32E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "uint32", v)
+	}
+	return result, nil
 }
 
-// TryUint64 loads an optional configuration value by its key, as a uint64, or returns an error if it doesn't exist.
+// TryUint64 loads a configuration value by its key, as a uint64, or returns an error if it is missing or invalid.
 func TryUint64(ctx *pulumi.Context, key string) (uint64, error) {
 	v, err := Try(ctx, key)
 	if err != nil {
 		return 0, err
 	}
-	return cast.ToUint64(v), nil
+	result, err := cast.ToUint64E(v)
+	if err != nil {
+		return 0, invalidValueError(key, "uint64", v)
+	}
+	return result, nil
 }
@@ -15,21 +15,46 @@
 package pulumi
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/mapper"
+	"github.com/pulumi/pulumi/pkg/util/retry"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
 	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
 
 // Context handles registration of resources and exposes metadata about the current deployment context.
 type Context struct {
+	*contextState
+
+	parent          Resource                    // the parent of resources registered with this context, by default.
+	transformations []ResourceTransformation    // the transformations applied to resources registered with this context.
+	providers       map[string]ProviderResource // the providers of resources registered with this context, by package.
+	autoNaming      *AutoNaming                 // the naming of resources registered with this context, if any.
+}
+
+// contextState is the state shared by the contexts of a program: the root context, and those of its components.
+type contextState struct {
 	ctx         context.Context
 	info        RunInfo
 	stackR      URN
@@ -41,15 +66,41 @@ type Context struct {
 	rpcs        int         // the number of outstanding RPC requests.
 	rpcsDone    *sync.Cond  // an event signaling completion of RPCs.
 	rpcsLock    *sync.Mutex // a lock protecting the RPC count and event.
+
+	keepSecretsOnce sync.Once // guards the query of whether the resource monitor supports secrets.
+	keepSecrets     bool      // true if the resource monitor supports secrets.
+
+	rpcRetries int // the number of times to retry idempotent monitor RPCs that fail because it is unavailable.
+
+	idempotentRegistrationOnce sync.Once // guards the query of whether the monitor deduplicates registrations.
+	idempotentRegistration     bool      // true if the monitor answers retried registrations without repeating them.
+
+	hooks     *hookServer // the server on which the engine runs the program's resource hooks, if it has any.
+	hooksLock sync.Mutex  // guards the hook server.
 }
 
+// defaultRPCRetries is the number of times that resource monitor RPCs are retried by default.
+const defaultRPCRetries = 5
+
 // NewContext creates a fresh run context out of the given metadata.
 func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
+	mutex := &sync.Mutex{}
+	state := &contextState{
+		ctx:        ctx,
+		info:       info,
+		exports:    make(map[string]interface{}),
+		rpcs:       0,
+		rpcsLock:   mutex,
+		rpcsDone:   sync.NewCond(mutex),
+		rpcRetries: defaultRPCRetries,
+	}
+
 	// Connect to the gRPC endpoints if we have addresses for them.
 	var monitorConn *grpc.ClientConn
 	var monitor pulumirpc.ResourceMonitorClient
 	if addr := info.MonitorAddr; addr != "" {
-		conn, err := grpc.Dial(info.MonitorAddr, grpc.WithInsecure())
+		conn, err := grpc.Dial(info.MonitorAddr, grpc.WithInsecure(), rpcutil.WithLocalDialer(),
+			grpc.WithUnaryInterceptor(state.retryUnavailable))
 		if err != nil {
 			return nil, errors.Wrap(err, "connecting to resource monitor over RPC")
 		}
@@ -60,7 +111,7 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 	var engineConn *grpc.ClientConn
 	var engine pulumirpc.EngineClient
 	if addr := info.EngineAddr; addr != "" {
-		conn, err := grpc.Dial(info.EngineAddr, grpc.WithInsecure())
+		conn, err := grpc.Dial(info.EngineAddr, grpc.WithInsecure(), rpcutil.WithLocalDialer())
 		if err != nil {
 			return nil, errors.Wrap(err, "connecting to engine over RPC")
 		}
@@ -68,23 +119,85 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 		engine = pulumirpc.NewEngineClient(engineConn)
 	}
 
-	mutex := &sync.Mutex{}
-	return &Context{
-		ctx:         ctx,
-		info:        info,
-		exports:     make(map[string]interface{}),
-		monitorConn: monitorConn,
-		monitor:     monitor,
-		engineConn:  engineConn,
-		engine:      engine,
-		rpcs:        0,
-		rpcsLock:    mutex,
-		rpcsDone:    sync.NewCond(mutex),
-	}, nil
+	state.monitorConn, state.monitor = monitorConn, monitor
+	state.engineConn, state.engine = engineConn, engine
+	return &Context{contextState: state}, nil
+}
+
+// idempotentMonitorMethods are the resource monitor's RPCs that may safely be sent more than once.  The others, such
+// as ReadResource and Invoke, may have taken effect even when the call reports that the monitor was unavailable, for
+// example because the connection was reset after the request was sent, and so are never resent.  RegisterResource is
+// resent only to monitors that answer a repeated registration without registering the resource again.
+var idempotentMonitorMethods = map[string]bool{
+	"/pulumirpc.ResourceMonitor/SupportsFeature": true,
+}
+
+const registerResourceMethod = "/pulumirpc.ResourceMonitor/RegisterResource"
+
+// retryUnavailable is a gRPC interceptor for the resource monitor's RPCs that retries the idempotent ones that fail
+// because the monitor is unavailable, such as when the connection to it is reset, backing off between attempts.
+//
+// The other RPCs instead wait for the connection to the monitor to be ready, so that a call made after the connection
+// drops is sent once it has been re-established rather than failing.  gRPC only sends a call once it is connected,
+// and only transparently retries a call that never reached the monitor, so the call still takes effect at most once.
+// Resource registrations are also retried if the monitor deduplicates them, tagged with a request ID that the retries
+// share.
+func (state *contextState) retryUnavailable(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+	idempotent := idempotentMonitorMethods[method]
+	if method == registerResourceMethod && state.rpcRetries > 0 {
+		idempotent = state.supportsIdempotentRegistration(ctx, cc)
+	}
+	if !idempotent {
+		if state.rpcRetries > 0 {
+			opts = append(opts, grpc.WaitForReady(true))
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	// Tag registrations with an ID that every attempt shares, so that the monitor recognizes the retries.
+	if method == registerResourceMethod {
+		ctx = rpcutil.WithRequestID(ctx, uuid.NewV4().String())
+	}
+
+	var err error
+	_, _, _ = retry.Until(ctx, retry.Acceptor{
+		Accept: func(try int, nextRetryTime time.Duration) (bool, interface{}, error) {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || status.Code(err) != codes.Unavailable || try >= state.rpcRetries {
+				return true, nil, nil
+			}
+			logging.V(5).Infof("%s: retrying in %v after error: %v", method, nextRetryTime, err)
+			return false, nil, nil
+		},
+	})
+	return err
+}
+
+// supportsIdempotentRegistration returns true if the resource monitor answers a retried resource registration, tagged
+// with the original's request ID, with the response to the original rather than registering the resource again, so
+// that registrations may be resent.  The answer is queried once and remembered.
+func (state *contextState) supportsIdempotentRegistration(ctx context.Context, cc *grpc.ClientConn) bool {
+	state.idempotentRegistrationOnce.Do(func() {
+		monitor := pulumirpc.NewResourceMonitorClient(cc)
+		resp, err := monitor.SupportsFeature(ctx, &pulumirpc.SupportsFeatureRequest{Id: "idempotentRegistration"})
+		if err != nil {
+			logging.V(9).Infof("SupportsFeature(idempotentRegistration): error: %v", err)
+			return
+		}
+		state.idempotentRegistration = resp.GetHasSupport()
+	})
+	return state.idempotentRegistration
 }
 
 // Close implements io.Closer and relinquishes any outstanding resources held by the context.
 func (ctx *Context) Close() error {
+	if ctx.hooks != nil {
+		if err := ctx.hooks.Close(); err != nil {
+			return err
+		}
+	}
 	if ctx.engineConn != nil {
 		if err := ctx.engineConn.Close(); err != nil {
 			return err
@@ -98,6 +211,39 @@ func (ctx *Context) Close() error {
 	return nil
 }
 
+// Context returns the context.Context of the current deployment.  It is canceled if the program is interrupted, for
+// example because the engine is shutting down mid-update, and may be passed to ApplyWithContext so that callbacks can
+// honor the cancellation.
+func (ctx *Context) Context() context.Context { return ctx.ctx }
+
+// supportsSecrets returns true if the resource monitor understands secret values, so that the values of secret
+// outputs may be sent to it as secrets rather than as plain values.  The answer is queried once and remembered.
+func (ctx *Context) supportsSecrets() bool {
+	ctx.keepSecretsOnce.Do(func() {
+		if ctx.monitor == nil {
+			return
+		}
+		resp, err := ctx.monitor.SupportsFeature(ctx.ctx, &pulumirpc.SupportsFeatureRequest{Id: "secrets"})
+		if err != nil {
+			// Older resource monitors do not implement SupportsFeature, and so do not support secrets either.
+			logging.V(9).Infof("SupportsFeature(secrets): error: %v", err)
+			return
+		}
+		ctx.keepSecrets = resp.GetHasSupport()
+	})
+	return ctx.keepSecrets
+}
+
+// awaitContext returns a context for awaiting the outputs that a resource's inputs depend on, which is done once the
+// program's context is, or once the output timeout elapses, if there is one.  The returned function must be called
+// once awaiting is done.
+func (ctx *Context) awaitContext() (context.Context, context.CancelFunc) {
+	if ctx.info.OutputTimeout <= 0 {
+		return context.WithCancel(ctx.ctx)
+	}
+	return context.WithTimeout(ctx.ctx, ctx.info.OutputTimeout)
+}
+
 // Project returns the current project name.
 func (ctx *Context) Project() string { return ctx.info.Project }
 
@@ -117,47 +263,160 @@ func (ctx *Context) GetConfig(key string) (string, bool) {
 }
 
 // Invoke will invoke a provider's function, identified by its token tok.  This function call is synchronous.
-func (ctx *Context) Invoke(tok string, args map[string]interface{}, opts ...InvokeOpt) (map[string]interface{}, error) {
+//
+// The args may be nil, a map[string]interface{}, or a struct (or pointer to one) whose fields are tagged with the
+// names of the function's arguments, as in `pulumi:"name"`; any Inputs among them are awaited before the call is made.
+// If result is non-nil, it must be a pointer to a struct whose fields are tagged in the same way, or to a
+// map[string]interface{}, into which the function's result is decoded.
+func (ctx *Context) Invoke(tok string, args interface{}, result interface{}, opts ...InvokeOpt) error {
 	if tok == "" {
-		return nil, errors.New("invoke token must not be empty")
+		return errors.New("invoke token must not be empty")
 	}
 
-	// Check for a provider option.
-	var provider string
-	for _, opt := range opts {
-		if opt.Provider != nil {
-			pr, err := ctx.resolveProviderReference(opt.Provider)
-			if err != nil {
-				return nil, err
-			}
-			provider = pr
-			break
-		}
+	argsMap, err := invokeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	provider, err := ctx.invokeProvider(tok, opts)
+	if err != nil {
+		return err
 	}
 
 	// Serialize arguments, first by awaiting them, and then marshaling them to the requisite gRPC values.
 	// TODO[pulumi/pulumi#1483]: feels like we should be propagating dependencies to the outputs, instead of ignoring.
-	rpcArgs, _, _, err := marshalInputs(args, false)
+	awaitCtx, cancel := ctx.awaitContext()
+	defer cancel()
+	rpcArgs, _, _, err := marshalInputs(awaitCtx, argsMap, false, false)
 	if err != nil {
-		return nil, errors.Wrap(err, "marshaling arguments")
+		return wrapErrorf(err, "marshaling arguments")
 	}
 
 	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
 	if err = ctx.beginRPC(); err != nil {
-		return nil, err
+		return err
 	}
 	defer ctx.endRPC()
 
-	// Now, invoke the RPC to the provider synchronously.
-	logging.V(9).Infof("Invoke(%s, #args=%d): RPC call being made synchronously", tok, len(args))
+	logging.V(9).Infof("Invoke(%s, #args=%d): RPC call being made synchronously", tok, len(argsMap))
+	outs, _, err := ctx.invoke(tok, provider, rpcArgs)
+	if err != nil {
+		return err
+	}
+	return decodeInvokeResult(tok, outs, result)
+}
+
+// InvokeOutput invokes a provider's function, identified by its token tok, without waiting for it to complete.  It
+// returns an output that is resolved to the function's result once its arguments, which are as for Invoke, are
+// available and the call completes, and that depends on the resources that the arguments depend on.  If any of the
+// arguments are unknown, as during a preview, the function is not called, and its result is unknown.  If any of them
+// are secret, so is its result.
+func (ctx *Context) InvokeOutput(tok string, args interface{}, opts ...InvokeOpt) MapOutput {
+	out := newOutput()
+	if tok == "" {
+		out.s.reject(errors.New("invoke token must not be empty"))
+		return MapOutput(out)
+	}
+	argsMap, err := invokeArgs(args)
+	if err != nil {
+		out.s.reject(err)
+		return MapOutput(out)
+	}
+
+	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
+	if err = ctx.beginRPC(); err != nil {
+		out.s.reject(err)
+		return MapOutput(out)
+	}
+
+	location := captureCallerLocation()
+	go func() {
+		var outs map[string]interface{}
+		var known, secret bool
+		var err error
+		defer func() {
+			if err != nil {
+				err = wrapErrorf(err, "invoking %s at %s", tok, location)
+			}
+			out.s.fulfillValue(outs, known, secret, err)
+			ctx.endRPC()
+		}()
+
+		provider, err := ctx.invokeProvider(tok, opts)
+		if err != nil {
+			return
+		}
+
+		// Await the arguments, and take on the dependencies of any outputs among them.
+		awaitCtx, cancel := ctx.awaitContext()
+		defer cancel()
+		pmap, pdeps, err := marshalInputMap(awaitCtx, argsMap)
+		if err != nil {
+			err = wrapErrorf(err, "marshaling arguments")
+			return
+		}
+		for _, deps := range pdeps {
+			out.s.addDependencies(deps)
+		}
+
+		// Round-trip the arguments through their gRPC form to find any unknown or secret values among them, and send
+		// them as plain values, as Invoke does.
+		rpcArgs, err := plugin.MarshalProperties(resource.NewPropertyMapFromMap(pmap),
+			plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+		if err != nil {
+			return
+		}
+		props, err := plugin.UnmarshalProperties(rpcArgs, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+		if err != nil {
+			return
+		}
+		secret = props.ContainsSecrets()
+		if props.ContainsUnknowns() {
+			logging.V(9).Infof("InvokeOutput(%s, ...): skipped, as its arguments are unknown", tok)
+			return
+		}
+		if rpcArgs, err = plugin.MarshalProperties(props, plugin.MarshalOptions{}); err != nil {
+			return
+		}
+
+		logging.V(9).Infof("InvokeOutput(%s, #args=%d): Goroutine spawned, RPC call being made", tok, len(argsMap))
+		var secrets map[string]bool
+		outs, secrets, err = ctx.invoke(tok, provider, rpcArgs)
+		known, secret = true, secret || len(secrets) > 0
+	}()
+
+	return MapOutput(out)
+}
+
+// invokeProvider returns the reference to the provider for the invoke of tok: that of a provider option, or else that
+// of a provider for the function's package that applies to the context, if any.
+func (ctx *Context) invokeProvider(tok string, opts []InvokeOpt) (string, error) {
+	providerRes := ctx.providers[packageOf(tok)]
+	for _, opt := range opts {
+		if opt.Provider != nil {
+			providerRes = opt.Provider
+			break
+		}
+	}
+	if providerRes == nil {
+		return "", nil
+	}
+	return ctx.resolveProviderReference(providerRes)
+}
+
+// invoke calls the function identified by tok with the given marshaled arguments, returning its unmarshaled result
+// along with the set of its properties whose values are secret.
+func (ctx *Context) invoke(tok, provider string,
+	args *structpb.Struct) (map[string]interface{}, map[string]bool, error) {
+
 	resp, err := ctx.monitor.Invoke(ctx.ctx, &pulumirpc.InvokeRequest{
 		Tok:      tok,
-		Args:     rpcArgs,
+		Args:     args,
 		Provider: provider,
 	})
 	if err != nil {
 		logging.V(9).Infof("Invoke(%s, ...): error: %v", tok, err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// If there were any failures from the provider, return them.
@@ -168,24 +427,89 @@ func (ctx *Context) Invoke(tok string, args map[string]interface{}, opts ...Invo
 			ferr = multierror.Append(ferr,
 				errors.Errorf("%s invoke failed: %s (%s)", tok, failure.Reason, failure.Property))
 		}
-		return nil, ferr
+		return nil, nil, ferr
 	}
 
-	// Otherwsie, simply unmarshal the output properties and return the result.
-	outs, err := unmarshalOutputs(resp.Return)
+	// Otherwise, simply unmarshal the output properties.
+	outs, secrets, err := unmarshalOutputs(resp.Return)
 	logging.V(9).Infof("Invoke(%s, ...): success: w/ %d outs (err=%v)", tok, len(outs), err)
-	return outs, err
+	return outs, secrets, err
+}
+
+// invokeArgs turns the arguments to an invoke into a property map.  Struct arguments are mapped by the names in their
+// fields' `pulumi` tags, leaving out untagged fields and nil optional ones, and their values are left to marshalInputs,
+// so that any Inputs among them are awaited.
+func invokeArgs(args interface{}) (map[string]interface{}, error) {
+	if args == nil {
+		return nil, nil
+	}
+	if m, ok := args.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	v := reflect.ValueOf(args)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, errors.Errorf("expected invoke arguments to be a map or a struct; got %T", args)
+	}
+
+	m := make(map[string]interface{})
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		tag := strings.Split(field.Tag.Get("pulumi"), ",")
+		if tag[0] == "" || tag[0] == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			if fv.IsNil() && len(tag) > 1 && tag[1] == "optional" {
+				continue
+			}
+		}
+		m[tag[0]] = fv.Interface()
+	}
+	return m, nil
+}
+
+// decodeInvokeResult decodes the result of the invoke of tok into result, which is nil, a pointer to a map, or a
+// pointer to a struct whose fields are tagged with the names of the result's properties.
+func decodeInvokeResult(tok string, outs map[string]interface{}, result interface{}) error {
+	switch result := result.(type) {
+	case nil:
+		return nil
+	case *map[string]interface{}:
+		*result = outs
+		return nil
+	}
+
+	v := reflect.ValueOf(result)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("expected invoke result to be a pointer to a map or a struct; got %T", result)
+	}
+	if err := mapper.MapI(outs, result); err != nil {
+		return errors.Wrapf(err, "decoding result of %s", tok)
+	}
+	return nil
 }
 
-// ReadResource reads an existing custom resource's state from the resource monitor.  Note that resources read in this
-// way will not be part of the resulting stack's state, as they are presumed to belong to another.
+// ReadResource reads an existing custom resource's state from the resource monitor, given its ID, which may be the
+// output of another resource.  Note that resources read in this way will not be part of the resulting stack's state,
+// as they are presumed to belong to another.  The resource is read during previews as well, unless its ID is unknown,
+// in which case its outputs are unknown too.
 func (ctx *Context) ReadResource(
-	t, name string, id ID, props map[string]interface{}, opts ...ResourceOpt) (*ResourceState, error) {
+	t, name string, id IDInput, props map[string]interface{}, opts ...ResourceOpt) (*ResourceState, error) {
 	if t == "" {
 		return nil, errors.New("resource type argument cannot be empty")
 	} else if name == "" {
 		return nil, errors.New("resource name argument (for URN creation) cannot be empty")
-	} else if id == "" {
+	} else if lit, isLit := id.(ID); id == nil || isLit && lit == "" {
 		return nil, errors.New("resource ID is required for lookup and cannot be empty")
 	}
 
@@ -194,8 +518,12 @@ func (ctx *Context) ReadResource(
 		return nil, err
 	}
 
-	// Create resolvers for the resource's outputs.
+	// Apply any transformations, then create resolvers for the resource's outputs, and remember where the read was
+	// requested, in case it fails.
+	props, opts = ctx.transform(t, name, props, opts)
 	res := makeResourceState(true, props)
+	location := captureCallerLocation()
+	idOut := id.ToIDOutput()
 
 	// Kick off the resource read operation.  This will happen asynchronously and resolve the above properties.
 	go func() {
@@ -204,32 +532,63 @@ func (ctx *Context) ReadResource(
 		var state *structpb.Struct
 		var err error
 		defer func() {
+			if err != nil {
+				err = wrapErrorf(err, "reading resource %s (%s) at %s", name, t, location)
+			}
 			res.resolve(ctx.DryRun(), err, props, urn, resID, state)
 			ctx.endRPC()
 		}()
 
 		// Prepare the inputs for an impending operation.
-		inputs, err := ctx.prepareResourceInputs(props, opts...)
+		inputs, err := ctx.prepareResourceInputs(t, props, opts...)
+		if err != nil {
+			return
+		}
+
+		// Wait for the ID, which makes the read depend on the resources that the ID is derived from.  An unknown ID is
+		// sent as such, so that the read is skipped.
+		awaitCtx, cancel := ctx.awaitContext()
+		defer cancel()
+		readID, known, err := idOut.await(awaitCtx)
 		if err != nil {
+			err = wrapErrorf(err, "awaiting ID")
 			return
 		}
+		rpcID := string(readID)
+		if !known {
+			rpcID = rpcTokenUnknownValue
+		}
+		deps := inputs.deps
+		for _, dep := range Output(idOut).Dependencies() {
+			depURN, _, depErr := dep.URN().await(awaitCtx)
+			if depErr != nil {
+				err = wrapErrorf(depErr, "awaiting ID")
+				return
+			}
+			deps = append(deps, string(depURN))
+		}
 
 		logging.V(9).Infof("ReadResource(%s, %s): Goroutine spawned, RPC call being made", t, name)
 		resp, err := ctx.monitor.ReadResource(ctx.ctx, &pulumirpc.ReadResourceRequest{
-			Type:       t,
-			Name:       name,
-			Parent:     inputs.parent,
-			Properties: inputs.rpcProps,
-			Provider:   inputs.provider,
+			Id:            rpcID,
+			Type:          t,
+			Name:          name,
+			Parent:        inputs.parent,
+			Properties:    inputs.rpcProps,
+			Dependencies:  deps,
+			Provider:      inputs.provider,
+			AcceptSecrets: true,
 		})
 		if err != nil {
-			logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
+			logging.V(9).Infof("ReadResource(%s, %s): error: %v", t, name, err)
 		} else {
-			logging.V(9).Infof("RegisterResource(%s, %s): success: %s %s ...", t, name, resp.Urn, id)
+			logging.V(9).Infof("ReadResource(%s, %s): success: %s %s ...", t, name, resp.Urn, rpcID)
 		}
 		if resp != nil {
-			urn, resID = resp.Urn, string(id)
-			state = resp.Properties
+			urn, state = resp.Urn, resp.Properties
+			if known {
+				resID = string(readID)
+			}
 		}
 	}()
 
@@ -246,14 +605,32 @@ func (ctx *Context) RegisterResource(
 	} else if name == "" {
 		return nil, errors.New("resource name argument (for URN creation) cannot be empty")
 	}
+	if !custom {
+		for _, opt := range opts {
+			if opt.Import != "" {
+				return nil, errors.Errorf("resource %s (%s) cannot be imported, as only custom resources may be", name, t)
+			}
+		}
+	}
 
 	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
 	if err := ctx.beginRPC(); err != nil {
 		return nil, err
 	}
 
-	// Create resolvers for the resource's outputs.
+	// Apply any transformations and auto-naming, then create resolvers for the resource's outputs, and remember where
+	// the registration was requested, in case it fails.
+	props, opts = ctx.transform(t, name, props, opts)
+	if custom {
+		named, err := ctx.autoName(t, name, props, opts)
+		if err != nil {
+			ctx.endRPC()
+			return nil, err
+		}
+		props = named
+	}
 	res := makeResourceState(custom, props)
+	location := captureCallerLocation()
 
 	// Kick off the resource registration.  If we are actually performing a deployment, the resulting properties
 	// will be resolved asynchronously as the RPC operation completes.  If we're just planning, values won't resolve.
@@ -263,12 +640,15 @@ func (ctx *Context) RegisterResource(
 		var state *structpb.Struct
 		var err error
 		defer func() {
+			if err != nil {
+				err = wrapErrorf(err, "registering resource %s (%s) at %s", name, t, location)
+			}
 			res.resolve(ctx.DryRun(), err, props, urn, resID, state)
 			ctx.endRPC()
 		}()
 
 		// Prepare the inputs for an impending operation.
-		inputs, err := ctx.prepareResourceInputs(props, opts...)
+		inputs, err := ctx.prepareResourceInputs(t, props, opts...)
 		if err != nil {
 			return
 		}
@@ -288,6 +668,8 @@ func (ctx *Context) RegisterResource(
 			ImportId:             inputs.importID,
 			CustomTimeouts:       inputs.customTimeouts,
 			IgnoreChanges:        inputs.ignoreChanges,
+			AcceptSecrets:        true,
+			Hooks:                hooksBinding(opts),
 		})
 		if err != nil {
 			logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -303,6 +685,42 @@ func (ctx *Context) RegisterResource(
 	return res, nil
 }
 
+// RegisterProviderResource registers a provider resource for the given package, such as "aws", which configures an
+// instance of the package's provider plugin with the given properties rather than with the stack's configuration.
+// Pass it to other resources in their Provider or Providers options to manage them with that instance, for example
+// to manage resources in a second region.
+func (ctx *Context) RegisterProviderResource(
+	pkg, name string, props map[string]interface{}, opts ...ResourceOpt) (*ResourceState, error) {
+	if pkg == "" {
+		return nil, errors.New("provider package argument cannot be empty")
+	}
+	return ctx.RegisterResource("pulumi:providers:"+pkg, name, true, props, opts...)
+}
+
+// transform applies the transformations in a resource's options, and then those of the context, to the resource's
+// properties and options, in order.
+func (ctx *Context) transform(t, name string, props map[string]interface{},
+	opts []ResourceOpt) (map[string]interface{}, []ResourceOpt) {
+
+	for _, transformation := range ctx.transformationsFor(opts) {
+		result := transformation(&ResourceTransformationArgs{Type: t, Name: name, Props: props, Opts: opts})
+		if result != nil {
+			props, opts = result.Props, result.Opts
+		}
+	}
+	return props, opts
+}
+
+// transformationsFor returns the transformations that apply to a resource with the given options: those in the options,
+// followed by those of the context.
+func (ctx *Context) transformationsFor(opts []ResourceOpt) []ResourceTransformation {
+	var transformations []ResourceTransformation
+	for _, opt := range opts {
+		transformations = append(transformations, opt.Transformations...)
+	}
+	return append(transformations, ctx.transformations...)
+}
+
 // ResourceState contains the results of a resource registration operation.
 type ResourceState struct {
 	// urn will resolve to the resource's URN after registration has completed.
@@ -346,8 +764,9 @@ func makeResourceState(custom bool, props map[string]interface{}) *ResourceState
 func (state *ResourceState) resolve(dryrun bool, err error, inputs map[string]interface{}, urn, id string,
 	result *structpb.Struct) {
 	var outprops map[string]interface{}
+	var secrets map[string]bool
 	if err == nil {
-		outprops, err = unmarshalOutputs(result)
+		outprops, secrets, err = unmarshalOutputs(result)
 	}
 	if err != nil {
 		// If there was an error, we must reject everything: URN, ID, and state properties.
@@ -382,7 +801,7 @@ func (state *ResourceState) resolve(dryrun bool, err error, inputs map[string]in
 			// if any exists.
 			v = inputs[k]
 		}
-		o.s.resolve(v, isKnown(v))
+		o.s.fulfillValue(v, isKnown(v), secrets[k], nil)
 	}
 }
 
@@ -400,22 +819,31 @@ type resourceInputs struct {
 	ignoreChanges       []string
 }
 
-// prepareResourceInputs prepares the inputs for a resource operation, shared between read and register.
-func (ctx *Context) prepareResourceInputs(props map[string]interface{}, opts ...ResourceOpt) (*resourceInputs, error) {
+// prepareResourceInputs prepares the inputs for a resource operation of the given type, shared between read and
+// register.
+func (ctx *Context) prepareResourceInputs(t string, props map[string]interface{},
+	opts ...ResourceOpt) (*resourceInputs, error) {
+
 	// Get the parent and dependency URNs from the options, in addition to the protection bit.  If there wasn't an
-	// explicit parent, and a root stack resource exists, we will automatically parent to that.
-	parent, optDeps, protect, provider, deleteBeforeReplace, importID, ignoreChanges, err := ctx.getOpts(opts...)
+	// explicit parent, we will automatically parent to the context's component, if any, or else to the root stack
+	// resource, if it exists.
+	parent, optDeps, protect, provider, deleteBeforeReplace, importID, ignoreChanges, err := ctx.getOpts(t, opts...)
 	if err != nil {
-		return nil, errors.Wrap(err, "resolving options")
+		return nil, wrapErrorf(err, "resolving options")
 	}
 
-	timeouts := ctx.getTimeouts(opts...)
+	timeouts, err := ctx.getTimeouts(opts...)
+	if err != nil {
+		return nil, wrapErrorf(err, "resolving options")
+	}
 
 	// Serialize all properties, first by awaiting them, and then marshaling them to the requisite gRPC values.
 	keepUnknowns := ctx.DryRun()
-	rpcProps, propertyDeps, rpcDeps, err := marshalInputs(props, keepUnknowns)
+	awaitCtx, cancel := ctx.awaitContext()
+	defer cancel()
+	rpcProps, propertyDeps, rpcDeps, err := marshalInputs(awaitCtx, props, keepUnknowns, ctx.supportsSecrets())
 	if err != nil {
-		return nil, errors.Wrap(err, "marshaling properties")
+		return nil, wrapErrorf(err, "marshaling properties")
 	}
 
 	// Convert the property dependencies map for RPC and remove duplicates.
@@ -461,24 +889,39 @@ func (ctx *Context) prepareResourceInputs(props map[string]interface{}, opts ...
 	}, nil
 }
 
-func (ctx *Context) getTimeouts(opts ...ResourceOpt) *pulumirpc.RegisterResourceRequest_CustomTimeouts {
+// getTimeouts returns the custom timeouts in a set of resource options, checking that they are valid durations.
+func (ctx *Context) getTimeouts(opts ...ResourceOpt) (*pulumirpc.RegisterResourceRequest_CustomTimeouts, error) {
 	var timeouts pulumirpc.RegisterResourceRequest_CustomTimeouts
 	for _, opt := range opts {
 		if opt.CustomTimeouts != nil {
 			timeouts.Update = opt.CustomTimeouts.Update
 			timeouts.Create = opt.CustomTimeouts.Create
 			timeouts.Delete = opt.CustomTimeouts.Delete
+			break
+		}
+	}
+
+	ops := []string{"create", "update", "delete"}
+	for i, timeout := range []string{timeouts.Create, timeouts.Update, timeouts.Delete} {
+		if timeout == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(timeout); err != nil {
+			return nil, errors.Errorf("invalid %s timeout %q: expected a duration such as \"30m\"", ops[i], timeout)
 		}
 	}
 
-	return &timeouts
+	return &timeouts, nil
 }
 
 // getOpts returns a set of resource options from an array of them. This includes the parent URN, any dependency URNs,
 // a boolean indicating whether the resource is to be protected, and the URN and ID of the resource's provider, if any.
-func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool, ID, []string, error) {
+// Unless the options name a provider explicitly, the provider is that of the resource type's package, if any, in the
+// options' providers or the context's.
+func (ctx *Context) getOpts(t string, opts ...ResourceOpt) (URN, []URN, bool, string, bool, ID, []string, error) {
 	var parent Resource
 	var deps []Resource
+	var depInputs []ResourceArrayInput
 	var protect bool
 	var provider ProviderResource
 	var deleteBeforeReplace bool
@@ -488,9 +931,8 @@ func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool
 		if parent == nil && opt.Parent != nil {
 			parent = opt.Parent
 		}
-		if deps == nil && opt.DependsOn != nil {
-			deps = opt.DependsOn
-		}
+		deps = append(deps, opt.DependsOn...)
+		depInputs = append(depInputs, opt.DependsOnInputs...)
 		if !protect && opt.Protect {
 			protect = true
 		}
@@ -503,9 +945,11 @@ func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool
 		if importID == "" && opt.Import != "" {
 			importID = opt.Import
 		}
-		if ignoreChanges == nil && opt.IgnoreChanges != nil {
-			ignoreChanges = opt.IgnoreChanges
-		}
+		ignoreChanges = append(ignoreChanges, opt.IgnoreChanges...)
+	}
+
+	if parent == nil {
+		parent = ctx.parent
 	}
 
 	var parentURN URN
@@ -519,6 +963,16 @@ func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool
 		parentURN = urn
 	}
 
+	if depInputs != nil {
+		awaitCtx, cancel := ctx.awaitContext()
+		resources, err := awaitDependsOnInputs(awaitCtx, depInputs)
+		cancel()
+		if err != nil {
+			return "", nil, false, "", false, "", nil, err
+		}
+		deps = append(deps, resources...)
+	}
+
 	var depURNs []URN
 	if deps != nil {
 		depURNs = make([]URN, len(deps))
@@ -531,6 +985,10 @@ func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool
 		}
 	}
 
+	if provider == nil {
+		provider = ctx.providersFor(opts)[packageOf(t)]
+	}
+
 	var providerRef string
 	if provider != nil {
 		pr, err := ctx.resolveProviderReference(provider)
@@ -540,7 +998,57 @@ func (ctx *Context) getOpts(opts ...ResourceOpt) (URN, []URN, bool, string, bool
 		providerRef = pr
 	}
 
-	return parentURN, depURNs, protect, providerRef, false, importID, ignoreChanges, nil
+	return parentURN, depURNs, protect, providerRef, deleteBeforeReplace, importID, ignoreChanges, nil
+}
+
+// providersFor returns the providers, by package, that apply to a resource with the given options: those in the
+// options, and those of the context for any other packages.
+func (ctx *Context) providersFor(opts []ResourceOpt) map[string]ProviderResource {
+	providers := make(map[string]ProviderResource)
+	for pkg, provider := range ctx.providers {
+		providers[pkg] = provider
+	}
+	for i := len(opts) - 1; i >= 0; i-- {
+		for pkg, provider := range opts[i].Providers {
+			providers[pkg] = provider
+		}
+	}
+	return providers
+}
+
+// awaitDependsOnInputs waits for the DependsOnInputs of a resource's options, and returns the resources that they
+// resolve to along with the resources that they themselves depend on.  Inputs whose values are unknown contribute only
+// their dependencies.  Waiting fails once the given context is done.
+func awaitDependsOnInputs(ctx context.Context, inputs []ResourceArrayInput) ([]Resource, error) {
+	var resources []Resource
+	for _, input := range inputs {
+		if input == nil {
+			continue
+		}
+		out := input.ToResourceArrayOutput()
+		v, known, err := out.s.await(ctx)
+		if err == context.DeadlineExceeded {
+			return nil, errors.New(
+				"timed out awaiting DependsOnInputs; an output that they depend on may never be resolved")
+		} else if err != nil {
+			return nil, err
+		}
+		resources = append(resources, out.s.dependencies()...)
+		if !known {
+			continue
+		}
+		rs, err := toResources(v)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, rs...)
+	}
+	return resources, nil
+}
+
+// packageOf returns the package of a resource type or function token, such as "aws" for "aws:s3/bucket:Bucket".
+func packageOf(tok string) string {
+	return strings.SplitN(tok, ":", 2)[0]
 }
 
 func (ctx *Context) resolveProviderReference(provider ProviderResource) (string, error) {
@@ -611,15 +1119,18 @@ var _ ProviderResource = (*ResourceState)(nil)
 // RegisterResourceOutputs completes the resource registration, attaching an optional set of computed outputs.
 func (ctx *Context) RegisterResourceOutputs(urn URN, outs map[string]interface{}) error {
 	keepUnknowns := ctx.DryRun()
-	outsMarshalled, _, _, err := marshalInputs(outs, keepUnknowns)
+	awaitCtx, cancel := ctx.awaitContext()
+	defer cancel()
+	outsMarshalled, _, _, err := marshalInputs(awaitCtx, outs, keepUnknowns, ctx.supportsSecrets())
 	if err != nil {
-		return errors.Wrap(err, "marshaling outputs")
+		return wrapErrorf(err, "marshaling outputs")
 	}
 
 	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
 	if err = ctx.beginRPC(); err != nil {
 		return err
 	}
+	defer ctx.endRPC()
 
 	// Register the outputs
 	logging.V(9).Infof("RegisterResourceOutputs(%s): RPC call being made", urn)
@@ -632,13 +1143,54 @@ func (ctx *Context) RegisterResourceOutputs(urn URN, outs map[string]interface{}
 	}
 
 	logging.V(9).Infof("RegisterResourceOutputs(%s): success", urn)
-
-	// Signal the completion of this RPC and notify any potential awaiters.
-	ctx.endRPC()
 	return nil
 }
 
-// Export registers a key and value pair with the current context's stack.
+// Export registers a key and value pair with the current context's stack.  The stack's outputs are registered
+// together, in a single call, once the program's body has finished running.
 func (ctx *Context) Export(name string, value interface{}) {
 	ctx.exports[name] = value
 }
+
+// ExportAll registers each of the given key and value pairs with the current context's stack, replacing the values
+// of any keys that have already been exported.
+func (ctx *Context) ExportAll(outputs map[string]interface{}) {
+	for name, value := range outputs {
+		ctx.exports[name] = value
+	}
+}
+
+// sdkPackagePrefix is the prefix of the names of functions in this package.
+const sdkPackagePrefix = "github.com/pulumi/pulumi/sdk/go/pulumi."
+
+// callerLocation is the call stack of program code that called into this package, such as to register a resource or
+// apply a function to an output.  Only the stack's program counters are captured, as that is cheap enough to do on
+// every call; they are resolved to the location of the innermost caller outside of this package only if an error
+// message needs it.
+type callerLocation []uintptr
+
+// captureCallerLocation captures the call stack of its caller.
+func captureCallerLocation() callerLocation {
+	pcs := make([]uintptr, 32)
+	return callerLocation(pcs[:runtime.Callers(2, pcs)])
+}
+
+// String returns the file and line of the innermost caller outside of this package.
+func (l callerLocation) String() string {
+	frames := runtime.CallersFrames(l)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, sdkPackagePrefix) || strings.HasSuffix(frame.File, "_test.go") {
+			file := frame.File
+			if wd, err := os.Getwd(); err == nil {
+				if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+					file = rel
+				}
+			}
+			return fmt.Sprintf("%s:%d", file, frame.Line)
+		}
+		if !more {
+			return "<unknown>"
+		}
+	}
+}
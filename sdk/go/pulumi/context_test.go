@@ -0,0 +1,691 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// tagWith returns a transformation that appends the given tag to a resource's "tags" property.
+func tagWith(tag string) ResourceTransformation {
+	return func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+		props := map[string]interface{}{}
+		for k, v := range args.Props {
+			props[k] = v
+		}
+		tags, _ := props["tags"].(string)
+		props["tags"] = tags + tag
+		return &ResourceTransformationResult{Props: props, Opts: args.Opts}
+	}
+}
+
+func TestTransformations(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	// Stack-level transformations are given as run options.
+	var options runOptions
+	WithTransformations(tagWith("s"))(&options)
+	ctx.transformations = options.transformations
+
+	// A resource's own transformations apply before those of its component, which apply before the stack's.
+	comp, err := NewComponentResource(ctx, "test:index:Component", "comp", ResourceOpt{
+		Transformations: []ResourceTransformation{tagWith("c")},
+	})
+	assert.NoError(t, err)
+	_, err = comp.Context().RegisterResource("test:index:Child", "child", true, nil, ResourceOpt{
+		Transformations: []ResourceTransformation{tagWith("r")},
+	})
+	assert.NoError(t, err)
+	_, err = ctx.RegisterResource("test:index:Child", "plain", true, map[string]interface{}{"tags": "p"})
+	assert.NoError(t, err)
+
+	// Transformations may rewrite options as well, and may leave resources unchanged by returning nil.
+	reparent := func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+		if args.Name != "moved" {
+			return nil
+		}
+		return &ResourceTransformationResult{Props: args.Props, Opts: []ResourceOpt{{Parent: comp}}}
+	}
+	_, err = ctx.RegisterResource("test:index:Child", "moved", true, nil, ResourceOpt{
+		Transformations: []ResourceTransformation{reparent},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, ctx.RegisterComponentOutputs(comp, nil))
+	ctx.waitForRPCs()
+
+	assert.Equal(t, map[string]interface{}{"tags": "cs"}, monitor.inputs["test:index:Component::comp"])
+	assert.Equal(t, map[string]interface{}{"tags": "rcs"}, monitor.inputs["test:index:Child::child"])
+	assert.Equal(t, map[string]interface{}{"tags": "ps"}, monitor.inputs["test:index:Child::plain"])
+	assert.Equal(t, "test:index:Component::comp", monitor.parents["test:index:Child::moved"])
+}
+
+func TestProviders(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	west, err := ctx.RegisterProviderResource("aws", "west", map[string]interface{}{"region": "us-west-2"})
+	assert.NoError(t, err)
+	east, err := ctx.RegisterProviderResource("aws", "east", map[string]interface{}{"region": "us-east-1"})
+	assert.NoError(t, err)
+
+	// Resources use the provider that they name, or else the provider of their package.
+	_, err = ctx.RegisterResource("aws:s3/bucket:Bucket", "a", true, nil, ResourceOpt{Provider: west})
+	assert.NoError(t, err)
+	_, err = ctx.RegisterResource("aws:s3/bucket:Bucket", "b", true, nil, ResourceOpt{
+		Providers: map[string]ProviderResource{"aws": east},
+	})
+	assert.NoError(t, err)
+	_, err = ctx.RegisterResource("aws:s3/bucket:Bucket", "c", true, nil)
+	assert.NoError(t, err)
+
+	// The children of a component use the component's providers for their packages, unless they name their own.
+	comp, err := NewComponentResource(ctx, "test:index:Component", "comp", ResourceOpt{
+		Providers: map[string]ProviderResource{"aws": west},
+	})
+	assert.NoError(t, err)
+	_, err = comp.Context().RegisterResource("aws:s3/bucket:Bucket", "d", true, nil)
+	assert.NoError(t, err)
+	_, err = comp.Context().RegisterResource("aws:s3/bucket:Bucket", "e", true, nil, ResourceOpt{Provider: east})
+	assert.NoError(t, err)
+	_, err = comp.Context().RegisterResource("gcp:storage/bucket:Bucket", "f", true, nil)
+	assert.NoError(t, err)
+	err = comp.Context().Invoke("aws:index/getAmi:getAmi", nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, ctx.RegisterComponentOutputs(comp, nil))
+	ctx.waitForRPCs()
+
+	westRef := "pulumi:providers:aws::west::west-id"
+	eastRef := "pulumi:providers:aws::east::east-id"
+	assert.Equal(t, map[string]interface{}{"region": "us-west-2"}, monitor.inputs["pulumi:providers:aws::west"])
+	assert.Equal(t, westRef, monitor.providers["aws:s3/bucket:Bucket::a"])
+	assert.Equal(t, eastRef, monitor.providers["aws:s3/bucket:Bucket::b"])
+	assert.Equal(t, "", monitor.providers["aws:s3/bucket:Bucket::c"])
+	assert.Equal(t, westRef, monitor.providers["aws:s3/bucket:Bucket::d"])
+	assert.Equal(t, eastRef, monitor.providers["aws:s3/bucket:Bucket::e"])
+	assert.Equal(t, "", monitor.providers["gcp:storage/bucket:Bucket::f"])
+	assert.Equal(t, westRef, monitor.providers["aws:index/getAmi:getAmi"])
+}
+
+func TestResourceOptions(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	// Protect and DeleteBeforeReplace are sent with the registration.
+	_, err = ctx.RegisterResource("test:index:Resource", "both", true, nil, ResourceOpt{
+		Protect:             true,
+		DeleteBeforeReplace: true,
+	})
+	assert.NoError(t, err)
+	_, err = ctx.RegisterResource("test:index:Resource", "neither", true, nil)
+	assert.NoError(t, err)
+
+	// Like the other options, they are set if any of the resource's options set them.
+	_, err = ctx.RegisterResource("test:index:Resource", "split", true, nil,
+		ResourceOpt{Protect: true}, ResourceOpt{DeleteBeforeReplace: true})
+	assert.NoError(t, err)
+	ctx.waitForRPCs()
+
+	both := monitor.requests["test:index:Resource::both"]
+	assert.True(t, both.GetProtect())
+	assert.True(t, both.GetDeleteBeforeReplace())
+	neither := monitor.requests["test:index:Resource::neither"]
+	assert.False(t, neither.GetProtect())
+	assert.False(t, neither.GetDeleteBeforeReplace())
+	split := monitor.requests["test:index:Resource::split"]
+	assert.True(t, split.GetProtect())
+	assert.True(t, split.GetDeleteBeforeReplace())
+}
+
+func TestCustomTimeouts(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	// Timeouts are sent as duration strings, and may be given as durations.
+	_, err = ctx.RegisterResource("test:index:Database", "db", true, nil, ResourceOpt{
+		CustomTimeouts: NewCustomTimeouts(time.Hour, 90*time.Minute, 0),
+	})
+	assert.NoError(t, err)
+
+	// Malformed timeouts fail the registration.
+	bad, err := ctx.RegisterResource("test:index:Database", "bad", true, nil, ResourceOpt{
+		CustomTimeouts: &CustomTimeouts{Create: "forever"},
+	})
+	assert.NoError(t, err)
+	_, _, err = bad.URN().await(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `resolving options: invalid create timeout "forever"`)
+	}
+	ctx.waitForRPCs()
+
+	timeouts := monitor.requests["test:index:Database::db"].GetCustomTimeouts()
+	assert.Equal(t, "1h0m0s", timeouts.GetCreate())
+	assert.Equal(t, "1h30m0s", timeouts.GetUpdate())
+	assert.Equal(t, "", timeouts.GetDelete())
+	assert.NotContains(t, monitor.requests, "test:index:Database::bad")
+}
+
+func TestImport(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	// The ID of the resource to import is sent with the registration.
+	_, err = ctx.RegisterResource("aws:s3/bucket:Bucket", "existing", true,
+		map[string]interface{}{"bucket": "my-bucket"}, ResourceOpt{Import: "my-bucket"})
+	assert.NoError(t, err)
+	_, err = ctx.RegisterResource("aws:s3/bucket:Bucket", "fresh", true, nil)
+	assert.NoError(t, err)
+
+	// Components cannot be imported.
+	_, err = NewComponentResource(ctx, "test:index:Component", "comp", ResourceOpt{Import: "comp"})
+	assert.EqualError(t, err, "resource comp (test:index:Component) cannot be imported, as only custom resources may be")
+	ctx.waitForRPCs()
+
+	assert.Equal(t, "my-bucket", monitor.requests["aws:s3/bucket:Bucket::existing"].GetImportId())
+	assert.Equal(t, "", monitor.requests["aws:s3/bucket:Bucket::fresh"].GetImportId())
+}
+
+func TestReadResource(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev", DryRun: true})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	state, _, _, err := marshalInputs(context.Background(), map[string]interface{}{"arn": "arn:aws:s3:::logs"},
+		true, true)
+	assert.NoError(t, err)
+	monitor.reads["logs"] = state
+
+	// Resources are read by their IDs, and their outputs are resolved to their state.
+	logs, err := ctx.ReadResource("aws:s3/bucket:Bucket", "logs", ID("logs"), map[string]interface{}{"arn": nil})
+	assert.NoError(t, err)
+	v, known, err := logs.State["arn"].s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "arn:aws:s3:::logs", v)
+	id, _, err := logs.ID().await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ID("logs"), id)
+
+	// IDs may be outputs of other resources, which the reads depend on.
+	bucket, err := ctx.RegisterResource("aws:s3/bucket:Bucket", "logs", true, nil)
+	assert.NoError(t, err)
+	byOutput, err := ctx.ReadResource("aws:s3/bucket:Bucket", "byOutput", IDOutput(bucket.ID().ApplyWithContext(
+		context.Background(), func(_ context.Context, _ ID) (interface{}, error) {
+			return ID("logs"), nil
+		})), map[string]interface{}{"arn": nil})
+	assert.NoError(t, err)
+	v, _, err = byOutput.State["arn"].s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "arn:aws:s3:::logs", v)
+
+	// Reads whose IDs are unknown during previews have unknown outputs.
+	unknown := newOutput()
+	unknown.s.resolve(nil, false)
+	skipped, err := ctx.ReadResource("aws:s3/bucket:Bucket", "skipped", IDOutput(unknown),
+		map[string]interface{}{"arn": nil})
+	assert.NoError(t, err)
+	_, known, err = skipped.State["arn"].s.await(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, known)
+	_, known, err = skipped.ID().await(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, known)
+
+	// IDs are required.
+	_, err = ctx.ReadResource("aws:s3/bucket:Bucket", "none", ID(""), nil)
+	assert.EqualError(t, err, "resource ID is required for lookup and cannot be empty")
+	ctx.waitForRPCs()
+
+	assert.Equal(t, []string{"aws:s3/bucket:Bucket::logs"},
+		monitor.readRequests["aws:s3/bucket:Bucket::byOutput"].GetDependencies())
+	assert.Equal(t, rpcTokenUnknownValue, monitor.readRequests["aws:s3/bucket:Bucket::skipped"].GetId())
+}
+
+func TestInvoke(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	ret, _, _, err := marshalInputs(context.Background(), map[string]interface{}{
+		"id":        "ami-1234",
+		"sizeGb":    8,
+		"tags":      map[string]interface{}{"os": "linux"},
+		"ignored":   true,
+		"snapshots": []interface{}{"snap-1", "snap-2"},
+	}, true, true)
+	assert.NoError(t, err)
+	monitor.results["aws:index/getAmi:getAmi"] = ret
+
+	type getAmiArgs struct {
+		Owner      StringInput `pulumi:"owner"`
+		MostRecent bool        `pulumi:"mostRecent"`
+		NameRegex  *string     `pulumi:"nameRegex,optional"`
+		internal   string
+	}
+	type getAmiResult struct {
+		ID        string            `pulumi:"id"`
+		SizeGB    int               `pulumi:"sizeGb"`
+		Tags      map[string]string `pulumi:"tags"`
+		Snapshots []string          `pulumi:"snapshots"`
+		Name      string            `pulumi:"name,optional"`
+	}
+
+	// Struct arguments are awaited and marshaled by their tags, and results are decoded into structs by theirs.
+	owner, resolve, _ := NewOutput()
+	go resolve("amazon")
+	var result getAmiResult
+	err = ctx.Invoke("aws:index/getAmi:getAmi", &getAmiArgs{
+		Owner:      StringOutput(owner),
+		MostRecent: true,
+		internal:   "x",
+	}, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"owner": "amazon", "mostRecent": true},
+		monitor.inputs["aws:index/getAmi:getAmi"])
+	assert.Equal(t, getAmiResult{
+		ID:        "ami-1234",
+		SizeGB:    8,
+		Tags:      map[string]string{"os": "linux"},
+		Snapshots: []string{"snap-1", "snap-2"},
+	}, result)
+
+	// Maps work for both, and results may be ignored.
+	var raw map[string]interface{}
+	err = ctx.Invoke("aws:index/getAmi:getAmi", map[string]interface{}{"owner": String("self")}, &raw)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"owner": "self"}, monitor.inputs["aws:index/getAmi:getAmi"])
+	assert.Equal(t, "ami-1234", raw["id"])
+	assert.NoError(t, ctx.Invoke("aws:index/getAmi:getAmi", nil, nil))
+
+	// Results that do not match the result's type, and arguments and results of other types, are rejected.
+	var wrong struct {
+		ID int `pulumi:"id"`
+	}
+	err = ctx.Invoke("aws:index/getAmi:getAmi", nil, &wrong)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "decoding result of aws:index/getAmi:getAmi")
+	}
+	err = ctx.Invoke("aws:index/getAmi:getAmi", "owner", nil)
+	assert.EqualError(t, err, "expected invoke arguments to be a map or a struct; got string")
+	err = ctx.Invoke("aws:index/getAmi:getAmi", nil, result)
+	assert.EqualError(t, err,
+		"expected invoke result to be a pointer to a map or a struct; got pulumi.getAmiResult")
+}
+
+func TestInvokeOutput(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev", DryRun: true})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	ret, _, _, err := marshalInputs(context.Background(), map[string]interface{}{"arn": "arn:aws:s3:::logs"},
+		true, true)
+	assert.NoError(t, err)
+	monitor.results["aws:s3/getBucket:getBucket"] = ret
+
+	// Invokes wait for their arguments, and their results depend on the resources that their arguments do.
+	bucket, err := ctx.RegisterResource("aws:s3/bucket:Bucket", "logs", true, nil)
+	assert.NoError(t, err)
+	result := ctx.InvokeOutput("aws:s3/getBucket:getBucket", map[string]interface{}{"bucket": bucket.ID()})
+	v, known, err := result.MapIndex(String("arn")).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "arn:aws:s3:::logs", v)
+	assert.Equal(t, map[string]interface{}{"bucket": "logs-id"}, monitor.inputs["aws:s3/getBucket:getBucket"])
+	if deps := Output(result).Dependencies(); assert.Len(t, deps, 1) {
+		assert.True(t, deps[0] == bucket)
+	}
+
+	// Secret arguments make for secret results, and are sent as plain values.
+	secret := ctx.InvokeOutput("aws:s3/getBucket:getBucket", map[string]interface{}{"bucket": ToSecret("private")})
+	_, known, isSecret, err := secret.s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, isSecret)
+	assert.Equal(t, map[string]interface{}{"bucket": "private"}, monitor.inputs["aws:s3/getBucket:getBucket"])
+
+	// Invokes with unknown arguments are not made, and their results are unknown.
+	unknown := newOutput()
+	unknown.s.resolve(nil, false)
+	_, known, err = ctx.InvokeOutput("aws:s3/getBucket:getSkipped", map[string]interface{}{"bucket": unknown}).
+		s.await(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, known)
+
+	// Failures reject the result.
+	_, _, err = ctx.InvokeOutput("aws:s3/getBucket:getBucket", "logs").s.await(context.Background())
+	assert.EqualError(t, err, "expected invoke arguments to be a map or a struct; got string")
+	ctx.waitForRPCs()
+
+	_, invoked := monitor.providers["aws:s3/getBucket:getSkipped"]
+	assert.False(t, invoked)
+}
+
+func TestDependsOnInputs(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	a, err := ctx.RegisterResource("test:index:Resource", "a", true, nil)
+	assert.NoError(t, err)
+	b, err := ctx.RegisterResource("test:index:Resource", "b", true, nil)
+	assert.NoError(t, err)
+	c, err := ctx.RegisterResource("test:index:Resource", "c", true, nil)
+	assert.NoError(t, err)
+
+	// Dependencies may be computed by applies, which are themselves depended on, as well as given directly.
+	conditional := ResourceArrayOutput(a.ID().Apply(func(id ID) (interface{}, error) {
+		return []Resource{b}, nil
+	}))
+	_, err = ctx.RegisterResource("test:index:Resource", "d", true, nil, ResourceOpt{
+		DependsOn:       []Resource{c},
+		DependsOnInputs: []ResourceArrayInput{conditional, ResourceArray{c}},
+	})
+	assert.NoError(t, err)
+
+	// Unknown dependencies are left out, and those of the wrong type are rejected.
+	unknown := newOutput()
+	unknown.s.resolve(nil, false)
+	_, err = ctx.RegisterResource("test:index:Resource", "e", true, nil, ResourceOpt{
+		DependsOnInputs: []ResourceArrayInput{ResourceArrayOutput(unknown)},
+	})
+	assert.NoError(t, err)
+	wrong, err := ctx.RegisterResource("test:index:Resource", "f", true, nil, ResourceOpt{
+		DependsOnInputs: []ResourceArrayInput{ResourceArrayOutput(String("a").ToStringOutput())},
+	})
+	assert.NoError(t, err)
+	_, _, err = wrong.URN().await(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "expected a list of resources, got string")
+	}
+	ctx.waitForRPCs()
+
+	assert.Equal(t, []string{"test:index:Resource::a", "test:index:Resource::b", "test:index:Resource::c"},
+		monitor.requests["test:index:Resource::d"].GetDependencies())
+	assert.Empty(t, monitor.requests["test:index:Resource::e"].GetDependencies())
+
+	// Waiting for dependencies that are never resolved fails once the output timeout elapses.
+	ctx, err = NewContext(context.Background(),
+		RunInfo{Project: "proj", Stack: "dev", OutputTimeout: 10 * time.Millisecond})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+	never, _, _ := NewOutput()
+	stuck, err := ctx.RegisterResource("test:index:Resource", "g", true, nil, ResourceOpt{
+		DependsOnInputs: []ResourceArrayInput{ResourceArrayOutput(never)},
+	})
+	assert.NoError(t, err)
+	_, _, err = stuck.URN().await(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "timed out awaiting DependsOnInputs")
+	}
+	ctx.waitForRPCs()
+}
+
+func TestMergedResourceOptions(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	a, err := ctx.RegisterResource("test:index:Resource", "a", true, nil)
+	assert.NoError(t, err)
+	b, err := ctx.RegisterResource("test:index:Resource", "b", true, nil)
+	assert.NoError(t, err)
+	callerParent, err := NewComponentResource(ctx, "test:index:Component", "caller")
+	assert.NoError(t, err)
+	defaultParent, err := NewComponentResource(ctx, "test:index:Component", "default")
+	assert.NoError(t, err)
+
+	// A component's defaults, given after its caller's options, only set what the caller's options do not, while
+	// lists of dependencies and ignored properties are combined.
+	caller := []ResourceOpt{Parent(callerParent), DependsOn(a), IgnoreChanges("tags"),
+		Timeouts(NewCustomTimeouts(time.Minute, 0, 0))}
+	defaults := []ResourceOpt{Parent(defaultParent), DependsOn(b), IgnoreChanges("name"), Protect(true),
+		Timeouts(NewCustomTimeouts(time.Hour, time.Hour, 0))}
+	_, err = ctx.RegisterResource("test:index:Resource", "c", true, nil, append(caller, defaults...)...)
+	assert.NoError(t, err)
+	ctx.waitForRPCs()
+
+	c := monitor.requests["test:index:Resource::c"]
+	assert.Equal(t, "test:index:Component::caller", c.GetParent())
+	assert.Equal(t, []string{"test:index:Resource::a", "test:index:Resource::b"}, c.GetDependencies())
+	assert.Equal(t, []string{"tags", "name"}, c.GetIgnoreChanges())
+	assert.True(t, c.GetProtect())
+	assert.Equal(t, "1m0s", c.GetCustomTimeouts().GetCreate())
+	assert.Equal(t, "", c.GetCustomTimeouts().GetUpdate())
+}
+
+func TestRetryUnavailable(t *testing.T) {
+	state := &contextState{rpcRetries: 2}
+
+	// failing returns an invoker that fails with the given code the given number of times before succeeding.
+	calls := 0
+	failing := func(code codes.Code, failures int) grpc.UnaryInvoker {
+		calls = 0
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+			opts ...grpc.CallOption) error {
+			calls++
+			if calls <= failures {
+				return status.Error(code, "connection reset")
+			}
+			return nil
+		}
+	}
+
+	const supportsFeature = "/pulumirpc.ResourceMonitor/SupportsFeature"
+
+	// Idempotent calls that fail because the monitor is unavailable are retried, up to the limit.
+	err := state.retryUnavailable(context.Background(), supportsFeature, nil, nil, nil,
+		failing(codes.Unavailable, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	err = state.retryUnavailable(context.Background(), supportsFeature, nil, nil, nil,
+		failing(codes.Unavailable, 3))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, calls)
+
+	// Other failures are not retried, and neither is anything once retries are disabled.
+	err = state.retryUnavailable(context.Background(), supportsFeature, nil, nil, nil,
+		failing(codes.InvalidArgument, 1))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, calls)
+	state.rpcRetries = 0
+	err = state.retryUnavailable(context.Background(), supportsFeature, nil, nil, nil,
+		failing(codes.Unavailable, 1))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls)
+
+	// Calls that may have taken effect before failing are never retried, and neither are registrations if the monitor
+	// does not deduplicate them.
+	state.rpcRetries = 2
+	state.idempotentRegistrationOnce.Do(func() {})
+	for _, method := range []string{registerResourceMethod, "/pulumirpc.ResourceMonitor/ReadResource",
+		"/pulumirpc.ResourceMonitor/Invoke"} {
+		err = state.retryUnavailable(context.Background(), method, nil, nil, nil, failing(codes.Unavailable, 1))
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 1, calls)
+	}
+
+	// Registrations are retried if it does, and every attempt carries the same request ID.
+	state.idempotentRegistration = true
+	var ids []string
+	invoker := failing(codes.Unavailable, 1)
+	err = state.retryUnavailable(context.Background(), registerResourceMethod, nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+			opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			ids = append(ids, md.Get(rpcutil.RequestIDMetadataKey)...)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	if assert.Len(t, ids, 2) {
+		assert.NotEmpty(t, ids[0])
+		assert.Equal(t, ids[0], ids[1])
+	}
+}
+
+// flakyMonitor is a resource monitor server that only implements resource registration.  Like the engine, it may
+// answer retried registrations, which carry the original's request ID, with the response to the original.
+type flakyMonitor struct {
+	pulumirpc.ResourceMonitorServer
+
+	idempotent bool           // true if retried registrations are answered with the original response.
+	requests   map[string]int // the number of times that each request ID has been seen.
+	drop       func()         // if set, the next registration calls this and then fails as if the connection was reset.
+	registered map[string]int // the number of times that each resource has been registered.
+	lock       sync.Mutex
+}
+
+func (m *flakyMonitor) SupportsFeature(ctx context.Context,
+	req *pulumirpc.SupportsFeatureRequest) (*pulumirpc.SupportsFeatureResponse, error) {
+	return &pulumirpc.SupportsFeatureResponse{HasSupport: req.GetId() == "idempotentRegistration" && m.idempotent}, nil
+}
+
+func (m *flakyMonitor) RegisterResource(ctx context.Context,
+	req *pulumirpc.RegisterResourceRequest) (*pulumirpc.RegisterResourceResponse, error) {
+
+	resp := &pulumirpc.RegisterResourceResponse{Urn: req.GetType() + "::" + req.GetName()}
+
+	m.lock.Lock()
+	if id := rpcutil.RequestID(ctx); id != "" {
+		m.requests[id]++
+		if m.idempotent && m.requests[id] > 1 {
+			m.lock.Unlock()
+			return resp, nil
+		}
+	}
+	m.registered[req.GetName()]++
+	drop := m.drop
+	m.drop = nil
+	m.lock.Unlock()
+
+	if drop != nil {
+		drop()
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return resp, nil
+}
+
+// serveMonitor serves the given resource monitor at the given address, returning the server.
+func serveMonitor(t *testing.T, addr string, monitor *flakyMonitor) *grpc.Server {
+	lis, err := net.Listen("tcp", addr)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	server := grpc.NewServer()
+	pulumirpc.RegisterResourceMonitorServer(server, monitor)
+	go func() { contract.IgnoreError(server.Serve(lis)) }()
+	return server
+}
+
+// restartMonitor stops the given server, dropping its connections, and serves the monitor again shortly afterwards.
+// The new server is sent to the returned channel.
+func restartMonitor(t *testing.T, server *grpc.Server, addr string, monitor *flakyMonitor) <-chan *grpc.Server {
+	restarted := make(chan *grpc.Server, 1)
+	go func() {
+		server.Stop()
+		time.Sleep(100 * time.Millisecond)
+		restarted <- serveMonitor(t, addr, monitor)
+	}()
+	return restarted
+}
+
+func TestRegisterResourceSurvivesDroppedConnection(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	addr := lis.Addr().String()
+	assert.NoError(t, lis.Close())
+
+	for _, idempotent := range []bool{true, false} {
+		monitor := &flakyMonitor{idempotent: idempotent, registered: map[string]int{}, requests: map[string]int{}}
+		server := serveMonitor(t, addr, monitor)
+		ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev", MonitorAddr: addr})
+		assert.NoError(t, err)
+
+		register := func(name string) (*pulumirpc.RegisterResourceResponse, error) {
+			callCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			return ctx.monitor.RegisterResource(callCtx, &pulumirpc.RegisterResourceRequest{
+				Type: "test:index:Resource",
+				Name: name,
+			})
+		}
+		resp, err := register("a")
+		assert.NoError(t, err)
+		assert.Equal(t, "test:index:Resource::a", resp.GetUrn())
+
+		if idempotent {
+			// If the connection is reset while the monitor handles a registration, the registration is resent once
+			// the connection is re-established, and the monitor answers it without registering the resource again.
+			var restarted <-chan *grpc.Server
+			monitor.lock.Lock()
+			monitor.drop = func() { restarted = restartMonitor(t, server, addr, monitor) }
+			monitor.lock.Unlock()
+			resp, err = register("b")
+			assert.NoError(t, err)
+			assert.Equal(t, "test:index:Resource::b", resp.GetUrn())
+			assert.Equal(t, 1, monitor.registered["b"])
+			server = <-restarted
+		} else {
+			// Otherwise, registrations are not resent, but a registration made after the connection drops waits for
+			// it to be re-established rather than failing.
+			restarted := restartMonitor(t, server, addr, monitor)
+			for ctx.monitorConn.GetState() == connectivity.Ready {
+				time.Sleep(10 * time.Millisecond)
+			}
+			resp, err = register("b")
+			assert.NoError(t, err)
+			assert.Equal(t, "test:index:Resource::b", resp.GetUrn())
+			server = <-restarted
+		}
+
+		// Without retries, registrations fail once the connection drops.
+		ctx.rpcRetries = 0
+		server.Stop()
+		_, err = register("c")
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.NoError(t, ctx.Close())
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dynamic lets Pulumi programs manage resources whose lifecycles are implemented by the programs themselves,
+// like the dynamic providers of the Node.js SDK.
+//
+// Go functions cannot be serialized into the state of the resources that they manage, so instead the engine runs the
+// program itself as the provider of its dynamic resources, using the pulumi-resource-pulumi-go plugin.  When it is
+// run that way, pulumi.Run serves the providers that the program has registered instead of running its body.  So,
+// providers must be registered before Run is called, usually by initializing package-level variables:
+//
+//	var counter = dynamic.Register("counter", &counterProvider{})
+//
+// and must remain registered under the same names for as long as any of their resources exist, since even deleting
+// a resource runs the current version of the program.
+package dynamic
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+const (
+	// resourceType is the type of all dynamic resources, whose package is served by pulumi-resource-pulumi-go.
+	resourceType = "pulumi-go:dynamic:Resource"
+	// providerKey is the property of each dynamic resource that holds the name of its provider.
+	providerKey = "__provider"
+)
+
+// UnknownValue is the value of inputs that are not known yet, as during previews.
+const UnknownValue = plugin.UnknownStringValue
+
+// ResourceProvider creates the resources of a dynamic provider.  Providers may also implement ResourceDiffer,
+// ResourceUpdater, ResourceReader, and ResourceDeleter, to take part in the rest of their resources' lifecycles.
+type ResourceProvider interface {
+	// Create creates a resource with the given inputs, and returns its ID along with its outputs.
+	Create(ctx context.Context, inputs map[string]interface{}) (string, map[string]interface{}, error)
+}
+
+// ResourceDiffer is implemented by providers that decide which changes to their resources' inputs require updates or
+// replacements.  The inputs of other providers' resources are diffed by the engine, and changes to them are updates.
+type ResourceDiffer interface {
+	// Diff compares the outputs of the resource with the given ID to its new inputs.
+	Diff(ctx context.Context, id string, olds, news map[string]interface{}) (DiffResult, error)
+}
+
+// DiffResult is the result of a ResourceDiffer's Diff.
+type DiffResult struct {
+	Changes             bool     // true if the resource must be updated or replaced.
+	Replaces            []string // the properties whose changes require the resource to be replaced, if any.
+	DeleteBeforeReplace bool     // true if the resource must be deleted before its replacement is created.
+}
+
+// ResourceUpdater is implemented by providers that update their resources in place.  The outputs of other providers'
+// resources are simply replaced by their new inputs when they are updated.
+type ResourceUpdater interface {
+	// Update updates the resource with the given ID from its old outputs to its new inputs, returning its new outputs.
+	Update(ctx context.Context, id string, olds, news map[string]interface{}) (map[string]interface{}, error)
+}
+
+// ResourceReader is implemented by providers that read the live state of their resources, for refreshes.  The state of
+// other providers' resources is left as it is.
+type ResourceReader interface {
+	// Read returns the current outputs of the resource with the given ID, given its last known outputs.
+	Read(ctx context.Context, id string, props map[string]interface{}) (map[string]interface{}, error)
+}
+
+// ResourceDeleter is implemented by providers whose resources must be cleaned up when they are deleted.
+type ResourceDeleter interface {
+	// Delete deletes the resource with the given ID and outputs.
+	Delete(ctx context.Context, id string, props map[string]interface{}) error
+}
+
+// Provider is a dynamic provider that has been registered with Register.
+type Provider struct {
+	name string
+}
+
+var providers = struct {
+	sync.Mutex
+	m map[string]ResourceProvider
+}{m: map[string]ResourceProvider{}}
+
+// Register registers a dynamic provider under the given name, which must be unique within the program, and returns
+// the Provider with which to create its resources.
+func Register(name string, provider ResourceProvider) *Provider {
+	providers.Lock()
+	defer providers.Unlock()
+
+	if name == "" || name == UnknownValue {
+		panic(errors.Errorf("invalid dynamic provider name %q", name))
+	} else if _, has := providers.m[name]; has {
+		panic(errors.Errorf("a dynamic provider named %q has already been registered", name))
+	}
+	providers.m[name] = provider
+	return &Provider{name: name}
+}
+
+// getProvider returns the provider registered under the given name.
+func getProvider(name string) (ResourceProvider, error) {
+	providers.Lock()
+	defer providers.Unlock()
+
+	provider, has := providers.m[name]
+	if !has {
+		return nil, errors.Errorf("no dynamic provider named %q is registered by the program", name)
+	}
+	return provider, nil
+}
+
+// NewResource registers a resource whose lifecycle is managed by the given dynamic provider, passing the given
+// properties to its methods as inputs.  As with pulumi.Context.RegisterResource, the returned resource's state only
+// has outputs for these properties, so properties that the provider computes should be included with nil values.
+func NewResource(ctx *pulumi.Context, provider *Provider, name string, props map[string]interface{},
+	opts ...pulumi.ResourceOpt) (*pulumi.ResourceState, error) {
+
+	if provider == nil {
+		return nil, errors.New("a dynamic provider is required")
+	}
+
+	inputs := map[string]interface{}{providerKey: provider.name}
+	for k, v := range props {
+		if k == providerKey {
+			return nil, errors.Errorf("the %s property of dynamic resources is reserved", providerKey)
+		}
+		inputs[k] = v
+	}
+	return ctx.RegisterResource(resourceType, name, true, inputs, opts...)
+}
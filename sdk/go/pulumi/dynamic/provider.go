@@ -0,0 +1,281 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"fmt"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	"github.com/pulumi/pulumi/pkg/version"
+	"github.com/pulumi/pulumi/sdk/go/pulumi/internal/dynamic"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func init() {
+	dynamic.Serve = serve
+}
+
+// serve serves the registered dynamic providers until the engine shuts them down.
+func serve() error {
+	// Fire up a gRPC server, letting the kernel choose a free port or socket for us.
+	addr, done, err := rpcutil.ServeLocal(nil, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, &providerServer{})
+			return nil
+		},
+	}, nil, true /*pipes*/)
+	if err != nil {
+		return errors.Wrap(err, "serving dynamic providers")
+	}
+
+	// The resource provider protocol requires that we now write out the port or socket we have chosen to listen on.
+	fmt.Printf("%s\n", rpcutil.HandshakeAddress(addr))
+
+	// Finally, wait for the server to stop serving.
+	return <-done
+}
+
+// providerServer implements the resource provider protocol for the package of dynamic resources, dispatching each
+// operation to the registered provider that is named by the resource's properties.
+type providerServer struct{}
+
+// unmarshal turns the properties of a dynamic resource into the map that is passed to its provider, along with the
+// name of the provider.
+func unmarshal(props *structpb.Struct) (map[string]interface{}, string, error) {
+	pm, err := plugin.UnmarshalProperties(props, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, "", err
+	}
+	m := pm.MapRepl(nil, func(v resource.PropertyValue) (interface{}, bool) {
+		if v.IsComputed() {
+			return UnknownValue, true
+		}
+		return nil, false
+	})
+
+	name, _ := m[providerKey].(string)
+	delete(m, providerKey)
+	return m, name, nil
+}
+
+// marshal turns the properties of a dynamic resource that are returned by its provider into their gRPC form, along
+// with the name of the provider.
+func marshal(props map[string]interface{}, name string) (*structpb.Struct, error) {
+	m := map[string]interface{}{providerKey: name}
+	for k, v := range props {
+		m[k] = v
+	}
+	return plugin.MarshalProperties(resource.NewPropertyMapFromMap(m), plugin.MarshalOptions{KeepUnknowns: true})
+}
+
+// diffProvider returns the provider for a diff of the given properties.  When the name of the new provider is not
+// known yet, that of the old one is used.
+func diffProvider(oldName, newName string) (ResourceProvider, error) {
+	if newName == UnknownValue {
+		return getProvider(oldName)
+	}
+	return getProvider(newName)
+}
+
+func (p *providerServer) CheckConfig(ctx context.Context,
+	req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "CheckConfig is not implemented by the dynamic provider")
+}
+
+func (p *providerServer) DiffConfig(ctx context.Context, req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "DiffConfig is not implemented by the dynamic provider")
+}
+
+func (p *providerServer) Configure(ctx context.Context,
+	req *pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
+	return &pulumirpc.ConfigureResponse{}, nil
+}
+
+func (p *providerServer) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
+	return nil, errors.Errorf("unknown function %s", req.GetTok())
+}
+
+func (p *providerServer) StreamInvoke(req *pulumirpc.InvokeRequest,
+	server pulumirpc.ResourceProvider_StreamInvokeServer) error {
+	return errors.Errorf("unknown function %s", req.GetTok())
+}
+
+// Check passes the new inputs through unchanged, after making sure that their provider is registered.
+func (p *providerServer) Check(ctx context.Context, req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	_, oldName, err := unmarshal(req.GetOlds())
+	if err != nil {
+		return nil, err
+	}
+	_, newName, err := unmarshal(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	if _, err = diffProvider(oldName, newName); err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
+}
+
+// Diff consults the provider if it is a ResourceDiffer, and otherwise leaves the diff to the engine.
+//
+// Note that we do not take any special action if the provider has changed, so that its implementation may be iterated
+// on without replacing its resources.  Each version of a provider must be able to handle the state of the last.
+func (p *providerServer) Diff(ctx context.Context, req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	olds, oldName, err := unmarshal(req.GetOlds())
+	if err != nil {
+		return nil, err
+	}
+	news, newName, err := unmarshal(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	provider, err := diffProvider(oldName, newName)
+	if err != nil {
+		return nil, err
+	}
+
+	differ, ok := provider.(ResourceDiffer)
+	if !ok {
+		return &pulumirpc.DiffResponse{Changes: pulumirpc.DiffResponse_DIFF_UNKNOWN}, nil
+	}
+	result, err := differ.Diff(ctx, req.GetId(), olds, news)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := pulumirpc.DiffResponse_DIFF_NONE
+	if result.Changes {
+		changes = pulumirpc.DiffResponse_DIFF_SOME
+	}
+	return &pulumirpc.DiffResponse{
+		Changes:             changes,
+		Replaces:            result.Replaces,
+		DeleteBeforeReplace: result.DeleteBeforeReplace,
+	}, nil
+}
+
+func (p *providerServer) Create(ctx context.Context, req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
+	inputs, name, err := unmarshal(req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	provider, err := getProvider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	id, outs, err := provider.Create(ctx, inputs)
+	if err != nil {
+		return nil, err
+	} else if id == "" {
+		return nil, errors.Errorf("dynamic provider %q created a resource without an ID", name)
+	}
+	props, err := marshal(outs, name)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CreateResponse{Id: id, Properties: props}, nil
+}
+
+// Read consults the provider if it is a ResourceReader, and otherwise returns the resource's state as it is.
+func (p *providerServer) Read(ctx context.Context, req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
+	state, name, err := unmarshal(req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	provider, err := getProvider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, ok := provider.(ResourceReader)
+	if !ok {
+		return &pulumirpc.ReadResponse{Id: req.GetId(), Properties: req.GetProperties()}, nil
+	}
+	outs, err := reader.Read(ctx, req.GetId(), state)
+	if err != nil {
+		return nil, err
+	}
+	props, err := marshal(outs, name)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.ReadResponse{Id: req.GetId(), Properties: props}, nil
+}
+
+// Update consults the provider if it is a ResourceUpdater, and otherwise makes the new inputs the resource's outputs.
+func (p *providerServer) Update(ctx context.Context, req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error) {
+	olds, _, err := unmarshal(req.GetOlds())
+	if err != nil {
+		return nil, err
+	}
+	news, name, err := unmarshal(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	provider, err := getProvider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	outs := news
+	if updater, ok := provider.(ResourceUpdater); ok {
+		if outs, err = updater.Update(ctx, req.GetId(), olds, news); err != nil {
+			return nil, err
+		}
+	}
+	props, err := marshal(outs, name)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.UpdateResponse{Properties: props}, nil
+}
+
+// Delete consults the provider if it is a ResourceDeleter, and otherwise does nothing.
+func (p *providerServer) Delete(ctx context.Context, req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
+	props, name, err := unmarshal(req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	provider, err := getProvider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleter, ok := provider.(ResourceDeleter); ok {
+		if err = deleter.Delete(ctx, req.GetId(), props); err != nil {
+			return nil, err
+		}
+	}
+	return &pbempty.Empty{}, nil
+}
+
+func (p *providerServer) Cancel(ctx context.Context, req *pbempty.Empty) (*pbempty.Empty, error) {
+	return &pbempty.Empty{}, nil
+}
+
+func (p *providerServer) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{Version: version.Version}, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// counterProvider numbers the resources that it creates, and replaces them when their prefixes change.
+type counterProvider struct {
+	count   int
+	deleted []string
+}
+
+func (p *counterProvider) Create(ctx context.Context, inputs map[string]interface{}) (string, map[string]interface{},
+	error) {
+	p.count++
+	return fmt.Sprintf("%v-%d", inputs["prefix"], p.count), map[string]interface{}{
+		"prefix": inputs["prefix"],
+		"number": p.count,
+	}, nil
+}
+
+func (p *counterProvider) Diff(ctx context.Context, id string, olds, news map[string]interface{}) (DiffResult, error) {
+	if olds["prefix"] != news["prefix"] {
+		return DiffResult{Changes: true, Replaces: []string{"prefix"}}, nil
+	}
+	return DiffResult{}, nil
+}
+
+func (p *counterProvider) Delete(ctx context.Context, id string, props map[string]interface{}) error {
+	p.deleted = append(p.deleted, id)
+	return nil
+}
+
+// noteProvider implements only Create.
+type noteProvider struct{}
+
+func (noteProvider) Create(ctx context.Context, inputs map[string]interface{}) (string, map[string]interface{},
+	error) {
+	return "note", inputs, nil
+}
+
+var (
+	counter = &counterProvider{}
+	_       = Register("counter", counter)
+	_       = Register("note", noteProvider{})
+)
+
+func props(t *testing.T, m map[string]interface{}) *structpb.Struct {
+	s, err := plugin.MarshalProperties(resource.NewPropertyMapFromMap(m), plugin.MarshalOptions{KeepUnknowns: true})
+	assert.NoError(t, err)
+	return s
+}
+
+func TestProviderServer(t *testing.T) {
+	server := &providerServer{}
+	ctx := context.Background()
+
+	// Resources are created by the providers that they name, which are recorded in their outputs.
+	created, err := server.Create(ctx, &pulumirpc.CreateRequest{
+		Properties: props(t, map[string]interface{}{providerKey: "counter", "prefix": "a"}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "a-1", created.GetId())
+	outs, name, err := unmarshal(created.GetProperties())
+	assert.NoError(t, err)
+	assert.Equal(t, "counter", name)
+	assert.Equal(t, map[string]interface{}{"prefix": "a", "number": float64(1)}, outs)
+
+	// Diffs are made by providers that implement them, even when their inputs are unknown, ...
+	diff, err := server.Diff(ctx, &pulumirpc.DiffRequest{
+		Id:   "a-1",
+		Olds: created.GetProperties(),
+		News: props(t, map[string]interface{}{providerKey: "counter", "prefix": resource.Computed{}}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pulumirpc.DiffResponse_DIFF_SOME, diff.GetChanges())
+	assert.Equal(t, []string{"prefix"}, diff.GetReplaces())
+	diff, err = server.Diff(ctx, &pulumirpc.DiffRequest{
+		Id:   "a-1",
+		Olds: created.GetProperties(),
+		News: props(t, map[string]interface{}{providerKey: "counter", "prefix": "a"}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pulumirpc.DiffResponse_DIFF_NONE, diff.GetChanges())
+
+	// ... and are otherwise left to the engine.
+	noteProps := props(t, map[string]interface{}{providerKey: "note", "text": "hello"})
+	diff, err = server.Diff(ctx, &pulumirpc.DiffRequest{Id: "note", Olds: noteProps, News: noteProps})
+	assert.NoError(t, err)
+	assert.Equal(t, pulumirpc.DiffResponse_DIFF_UNKNOWN, diff.GetChanges())
+
+	// Resources whose providers do not implement updates, reads, and deletes take their inputs as their outputs, keep
+	// their state, and are simply forgotten.
+	updated, err := server.Update(ctx, &pulumirpc.UpdateRequest{
+		Id:   "note",
+		Olds: noteProps,
+		News: props(t, map[string]interface{}{providerKey: "note", "text": "goodbye"}),
+	})
+	assert.NoError(t, err)
+	outs, name, err = unmarshal(updated.GetProperties())
+	assert.NoError(t, err)
+	assert.Equal(t, "note", name)
+	assert.Equal(t, map[string]interface{}{"text": "goodbye"}, outs)
+	read, err := server.Read(ctx, &pulumirpc.ReadRequest{Id: "note", Properties: noteProps})
+	assert.NoError(t, err)
+	assert.Equal(t, noteProps, read.GetProperties())
+	_, err = server.Delete(ctx, &pulumirpc.DeleteRequest{Id: "note", Properties: noteProps})
+	assert.NoError(t, err)
+
+	_, err = server.Delete(ctx, &pulumirpc.DeleteRequest{Id: "a-1", Properties: created.GetProperties()})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a-1"}, counter.deleted)
+
+	// Resources whose providers are not registered are rejected.
+	_, err = server.Check(ctx, &pulumirpc.CheckRequest{
+		News: props(t, map[string]interface{}{providerKey: "missing"}),
+	})
+	assert.EqualError(t, err, `no dynamic provider named "missing" is registered by the program`)
+}
+
+func TestRegister(t *testing.T) {
+	assert.Panics(t, func() { Register("counter", counter) })
+	assert.Panics(t, func() { Register("", counter) })
+}
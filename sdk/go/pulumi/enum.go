@@ -0,0 +1,75 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Enum is implemented by types whose values are restricted to a fixed set, such as the enums declared by a provider's
+// schema.  An enum is usually a named string or number type with a constant for each of its values, so that passing
+// an invalid value is caught at compile time:
+//
+//	type BucketACL string
+//
+//	const (
+//	    BucketACLPrivate    BucketACL = "private"
+//	    BucketACLPublicRead BucketACL = "public-read"
+//	)
+//
+//	func (BucketACL) EnumValues() []interface{} {
+//	    return []interface{}{BucketACLPrivate, BucketACLPublicRead}
+//	}
+//
+//	func (e BucketACL) ToStringOutput() pulumi.StringOutput {
+//	    return pulumi.StringOutput(pulumi.EnumOutput(e))
+//	}
+//
+// Values that are converted from other types are validated before they are sent to the provider.
+type Enum interface {
+	// EnumValues returns the valid values of the enum type.
+	EnumValues() []interface{}
+}
+
+// EnumOutput returns an output that is resolved to the given enum value, or rejected if the value is not valid.  This
+// allows enum types to implement the input interfaces of their underlying types.
+func EnumOutput(e Enum) Output {
+	out := newOutput()
+	if err := validateEnum(e); err != nil {
+		out.s.reject(err)
+	} else {
+		out.s.resolve(e, true)
+	}
+	return out
+}
+
+// validateEnum returns an error if the given value is not one of its type's valid values.
+func validateEnum(e Enum) error {
+	values := e.EnumValues()
+	for _, v := range values {
+		if v == e {
+			return nil
+		}
+	}
+
+	valid := make([]string, len(values))
+	for i, v := range values {
+		valid[i] = fmt.Sprintf("%v", v)
+	}
+	return errors.Errorf("invalid value %v for %T; expected one of %s", e, e, strings.Join(valid, ", "))
+}
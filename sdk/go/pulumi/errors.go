@@ -0,0 +1,75 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// wrappedError is an error annotated with a message.  Unlike the errors of github.com/pkg/errors, it may be unwrapped
+// by the errors.Is and errors.As functions of the standard library, so that programs may inspect the errors that flow
+// through outputs, such as those returned by appliers or by resource registration.
+type wrappedError struct {
+	msg string
+	err error
+}
+
+func (e *wrappedError) Error() string { return e.msg + ": " + e.err.Error() }
+
+// Unwrap returns the annotated error, for errors.Is and errors.As.
+func (e *wrappedError) Unwrap() error { return e.err }
+
+// Cause returns the annotated error, for errors.Cause of github.com/pkg/errors.
+func (e *wrappedError) Cause() error { return e.err }
+
+// wrapErrorf annotates err with a formatted message, as errors.Wrapf does.  It returns nil if err is nil.
+func wrapErrorf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &wrappedError{msg: fmt.Sprintf(format, args...), err: err}
+}
+
+// runError is the error of a program, which may have failed in several ways at once, such as by returning an error and
+// by failing to register its outputs.  errors.Is and errors.As look for the target among all of its errors.
+type runError struct {
+	errs *multierror.Error
+}
+
+func (e runError) Error() string { return e.errs.Error() }
+
+// Is returns true if any of the program's errors matches target.
+func (e runError) Is(target error) bool {
+	for _, err := range e.errs.Errors {
+		if stderrors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the program's errors that matches target, and if there is one, sets target to it and returns
+// true.
+func (e runError) As(target interface{}) bool {
+	for _, err := range e.errs.Errors {
+		if stderrors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type quotaError struct {
+	limit int
+}
+
+func (e *quotaError) Error() string { return "quota exceeded" }
+
+func TestErrorsThroughOutputs(t *testing.T) {
+	// Errors returned by appliers keep their identity and type through further applies and marshaling.
+	out := String("x").ToStringOutput().Apply(func(string) (interface{}, error) {
+		return nil, &quotaError{limit: 5}
+	})
+	derived := StringOutput(out).Apply(func(v string) (interface{}, error) {
+		return v, nil
+	})
+	_, _, _, err := marshalInputs(context.Background(), map[string]interface{}{"name": derived}, true, true)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "awaiting input property name: apply at ")
+
+	var quota *quotaError
+	if assert.True(t, stderrors.As(err, &quota)) {
+		assert.Equal(t, 5, quota.limit)
+	}
+	assert.Equal(t, quota, errors.Cause(err))
+
+	// Rejected outputs keep their errors as well.
+	failed, _, reject := NewOutput()
+	reject(os.ErrNotExist)
+	_, _, _, err = marshalInputs(context.Background(), map[string]interface{}{"file": failed}, true, true)
+	assert.True(t, stderrors.Is(err, os.ErrNotExist))
+}
+
+func TestRunErrorIsAndAs(t *testing.T) {
+	var result error
+	result = multierror.Append(result, errors.New("body failed"))
+	result = multierror.Append(result, wrapErrorf(&quotaError{limit: 1}, "marshaling outputs"))
+	err := error(runError{result.(*multierror.Error)})
+
+	// The message is unchanged, and any of the errors may be found.
+	assert.Equal(t, result.Error(), err.Error())
+	var quota *quotaError
+	assert.True(t, stderrors.As(err, &quota))
+	assert.False(t, stderrors.Is(err, os.ErrNotExist))
+}
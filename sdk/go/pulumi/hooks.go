@@ -0,0 +1,254 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// ResourceHookFunc is a callback that the engine runs around an operation on a resource, such as a smoke test that
+// runs once a service has been created, or draining connections before it is deleted.  If it returns an error, the
+// engine fails the operation's step, which halts the update.
+type ResourceHookFunc func(args *ResourceHookArgs) error
+
+// ResourceHookArgs describes the resource that a ResourceHookFunc is run for.  The properties that do not apply to the
+// operation, such as the old inputs of a resource that is being created, are nil.
+type ResourceHookArgs struct {
+	// URN is the resource's URN.
+	URN URN
+	// ID is the resource's ID, if it has one.
+	ID ID
+	// NewInputs are the resource's new input properties, if it is being created or updated.
+	NewInputs map[string]interface{}
+	// OldInputs are the resource's old input properties, if it is being updated or deleted.
+	OldInputs map[string]interface{}
+	// NewOutputs are the resource's new output properties, once it has been created or updated.
+	NewOutputs map[string]interface{}
+	// OldOutputs are the resource's old output properties, if it is being updated or deleted.
+	OldOutputs map[string]interface{}
+}
+
+// ResourceHook is a hook that the program has registered with the engine using RegisterResourceHook.  Pass it to
+// resources with the Hooks option.
+type ResourceHook struct {
+	name string
+}
+
+// Name returns the name that the hook was registered with.
+func (hook *ResourceHook) Name() string { return hook.name }
+
+// ResourceHooks are the lifecycle hooks of a resource.  The engine runs them as part of the steps that create, update
+// and delete the resource, so they do not run during previews.  A failing Before hook fails the step before the
+// resource's provider is asked to perform the operation; a failing After hook fails the update once the operation is
+// done, except for AfterDelete, which only warns.  A resource that is replaced runs its create and delete hooks.
+//
+// The engine records a resource's hooks in its state, so that its delete hooks can run once the program no longer
+// registers it.  They run only if the program still registers hooks by those names.  `pulumi destroy` does not run
+// the program, so it cannot run them either; it refuses to delete resources that have delete hooks unless it is
+// passed --skip-resource-hooks, in which case it deletes them without running their hooks.
+type ResourceHooks struct {
+	// BeforeCreate runs before the resource is created.
+	BeforeCreate []*ResourceHook
+	// AfterCreate runs after the resource has been created.
+	AfterCreate []*ResourceHook
+	// BeforeUpdate runs before the resource is updated.
+	BeforeUpdate []*ResourceHook
+	// AfterUpdate runs after the resource has been updated.
+	AfterUpdate []*ResourceHook
+	// BeforeDelete runs before the resource is deleted.
+	BeforeDelete []*ResourceHook
+	// AfterDelete runs after the resource has been deleted.
+	AfterDelete []*ResourceHook
+}
+
+// RegisterResourceHook registers a hook with the engine under the given name, which must be unique within the
+// program.  The program keeps running after its body returns, so that the engine can run the hook until it has
+// finished the update.
+func (ctx *Context) RegisterResourceHook(name string, hook ResourceHookFunc) (*ResourceHook, error) {
+	if name == "" {
+		return nil, errors.New("resource hook name cannot be empty")
+	}
+	if hook == nil {
+		return nil, errors.Errorf("resource hook %s must have a function", name)
+	}
+
+	server, err := ctx.resourceHookServer()
+	if err != nil {
+		return nil, err
+	}
+	if err = server.add(name, hook); err != nil {
+		return nil, err
+	}
+
+	_, err = ctx.monitor.RegisterResourceHook(ctx.ctx, &pulumirpc.RegisterResourceHookRequest{
+		Name:    name,
+		Address: server.addr,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "registering resource hook %s", name)
+	}
+	return &ResourceHook{name: name}, nil
+}
+
+// resourceHookServer returns the server on which the engine runs the program's hooks, starting it if necessary.
+func (ctx *Context) resourceHookServer() (*hookServer, error) {
+	ctx.hooksLock.Lock()
+	defer ctx.hooksLock.Unlock()
+
+	if ctx.hooks == nil {
+		server, err := newHookServer()
+		if err != nil {
+			return nil, errors.Wrap(err, "starting resource hook server")
+		}
+		ctx.hooks = server
+	}
+	return ctx.hooks, nil
+}
+
+// hasResourceHooks returns true if the program has registered any hooks.
+func (ctx *Context) hasResourceHooks() bool {
+	ctx.hooksLock.Lock()
+	defer ctx.hooksLock.Unlock()
+	return ctx.hooks != nil
+}
+
+// waitForShutdown tells the engine that the program has registered all of its resources, and then serves the
+// program's hooks until the engine has finished the update.
+func (ctx *Context) waitForShutdown() error {
+	if ctx.DryRun() || !ctx.hasResourceHooks() {
+		return nil
+	}
+	_, err := ctx.monitor.SignalAndWaitForShutdown(ctx.ctx, &empty.Empty{})
+	return err
+}
+
+// hooksBinding returns the names of the hooks in a resource's options, or nil if there are none.
+func hooksBinding(opts []ResourceOpt) *pulumirpc.RegisterResourceRequest_ResourceHooksBinding {
+	names := func(hooks []*ResourceHook) []string {
+		var result []string
+		for _, hook := range hooks {
+			result = append(result, hook.name)
+		}
+		return result
+	}
+
+	var binding *pulumirpc.RegisterResourceRequest_ResourceHooksBinding
+	for _, opt := range opts {
+		for _, hooks := range opt.Hooks {
+			if binding == nil {
+				binding = &pulumirpc.RegisterResourceRequest_ResourceHooksBinding{}
+			}
+			binding.BeforeCreate = append(binding.BeforeCreate, names(hooks.BeforeCreate)...)
+			binding.AfterCreate = append(binding.AfterCreate, names(hooks.AfterCreate)...)
+			binding.BeforeUpdate = append(binding.BeforeUpdate, names(hooks.BeforeUpdate)...)
+			binding.AfterUpdate = append(binding.AfterUpdate, names(hooks.AfterUpdate)...)
+			binding.BeforeDelete = append(binding.BeforeDelete, names(hooks.BeforeDelete)...)
+			binding.AfterDelete = append(binding.AfterDelete, names(hooks.AfterDelete)...)
+		}
+	}
+	return binding
+}
+
+// hookServer implements the ResourceHooks service, through which the engine runs the program's hooks.
+type hookServer struct {
+	addr   string
+	cancel chan bool
+	done   chan error
+
+	lock  sync.Mutex
+	hooks map[string]ResourceHookFunc
+}
+
+func newHookServer() (*hookServer, error) {
+	server := &hookServer{
+		cancel: make(chan bool),
+		hooks:  make(map[string]ResourceHookFunc),
+	}
+	addr, done, err := rpcutil.ServeLocal(server.cancel, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceHooksServer(srv, server)
+			return nil
+		},
+	}, nil, false /*pipes*/)
+	if err != nil {
+		return nil, err
+	}
+	server.addr, server.done = addr, done
+	return server, nil
+}
+
+// add adds a hook to the server, failing if there is already one by the same name.
+func (server *hookServer) add(name string, hook ResourceHookFunc) error {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	if _, has := server.hooks[name]; has {
+		return errors.Errorf("resource hook %s is already registered", name)
+	}
+	server.hooks[name] = hook
+	return nil
+}
+
+// Close stops the server.
+func (server *hookServer) Close() error {
+	close(server.cancel)
+	return <-server.done
+}
+
+// RunHook runs the requested hook, returning its error, if any, in the response.
+func (server *hookServer) RunHook(ctx context.Context,
+	req *pulumirpc.RunResourceHookRequest) (*pulumirpc.RunResourceHookResponse, error) {
+
+	server.lock.Lock()
+	hook, has := server.hooks[req.GetHook()]
+	server.lock.Unlock()
+	if !has {
+		return nil, errors.Errorf("unknown resource hook %s", req.GetHook())
+	}
+
+	args := &ResourceHookArgs{URN: URN(req.GetUrn()), ID: ID(req.GetId())}
+	for _, prop := range []struct {
+		obj *structpb.Struct
+		dst *map[string]interface{}
+	}{
+		{req.GetNewInputs(), &args.NewInputs},
+		{req.GetOldInputs(), &args.OldInputs},
+		{req.GetNewOutputs(), &args.NewOutputs},
+		{req.GetOldOutputs(), &args.OldOutputs},
+	} {
+		if prop.obj == nil {
+			continue
+		}
+		props, _, err := unmarshalOutputs(prop.obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unmarshaling arguments of resource hook %s", req.GetHook())
+		}
+		*prop.dst = props
+	}
+
+	if err := hook(args); err != nil {
+		return &pulumirpc.RunResourceHookResponse{Error: err.Error()}, nil
+	}
+	return &pulumirpc.RunResourceHookResponse{}, nil
+}
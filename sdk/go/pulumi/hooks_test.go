@@ -0,0 +1,120 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func TestResourceHooks(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	var ran []*ResourceHookArgs
+	smokeTest, err := ctx.RegisterResourceHook("smokeTest", func(args *ResourceHookArgs) error {
+		ran = append(ran, args)
+		return nil
+	})
+	assert.NoError(t, err)
+	drain, err := ctx.RegisterResourceHook("drain", func(args *ResourceHookArgs) error {
+		return errors.New("connections still open")
+	})
+	assert.NoError(t, err)
+
+	// Hooks must have unique names.
+	_, err = ctx.RegisterResourceHook("drain", func(args *ResourceHookArgs) error { return nil })
+	assert.EqualError(t, err, "resource hook drain is already registered")
+
+	// Resources send the names of their hooks to the engine.
+	_, err = ctx.RegisterResource("test:index:Resource", "a", true, nil, Hooks(&ResourceHooks{
+		AfterCreate:  []*ResourceHook{smokeTest},
+		AfterUpdate:  []*ResourceHook{smokeTest},
+		BeforeDelete: []*ResourceHook{drain},
+	}))
+	assert.NoError(t, err)
+	_, err = ctx.RegisterResource("test:index:Resource", "b", true, nil)
+	assert.NoError(t, err)
+	ctx.waitForRPCs()
+
+	assert.Equal(t, &pulumirpc.RegisterResourceRequest_ResourceHooksBinding{
+		AfterCreate:  []string{"smokeTest"},
+		AfterUpdate:  []string{"smokeTest"},
+		BeforeDelete: []string{"drain"},
+	}, monitor.requests["test:index:Resource::a"].GetHooks())
+	assert.Nil(t, monitor.requests["test:index:Resource::b"].GetHooks())
+
+	// The engine runs the hooks at the address that they were registered with.
+	addr := monitor.hooks["smokeTest"]
+	assert.Equal(t, addr, monitor.hooks["drain"])
+	conn, err := grpc.Dial(addr, grpc.WithInsecure(), rpcutil.WithLocalDialer())
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, conn.Close()) }()
+	hooks := pulumirpc.NewResourceHooksClient(conn)
+
+	outputs, err := plugin.MarshalProperties(
+		resource.NewPropertyMapFromMap(map[string]interface{}{"endpoint": "https://example.com"}),
+		plugin.MarshalOptions{})
+	assert.NoError(t, err)
+	resp, err := hooks.RunHook(context.Background(), &pulumirpc.RunResourceHookRequest{
+		Hook:       "smokeTest",
+		Urn:        "test:index:Resource::a",
+		Id:         "a-id",
+		NewOutputs: outputs,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetError())
+	assert.Equal(t, []*ResourceHookArgs{{
+		URN:        "test:index:Resource::a",
+		ID:         "a-id",
+		NewOutputs: map[string]interface{}{"endpoint": "https://example.com"},
+	}}, ran)
+
+	// Failing hooks report their errors to the engine.
+	resp, err = hooks.RunHook(context.Background(), &pulumirpc.RunResourceHookRequest{Hook: "drain"})
+	assert.NoError(t, err)
+	assert.Equal(t, "connections still open", resp.GetError())
+
+	// Once the program has registered its resources, it waits for the engine to finish running its hooks.
+	assert.NoError(t, ctx.waitForShutdown())
+	assert.True(t, monitor.shutdown)
+}
+
+func TestResourceHooksPreview(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev", DryRun: true})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	// Hooks are registered during previews, but as the engine does not run them, the program need not wait for it.
+	_, err = ctx.RegisterResourceHook("smokeTest", func(args *ResourceHookArgs) error { return nil })
+	assert.NoError(t, err)
+	assert.Contains(t, monitor.hooks, "smokeTest")
+	assert.NoError(t, ctx.waitForShutdown())
+	assert.False(t, monitor.shutdown)
+}
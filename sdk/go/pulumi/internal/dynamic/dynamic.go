@@ -0,0 +1,21 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dynamic lets the pulumi package serve the dynamic providers of a program, which are defined by the
+// pulumi/dynamic package that itself depends on the pulumi package.
+package dynamic
+
+// Serve serves the dynamic providers that the program has registered to the engine, until the engine shuts them down.
+// It is nil unless the pulumi/dynamic package is part of the program, which sets it when it is initialized.
+var Serve func() error
@@ -0,0 +1,26 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package outputs exposes the machinery of the pulumi package's outputs to its sibling packages, such as pulumitest,
+// without adding it to the pulumi package's API.
+package outputs
+
+import (
+	"context"
+)
+
+// Await waits for the given output, which may be an Output or any of the typed outputs, to be fulfilled, and returns
+// its value, whether it is known, whether it is secret, and the error it was rejected with, if any.  Values that are
+// not outputs are returned as they are.  The pulumi package sets it when it is initialized.
+var Await func(ctx context.Context, out interface{}) (value interface{}, known, secret bool, err error)
@@ -0,0 +1,33 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"encoding/json"
+)
+
+// JSONMarshal returns an output of the JSON encoding of v, as encoding/json would produce it, once all of the outputs
+// nested within v are available.  Outputs may appear anywhere within v's maps and slices, such as the statements of an
+// IAM policy document, and are encoded as their values.  The result accumulates the dependencies of every nested
+// output, is unknown if any of them is unknown, and is secret if any of them is secret.
+func JSONMarshal(v interface{}) StringOutput {
+	return StringOutput(ToOutput(v).Apply(func(resolved interface{}) (interface{}, error) {
+		b, err := json.Marshal(resolved)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}))
+}
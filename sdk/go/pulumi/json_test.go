@@ -0,0 +1,84 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONMarshal(t *testing.T) {
+	bucket := makeResourceState(true, map[string]interface{}{"arn": nil})
+	bucket.resolve(false, nil, nil, "urn", "id", nil)
+	arn, resolve, _ := NewOutput()
+	go func() {
+		resolve("arn:aws:s3:::bucket")
+	}()
+
+	// Outputs nested within maps and slices are encoded as their values, and the result depends on their resources.
+	policy := map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{{
+			"Effect":   "Allow",
+			"Action":   []string{"s3:GetObject"},
+			"Resource": Concat(StringOutput(arn), String("/*")),
+			"Bucket":   bucket.ID(),
+		}},
+	}
+	out := JSONMarshal(policy)
+	assert.Equal(t, []Resource{bucket}, out.s.dependencies())
+	v, known, secret, err := out.s.awaitValue(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.False(t, secret)
+	assert.JSONEq(t, `{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Action": ["s3:GetObject"],
+			"Resource": "arn:aws:s3:::bucket/*",
+			"Bucket": "id"
+		}]
+	}`, v.(string))
+
+	// Byte slices and other values are encoded as encoding/json would encode them.
+	v, _, err = JSONMarshal(map[string]interface{}{"data": []byte("hi"), "n": Int(1)}).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"data": "aGk=", "n": 1}`, v.(string))
+
+	// Secrets make the result secret.
+	_, _, secret, err = JSONMarshal([]interface{}{ToSecret("hunter2")}).s.awaitValue(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, secret)
+
+	// If any nested output is unknown or rejected, so is the result.
+	unknown := newOutput()
+	go func() {
+		unknown.s.fulfill(nil, false, nil)
+	}()
+	_, known, err = JSONMarshal(map[string]interface{}{"a": unknown}).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.False(t, known)
+
+	failed, _, reject := NewOutput()
+	go func() {
+		reject(errors.New("boom"))
+	}()
+	_, _, err = JSONMarshal([]interface{}{failed}).s.await(context.Background())
+	assert.NotNil(t, err)
+}
@@ -17,11 +17,14 @@ package pulumi
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/sdk/go/pulumi/asset"
+	"github.com/pulumi/pulumi/sdk/go/pulumi/internal/outputs"
 )
 
 const (
@@ -45,32 +48,47 @@ type outputState struct {
 
 	state uint32 // one of output{Pending,Resolved,Rejected}
 
-	value interface{} // the value of this output if it is resolved.
-	err   error       // the error associated with this output if it is rejected.
-	known bool        // true if this output's value is known.
+	value  interface{} // the value of this output if it is resolved.
+	err    error       // the error associated with this output if it is rejected.
+	known  bool        // true if this output's value is known.
+	secret bool        // true if this output's value is secret.
 
 	deps []Resource // the dependencies associated with this output property.
+
+	callbacks []func() // the functions to call once this output is fulfilled.
 }
 
 func (o *outputState) dependencies() []Resource {
 	if o == nil {
 		return nil
 	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 	return o.deps
 }
 
+// addDependencies adds to the dependencies of the output, for those that are only discovered as it is resolved.
+func (o *outputState) addDependencies(deps []Resource) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	// Copy the dependencies, which may share their backing array with those of other outputs.
+	o.deps = append(append([]Resource(nil), o.deps...), deps...)
+}
+
 func (o *outputState) fulfill(value interface{}, known bool, err error) {
+	o.fulfillValue(value, known, false, err)
+}
+
+func (o *outputState) fulfillValue(value interface{}, known, secret bool, err error) {
 	if o == nil {
 		return
 	}
 
 	o.mutex.Lock()
-	defer func() {
-		o.mutex.Unlock()
-		o.cond.Broadcast()
-	}()
-
 	if o.state != outputPending {
+		o.mutex.Unlock()
 		return
 	}
 
@@ -79,6 +97,16 @@ func (o *outputState) fulfill(value interface{}, known bool, err error) {
 	} else {
 		o.state, o.value, o.known = outputResolved, value, known
 	}
+	o.secret = secret
+
+	callbacks := o.callbacks
+	o.callbacks = nil
+	o.mutex.Unlock()
+	o.cond.Broadcast()
+
+	for _, callback := range callbacks {
+		callback()
+	}
 }
 
 func (o *outputState) resolve(value interface{}, known bool) {
@@ -90,31 +118,123 @@ func (o *outputState) reject(err error) {
 }
 
 func (o *outputState) await(ctx context.Context) (interface{}, bool, error) {
+	v, known, _, err := o.awaitValue(ctx)
+	return v, known, err
+}
+
+// awaitValue is like await, but also returns whether the value is secret.  A value is secret if the output itself, or
+// any output that it was resolved to, is secret.
+func (o *outputState) awaitValue(ctx context.Context) (interface{}, bool, bool, error) {
+	secret := false
 	for {
-		if o == nil {
-			// If the state is nil, treat its value as resolved and unknown.
-			return nil, false, nil
+		v, known, s, err := o.awaitOnce(ctx)
+		secret = secret || s
+		if err != nil || !known {
+			return nil, known, secret, err
 		}
 
-		o.mutex.Lock()
-		for o.state == outputPending {
-			if ctx.Err() != nil {
-				return nil, true, ctx.Err()
-			}
-			o.cond.Wait()
+		ov, ok := isOutput(v)
+		if !ok {
+			return v, true, secret, nil
 		}
+		o = ov.s
+	}
+}
+
+// whenFulfilled calls f with the output's value, as awaitValue would return it, once the output and any output that it
+// was resolved to are fulfilled.  Rather than waiting on a goroutine of its own, f is registered with the pending
+// outputs and run by the apply scheduler, so that pending applies cost no more than their callbacks.  If ctx is done
+// first, f is called with ctx's error instead.
+func (o *outputState) whenFulfilled(ctx context.Context, f func(v interface{}, known, secret bool, err error)) {
+	var once sync.Once
+	onDone := &doneCallback{}
+	call := func(v interface{}, known, secret bool, err error) {
+		once.Do(func() {
+			onDone.cancel()
+			scheduleApply(func() { f(v, known, secret, err) })
+		})
+	}
+	onDone.f = func() { call(nil, true, false, ctx.Err()) }
+	onDone.register(ctx)
+
+	o.follow(false, call)
+}
+
+// follow calls f once the output is fulfilled, following any output that it was resolved to.  f is called on the
+// goroutine that fulfills the last output, or on the caller's if it is already fulfilled.
+func (o *outputState) follow(secret bool, f func(v interface{}, known, secret bool, err error)) {
+	if o == nil {
+		// If the state is nil, treat its value as resolved and unknown.
+		f(nil, false, secret, nil)
+		return
+	}
+
+	o.mutex.Lock()
+	if o.state == outputPending {
+		o.callbacks = append(o.callbacks, func() { o.follow(secret, f) })
 		o.mutex.Unlock()
+		return
+	}
+	v, known, err := o.value, o.known, o.err
+	secret = secret || o.secret
+	o.mutex.Unlock()
 
-		if !o.known || o.err != nil {
-			return nil, o.known, o.err
-		}
+	if err != nil || !known {
+		f(nil, known, secret, err)
+		return
+	}
+	if ov, ok := isOutput(v); ok {
+		ov.s.follow(secret, f)
+		return
+	}
+	f(v, true, secret, nil)
+}
 
-		ov, ok := isOutput(o.value)
-		if !ok {
-			return o.value, true, nil
+// awaitOnce waits for the output to be fulfilled, and returns its value, which may itself be an output.
+func (o *outputState) awaitOnce(ctx context.Context) (interface{}, bool, bool, error) {
+	if o == nil {
+		// If the state is nil, treat its value as resolved and unknown.
+		return nil, false, false, nil
+	}
+
+	o.mutex.Lock()
+	stop := o.wakeOnCancel(ctx)
+	for o.state == outputPending {
+		if ctx.Err() != nil {
+			o.mutex.Unlock()
+			stop()
+			return nil, true, false, ctx.Err()
 		}
-		o = ov.s
+		o.cond.Wait()
 	}
+	o.mutex.Unlock()
+	stop()
+
+	if !o.known || o.err != nil {
+		return nil, o.known, o.secret, o.err
+	}
+	return o.value, true, o.secret, nil
+}
+
+// wakeOnCancel wakes up the waiters for a pending output if the given context is canceled, so that they notice the
+// cancellation rather than waiting for the output to be fulfilled.  The returned function must be called once waiting
+// is done.  The output's mutex must be held.
+func (o *outputState) wakeOnCancel(ctx context.Context) func() {
+	if o.state != outputPending || ctx.Done() == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			o.mutex.Lock()
+			o.cond.Broadcast()
+			o.mutex.Unlock()
+		case <-done:
+		}
+	}()
+	return func() { close(done) }
 }
 
 func newOutput(deps ...Resource) Output {
@@ -140,6 +260,16 @@ func isOutput(v interface{}) (Output, bool) {
 	return Output{}, false
 }
 
+func init() {
+	outputs.Await = func(ctx context.Context, v interface{}) (interface{}, bool, bool, error) {
+		out, ok := isOutput(v)
+		if !ok {
+			return v, true, false, nil
+		}
+		return out.s.awaitValue(ctx)
+	}
+}
+
 // NewOutput returns an output value that can be used to rendezvous with the production of a value or error.  The
 // function returns the output itself, plus two functions: one for resolving a value, and another for rejecting with an
 // error; exactly one function must be called. This acts like a promise.
@@ -172,23 +302,110 @@ func (out Output) Apply(applier func(v interface{}) (interface{}, error)) Output
 func (out Output) ApplyWithContext(ctx context.Context,
 	applier func(ctx context.Context, v interface{}) (interface{}, error)) Output {
 
-	result := newOutput(out.s.deps...)
-	go func() {
-		v, known, err := out.s.await(ctx)
+	result := newOutput(out.s.dependencies()...)
+	location := captureCallerLocation()
+	out.s.whenFulfilled(ctx, func(v interface{}, known, secret bool, err error) {
 		if err != nil || !known {
-			result.s.fulfill(nil, known, err)
+			result.s.fulfillValue(nil, known, secret, err)
 			return
 		}
 
-		// If we have a known value, run the applier to transform it.
+		// If we have a known value, run the applier to transform it.  If it fails, note where it was applied, so that
+		// the failure can be traced back to the program.
 		u, err := applier(ctx, v)
 		if err != nil {
-			result.s.reject(err)
+			result.s.reject(wrapErrorf(err, "apply at %s", location))
+			return
+		}
+
+		// Fulfill the result, which is secret if its input was.
+		result.s.fulfillValue(u, true, secret, nil)
+	})
+	return result
+}
+
+// ApplyWithUnknowns is like Apply, but also runs the applier if the output's value is unknown, as it may be during a
+// preview, in which case the applier is passed a nil value and false.  The result is known unless the applier returns
+// an unknown output.  This lets component authors compute placeholder values during previews, rather than leaving
+// everything derived from an unknown value unknown.
+func (out Output) ApplyWithUnknowns(applier func(v interface{}, known bool) (interface{}, error)) Output {
+	result := newOutput(out.s.dependencies()...)
+	location := captureCallerLocation()
+	out.s.whenFulfilled(context.Background(), func(v interface{}, known, secret bool, err error) {
+		if err != nil {
+			result.s.fulfillValue(nil, known, secret, err)
+			return
+		}
+
+		u, err := applier(v, known)
+		if err != nil {
+			result.s.reject(wrapErrorf(err, "apply at %s", location))
 			return
 		}
+		result.s.fulfillValue(u, true, secret, nil)
+	})
+	return result
+}
+
+// IsKnown returns an output of whether the output's value is known.  Values may be unknown during previews, for
+// example the IDs of resources that are yet to be created.  The result is always known, and is rejected if the output
+// is.
+func (out Output) IsKnown() BoolOutput {
+	return BoolOutput(out.ApplyWithUnknowns(func(_ interface{}, known bool) (interface{}, error) {
+		return known, nil
+	}))
+}
 
-		// Fulfill the result.
-		result.s.fulfill(u, true, nil)
+// ToSecret returns an output whose value is that of the given input, but which is marked as secret.  The input may be
+// a plain value or an output, whose dependencies the result shares.  Secrets are encrypted when they are stored in the
+// stack's checkpoint, and outputs derived from a secret, for example by Apply, are secret too.
+func ToSecret(input interface{}) Output {
+	var deps []Resource
+	if out, ok := isOutput(input); ok {
+		deps = out.s.dependencies()
+	}
+
+	result := newOutput(deps...)
+	result.s.fulfillValue(input, true, true, nil)
+	return result
+}
+
+// Dependencies returns the resources that the output depends on, which are those whose outputs it was derived from.
+// Typed outputs, such as StringOutput, may be converted to an Output to inspect their dependencies.  Outputs whose
+// nested outputs are only discovered as they are resolved, such as those returned by Flatten, may gain dependencies
+// once they are resolved.
+func (out Output) Dependencies() []Resource {
+	return append([]Resource(nil), out.s.dependencies()...)
+}
+
+// Flatten returns an output of the value of an output whose value may itself be an output, as when an applier returns
+// an output, for example one returned by a helper library.  Unlike the output itself, once it is resolved, the result
+// also depends on the resources that the nested outputs depend on.  It may be converted to the type of the nested
+// output, such as StringOutput.
+func Flatten(out Output) Output {
+	result := newOutput(out.s.dependencies()...)
+	go func() {
+		var deps []Resource
+		secret := false
+		o := out.s
+		for {
+			v, known, s, err := o.awaitOnce(context.Background())
+			secret = secret || s
+			if err != nil || !known {
+				result.s.addDependencies(deps)
+				result.s.fulfillValue(nil, known, secret, err)
+				return
+			}
+
+			inner, ok := isOutput(v)
+			if !ok {
+				result.s.addDependencies(deps)
+				result.s.fulfillValue(v, true, secret, nil)
+				return
+			}
+			deps = append(deps, inner.s.dependencies()...)
+			o = inner.s
+		}
 	}()
 	return result
 }
@@ -215,6 +432,16 @@ func (out ArchiveOutput) ApplyWithContext(ctx context.Context, applier func(cont
 	})
 }
 
+// ToArchiveOutput returns the output itself, so that an ArchiveOutput may be used as an ArchiveInput.
+func (out ArchiveOutput) ToArchiveOutput() ArchiveOutput {
+	return out
+}
+
+// ArchiveInput is an archive value that may or may not be known yet.  It is implemented by ArchiveOutput.
+type ArchiveInput interface {
+	ToArchiveOutput() ArchiveOutput
+}
+
 // ArrayOutput is an Output that is typed to return arrays of values.
 type ArrayOutput Output
 
@@ -234,6 +461,98 @@ func (out ArrayOutput) ApplyWithContext(ctx context.Context, applier func(contex
 	})
 }
 
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// ApplyAll applies a transformation to the array's elements when they are available, passing each element as a
+// separate parameter of the applier, converted to the type of that parameter.  This is typically used with All, so
+// that, for example, combining an IntOutput and a StringOutput calls a func(int, string) (interface{}, error).  The
+// applier must be a function that has one parameter per element and returns a value and an error.
+func (out ArrayOutput) ApplyAll(applier interface{}) Output {
+	return out.applyAll(context.Background(), applier, false)
+}
+
+// ApplyAllWithContext is like ApplyAll, but the applier's first parameter must be a context.Context, which is passed
+// ctx.  The elements are passed as the remaining parameters.
+func (out ArrayOutput) ApplyAllWithContext(ctx context.Context, applier interface{}) Output {
+	return out.applyAll(ctx, applier, true)
+}
+
+func (out ArrayOutput) applyAll(ctx context.Context, applier interface{}, withContext bool) Output {
+	fn := reflect.ValueOf(applier)
+	if fn.Kind() != reflect.Func {
+		panic(errors.Errorf("applier must be a function; got %T", applier))
+	}
+	ft := fn.Type()
+	first := 0
+	if withContext {
+		if ft.NumIn() == 0 || ft.In(0) != contextType {
+			panic(errors.Errorf("applier must take a context.Context as its first parameter; got %v", ft))
+		}
+		first = 1
+	}
+	if ft.IsVariadic() || ft.NumOut() != 2 || ft.Out(1) != errorType {
+		panic(errors.Errorf("applier must be a non-variadic function that returns a value and an error; got %v", ft))
+	}
+
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		values := convert(v, arrayType).([]interface{})
+		if len(values) != ft.NumIn()-first {
+			return nil, errors.Errorf("applier takes %d values; got %d", ft.NumIn()-first, len(values))
+		}
+
+		args := make([]reflect.Value, ft.NumIn())
+		if withContext {
+			args[0] = reflect.ValueOf(ctx)
+		}
+		for i, value := range values {
+			t := ft.In(first + i)
+			if value == nil {
+				args[first+i] = reflect.Zero(t)
+			} else {
+				args[first+i] = reflect.ValueOf(convert(value, t))
+			}
+		}
+
+		results := fn.Call(args)
+		err, _ := results[1].Interface().(error)
+		return results[0].Interface(), err
+	})
+}
+
+// Index returns an output of the array's element at the given index, once both the array and the index are available.
+// The element is nil if the index is out of range.
+func (out ArrayOutput) Index(i IntInput) Output {
+	return All(out, i.ToIntOutput()).ApplyAll(func(arr []interface{}, i int) (interface{}, error) {
+		if i < 0 || i >= len(arr) {
+			return nil, nil
+		}
+		return arr[i], nil
+	})
+}
+
+// ToArrayOutput returns the output itself, so that an ArrayOutput may be used as an ArrayInput.
+func (out ArrayOutput) ToArrayOutput() ArrayOutput {
+	return out
+}
+
+// ArrayInput is an array value that may or may not be known yet: either an Array or an ArrayOutput.
+type ArrayInput interface {
+	ToArrayOutput() ArrayOutput
+}
+
+// Array is a literal array that may be used as an ArrayInput.
+type Array []interface{}
+
+// ToArrayOutput returns an output that is resolved to the array's value.
+func (a Array) ToArrayOutput() ArrayOutput {
+	out := newOutput()
+	out.s.resolve([]interface{}(a), true)
+	return ArrayOutput(out)
+}
+
 // AssetOutput is an Output that is typed to return asset values.
 type AssetOutput Output
 
@@ -253,6 +572,16 @@ func (out AssetOutput) ApplyWithContext(ctx context.Context, applier func(contex
 	})
 }
 
+// ToAssetOutput returns the output itself, so that an AssetOutput may be used as an AssetInput.
+func (out AssetOutput) ToAssetOutput() AssetOutput {
+	return out
+}
+
+// AssetInput is an asset value that may or may not be known yet.  It is implemented by AssetOutput.
+type AssetInput interface {
+	ToAssetOutput() AssetOutput
+}
+
 // BoolOutput is an Output that is typed to return bool values.
 type BoolOutput Output
 
@@ -272,6 +601,31 @@ func (out BoolOutput) ApplyWithContext(ctx context.Context, applier func(context
 	})
 }
 
+// ToBoolOutput returns the output itself, so that a BoolOutput may be used as a BoolInput.
+func (out BoolOutput) ToBoolOutput() BoolOutput {
+	return out
+}
+
+// BoolInput is a bool value that may or may not be known yet: either a Bool or a BoolOutput.
+type BoolInput interface {
+	ToBoolOutput() BoolOutput
+}
+
+// Bool is a literal bool that may be used as a BoolInput.
+type Bool bool
+
+// ToBoolOutput returns an output that is resolved to the bool's value.
+func (b Bool) ToBoolOutput() BoolOutput {
+	out := newOutput()
+	out.s.resolve(bool(b), true)
+	return BoolOutput(out)
+}
+
+// ToBoolPtrOutput returns an output that is resolved to a pointer to the bool's value.
+func (b Bool) ToBoolPtrOutput() BoolPtrOutput {
+	return BoolPtr(bool(b)).ToBoolPtrOutput()
+}
+
 // Float32Output is an Output that is typed to return float32 values.
 type Float32Output Output
 
@@ -291,6 +645,31 @@ func (out Float32Output) ApplyWithContext(ctx context.Context, applier func(cont
 	})
 }
 
+// ToFloat32Output returns the output itself, so that a Float32Output may be used as a Float32Input.
+func (out Float32Output) ToFloat32Output() Float32Output {
+	return out
+}
+
+// Float32Input is a float32 value that may or may not be known yet: either a Float32 or a Float32Output.
+type Float32Input interface {
+	ToFloat32Output() Float32Output
+}
+
+// Float32 is a literal float32 that may be used as a Float32Input.
+type Float32 float32
+
+// ToFloat32Output returns an output that is resolved to the float32's value.
+func (f Float32) ToFloat32Output() Float32Output {
+	out := newOutput()
+	out.s.resolve(float32(f), true)
+	return Float32Output(out)
+}
+
+// ToFloat32PtrOutput returns an output that is resolved to a pointer to the float32's value.
+func (f Float32) ToFloat32PtrOutput() Float32PtrOutput {
+	return Float32Ptr(float32(f)).ToFloat32PtrOutput()
+}
+
 // Float64Output is an Output that is typed to return float64 values.
 type Float64Output Output
 
@@ -310,6 +689,31 @@ func (out Float64Output) ApplyWithContext(ctx context.Context, applier func(cont
 	})
 }
 
+// ToFloat64Output returns the output itself, so that a Float64Output may be used as a Float64Input.
+func (out Float64Output) ToFloat64Output() Float64Output {
+	return out
+}
+
+// Float64Input is a float64 value that may or may not be known yet: either a Float64 or a Float64Output.
+type Float64Input interface {
+	ToFloat64Output() Float64Output
+}
+
+// Float64 is a literal float64 that may be used as a Float64Input.
+type Float64 float64
+
+// ToFloat64Output returns an output that is resolved to the float64's value.
+func (f Float64) ToFloat64Output() Float64Output {
+	out := newOutput()
+	out.s.resolve(float64(f), true)
+	return Float64Output(out)
+}
+
+// ToFloat64PtrOutput returns an output that is resolved to a pointer to the float64's value.
+func (f Float64) ToFloat64PtrOutput() Float64PtrOutput {
+	return Float64Ptr(float64(f)).ToFloat64PtrOutput()
+}
+
 // IDOutput is an Output that is typed to return ID values.
 type IDOutput Output
 
@@ -337,6 +741,23 @@ func (out IDOutput) ApplyWithContext(ctx context.Context, applier func(context.C
 	})
 }
 
+// ToIDOutput returns the output itself, so that an IDOutput may be used as an IDInput.
+func (out IDOutput) ToIDOutput() IDOutput {
+	return out
+}
+
+// IDInput is an ID value that may or may not be known yet: either an ID or an IDOutput.
+type IDInput interface {
+	ToIDOutput() IDOutput
+}
+
+// ToIDOutput returns an output that is resolved to the ID's value.
+func (id ID) ToIDOutput() IDOutput {
+	out := newOutput()
+	out.s.resolve(id, true)
+	return IDOutput(out)
+}
+
 // IntOutput is an Output that is typed to return int values.
 type IntOutput Output
 
@@ -356,6 +777,31 @@ func (out IntOutput) ApplyWithContext(ctx context.Context, applier func(context.
 	})
 }
 
+// ToIntOutput returns the output itself, so that an IntOutput may be used as an IntInput.
+func (out IntOutput) ToIntOutput() IntOutput {
+	return out
+}
+
+// IntInput is an int value that may or may not be known yet: either an Int or an IntOutput.
+type IntInput interface {
+	ToIntOutput() IntOutput
+}
+
+// Int is a literal int that may be used as an IntInput.
+type Int int
+
+// ToIntOutput returns an output that is resolved to the int's value.
+func (i Int) ToIntOutput() IntOutput {
+	out := newOutput()
+	out.s.resolve(int(i), true)
+	return IntOutput(out)
+}
+
+// ToIntPtrOutput returns an output that is resolved to a pointer to the int's value.
+func (i Int) ToIntPtrOutput() IntPtrOutput {
+	return IntPtr(int(i)).ToIntPtrOutput()
+}
+
 // Int8Output is an Output that is typed to return int8 values.
 type Int8Output Output
 
@@ -375,6 +821,31 @@ func (out Int8Output) ApplyWithContext(ctx context.Context, applier func(context
 	})
 }
 
+// ToInt8Output returns the output itself, so that an Int8Output may be used as an Int8Input.
+func (out Int8Output) ToInt8Output() Int8Output {
+	return out
+}
+
+// Int8Input is an int8 value that may or may not be known yet: either an Int8 or an Int8Output.
+type Int8Input interface {
+	ToInt8Output() Int8Output
+}
+
+// Int8 is a literal int8 that may be used as an Int8Input.
+type Int8 int8
+
+// ToInt8Output returns an output that is resolved to the int8's value.
+func (i Int8) ToInt8Output() Int8Output {
+	out := newOutput()
+	out.s.resolve(int8(i), true)
+	return Int8Output(out)
+}
+
+// ToInt8PtrOutput returns an output that is resolved to a pointer to the int8's value.
+func (i Int8) ToInt8PtrOutput() Int8PtrOutput {
+	return Int8Ptr(int8(i)).ToInt8PtrOutput()
+}
+
 // Int16Output is an Output that is typed to return int16 values.
 type Int16Output Output
 
@@ -394,6 +865,31 @@ func (out Int16Output) ApplyWithContext(ctx context.Context, applier func(contex
 	})
 }
 
+// ToInt16Output returns the output itself, so that an Int16Output may be used as an Int16Input.
+func (out Int16Output) ToInt16Output() Int16Output {
+	return out
+}
+
+// Int16Input is an int16 value that may or may not be known yet: either an Int16 or an Int16Output.
+type Int16Input interface {
+	ToInt16Output() Int16Output
+}
+
+// Int16 is a literal int16 that may be used as an Int16Input.
+type Int16 int16
+
+// ToInt16Output returns an output that is resolved to the int16's value.
+func (i Int16) ToInt16Output() Int16Output {
+	out := newOutput()
+	out.s.resolve(int16(i), true)
+	return Int16Output(out)
+}
+
+// ToInt16PtrOutput returns an output that is resolved to a pointer to the int16's value.
+func (i Int16) ToInt16PtrOutput() Int16PtrOutput {
+	return Int16Ptr(int16(i)).ToInt16PtrOutput()
+}
+
 // Int32Output is an Output that is typed to return int32 values.
 type Int32Output Output
 
@@ -413,6 +909,31 @@ func (out Int32Output) ApplyWithContext(ctx context.Context, applier func(contex
 	})
 }
 
+// ToInt32Output returns the output itself, so that an Int32Output may be used as an Int32Input.
+func (out Int32Output) ToInt32Output() Int32Output {
+	return out
+}
+
+// Int32Input is an int32 value that may or may not be known yet: either an Int32 or an Int32Output.
+type Int32Input interface {
+	ToInt32Output() Int32Output
+}
+
+// Int32 is a literal int32 that may be used as an Int32Input.
+type Int32 int32
+
+// ToInt32Output returns an output that is resolved to the int32's value.
+func (i Int32) ToInt32Output() Int32Output {
+	out := newOutput()
+	out.s.resolve(int32(i), true)
+	return Int32Output(out)
+}
+
+// ToInt32PtrOutput returns an output that is resolved to a pointer to the int32's value.
+func (i Int32) ToInt32PtrOutput() Int32PtrOutput {
+	return Int32Ptr(int32(i)).ToInt32PtrOutput()
+}
+
 // Int64Output is an Output that is typed to return int64 values.
 type Int64Output Output
 
@@ -432,6 +953,31 @@ func (out Int64Output) ApplyWithContext(ctx context.Context, applier func(contex
 	})
 }
 
+// ToInt64Output returns the output itself, so that an Int64Output may be used as an Int64Input.
+func (out Int64Output) ToInt64Output() Int64Output {
+	return out
+}
+
+// Int64Input is an int64 value that may or may not be known yet: either an Int64 or an Int64Output.
+type Int64Input interface {
+	ToInt64Output() Int64Output
+}
+
+// Int64 is a literal int64 that may be used as an Int64Input.
+type Int64 int64
+
+// ToInt64Output returns an output that is resolved to the int64's value.
+func (i Int64) ToInt64Output() Int64Output {
+	out := newOutput()
+	out.s.resolve(int64(i), true)
+	return Int64Output(out)
+}
+
+// ToInt64PtrOutput returns an output that is resolved to a pointer to the int64's value.
+func (i Int64) ToInt64PtrOutput() Int64PtrOutput {
+	return Int64Ptr(int64(i)).ToInt64PtrOutput()
+}
+
 // MapOutput is an Output that is typed to return map values.
 type MapOutput Output
 
@@ -451,6 +997,34 @@ func (out MapOutput) ApplyWithContext(ctx context.Context, applier func(context.
 	})
 }
 
+// MapIndex returns an output of the map's value for the given key, once both the map and the key are available.  The
+// value is nil if the map has no such key.
+func (out MapOutput) MapIndex(k StringInput) Output {
+	return All(out, k.ToStringOutput()).ApplyAll(func(m map[string]interface{}, k string) (interface{}, error) {
+		return m[k], nil
+	})
+}
+
+// ToMapOutput returns the output itself, so that a MapOutput may be used as a MapInput.
+func (out MapOutput) ToMapOutput() MapOutput {
+	return out
+}
+
+// MapInput is a map value that may or may not be known yet: either a Map or a MapOutput.
+type MapInput interface {
+	ToMapOutput() MapOutput
+}
+
+// Map is a literal map that may be used as a MapInput.
+type Map map[string]interface{}
+
+// ToMapOutput returns an output that is resolved to the map's value.
+func (m Map) ToMapOutput() MapOutput {
+	out := newOutput()
+	out.s.resolve(map[string]interface{}(m), true)
+	return MapOutput(out)
+}
+
 // StringOutput is an Output that is typed to return number values.
 type StringOutput Output
 
@@ -468,25 +1042,318 @@ func (out StringOutput) ApplyWithContext(ctx context.Context, applier func(conte
 	})
 }
 
-// UintOutput is an Output that is typed to return uint values.
-type UintOutput Output
-
-var uintType = reflect.TypeOf(uint(0))
+// ToStringOutput returns the output itself, so that a StringOutput may be used as a StringInput.
+func (out StringOutput) ToStringOutput() StringOutput {
+	return out
+}
 
-// Apply applies a transformation to the archive value when it is available.
-func (out UintOutput) Apply(applier func(uint) (interface{}, error)) Output {
-	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint) (interface{}, error) {
-		return applier(v)
-	})
+// StringInput is a string value that may or may not be known yet: either a String or a StringOutput.
+type StringInput interface {
+	ToStringOutput() StringOutput
 }
 
-// ApplyWithContext applies a transformation to the archive value when it is available.
+// String is a literal string that may be used as a StringInput.
+type String string
+
+// ToStringOutput returns an output that is resolved to the string's value.
+func (s String) ToStringOutput() StringOutput {
+	out := newOutput()
+	out.s.resolve(string(s), true)
+	return StringOutput(out)
+}
+
+// ToStringPtrOutput returns an output that is resolved to a pointer to the string's value.
+func (s String) ToStringPtrOutput() StringPtrOutput {
+	return StringPtr(string(s)).ToStringPtrOutput()
+}
+
+// Concat returns an output that concatenates the values of the given parts once all of them are available.  The
+// result accumulates the dependencies of every part, and is unknown if any part is unknown.
+func Concat(parts ...StringInput) StringOutput {
+	return Join("", parts...)
+}
+
+// Join returns an output that joins the values of the given parts, separated by sep, once all of them are
+// available.  The result accumulates the dependencies of every part, and is unknown if any part is unknown.
+func Join(sep string, parts ...StringInput) StringOutput {
+	outs := make([]interface{}, len(parts))
+	for i, part := range parts {
+		outs[i] = part.ToStringOutput()
+	}
+
+	return StringOutput(All(outs...).Apply(func(vs []interface{}) (interface{}, error) {
+		values := make([]string, len(vs))
+		for i, v := range vs {
+			values[i] = convert(v, stringType).(string)
+		}
+		return strings.Join(values, sep), nil
+	}))
+}
+
+// All returns an output of an array of the values of the given inputs, once all of them are available.  Any input may
+// be an output, in which case its value takes its place in the array.  The result accumulates the dependencies of
+// every output input, is unknown if any of them is unknown, and is secret if any of them is secret.  Use
+// ArrayOutput.ApplyAll to receive the values as separate, typed parameters.
+func All(inputs ...interface{}) ArrayOutput {
+	outs := make(map[int]Output)
+	var deps []Resource
+	for i, input := range inputs {
+		if out, ok := isOutput(input); ok {
+			outs[i] = out
+			deps = append(deps, out.s.dependencies()...)
+		}
+	}
+
+	// Collect the values in order, moving on to the next output input once the previous one is fulfilled.
+	result := newOutput(deps...)
+	values := make([]interface{}, len(inputs))
+	anySecret := false
+	var collect func(i int)
+	collect = func(i int) {
+		for ; i < len(inputs); i++ {
+			out, ok := outs[i]
+			if !ok {
+				values[i] = inputs[i]
+				continue
+			}
+
+			i := i
+			out.s.whenFulfilled(context.Background(), func(v interface{}, known, secret bool, err error) {
+				anySecret = anySecret || secret
+				if err != nil || !known {
+					result.s.fulfillValue(nil, known, anySecret, err)
+					return
+				}
+				values[i] = v
+				collect(i + 1)
+			})
+			return
+		}
+		result.s.fulfillValue(values, true, anySecret, nil)
+	}
+	collect(0)
+	return ArrayOutput(result)
+}
+
+// ToOutput returns an output of v in which any outputs nested within v's maps and slices are replaced by their values,
+// once all of them are available.  This lifts a structure that may contain outputs, such as the arguments of a
+// component, to a single output.  Nested maps with string keys and slices are copied to map[string]interface{} and
+// []interface{} values; other values are left as they are.  The result accumulates the dependencies of every nested
+// output, is unknown if any of them is unknown, and is secret if any of them is secret.
+func ToOutput(v interface{}) Output {
+	result := newOutput(nestedDependencies(v)...)
+	go func() {
+		resolved, known, secret, err := awaitNested(context.Background(), v)
+		result.s.fulfillValue(resolved, known, secret, err)
+	}()
+	return result
+}
+
+// nestedDependencies returns the dependencies of the outputs nested within v's maps and slices.
+func nestedDependencies(v interface{}) []Resource {
+	if out, ok := isOutput(v); ok {
+		return out.s.dependencies()
+	}
+
+	var deps []Resource
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if !isBytes(rv) {
+			for i := 0; i < rv.Len(); i++ {
+				deps = append(deps, nestedDependencies(rv.Index(i).Interface())...)
+			}
+		}
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			deps = append(deps, nestedDependencies(rv.MapIndex(key).Interface())...)
+		}
+	}
+	return deps
+}
+
+// awaitNested returns a copy of v in which the outputs nested within its maps and slices are replaced by their values,
+// once all of them are available, along with whether all of them are known and whether any of them is secret.
+func awaitNested(ctx context.Context, v interface{}) (interface{}, bool, bool, error) {
+	if out, ok := isOutput(v); ok {
+		ov, known, secret, err := out.s.awaitValue(ctx)
+		if err != nil || !known {
+			return nil, known, secret, err
+		}
+		e, known, s, err := awaitNested(ctx, ov)
+		return e, known, secret || s, err
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if isBytes(rv) || (rv.Kind() == reflect.Slice && rv.IsNil()) {
+			break
+		}
+		arr := make([]interface{}, rv.Len())
+		anySecret := false
+		for i := range arr {
+			e, known, secret, err := awaitNested(ctx, rv.Index(i).Interface())
+			anySecret = anySecret || secret
+			if err != nil || !known {
+				return nil, known, anySecret, err
+			}
+			arr[i] = e
+		}
+		return arr, true, anySecret, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String || rv.IsNil() {
+			break
+		}
+		obj := make(map[string]interface{})
+		anySecret := false
+		for _, key := range rv.MapKeys() {
+			e, known, secret, err := awaitNested(ctx, rv.MapIndex(key).Interface())
+			anySecret = anySecret || secret
+			if err != nil || !known {
+				return nil, known, anySecret, err
+			}
+			obj[key.String()] = e
+		}
+		return obj, true, anySecret, nil
+	}
+	return v, true, false, nil
+}
+
+// isBytes returns true if rv is a byte slice or array, which cannot contain outputs, and which is best left as it is.
+func isBytes(rv reflect.Value) bool {
+	return rv.Type().Elem().Kind() == reflect.Uint8
+}
+
+// ApplyN applies a transformation to the values of the given inputs once all of them are available, passing each value
+// as a separate parameter of the applier, converted to the type of that parameter.  For example, an IntOutput and a
+// StringOutput may be passed to a func(int, string) (interface{}, error).  Any input may be a plain value.  As with
+// Apply, the result is an Output, which may be converted to the typed output of the applier's result.
+func ApplyN(applier interface{}, inputs ...interface{}) Output {
+	checkApplierArity(applier, len(inputs), false)
+	return All(inputs...).ApplyAll(applier)
+}
+
+// ApplyNWithContext is like ApplyN, but the applier's first parameter must be a context.Context, which is passed ctx.
+func ApplyNWithContext(ctx context.Context, applier interface{}, inputs ...interface{}) Output {
+	checkApplierArity(applier, len(inputs), true)
+	return All(inputs...).ApplyAllWithContext(ctx, applier)
+}
+
+// checkApplierArity panics if the applier is a function that does not take one parameter per input, besides its
+// context, if any.  The applier's other requirements are checked by ArrayOutput.ApplyAll.
+func checkApplierArity(applier interface{}, inputs int, withContext bool) {
+	if fn := reflect.ValueOf(applier); fn.Kind() == reflect.Func {
+		params := fn.Type().NumIn()
+		if withContext {
+			params--
+		}
+		if params != inputs {
+			panic(errors.Errorf("applier takes %d values; got %d inputs", params, inputs))
+		}
+	}
+}
+
+// Sprintf returns an output that formats the given arguments according to the format specifier, as fmt.Sprintf does,
+// once all of them are available.  Any argument may be an output, such as a StringOutput or IntOutput, in which case
+// its value is formatted in its place.  The result accumulates the dependencies of every output argument, and is
+// unknown if any of them is unknown.
+func Sprintf(format string, args ...interface{}) StringOutput {
+	return StringOutput(All(args...).Apply(func(values []interface{}) (interface{}, error) {
+		return fmt.Sprintf(format, values...), nil
+	}))
+}
+
+// NewStringAssetOutput returns an output of an asset backed by the given text, which may not be known yet.  The asset
+// is created once the text is known.
+func NewStringAssetOutput(text StringInput) AssetOutput {
+	return AssetOutput(text.ToStringOutput().Apply(func(v string) (interface{}, error) {
+		return asset.NewStringAsset(v), nil
+	}))
+}
+
+// NewAssetArchiveOutput returns an output of an archive of the given named assets and archives, any of which may be
+// outputs that are not known yet.  The archive is created once all of its contents are known, and depends on every
+// output it was created from.
+func NewAssetArchiveOutput(assets map[string]interface{}) ArchiveOutput {
+	outs := make(map[string]Output)
+	var deps []Resource
+	for k, a := range assets {
+		if out, ok := isOutput(a); ok {
+			outs[k] = out
+			deps = append(deps, out.s.dependencies()...)
+		}
+	}
+
+	result := newOutput(deps...)
+	go func() {
+		contents := make(map[string]interface{})
+		anySecret := false
+		for k, a := range assets {
+			if out, ok := outs[k]; ok {
+				v, known, secret, err := out.s.awaitValue(context.Background())
+				anySecret = anySecret || secret
+				if err != nil || !known {
+					result.s.fulfillValue(nil, known, anySecret, err)
+					return
+				}
+				a = v
+			}
+			switch a.(type) {
+			case asset.Asset, asset.Archive:
+				contents[k] = a
+			default:
+				result.s.reject(errors.Errorf("expected archive contents to be assets or archives; %s is %T", k, a))
+				return
+			}
+		}
+		result.s.fulfillValue(asset.NewAssetArchive(contents), true, anySecret, nil)
+	}()
+	return ArchiveOutput(result)
+}
+
+// UintOutput is an Output that is typed to return uint values.
+type UintOutput Output
+
+var uintType = reflect.TypeOf(uint(0))
+
+// Apply applies a transformation to the archive value when it is available.
+func (out UintOutput) Apply(applier func(uint) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v uint) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the archive value when it is available.
 func (out UintOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, uint) (interface{}, error)) Output {
 	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
 		return applier(ctx, convert(v, uintType).(uint))
 	})
 }
 
+// ToUintOutput returns the output itself, so that an UintOutput may be used as an UintInput.
+func (out UintOutput) ToUintOutput() UintOutput {
+	return out
+}
+
+// UintInput is a uint value that may or may not be known yet: either a Uint or an UintOutput.
+type UintInput interface {
+	ToUintOutput() UintOutput
+}
+
+// Uint is a literal uint that may be used as a UintInput.
+type Uint uint
+
+// ToUintOutput returns an output that is resolved to the uint's value.
+func (u Uint) ToUintOutput() UintOutput {
+	out := newOutput()
+	out.s.resolve(uint(u), true)
+	return UintOutput(out)
+}
+
+// ToUintPtrOutput returns an output that is resolved to a pointer to the uint's value.
+func (u Uint) ToUintPtrOutput() UintPtrOutput {
+	return UintPtr(uint(u)).ToUintPtrOutput()
+}
+
 // Uint8Output is an Output that is typed to return uint8 values.
 type Uint8Output Output
 
@@ -506,6 +1373,31 @@ func (out Uint8Output) ApplyWithContext(ctx context.Context, applier func(contex
 	})
 }
 
+// ToUint8Output returns the output itself, so that an Uint8Output may be used as an Uint8Input.
+func (out Uint8Output) ToUint8Output() Uint8Output {
+	return out
+}
+
+// Uint8Input is a uint8 value that may or may not be known yet: either a Uint8 or an Uint8Output.
+type Uint8Input interface {
+	ToUint8Output() Uint8Output
+}
+
+// Uint8 is a literal uint8 that may be used as a Uint8Input.
+type Uint8 uint8
+
+// ToUint8Output returns an output that is resolved to the uint8's value.
+func (u Uint8) ToUint8Output() Uint8Output {
+	out := newOutput()
+	out.s.resolve(uint8(u), true)
+	return Uint8Output(out)
+}
+
+// ToUint8PtrOutput returns an output that is resolved to a pointer to the uint8's value.
+func (u Uint8) ToUint8PtrOutput() Uint8PtrOutput {
+	return Uint8Ptr(uint8(u)).ToUint8PtrOutput()
+}
+
 // Uint16Output is an Output that is typed to return uint16 values.
 type Uint16Output Output
 
@@ -525,6 +1417,31 @@ func (out Uint16Output) ApplyWithContext(ctx context.Context, applier func(conte
 	})
 }
 
+// ToUint16Output returns the output itself, so that an Uint16Output may be used as an Uint16Input.
+func (out Uint16Output) ToUint16Output() Uint16Output {
+	return out
+}
+
+// Uint16Input is a uint16 value that may or may not be known yet: either a Uint16 or an Uint16Output.
+type Uint16Input interface {
+	ToUint16Output() Uint16Output
+}
+
+// Uint16 is a literal uint16 that may be used as a Uint16Input.
+type Uint16 uint16
+
+// ToUint16Output returns an output that is resolved to the uint16's value.
+func (u Uint16) ToUint16Output() Uint16Output {
+	out := newOutput()
+	out.s.resolve(uint16(u), true)
+	return Uint16Output(out)
+}
+
+// ToUint16PtrOutput returns an output that is resolved to a pointer to the uint16's value.
+func (u Uint16) ToUint16PtrOutput() Uint16PtrOutput {
+	return Uint16Ptr(uint16(u)).ToUint16PtrOutput()
+}
+
 // Uint32Output is an Output that is typed to return uint32 values.
 type Uint32Output Output
 
@@ -544,6 +1461,31 @@ func (out Uint32Output) ApplyWithContext(ctx context.Context, applier func(conte
 	})
 }
 
+// ToUint32Output returns the output itself, so that an Uint32Output may be used as an Uint32Input.
+func (out Uint32Output) ToUint32Output() Uint32Output {
+	return out
+}
+
+// Uint32Input is a uint32 value that may or may not be known yet: either a Uint32 or an Uint32Output.
+type Uint32Input interface {
+	ToUint32Output() Uint32Output
+}
+
+// Uint32 is a literal uint32 that may be used as a Uint32Input.
+type Uint32 uint32
+
+// ToUint32Output returns an output that is resolved to the uint32's value.
+func (u Uint32) ToUint32Output() Uint32Output {
+	out := newOutput()
+	out.s.resolve(uint32(u), true)
+	return Uint32Output(out)
+}
+
+// ToUint32PtrOutput returns an output that is resolved to a pointer to the uint32's value.
+func (u Uint32) ToUint32PtrOutput() Uint32PtrOutput {
+	return Uint32Ptr(uint32(u)).ToUint32PtrOutput()
+}
+
 // Uint64Output is an Output that is typed to return uint64 values.
 type Uint64Output Output
 
@@ -563,6 +1505,31 @@ func (out Uint64Output) ApplyWithContext(ctx context.Context, applier func(conte
 	})
 }
 
+// ToUint64Output returns the output itself, so that an Uint64Output may be used as an Uint64Input.
+func (out Uint64Output) ToUint64Output() Uint64Output {
+	return out
+}
+
+// Uint64Input is a uint64 value that may or may not be known yet: either a Uint64 or an Uint64Output.
+type Uint64Input interface {
+	ToUint64Output() Uint64Output
+}
+
+// Uint64 is a literal uint64 that may be used as a Uint64Input.
+type Uint64 uint64
+
+// ToUint64Output returns an output that is resolved to the uint64's value.
+func (u Uint64) ToUint64Output() Uint64Output {
+	out := newOutput()
+	out.s.resolve(uint64(u), true)
+	return Uint64Output(out)
+}
+
+// ToUint64PtrOutput returns an output that is resolved to a pointer to the uint64's value.
+func (u Uint64) ToUint64PtrOutput() Uint64PtrOutput {
+	return Uint64Ptr(uint64(u)).ToUint64PtrOutput()
+}
+
 // URNOutput is an Output that is typed to return URN values.
 type URNOutput Output
 
@@ -588,6 +1555,758 @@ func (out URNOutput) ApplyWithContext(ctx context.Context, applier func(context.
 	})
 }
 
+// ToURNOutput returns the output itself, so that a URNOutput may be used as a URNInput.
+func (out URNOutput) ToURNOutput() URNOutput {
+	return out
+}
+
+// URNInput is a URN value that may or may not be known yet: either a URN or a URNOutput.
+type URNInput interface {
+	ToURNOutput() URNOutput
+}
+
+// ToURNOutput returns an output that is resolved to the URN's value.
+func (urn URN) ToURNOutput() URNOutput {
+	out := newOutput()
+	out.s.resolve(urn, true)
+	return URNOutput(out)
+}
+
+// BoolPtrOutput is an Output that is typed to return optional bool values, which are nil if unset.
+type BoolPtrOutput Output
+
+// Apply applies a transformation to the optional bool value when it is available.
+func (out BoolPtrOutput) Apply(applier func(*bool) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *bool) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional bool value when it is available.
+func (out BoolPtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *bool) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, boolType).(*bool))
+	})
+}
+
+// Elem returns an output of the bool value that the pointer refers to, or of false if it is nil.
+func (out BoolPtrOutput) Elem() BoolOutput {
+	return BoolOutput(out.Apply(func(v *bool) (interface{}, error) {
+		if v == nil {
+			return false, nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToBoolPtrOutput returns the output itself, so that a BoolPtrOutput may be used as a BoolPtrInput.
+func (out BoolPtrOutput) ToBoolPtrOutput() BoolPtrOutput {
+	return out
+}
+
+// ToBoolPtrOutput returns an output of a pointer to the bool value, so that a BoolOutput may be used as a BoolPtrInput.
+func (out BoolOutput) ToBoolPtrOutput() BoolPtrOutput {
+	return BoolPtrOutput(out.Apply(func(v bool) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// BoolPtrInput is an optional bool value that may or may not be known yet.
+type BoolPtrInput interface {
+	ToBoolPtrOutput() BoolPtrOutput
+}
+
+// BoolPtr returns a BoolPtrInput that is set to the given bool value.
+func BoolPtr(v bool) BoolPtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return BoolPtrOutput(out)
+}
+
+// Float32PtrOutput is an Output that is typed to return optional float32 values, which are nil if unset.
+type Float32PtrOutput Output
+
+// Apply applies a transformation to the optional float32 value when it is available.
+func (out Float32PtrOutput) Apply(applier func(*float32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *float32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional float32 value when it is available.
+func (out Float32PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *float32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, float32Type).(*float32))
+	})
+}
+
+// Elem returns an output of the float32 value that the pointer refers to, or of 0 if it is nil.
+func (out Float32PtrOutput) Elem() Float32Output {
+	return Float32Output(out.Apply(func(v *float32) (interface{}, error) {
+		if v == nil {
+			return float32(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToFloat32PtrOutput returns the output itself, so that a Float32PtrOutput may be used as a Float32PtrInput.
+func (out Float32PtrOutput) ToFloat32PtrOutput() Float32PtrOutput {
+	return out
+}
+
+// ToFloat32PtrOutput returns an output of a pointer to the float32 value, so that a Float32Output may be used as a Float32PtrInput.
+func (out Float32Output) ToFloat32PtrOutput() Float32PtrOutput {
+	return Float32PtrOutput(out.Apply(func(v float32) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Float32PtrInput is an optional float32 value that may or may not be known yet.
+type Float32PtrInput interface {
+	ToFloat32PtrOutput() Float32PtrOutput
+}
+
+// Float32Ptr returns a Float32PtrInput that is set to the given float32 value.
+func Float32Ptr(v float32) Float32PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Float32PtrOutput(out)
+}
+
+// Float64PtrOutput is an Output that is typed to return optional float64 values, which are nil if unset.
+type Float64PtrOutput Output
+
+// Apply applies a transformation to the optional float64 value when it is available.
+func (out Float64PtrOutput) Apply(applier func(*float64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *float64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional float64 value when it is available.
+func (out Float64PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *float64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, float64Type).(*float64))
+	})
+}
+
+// Elem returns an output of the float64 value that the pointer refers to, or of 0 if it is nil.
+func (out Float64PtrOutput) Elem() Float64Output {
+	return Float64Output(out.Apply(func(v *float64) (interface{}, error) {
+		if v == nil {
+			return 0, nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToFloat64PtrOutput returns the output itself, so that a Float64PtrOutput may be used as a Float64PtrInput.
+func (out Float64PtrOutput) ToFloat64PtrOutput() Float64PtrOutput {
+	return out
+}
+
+// ToFloat64PtrOutput returns an output of a pointer to the float64 value, so that a Float64Output may be used as a Float64PtrInput.
+func (out Float64Output) ToFloat64PtrOutput() Float64PtrOutput {
+	return Float64PtrOutput(out.Apply(func(v float64) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Float64PtrInput is an optional float64 value that may or may not be known yet.
+type Float64PtrInput interface {
+	ToFloat64PtrOutput() Float64PtrOutput
+}
+
+// Float64Ptr returns a Float64PtrInput that is set to the given float64 value.
+func Float64Ptr(v float64) Float64PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Float64PtrOutput(out)
+}
+
+// IntPtrOutput is an Output that is typed to return optional int values, which are nil if unset.
+type IntPtrOutput Output
+
+// Apply applies a transformation to the optional int value when it is available.
+func (out IntPtrOutput) Apply(applier func(*int) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *int) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional int value when it is available.
+func (out IntPtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *int) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, intType).(*int))
+	})
+}
+
+// Elem returns an output of the int value that the pointer refers to, or of 0 if it is nil.
+func (out IntPtrOutput) Elem() IntOutput {
+	return IntOutput(out.Apply(func(v *int) (interface{}, error) {
+		if v == nil {
+			return 0, nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToIntPtrOutput returns the output itself, so that a IntPtrOutput may be used as a IntPtrInput.
+func (out IntPtrOutput) ToIntPtrOutput() IntPtrOutput {
+	return out
+}
+
+// ToIntPtrOutput returns an output of a pointer to the int value, so that a IntOutput may be used as a IntPtrInput.
+func (out IntOutput) ToIntPtrOutput() IntPtrOutput {
+	return IntPtrOutput(out.Apply(func(v int) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// IntPtrInput is an optional int value that may or may not be known yet.
+type IntPtrInput interface {
+	ToIntPtrOutput() IntPtrOutput
+}
+
+// IntPtr returns a IntPtrInput that is set to the given int value.
+func IntPtr(v int) IntPtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return IntPtrOutput(out)
+}
+
+// Int8PtrOutput is an Output that is typed to return optional int8 values, which are nil if unset.
+type Int8PtrOutput Output
+
+// Apply applies a transformation to the optional int8 value when it is available.
+func (out Int8PtrOutput) Apply(applier func(*int8) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *int8) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional int8 value when it is available.
+func (out Int8PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *int8) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, int8Type).(*int8))
+	})
+}
+
+// Elem returns an output of the int8 value that the pointer refers to, or of 0 if it is nil.
+func (out Int8PtrOutput) Elem() Int8Output {
+	return Int8Output(out.Apply(func(v *int8) (interface{}, error) {
+		if v == nil {
+			return int8(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToInt8PtrOutput returns the output itself, so that an Int8PtrOutput may be used as an Int8PtrInput.
+func (out Int8PtrOutput) ToInt8PtrOutput() Int8PtrOutput {
+	return out
+}
+
+// ToInt8PtrOutput returns an output of a pointer to the int8 value, so that an Int8Output may be used as an Int8PtrInput.
+func (out Int8Output) ToInt8PtrOutput() Int8PtrOutput {
+	return Int8PtrOutput(out.Apply(func(v int8) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Int8PtrInput is an optional int8 value that may or may not be known yet.
+type Int8PtrInput interface {
+	ToInt8PtrOutput() Int8PtrOutput
+}
+
+// Int8Ptr returns an Int8PtrInput that is set to the given int8 value.
+func Int8Ptr(v int8) Int8PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Int8PtrOutput(out)
+}
+
+// Int16PtrOutput is an Output that is typed to return optional int16 values, which are nil if unset.
+type Int16PtrOutput Output
+
+// Apply applies a transformation to the optional int16 value when it is available.
+func (out Int16PtrOutput) Apply(applier func(*int16) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *int16) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional int16 value when it is available.
+func (out Int16PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *int16) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, int16Type).(*int16))
+	})
+}
+
+// Elem returns an output of the int16 value that the pointer refers to, or of 0 if it is nil.
+func (out Int16PtrOutput) Elem() Int16Output {
+	return Int16Output(out.Apply(func(v *int16) (interface{}, error) {
+		if v == nil {
+			return int16(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToInt16PtrOutput returns the output itself, so that an Int16PtrOutput may be used as an Int16PtrInput.
+func (out Int16PtrOutput) ToInt16PtrOutput() Int16PtrOutput {
+	return out
+}
+
+// ToInt16PtrOutput returns an output of a pointer to the int16 value, so that an Int16Output may be used as an Int16PtrInput.
+func (out Int16Output) ToInt16PtrOutput() Int16PtrOutput {
+	return Int16PtrOutput(out.Apply(func(v int16) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Int16PtrInput is an optional int16 value that may or may not be known yet.
+type Int16PtrInput interface {
+	ToInt16PtrOutput() Int16PtrOutput
+}
+
+// Int16Ptr returns an Int16PtrInput that is set to the given int16 value.
+func Int16Ptr(v int16) Int16PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Int16PtrOutput(out)
+}
+
+// Int32PtrOutput is an Output that is typed to return optional int32 values, which are nil if unset.
+type Int32PtrOutput Output
+
+// Apply applies a transformation to the optional int32 value when it is available.
+func (out Int32PtrOutput) Apply(applier func(*int32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *int32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional int32 value when it is available.
+func (out Int32PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *int32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, int32Type).(*int32))
+	})
+}
+
+// Elem returns an output of the int32 value that the pointer refers to, or of 0 if it is nil.
+func (out Int32PtrOutput) Elem() Int32Output {
+	return Int32Output(out.Apply(func(v *int32) (interface{}, error) {
+		if v == nil {
+			return int32(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToInt32PtrOutput returns the output itself, so that an Int32PtrOutput may be used as an Int32PtrInput.
+func (out Int32PtrOutput) ToInt32PtrOutput() Int32PtrOutput {
+	return out
+}
+
+// ToInt32PtrOutput returns an output of a pointer to the int32 value, so that an Int32Output may be used as an Int32PtrInput.
+func (out Int32Output) ToInt32PtrOutput() Int32PtrOutput {
+	return Int32PtrOutput(out.Apply(func(v int32) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Int32PtrInput is an optional int32 value that may or may not be known yet.
+type Int32PtrInput interface {
+	ToInt32PtrOutput() Int32PtrOutput
+}
+
+// Int32Ptr returns an Int32PtrInput that is set to the given int32 value.
+func Int32Ptr(v int32) Int32PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Int32PtrOutput(out)
+}
+
+// Int64PtrOutput is an Output that is typed to return optional int64 values, which are nil if unset.
+type Int64PtrOutput Output
+
+// Apply applies a transformation to the optional int64 value when it is available.
+func (out Int64PtrOutput) Apply(applier func(*int64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *int64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional int64 value when it is available.
+func (out Int64PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *int64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, int64Type).(*int64))
+	})
+}
+
+// Elem returns an output of the int64 value that the pointer refers to, or of 0 if it is nil.
+func (out Int64PtrOutput) Elem() Int64Output {
+	return Int64Output(out.Apply(func(v *int64) (interface{}, error) {
+		if v == nil {
+			return int64(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToInt64PtrOutput returns the output itself, so that an Int64PtrOutput may be used as an Int64PtrInput.
+func (out Int64PtrOutput) ToInt64PtrOutput() Int64PtrOutput {
+	return out
+}
+
+// ToInt64PtrOutput returns an output of a pointer to the int64 value, so that an Int64Output may be used as an Int64PtrInput.
+func (out Int64Output) ToInt64PtrOutput() Int64PtrOutput {
+	return Int64PtrOutput(out.Apply(func(v int64) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Int64PtrInput is an optional int64 value that may or may not be known yet.
+type Int64PtrInput interface {
+	ToInt64PtrOutput() Int64PtrOutput
+}
+
+// Int64Ptr returns an Int64PtrInput that is set to the given int64 value.
+func Int64Ptr(v int64) Int64PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Int64PtrOutput(out)
+}
+
+// StringPtrOutput is an Output that is typed to return optional string values, which are nil if unset.
+type StringPtrOutput Output
+
+// Apply applies a transformation to the optional string value when it is available.
+func (out StringPtrOutput) Apply(applier func(*string) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *string) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional string value when it is available.
+func (out StringPtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *string) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, stringType).(*string))
+	})
+}
+
+// Elem returns an output of the string value that the pointer refers to, or of "" if it is nil.
+func (out StringPtrOutput) Elem() StringOutput {
+	return StringOutput(out.Apply(func(v *string) (interface{}, error) {
+		if v == nil {
+			return "", nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToStringPtrOutput returns the output itself, so that a StringPtrOutput may be used as a StringPtrInput.
+func (out StringPtrOutput) ToStringPtrOutput() StringPtrOutput {
+	return out
+}
+
+// ToStringPtrOutput returns an output of a pointer to the string value, so that a StringOutput may be used as a StringPtrInput.
+func (out StringOutput) ToStringPtrOutput() StringPtrOutput {
+	return StringPtrOutput(out.Apply(func(v string) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// StringPtrInput is an optional string value that may or may not be known yet.
+type StringPtrInput interface {
+	ToStringPtrOutput() StringPtrOutput
+}
+
+// StringPtr returns a StringPtrInput that is set to the given string value.
+func StringPtr(v string) StringPtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return StringPtrOutput(out)
+}
+
+// UintPtrOutput is an Output that is typed to return optional uint values, which are nil if unset.
+type UintPtrOutput Output
+
+// Apply applies a transformation to the optional uint value when it is available.
+func (out UintPtrOutput) Apply(applier func(*uint) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *uint) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional uint value when it is available.
+func (out UintPtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *uint) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, uintType).(*uint))
+	})
+}
+
+// Elem returns an output of the uint value that the pointer refers to, or of 0 if it is nil.
+func (out UintPtrOutput) Elem() UintOutput {
+	return UintOutput(out.Apply(func(v *uint) (interface{}, error) {
+		if v == nil {
+			return uint(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToUintPtrOutput returns the output itself, so that an UintPtrOutput may be used as an UintPtrInput.
+func (out UintPtrOutput) ToUintPtrOutput() UintPtrOutput {
+	return out
+}
+
+// ToUintPtrOutput returns an output of a pointer to the uint value, so that an UintOutput may be used as an UintPtrInput.
+func (out UintOutput) ToUintPtrOutput() UintPtrOutput {
+	return UintPtrOutput(out.Apply(func(v uint) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// UintPtrInput is an optional uint value that may or may not be known yet.
+type UintPtrInput interface {
+	ToUintPtrOutput() UintPtrOutput
+}
+
+// UintPtr returns an UintPtrInput that is set to the given uint value.
+func UintPtr(v uint) UintPtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return UintPtrOutput(out)
+}
+
+// Uint8PtrOutput is an Output that is typed to return optional uint8 values, which are nil if unset.
+type Uint8PtrOutput Output
+
+// Apply applies a transformation to the optional uint8 value when it is available.
+func (out Uint8PtrOutput) Apply(applier func(*uint8) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *uint8) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional uint8 value when it is available.
+func (out Uint8PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *uint8) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, uint8Type).(*uint8))
+	})
+}
+
+// Elem returns an output of the uint8 value that the pointer refers to, or of 0 if it is nil.
+func (out Uint8PtrOutput) Elem() Uint8Output {
+	return Uint8Output(out.Apply(func(v *uint8) (interface{}, error) {
+		if v == nil {
+			return uint8(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToUint8PtrOutput returns the output itself, so that an Uint8PtrOutput may be used as an Uint8PtrInput.
+func (out Uint8PtrOutput) ToUint8PtrOutput() Uint8PtrOutput {
+	return out
+}
+
+// ToUint8PtrOutput returns an output of a pointer to the uint8 value, so that an Uint8Output may be used as an Uint8PtrInput.
+func (out Uint8Output) ToUint8PtrOutput() Uint8PtrOutput {
+	return Uint8PtrOutput(out.Apply(func(v uint8) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Uint8PtrInput is an optional uint8 value that may or may not be known yet.
+type Uint8PtrInput interface {
+	ToUint8PtrOutput() Uint8PtrOutput
+}
+
+// Uint8Ptr returns an Uint8PtrInput that is set to the given uint8 value.
+func Uint8Ptr(v uint8) Uint8PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Uint8PtrOutput(out)
+}
+
+// Uint16PtrOutput is an Output that is typed to return optional uint16 values, which are nil if unset.
+type Uint16PtrOutput Output
+
+// Apply applies a transformation to the optional uint16 value when it is available.
+func (out Uint16PtrOutput) Apply(applier func(*uint16) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *uint16) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional uint16 value when it is available.
+func (out Uint16PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *uint16) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, uint16Type).(*uint16))
+	})
+}
+
+// Elem returns an output of the uint16 value that the pointer refers to, or of 0 if it is nil.
+func (out Uint16PtrOutput) Elem() Uint16Output {
+	return Uint16Output(out.Apply(func(v *uint16) (interface{}, error) {
+		if v == nil {
+			return uint16(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToUint16PtrOutput returns the output itself, so that an Uint16PtrOutput may be used as an Uint16PtrInput.
+func (out Uint16PtrOutput) ToUint16PtrOutput() Uint16PtrOutput {
+	return out
+}
+
+// ToUint16PtrOutput returns an output of a pointer to the uint16 value, so that an Uint16Output may be used as an Uint16PtrInput.
+func (out Uint16Output) ToUint16PtrOutput() Uint16PtrOutput {
+	return Uint16PtrOutput(out.Apply(func(v uint16) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Uint16PtrInput is an optional uint16 value that may or may not be known yet.
+type Uint16PtrInput interface {
+	ToUint16PtrOutput() Uint16PtrOutput
+}
+
+// Uint16Ptr returns an Uint16PtrInput that is set to the given uint16 value.
+func Uint16Ptr(v uint16) Uint16PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Uint16PtrOutput(out)
+}
+
+// Uint32PtrOutput is an Output that is typed to return optional uint32 values, which are nil if unset.
+type Uint32PtrOutput Output
+
+// Apply applies a transformation to the optional uint32 value when it is available.
+func (out Uint32PtrOutput) Apply(applier func(*uint32) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *uint32) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional uint32 value when it is available.
+func (out Uint32PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *uint32) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, uint32Type).(*uint32))
+	})
+}
+
+// Elem returns an output of the uint32 value that the pointer refers to, or of 0 if it is nil.
+func (out Uint32PtrOutput) Elem() Uint32Output {
+	return Uint32Output(out.Apply(func(v *uint32) (interface{}, error) {
+		if v == nil {
+			return uint32(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToUint32PtrOutput returns the output itself, so that an Uint32PtrOutput may be used as an Uint32PtrInput.
+func (out Uint32PtrOutput) ToUint32PtrOutput() Uint32PtrOutput {
+	return out
+}
+
+// ToUint32PtrOutput returns an output of a pointer to the uint32 value, so that an Uint32Output may be used as an Uint32PtrInput.
+func (out Uint32Output) ToUint32PtrOutput() Uint32PtrOutput {
+	return Uint32PtrOutput(out.Apply(func(v uint32) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Uint32PtrInput is an optional uint32 value that may or may not be known yet.
+type Uint32PtrInput interface {
+	ToUint32PtrOutput() Uint32PtrOutput
+}
+
+// Uint32Ptr returns an Uint32PtrInput that is set to the given uint32 value.
+func Uint32Ptr(v uint32) Uint32PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Uint32PtrOutput(out)
+}
+
+// Uint64PtrOutput is an Output that is typed to return optional uint64 values, which are nil if unset.
+type Uint64PtrOutput Output
+
+// Apply applies a transformation to the optional uint64 value when it is available.
+func (out Uint64PtrOutput) Apply(applier func(*uint64) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v *uint64) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the optional uint64 value when it is available.
+func (out Uint64PtrOutput) ApplyWithContext(ctx context.Context, applier func(context.Context, *uint64) (interface{}, error)) Output {
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		return applier(ctx, convertPtr(v, uint64Type).(*uint64))
+	})
+}
+
+// Elem returns an output of the uint64 value that the pointer refers to, or of 0 if it is nil.
+func (out Uint64PtrOutput) Elem() Uint64Output {
+	return Uint64Output(out.Apply(func(v *uint64) (interface{}, error) {
+		if v == nil {
+			return uint64(0), nil
+		}
+		return *v, nil
+	}))
+}
+
+// ToUint64PtrOutput returns the output itself, so that an Uint64PtrOutput may be used as an Uint64PtrInput.
+func (out Uint64PtrOutput) ToUint64PtrOutput() Uint64PtrOutput {
+	return out
+}
+
+// ToUint64PtrOutput returns an output of a pointer to the uint64 value, so that an Uint64Output may be used as an Uint64PtrInput.
+func (out Uint64Output) ToUint64PtrOutput() Uint64PtrOutput {
+	return Uint64PtrOutput(out.Apply(func(v uint64) (interface{}, error) {
+		return &v, nil
+	}))
+}
+
+// Uint64PtrInput is an optional uint64 value that may or may not be known yet.
+type Uint64PtrInput interface {
+	ToUint64PtrOutput() Uint64PtrOutput
+}
+
+// Uint64Ptr returns an Uint64PtrInput that is set to the given uint64 value.
+func Uint64Ptr(v uint64) Uint64PtrInput {
+	out := newOutput()
+	out.s.resolve(&v, true)
+	return Uint64PtrOutput(out)
+}
+
+// convertPtr converts an output value to a pointer to a value of the given type.  Nil values, including nil pointers,
+// are converted to nil pointers.
+func convertPtr(v interface{}, to reflect.Type) interface{} {
+	ptrType := reflect.PtrTo(to)
+	rv := reflect.ValueOf(v)
+	if v != nil && rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			v = nil
+		} else {
+			v = rv.Elem().Interface()
+		}
+	}
+	if v == nil {
+		return reflect.Zero(ptrType).Interface()
+	}
+
+	ptr := reflect.New(to)
+	ptr.Elem().Set(reflect.ValueOf(convert(v, to)))
+	return ptr.Interface()
+}
+
 func convert(v interface{}, to reflect.Type) interface{} {
 	rv := reflect.ValueOf(v)
 	if !rv.Type().ConvertibleTo(to) {
@@ -16,10 +16,13 @@ package pulumi
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/go/pulumi/asset"
 )
 
 func assertApplied(t *testing.T, o Output) {
@@ -134,6 +137,188 @@ func TestStringOutputs(t *testing.T) {
 	}
 }
 
+func TestConcatAndJoin(t *testing.T) {
+	out, resolve, _ := NewOutput()
+	go func() {
+		resolve("bucket")
+	}()
+	{
+		v, known, err := Concat(String("arn:aws:s3:::"), StringOutput(out), String("/*")).s.await(context.Background())
+		assert.Nil(t, err)
+		assert.True(t, known)
+		assert.Equal(t, "arn:aws:s3:::bucket/*", v)
+	}
+	{
+		v, known, err := Join(".", String("www"), StringOutput(out), String("com")).s.await(context.Background())
+		assert.Nil(t, err)
+		assert.True(t, known)
+		assert.Equal(t, "www.bucket.com", v)
+	}
+	// If any part is unknown, so is the result.
+	{
+		unknown := newOutput()
+		go func() {
+			unknown.s.fulfill(nil, false, nil)
+		}()
+		_, known, err := Concat(String("a"), StringOutput(unknown)).s.await(context.Background())
+		assert.Nil(t, err)
+		assert.False(t, known)
+	}
+	// If any part is rejected, so is the result.
+	{
+		failed, _, reject := NewOutput()
+		go func() {
+			reject(errors.New("boom"))
+		}()
+		_, _, err := Join(",", StringOutput(failed), String("b")).s.await(context.Background())
+		assert.NotNil(t, err)
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	host, resolveHost, _ := NewOutput()
+	port, resolvePort, _ := NewOutput()
+	go func() {
+		resolveHost("db.example.com")
+		resolvePort(5432)
+	}()
+	{
+		v, known, err := Sprintf("postgres://%s:%d/%s", StringOutput(host), IntOutput(port), "app").
+			s.await(context.Background())
+		assert.Nil(t, err)
+		assert.True(t, known)
+		assert.Equal(t, "postgres://db.example.com:5432/app", v)
+	}
+	// If any argument is unknown, so is the result.
+	{
+		unknown := newOutput()
+		go func() {
+			unknown.s.fulfill(nil, false, nil)
+		}()
+		_, known, err := Sprintf("%s-%s", StringOutput(host), StringOutput(unknown)).s.await(context.Background())
+		assert.Nil(t, err)
+		assert.False(t, known)
+	}
+	// If any argument is rejected, so is the result.
+	{
+		failed, _, reject := NewOutput()
+		go func() {
+			reject(errors.New("boom"))
+		}()
+		_, _, err := Sprintf("%v", failed).s.await(context.Background())
+		assert.NotNil(t, err)
+	}
+}
+
+func TestPointerOutputs(t *testing.T) {
+	// A set pointer input resolves to a pointer to its value.
+	{
+		v, known, err := IntPtr(0).ToIntPtrOutput().Elem().s.await(context.Background())
+		assert.Nil(t, err)
+		assert.True(t, known)
+		assert.Equal(t, 0, v)
+	}
+	// An unset value resolves to a nil pointer, whose element is the zero value.
+	{
+		out, resolve, _ := NewOutput()
+		go func() {
+			resolve(nil)
+		}()
+		p := StringPtrOutput(out)
+		assertApplied(t, p.Apply(func(v *string) (interface{}, error) {
+			assert.Nil(t, v)
+			return nil, nil
+		}))
+		v, known, err := p.Elem().s.await(context.Background())
+		assert.Nil(t, err)
+		assert.True(t, known)
+		assert.Equal(t, "", v)
+	}
+	// Plain values are boxed, and non-pointer outputs may be used as pointer inputs.
+	{
+		out, resolve, _ := NewOutput()
+		go func() {
+			resolve(true)
+		}()
+		var input BoolPtrInput = BoolOutput(out).ToBoolPtrOutput()
+		assertApplied(t, BoolPtrOutput(out).Apply(func(v *bool) (interface{}, error) {
+			assert.Equal(t, true, *v)
+			return nil, nil
+		}))
+		assertApplied(t, input.ToBoolPtrOutput().Apply(func(v *bool) (interface{}, error) {
+			assert.Equal(t, true, *v)
+			return nil, nil
+		}))
+	}
+	{
+		var input StringPtrInput = String("x")
+		v, _, err := input.ToStringPtrOutput().s.await(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "x", *v.(*string))
+		f, _, err := Float64Ptr(1.5).ToFloat64PtrOutput().Elem().s.await(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 1.5, f)
+	}
+	// Every numeric type has pointer outputs, whose nil elements are zero values of the right type.
+	{
+		out, resolve, _ := NewOutput()
+		go func() {
+			resolve(nil)
+		}()
+		assertApplied(t, Uint16PtrOutput(out).Elem().Apply(func(v uint16) (interface{}, error) {
+			assert.Equal(t, uint16(0), v)
+			return nil, nil
+		}))
+		var input Int64PtrInput = Int64(7)
+		assertApplied(t, input.ToInt64PtrOutput().Apply(func(v *int64) (interface{}, error) {
+			assert.Equal(t, int64(7), *v)
+			return nil, nil
+		}))
+		assertApplied(t, Float32(2.5).ToFloat32Output().ToFloat32PtrOutput().Apply(
+			func(v *float32) (interface{}, error) {
+				assert.Equal(t, float32(2.5), *v)
+				return nil, nil
+			}))
+	}
+}
+
+func TestAssetOutputs(t *testing.T) {
+	text, resolve, _ := NewOutput()
+	go func() {
+		resolve("hello")
+	}()
+
+	a := NewStringAssetOutput(StringOutput(text))
+	archive := NewAssetArchiveOutput(map[string]interface{}{
+		"index.html": a,
+		"static":     asset.NewFileArchive("./static"),
+	})
+	v, known, err := archive.s.await(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, known)
+	contents := v.(asset.Archive).Assets()
+	assert.Equal(t, "hello", contents["index.html"].(asset.Asset).Text())
+	assert.Equal(t, "./static", contents["static"].(asset.Archive).Path())
+
+	// If any of the contents are unknown, so is the archive.
+	{
+		unknown := newOutput()
+		go func() {
+			unknown.s.fulfill(nil, false, nil)
+		}()
+		_, known, err := NewAssetArchiveOutput(map[string]interface{}{
+			"a": NewStringAssetOutput(StringOutput(unknown)),
+		}).s.await(context.Background())
+		assert.Nil(t, err)
+		assert.False(t, known)
+	}
+	// Contents that are not assets or archives are rejected.
+	{
+		_, _, err := NewAssetArchiveOutput(map[string]interface{}{"a": StringOutput(text)}).s.await(context.Background())
+		assert.EqualError(t, err, "expected archive contents to be assets or archives; a is string")
+	}
+}
+
 func TestResolveOutputToOutput(t *testing.T) {
 	// Test that resolving an output to an output yields the value, not the output.
 	{
@@ -268,3 +453,319 @@ func TestOutputApply(t *testing.T) {
 		assert.Nil(t, v)
 	}
 }
+
+func TestApplyErrorLocation(t *testing.T) {
+	out, resolve, _ := NewOutput()
+	go func() { resolve(42) }()
+	boom := errors.New("boom")
+	app := IntOutput(out).Apply(func(v int) (interface{}, error) {
+		return nil, boom
+	})
+	_, _, err := app.s.await(context.Background())
+	assert.Equal(t, boom, errors.Cause(err))
+	assert.Regexp(t, `^apply at properties_test\.go:\d+: boom$`, err.Error())
+}
+
+func TestApplyWithContextCanceled(t *testing.T) {
+	out, resolve, _ := NewOutput()
+
+	// An apply whose context is canceled is rejected without waiting for its input, and without running the applier.
+	ctx, cancel := context.WithCancel(context.Background())
+	called := false
+	app := StringOutput(out).ApplyWithContext(ctx, func(_ context.Context, v string) (interface{}, error) {
+		called = true
+		return v, nil
+	})
+	cancel()
+	_, _, err := app.s.await(context.Background())
+	assert.Equal(t, context.Canceled, err)
+	assert.False(t, called)
+
+	// The input is unaffected, and may still be applied to once it is resolved.
+	resolve("hello")
+	v, known, err := StringOutput(out).ApplyWithContext(context.Background(),
+		func(_ context.Context, v string) (interface{}, error) {
+			return v + "!", nil
+		}).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "hello!", v)
+}
+
+func TestApplyWithUnknowns(t *testing.T) {
+	// Unknown values are passed to the applier, whose result is known.
+	unknown := newOutput()
+	unknown.s.resolve(nil, false)
+	v, known, err := unknown.ApplyWithUnknowns(func(v interface{}, known bool) (interface{}, error) {
+		if !known {
+			return "<computed>", nil
+		}
+		return v, nil
+	}).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "<computed>", v)
+
+	v, known, err = unknown.IsKnown().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, false, v)
+
+	// Known values are passed as they are, including those of nested outputs.
+	v, _, err = ToOutput([]interface{}{String("a")}).IsKnown().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	// Rejections are not passed to the applier.
+	failed, _, reject := NewOutput()
+	reject(errors.New("boom"))
+	_, _, err = failed.ApplyWithUnknowns(func(v interface{}, known bool) (interface{}, error) {
+		panic("should not be called")
+	}).s.await(context.Background())
+	assert.EqualError(t, err, "boom")
+}
+
+func TestLiteralInputs(t *testing.T) {
+	v, known, err := Int(42).ToIntOutput().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, 42, v)
+
+	u, known, err := ID("id").ToIDOutput().await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, ID("id"), u)
+
+	p, known, err := Bool(true).ToBoolPtrOutput().s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, true, *p.(*bool))
+
+	// Outputs convert to themselves.
+	out := Float32(1.5).ToFloat32Output()
+	assert.Equal(t, out, out.ToFloat32Output())
+}
+
+func TestSecrets(t *testing.T) {
+	// A secret stays secret through applies and the helpers that combine outputs.
+	secret := ToSecret("hunter2")
+	for _, out := range []Output{
+		secret,
+		StringOutput(secret).Apply(func(v string) (interface{}, error) { return len(v), nil }),
+		Output(Concat(String("pass:"), StringOutput(secret))),
+		Output(Sprintf("%s@%s", "admin", secret)),
+	} {
+		_, known, isSecret, err := out.s.awaitValue(context.Background())
+		assert.Nil(t, err)
+		assert.True(t, known)
+		assert.True(t, isSecret)
+	}
+
+	// Outputs derived only from plain values are not secret.
+	_, _, isSecret, err := Sprintf("%s@%s", "admin", String("host").ToStringOutput()).s.awaitValue(context.Background())
+	assert.Nil(t, err)
+	assert.False(t, isSecret)
+
+	// Outputs made secret keep the dependencies of their inputs.
+	res := makeResourceState(true, nil)
+	assert.Equal(t, []Resource{res}, ToSecret(res.URN()).s.dependencies())
+}
+
+func TestAll(t *testing.T) {
+	port, resolvePort, _ := NewOutput()
+	host, resolveHost, _ := NewOutput()
+	go func() {
+		resolvePort(8080.0)
+		resolveHost("localhost")
+	}()
+
+	// The values of all inputs, whether outputs or not, are collected in order.
+	all := All(IntOutput(port), StringOutput(host), true, nil)
+	v, known, err := all.s.await(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.Equal(t, []interface{}{8080.0, "localhost", true, nil}, v)
+
+	// ApplyAll passes each value as a parameter of its own type.
+	v, known, err = all.ApplyAll(func(p int, h string, tls bool, opt *string) (interface{}, error) {
+		assert.Nil(t, opt)
+		if tls {
+			return fmt.Sprintf("https://%s:%d", h, p), nil
+		}
+		return nil, nil
+	}).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "https://localhost:8080", v)
+
+	// ApplyAllWithContext passes the context first.
+	v, _, err = All(String("a"), Int(1)).ApplyAllWithContext(context.Background(),
+		func(ctx context.Context, s string, i int) (interface{}, error) {
+			assert.NotNil(t, ctx)
+			return fmt.Sprintf("%s%d", s, i), nil
+		}).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "a1", v)
+
+	// Appliers that do not match the number of values, or that return an error, reject the result.
+	_, _, err = All(1, 2).ApplyAll(func(a int) (interface{}, error) {
+		return a, nil
+	}).s.await(context.Background())
+	assert.NotNil(t, err)
+	_, _, err = All(1).ApplyAll(func(a int) (interface{}, error) {
+		return nil, errors.New("boom")
+	}).s.await(context.Background())
+	assert.NotNil(t, err)
+
+	// Appliers of the wrong shape are rejected immediately.
+	assert.Panics(t, func() { All(1).ApplyAll(func(a int) int { return a }) })
+	assert.Panics(t, func() {
+		All(1).ApplyAllWithContext(context.Background(), func(a int) (interface{}, error) { return a, nil })
+	})
+
+	// If any input is unknown, so is the result.
+	unknown := newOutput()
+	go func() {
+		unknown.s.fulfill(nil, false, nil)
+	}()
+	_, known, err = All(1, unknown).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.False(t, known)
+}
+
+func TestIndexAndMapIndex(t *testing.T) {
+	arr, resolveArr, _ := NewOutput()
+	m, resolveMap, _ := NewOutput()
+	go func() {
+		resolveArr([]interface{}{"a", "b"})
+		resolveMap(map[string]interface{}{"zone": "us-west-2a"})
+	}()
+
+	// Elements may be looked up by literals or by outputs, and typed by converting the result.
+	assertApplied(t, StringOutput(ArrayOutput(arr).Index(Int(1))).Apply(func(v string) (interface{}, error) {
+		assert.Equal(t, "b", v)
+		return nil, nil
+	}))
+	key := String("zone").ToStringOutput()
+	v, known, err := MapOutput(m).MapIndex(key).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "us-west-2a", v)
+
+	// Missing elements are nil.
+	v, _, err = ArrayOutput(arr).Index(Int(2)).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+	v, _, err = MapOutput(m).MapIndex(String("region")).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+}
+
+func TestFlatten(t *testing.T) {
+	outer := makeResourceState(true, nil)
+	outer.resolve(false, nil, nil, "outer", "", nil)
+	inner := makeResourceState(true, map[string]interface{}{"name": nil})
+	s, _, _, _ := marshalInputs(context.Background(), map[string]interface{}{"name": "bucket"}, true, true)
+	inner.resolve(false, nil, nil, "inner", "", s)
+
+	// An applier that returns an output, such as one from a helper library, yields an output of an output.
+	nested := outer.URN().Apply(func(URN) (interface{}, error) {
+		return ToSecret(StringOutput(inner.State["name"])), nil
+	})
+	assert.Equal(t, []Resource{outer}, nested.s.dependencies())
+
+	// Flattening it yields the innermost value, along with the dependencies and secretness of the nested outputs.
+	flat := StringOutput(Flatten(nested))
+	v, known, secret, err := flat.s.awaitValue(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, "bucket", v)
+	assert.Contains(t, flat.s.dependencies(), outer)
+	assert.Contains(t, flat.s.dependencies(), inner)
+
+	// Errors in nested outputs are propagated.
+	failed, _, reject := NewOutput()
+	go func() {
+		reject(errors.New("boom"))
+	}()
+	_, _, err = Flatten(outer.URN().Apply(func(URN) (interface{}, error) {
+		return failed, nil
+	})).s.await(context.Background())
+	assert.NotNil(t, err)
+}
+
+func TestDependencies(t *testing.T) {
+	a := makeResourceState(true, nil)
+	b := makeResourceState(true, nil)
+
+	// Outputs depend on the resources they were derived from, and plain outputs depend on none.
+	assert.Equal(t, []Resource{a}, Output(a.URN()).Dependencies())
+	assert.Equal(t, []Resource{a, b}, Output(Sprintf("%s/%s", a.URN(), b.URN())).Dependencies())
+	assert.Empty(t, Output(String("x").ToStringOutput()).Dependencies())
+
+	// The returned slice is a copy.
+	deps := Output(a.URN()).Dependencies()
+	deps[0] = b
+	assert.Equal(t, []Resource{a}, Output(a.URN()).Dependencies())
+}
+
+func TestApplyN(t *testing.T) {
+	port, resolve, _ := NewOutput()
+	go func() {
+		resolve(5432.0)
+	}()
+
+	// The values of outputs and plain inputs are passed as typed parameters.
+	url := StringOutput(ApplyN(func(host string, port int, tls bool) (interface{}, error) {
+		return fmt.Sprintf("%s:%d?tls=%v", host, port, tls), nil
+	}, String("db").ToStringOutput(), IntOutput(port), true))
+	v, known, err := url.s.await(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "db:5432?tls=true", v)
+
+	v, _, err = ApplyNWithContext(context.Background(), func(ctx context.Context, port int) (interface{}, error) {
+		return port + 1, nil
+	}, IntOutput(port)).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 5433, v)
+
+	// Appliers must take one parameter per input.
+	assert.Panics(t, func() {
+		ApplyN(func(a, b int) (interface{}, error) { return a + b, nil }, 1)
+	})
+}
+
+func TestToOutput(t *testing.T) {
+	res := makeResourceState(true, nil)
+	res.resolve(false, nil, nil, "urn", "id", nil)
+	name, resolve, _ := NewOutput()
+	go func() {
+		resolve("web")
+	}()
+
+	// Outputs nested within maps and slices are lifted, along with their dependencies.
+	args := map[string]interface{}{
+		"name":  StringOutput(name),
+		"ports": []interface{}{80, Int(443).ToIntOutput()},
+		"tags":  map[string]IDInput{"owner": res.ID()},
+		"data":  []byte("raw"),
+	}
+	out := ToOutput(args)
+	assert.Equal(t, []Resource{res}, out.s.dependencies())
+	v, known, err := out.s.await(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.Equal(t, map[string]interface{}{
+		"name":  "web",
+		"ports": []interface{}{80, 443},
+		"tags":  map[string]interface{}{"owner": ID("id")},
+		"data":  []byte("raw"),
+	}, v)
+
+	// Plain values are lifted as they are.
+	v, _, err = ToOutput(42).s.await(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 42, v)
+}
@@ -0,0 +1,61 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pulumitest provides helpers for unit testing code that produces or consumes outputs, such as component
+// resources and functions that transform outputs, without running a Pulumi program.
+package pulumitest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/go/pulumi/internal/outputs"
+)
+
+// Await waits for the given output, which may be an Output or any of the typed outputs such as StringOutput, to be
+// fulfilled.  It returns the output's value, whether the value is known, and the error that the output was rejected
+// with, if any.  If ctx is done before the output is fulfilled, its error is returned instead.
+func Await(ctx context.Context, out interface{}) (interface{}, bool, error) {
+	v, known, _, err := outputs.Await(ctx, out)
+	return v, known, err
+}
+
+// IsSecret waits for the given output to be fulfilled, as Await does, and returns whether its value is secret.
+func IsSecret(ctx context.Context, out interface{}) (bool, error) {
+	_, _, secret, err := outputs.Await(ctx, out)
+	return secret, err
+}
+
+// MustAwait waits for the given output to be fulfilled, and returns its value.  It fails the test immediately if the
+// output is rejected, or if its value is unknown.
+func MustAwait(t testing.TB, out interface{}) interface{} {
+	t.Helper()
+
+	v, known, err := Await(context.Background(), out)
+	if err != nil {
+		t.Fatalf("output was rejected: %v", err)
+	}
+	if !known {
+		t.Fatalf("output is unknown")
+	}
+	return v
+}
+
+// NewResolvedOutput returns an output that is already resolved to the given value, which is known and not secret.
+func NewResolvedOutput(v interface{}) pulumi.Output {
+	out, resolve, _ := pulumi.NewOutput()
+	resolve(v)
+	return out
+}
@@ -0,0 +1,58 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumitest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+func TestAwait(t *testing.T) {
+	// Typed outputs and the outputs derived from them may be awaited.
+	greeting := pulumi.StringOutput(NewResolvedOutput("hello")).Apply(func(v string) (interface{}, error) {
+		return v + "!", nil
+	})
+	assert.Equal(t, "hello!", MustAwait(t, greeting))
+	assert.Equal(t, 42, MustAwait(t, pulumi.Int(42).ToIntOutput()))
+
+	v, known, err := Await(context.Background(), pulumi.Sprintf("%v-%v", greeting, 1))
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "hello!-1", v)
+
+	// Rejections are returned as errors.
+	failed, _, reject := pulumi.NewOutput()
+	reject(errors.New("boom"))
+	_, _, err = Await(context.Background(), failed)
+	assert.EqualError(t, err, "boom")
+
+	// Secrets may be recognized.
+	secret, err := IsSecret(context.Background(), pulumi.ToSecret("hunter2"))
+	assert.NoError(t, err)
+	assert.True(t, secret)
+
+	// Outputs that are never fulfilled stop being awaited once the context is done.
+	pending, _, _ := pulumi.NewOutput()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = Await(ctx, pending)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
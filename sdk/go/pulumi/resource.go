@@ -14,6 +14,13 @@
 
 package pulumi
 
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
 type (
 	// ID is a unique identifier assigned by a resource provider to a resource.
 	ID string
@@ -50,16 +57,104 @@ type ProviderResource interface {
 	CustomResource
 }
 
+// ResourceArrayOutput is an Output that is typed to return lists of resources, such as those that are only created
+// within an Apply.
+type ResourceArrayOutput Output
+
+// Apply applies a transformation to the resources when they are available.
+func (out ResourceArrayOutput) Apply(applier func([]Resource) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v []Resource) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the resources when they are available.
+func (out ResourceArrayOutput) ApplyWithContext(ctx context.Context,
+	applier func(context.Context, []Resource) (interface{}, error)) Output {
+
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		resources, err := toResources(v)
+		if err != nil {
+			return nil, err
+		}
+		return applier(ctx, resources)
+	})
+}
+
+// ToResourceArrayOutput returns the output itself, so that a ResourceArrayOutput may be used as a ResourceArrayInput.
+func (out ResourceArrayOutput) ToResourceArrayOutput() ResourceArrayOutput {
+	return out
+}
+
+// ResourceArrayInput is a list of resources that may or may not be known yet: either a ResourceArray or a
+// ResourceArrayOutput.
+type ResourceArrayInput interface {
+	ToResourceArrayOutput() ResourceArrayOutput
+}
+
+// ResourceArray is a literal list of resources that may be used as a ResourceArrayInput.
+type ResourceArray []Resource
+
+// ToResourceArrayOutput returns an output that is resolved to the list of resources.
+func (a ResourceArray) ToResourceArrayOutput() ResourceArrayOutput {
+	out := newOutput()
+	out.s.resolve([]Resource(a), true)
+	return ResourceArrayOutput(out)
+}
+
+// toResources converts the value of a ResourceArrayOutput to a list of resources.  Outputs that are resolved by
+// applies may hold a []Resource, a []interface{} of resources, a single Resource, or nil for no resources at all.
+func toResources(v interface{}) ([]Resource, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case []Resource:
+		return v, nil
+	case ResourceArray:
+		return v, nil
+	case Resource:
+		return []Resource{v}, nil
+	case []interface{}:
+		resources := make([]Resource, len(v))
+		for i, e := range v {
+			r, ok := e.(Resource)
+			if !ok {
+				return nil, errors.Errorf("expected a list of resources, got a %T at index %d", e, i)
+			}
+			resources[i] = r
+		}
+		return resources, nil
+	default:
+		return nil, errors.Errorf("expected a list of resources, got %T", v)
+	}
+}
+
 // ResourceOpt contains optional settings that control a resource's behavior.
+//
+// Resources accept any number of ResourceOpts, which are merged: the first to set each setting wins, except for those
+// that are lists or maps, such as DependsOn and Transformations, which are combined.  So, a component may pass the
+// options that it was given to its children followed by its own defaults, which only apply where the caller's options
+// leave settings unset.  The functions named after each setting, such as Parent and Protect, return ResourceOpts that
+// set only that setting, so that options may be composed as:
+//
+//	ctx.RegisterResource(t, name, true, props, pulumi.Parent(component), pulumi.Protect(true))
 type ResourceOpt struct {
 	// Parent is an optional parent resource to which this resource belongs.
 	Parent Resource
 	// DependsOn is an optional array of explicit dependencies on other resources.
 	DependsOn []Resource
+	// DependsOnInputs is an optional list of further explicit dependencies that may not be known until other outputs
+	// are resolved, such as resources that are only created conditionally within an Apply.  The resource also depends
+	// on the resources that those outputs depend on.  Any that are unknown, as during previews, are left out.
+	DependsOnInputs []ResourceArrayInput
 	// Protect, when set to true, ensures that this resource cannot be deleted (without first setting it to false).
 	Protect bool
 	// Provider is an optional provider resource to use for this resource's CRUD operations.
 	Provider ProviderResource
+	// Providers is an optional map from package names, such as "aws", to the provider resources to use for resources
+	// of those packages.  It applies to this resource, unless Provider is set, and if this resource is a component, to
+	// its children.
+	Providers map[string]ProviderResource
 	// DeleteBeforeReplace, when set to true, ensures that this resource is deleted prior to replacement.
 	DeleteBeforeReplace bool
 	// Import, when provided with a resource ID, indicates that this resource's provider should import its state from
@@ -67,10 +162,113 @@ type ResourceOpt struct {
 	// current state. Once a resource has been imported, the import property must be removed from the resource's
 	// options.
 	Import ID
-	// CustomTimeouts is an optional configuration block used for CRUD operations
+	// CustomTimeouts optionally overrides how long the resource's provider may take to create, update, or delete it,
+	// for long-running resources such as databases and clusters.
 	CustomTimeouts *CustomTimeouts
 	// Ignore changes to any of the specified properties.
 	IgnoreChanges []string
+	// Transformations is an optional list of transformations to apply to this resource's properties and options
+	// before it is registered.  If the resource is a component, they are also applied to its children.
+	Transformations []ResourceTransformation
+	// AutoNaming optionally customizes the physical name of this resource, if it is a custom resource that is not
+	// given one, instead of leaving it to the resource's provider.  If the resource is a component, it applies to its
+	// children.
+	AutoNaming *AutoNaming
+	// Hooks is an optional list of lifecycle hooks that the engine runs around its operations on this resource.
+	Hooks []*ResourceHooks
+}
+
+// Parent returns a ResourceOpt that sets the parent resource to which a resource belongs.
+func Parent(r Resource) ResourceOpt {
+	return ResourceOpt{Parent: r}
+}
+
+// DependsOn returns a ResourceOpt that adds explicit dependencies on other resources.
+func DependsOn(resources ...Resource) ResourceOpt {
+	return ResourceOpt{DependsOn: resources}
+}
+
+// DependsOnInputs returns a ResourceOpt that adds explicit dependencies on resources that may not be known until other
+// outputs are resolved.
+func DependsOnInputs(inputs ...ResourceArrayInput) ResourceOpt {
+	return ResourceOpt{DependsOnInputs: inputs}
+}
+
+// Protect returns a ResourceOpt that sets whether a resource is protected from being deleted.
+func Protect(protect bool) ResourceOpt {
+	return ResourceOpt{Protect: protect}
+}
+
+// Provider returns a ResourceOpt that sets the provider resource to use for a resource's CRUD operations.
+func Provider(provider ProviderResource) ResourceOpt {
+	return ResourceOpt{Provider: provider}
+}
+
+// Providers returns a ResourceOpt that sets the provider resources to use for resources of the given packages.
+func Providers(providers map[string]ProviderResource) ResourceOpt {
+	return ResourceOpt{Providers: providers}
+}
+
+// DeleteBeforeReplace returns a ResourceOpt that sets whether a resource is deleted before it is replaced.
+func DeleteBeforeReplace(deleteBeforeReplace bool) ResourceOpt {
+	return ResourceOpt{DeleteBeforeReplace: deleteBeforeReplace}
+}
+
+// Import returns a ResourceOpt that imports a resource's state from the cloud resource with the given ID.
+func Import(id ID) ResourceOpt {
+	return ResourceOpt{Import: id}
+}
+
+// Timeouts returns a ResourceOpt that overrides how long a resource's provider may take to create, update, or delete
+// it.
+func Timeouts(timeouts *CustomTimeouts) ResourceOpt {
+	return ResourceOpt{CustomTimeouts: timeouts}
+}
+
+// IgnoreChanges returns a ResourceOpt that ignores changes to the given properties of a resource.
+func IgnoreChanges(properties ...string) ResourceOpt {
+	return ResourceOpt{IgnoreChanges: properties}
+}
+
+// Transformations returns a ResourceOpt that adds transformations to apply to a resource, and if it is a component,
+// to its children.
+func Transformations(transformations ...ResourceTransformation) ResourceOpt {
+	return ResourceOpt{Transformations: transformations}
+}
+
+// AutoName returns a ResourceOpt that customizes the physical name of a resource that is not given one.
+func AutoName(naming *AutoNaming) ResourceOpt {
+	return ResourceOpt{AutoNaming: naming}
+}
+
+// Hooks returns a ResourceOpt that adds lifecycle hooks that the engine runs around its operations on a resource.
+func Hooks(hooks ...*ResourceHooks) ResourceOpt {
+	return ResourceOpt{Hooks: hooks}
+}
+
+// ResourceTransformation rewrites the properties and options of a resource before it is registered, for example to
+// add tags or enforce naming conventions.  It returns the new properties and options, or nil to leave them unchanged.
+type ResourceTransformation func(args *ResourceTransformationArgs) *ResourceTransformationResult
+
+// ResourceTransformationArgs describes the resource that a ResourceTransformation is applied to.
+type ResourceTransformationArgs struct {
+	// Type is the resource's type token.
+	Type string
+	// Name is the resource's name.
+	Name string
+	// Props are the resource's input properties.
+	Props map[string]interface{}
+	// Opts are the resource's options.
+	Opts []ResourceOpt
+}
+
+// ResourceTransformationResult is the result of a ResourceTransformation, which replaces the resource's properties
+// and options.
+type ResourceTransformationResult struct {
+	// Props are the resource's new input properties.
+	Props map[string]interface{}
+	// Opts are the resource's new options.
+	Opts []ResourceOpt
 }
 
 // InvokeOpt contains optional settings that control an invoke's behavior.
@@ -79,8 +277,22 @@ type InvokeOpt struct {
 	Provider ProviderResource
 }
 
+// CustomTimeouts are the timeouts of a resource's CRUD operations.  Each is a duration string, such as "1h30m", as
+// parsed by time.ParseDuration, or empty to use the provider's default timeout for the operation.
 type CustomTimeouts struct {
 	Create string
 	Update string
 	Delete string
 }
+
+// NewCustomTimeouts returns the custom timeouts for the given durations.  A zero duration uses the provider's default
+// timeout for that operation.
+func NewCustomTimeouts(create, update, delete time.Duration) *CustomTimeouts {
+	format := func(d time.Duration) string {
+		if d == 0 {
+			return ""
+		}
+		return d.String()
+	}
+	return &CustomTimeouts{Create: format(create), Update: format(update), Delete: format(delete)}
+}
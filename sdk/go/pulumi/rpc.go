@@ -16,6 +16,8 @@ package pulumi
 
 import (
 	"reflect"
+	"sort"
+	"time"
 
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/pkg/errors"
@@ -27,25 +29,25 @@ import (
 	"github.com/pulumi/pulumi/sdk/go/pulumi/asset"
 )
 
-// marshalInputs turns resource property inputs into a gRPC struct suitable for marshaling.
-func marshalInputs(props map[string]interface{},
-	keepUnknowns bool) (*structpb.Struct, map[string][]URN, []URN, error) {
+// marshalInputs turns resource property inputs into a gRPC struct suitable for marshaling.  Unless keepSecrets is
+// true, the values of secret outputs are marshaled as plain values, for resource monitors that do not support secrets.
+// If ctx is done before all of the outputs among the inputs are resolved, an error names the property that was being
+// awaited.
+func marshalInputs(ctx context.Context, props map[string]interface{},
+	keepUnknowns, keepSecrets bool) (*structpb.Struct, map[string][]URN, []URN, error) {
 
-	var depURNs []URN
-	pmap, pdeps := make(map[string]interface{}), make(map[string][]URN)
-	for key := range props {
-		// Get the underlying value, possibly waiting for an output to arrive.
-		v, resourceDeps, err := marshalInput(props[key])
-		if err != nil {
-			return nil, nil, nil, errors.Wrapf(err, "awaiting input property %s", key)
-		}
-
-		pmap[key] = v
+	pmap, resourceDeps, err := marshalInputMap(ctx, props)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-		// Record all dependencies accumulated from reading this property.
-		deps := make([]URN, 0, len(resourceDeps))
-		for _, dep := range resourceDeps {
-			depURN, _, err := dep.URN().await(context.TODO())
+	// Record all dependencies accumulated from reading the properties, in the order that they were visited.
+	var depURNs []URN
+	pdeps := make(map[string][]URN)
+	for _, key := range sortedKeys(props) {
+		deps := make([]URN, 0, len(resourceDeps[key]))
+		for _, dep := range resourceDeps[key] {
+			depURN, _, err := dep.URN().await(ctx)
 			if err != nil {
 				return nil, nil, nil, err
 			}
@@ -59,11 +61,43 @@ func marshalInputs(props map[string]interface{},
 	// Marshal all properties for the RPC call.
 	m, err := plugin.MarshalProperties(
 		resource.NewPropertyMapFromMap(pmap),
-		plugin.MarshalOptions{KeepUnknowns: keepUnknowns},
+		plugin.MarshalOptions{KeepUnknowns: keepUnknowns, KeepSecrets: keepSecrets},
 	)
 	return m, pdeps, depURNs, err
 }
 
+// marshalInputMap awaits and marshals each of the given property inputs, returning their raw serializable values along
+// with the resources that each depends on.
+func marshalInputMap(ctx context.Context,
+	props map[string]interface{}) (map[string]interface{}, map[string][]Resource, error) {
+
+	// Visit the properties in a stable order, so that any errors and dependencies are reported deterministically.
+	pmap, pdeps := make(map[string]interface{}), make(map[string][]Resource)
+	for _, key := range sortedKeys(props) {
+		// Get the underlying value, possibly waiting for an output to arrive.
+		v, resourceDeps, err := marshalInput(ctx, props[key])
+		if err == context.DeadlineExceeded {
+			return nil, nil, errors.Errorf(
+				"timed out awaiting input property %s; an output that it depends on may never be resolved", key)
+		} else if err != nil {
+			return nil, nil, wrapErrorf(err, "awaiting input property %s", key)
+		}
+
+		pmap[key] = v
+		pdeps[key] = resourceDeps
+	}
+	return pmap, pdeps, nil
+}
+
+func sortedKeys(props map[string]interface{}) []string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // `gosec` thinks these are credentials, but they are not.
 // nolint: gosec
 const (
@@ -75,7 +109,7 @@ const (
 )
 
 // marshalInput marshals an input value, returning its raw serializable value along with any dependencies.
-func marshalInput(v interface{}) (interface{}, []Resource, error) {
+func marshalInput(ctx context.Context, v interface{}) (interface{}, []Resource, error) {
 	for {
 		// If v is nil, just return that.
 		if v == nil {
@@ -84,7 +118,14 @@ func marshalInput(v interface{}) (interface{}, []Resource, error) {
 
 		// If this is an Output, recurse.
 		if out, ok := isOutput(v); ok {
-			return marshalInputOutput(out)
+			return marshalInputOutput(ctx, out)
+		}
+
+		// Enums must hold one of their valid values.
+		if e, ok := v.(Enum); ok {
+			if err := validateEnum(e); err != nil {
+				return nil, nil, err
+			}
 		}
 
 		// Next, look for some well known types.
@@ -101,7 +142,7 @@ func marshalInput(v interface{}) (interface{}, []Resource, error) {
 			if as := v.Assets(); as != nil {
 				assets = make(map[string]interface{})
 				for k, a := range as {
-					aa, _, err := marshalInput(a)
+					aa, _, err := marshalInput(ctx, a)
 					if err != nil {
 						return nil, nil, err
 					}
@@ -115,9 +156,17 @@ func marshalInput(v interface{}) (interface{}, []Resource, error) {
 				"path":                v.Path(),
 				"uri":                 v.URI(),
 			}, nil, nil
+		case time.Duration:
+			return v.String(), nil, nil
+		case Duration:
+			return time.Duration(v).String(), nil, nil
+		case time.Time:
+			return v.Format(time.RFC3339Nano), nil, nil
+		case Time:
+			return time.Time(v).Format(time.RFC3339Nano), nil, nil
 		case CustomResource:
 			// Resources aren't serializable; instead, serialize a reference to ID, tracking as a dependency.
-			e, d, err := marshalInput(v.ID())
+			e, d, err := marshalInput(ctx, v.ID())
 			if err != nil {
 				return nil, nil, err
 			}
@@ -164,7 +213,7 @@ func marshalInput(v interface{}) (interface{}, []Resource, error) {
 			var deps []Resource
 			for i := 0; i < rv.Len(); i++ {
 				elem := rv.Index(i)
-				e, d, err := marshalInput(elem.Interface())
+				e, d, err := marshalInput(ctx, elem.Interface())
 				if err != nil {
 					return nil, nil, err
 				}
@@ -183,7 +232,7 @@ func marshalInput(v interface{}) (interface{}, []Resource, error) {
 						errors.Errorf("expected map keys to be strings; got %v", reflect.TypeOf(key.Interface()))
 				}
 				value := rv.MapIndex(key)
-				mv, d, err := marshalInput(value.Interface())
+				mv, d, err := marshalInput(ctx, value.Interface())
 				if err != nil {
 					return nil, nil, err
 				}
@@ -202,49 +251,65 @@ func marshalInput(v interface{}) (interface{}, []Resource, error) {
 
 }
 
-func marshalInputOutput(out Output) (interface{}, []Resource, error) {
+func marshalInputOutput(ctx context.Context, out Output) (interface{}, []Resource, error) {
 	// Await the value and return its raw value.
-	ov, known, err := out.s.await(context.TODO())
+	ov, known, secret, err := out.s.awaitValue(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// If the value is known, marshal it.
+	// If the value is known, marshal it.  Otherwise, simply use the unknown value sentinel.
+	e, deps := interface{}(rpcTokenUnknownValue), out.s.dependencies()
 	if known {
-		e, d, merr := marshalInput(ov)
+		v, d, merr := marshalInput(ctx, ov)
 		if merr != nil {
 			return nil, nil, merr
 		}
-		return e, append(out.s.dependencies(), d...), nil
+		e, deps = v, append(deps, d...)
 	}
 
-	// Otherwise, simply return the unknown value sentinel.
-	return rpcTokenUnknownValue, out.s.dependencies(), nil
+	// If the output is secret, wrap its value so that the resource monitor knows to keep it secret.
+	if secret {
+		e = &resource.Secret{Element: resource.NewPropertyValue(e)}
+	}
+	return e, deps, nil
 }
 
-// unmarshalOutputs unmarshals all the outputs into a simple map.
-func unmarshalOutputs(outs *structpb.Struct) (map[string]interface{}, error) {
-	outprops, err := plugin.UnmarshalProperties(outs, plugin.MarshalOptions{})
+// unmarshalOutputs unmarshals all the outputs into a simple map, along with the set of outputs whose values are secret
+// or contain secrets.
+func unmarshalOutputs(outs *structpb.Struct) (map[string]interface{}, map[string]bool, error) {
+	outprops, err := plugin.UnmarshalProperties(outs, plugin.MarshalOptions{KeepSecrets: true})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	result := make(map[string]interface{})
+	result, secrets := make(map[string]interface{}), make(map[string]bool)
 	for k, v := range outprops.Mappable() {
-		result[k], err = unmarshalOutput(v)
+		var secret bool
+		result[k], secret, err = unmarshalOutput(v)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if secret {
+			secrets[k] = true
 		}
 	}
-	return result, nil
+	return result, secrets, nil
 }
 
 // unmarshalOutput unmarshals a single output variable into its runtime representation.  For the most part, this just
-// returns the raw value.  In a small number of cases, we need to change a type.
-func unmarshalOutput(v interface{}) (interface{}, error) {
+// returns the raw value.  In a small number of cases, we need to change a type.  Secrets are replaced by their values,
+// and the returned bool is true if the value contained any.
+func unmarshalOutput(v interface{}) (interface{}, bool, error) {
 	// Check for nils and unknowns.
 	if v == nil || v == rpcTokenUnknownValue {
-		return nil, nil
+		return nil, false, nil
+	}
+
+	// Secrets unmarshaled by the plugin package carry their value as a property value.
+	if s, ok := v.(*resource.Secret); ok {
+		e, _, err := unmarshalOutput(s.Element.Mappable())
+		return e, true, err
 	}
 
 	// In the case of assets and archives, turn these into real asset and archive structures.
@@ -253,34 +318,39 @@ func unmarshalOutput(v interface{}) (interface{}, error) {
 			switch sig {
 			case rpcTokenSpecialAssetSig:
 				if path := m["path"]; path != nil {
-					return asset.NewFileAsset(cast.ToString(path)), nil
+					return asset.NewFileAsset(cast.ToString(path)), false, nil
 				} else if text := m["text"]; text != nil {
-					return asset.NewStringAsset(cast.ToString(text)), nil
+					return asset.NewStringAsset(cast.ToString(text)), false, nil
 				} else if uri := m["uri"]; uri != nil {
-					return asset.NewRemoteAsset(cast.ToString(uri)), nil
+					return asset.NewRemoteAsset(cast.ToString(uri)), false, nil
 				}
-				return nil, errors.New("expected asset to be one of File, String, or Remote; got none")
+				return nil, false, errors.New("expected asset to be one of File, String, or Remote; got none")
 			case rpcTokenSpecialArchiveSig:
 				if assets := m["assets"]; assets != nil {
 					as := make(map[string]interface{})
 					for k, v := range assets.(map[string]interface{}) {
-						a, err := unmarshalOutput(v)
+						a, _, err := unmarshalOutput(v)
 						if err != nil {
-							return nil, err
+							return nil, false, err
 						}
 						as[k] = a
 					}
-					return asset.NewAssetArchive(as), nil
+					return asset.NewAssetArchive(as), false, nil
 				} else if path := m["path"]; path != nil {
-					return asset.NewFileArchive(cast.ToString(path)), nil
+					return asset.NewFileArchive(cast.ToString(path)), false, nil
 				} else if uri := m["uri"]; uri != nil {
-					return asset.NewRemoteArchive(cast.ToString(uri)), nil
+					return asset.NewRemoteArchive(cast.ToString(uri)), false, nil
 				}
-				return nil, errors.New("expected asset to be one of File, String, or Remote; got none")
+				return nil, false, errors.New("expected asset to be one of File, String, or Remote; got none")
 			case rpcTokenSpecialSecretSig:
-				return nil, errors.New("this version of the Pulumi SDK does not support first-class secrets")
+				value, hasValue := m["value"]
+				if !hasValue {
+					return nil, false, errors.New("expected secret to have a value; got none")
+				}
+				e, _, err := unmarshalOutput(value)
+				return e, true, err
 			default:
-				return nil, errors.Errorf("unrecognized signature '%v' in output value", sig)
+				return nil, false, errors.Errorf("unrecognized signature '%v' in output value", sig)
 			}
 		}
 	}
@@ -291,33 +361,38 @@ func unmarshalOutput(v interface{}) (interface{}, error) {
 	case reflect.Array, reflect.Slice:
 		// If an array or a slice, create a new array by recursing into elements.
 		var arr []interface{}
+		secret := false
 		for i := 0; i < rv.Len(); i++ {
 			elem := rv.Index(i)
-			e, err := unmarshalOutput(elem.Interface())
+			e, s, err := unmarshalOutput(elem.Interface())
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 			arr = append(arr, e)
+			secret = secret || s
 		}
-		return arr, nil
+		return arr, secret, nil
 	case reflect.Map:
 		// For maps, only support string-based keys, and recurse into the values.
 		obj := make(map[string]interface{})
+		secret := false
 		for _, key := range rv.MapKeys() {
 			k, ok := key.Interface().(string)
 			if !ok {
-				return nil, errors.Errorf("expected map keys to be strings; got %v", reflect.TypeOf(key.Interface()))
+				return nil, false,
+					errors.Errorf("expected map keys to be strings; got %v", reflect.TypeOf(key.Interface()))
 			}
 			value := rv.MapIndex(key)
-			mv, err := unmarshalOutput(value)
+			mv, s, err := unmarshalOutput(value.Interface())
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 
 			obj[k] = mv
+			secret = secret || s
 		}
-		return obj, nil
+		return obj, secret, nil
 	}
 
-	return v, nil
+	return v, false, nil
 }
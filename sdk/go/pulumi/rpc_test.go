@@ -15,7 +15,9 @@
 package pulumi
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -56,13 +58,13 @@ func TestMarshalRoundtrip(t *testing.T) {
 	}
 
 	// Marshal those inputs.
-	m, pdeps, deps, err := marshalInputs(input, true)
+	m, pdeps, deps, err := marshalInputs(context.Background(), input, true, true)
 	if !assert.Nil(t, err) {
 		assert.Equal(t, len(input), len(pdeps))
 		assert.Equal(t, 0, len(deps))
 
 		// Now just unmarshal and ensure the resulting map matches.
-		res, err := unmarshalOutputs(m)
+		res, _, err := unmarshalOutputs(m)
 		if !assert.Nil(t, err) {
 			if !assert.NotNil(t, res) {
 				assert.Equal(t, "a string", res["s"])
@@ -97,13 +99,13 @@ func TestMarshalRoundtrip(t *testing.T) {
 	}
 
 	// Marshal those inputs without unknowns.
-	m, pdeps, deps, err = marshalInputs(input, false)
+	m, pdeps, deps, err = marshalInputs(context.Background(), input, false, true)
 	if !assert.Nil(t, err) {
 		assert.Equal(t, len(input), len(pdeps))
 		assert.Equal(t, 0, len(deps))
 
 		// Now just unmarshal and ensure the resulting map matches.
-		res, err := unmarshalOutputs(m)
+		res, _, err := unmarshalOutputs(m)
 		if !assert.Nil(t, err) {
 			if !assert.NotNil(t, res) {
 				assert.Equal(t, "a string", res["s"])
@@ -141,7 +143,7 @@ func TestMarshalRoundtrip(t *testing.T) {
 func TestResourceState(t *testing.T) {
 	state := makeResourceState(true, map[string]interface{}{"baz": nil})
 
-	s, _, _, _ := marshalInputs(map[string]interface{}{"baz": "qux"}, true)
+	s, _, _, _ := marshalInputs(context.Background(), map[string]interface{}{"baz": "qux"}, true, true)
 	state.resolve(false, nil, nil, "foo", "bar", s)
 
 	input := map[string]interface{}{
@@ -149,7 +151,7 @@ func TestResourceState(t *testing.T) {
 		"id":  state.id,
 		"baz": state.State["baz"],
 	}
-	m, pdeps, deps, err := marshalInputs(input, true)
+	m, pdeps, deps, err := marshalInputs(context.Background(), input, true, true)
 	assert.Nil(t, err)
 	assert.Equal(t, map[string][]URN{
 		"urn": {"foo"},
@@ -158,7 +160,7 @@ func TestResourceState(t *testing.T) {
 	}, pdeps)
 	assert.Equal(t, []URN{"foo", "foo", "foo"}, deps)
 
-	res, err := unmarshalOutputs(m)
+	res, _, err := unmarshalOutputs(m)
 	assert.Nil(t, err)
 	assert.Equal(t, map[string]interface{}{
 		"urn": "foo",
@@ -167,20 +169,181 @@ func TestResourceState(t *testing.T) {
 	}, res)
 }
 
-func TestUnmarshalUnsupportedSecret(t *testing.T) {
-	m, _, err := marshalInput(map[string]interface{}{
+func TestUnmarshalSecretWithoutValue(t *testing.T) {
+	m, _, err := marshalInput(context.Background(), map[string]interface{}{
 		rpcTokenSpecialSigKey: rpcTokenSpecialSecretSig,
 	})
 	assert.NoError(t, err)
-	_, err = unmarshalOutput(m)
+	_, _, err = unmarshalOutput(m)
 	assert.Error(t, err)
 }
 
+func TestMarshalSecrets(t *testing.T) {
+	input := map[string]interface{}{
+		"password": ToSecret("hunter2"),
+		"derived": ToSecret(String("db")).Apply(func(v interface{}) (interface{}, error) {
+			return v.(String) + "-host", nil
+		}),
+		"nested": map[string]interface{}{"plain": "a", "secret": ToSecret(1)},
+		"plain":  "b",
+	}
+
+	// Secrets, and values derived from them, are kept secret if the resource monitor supports them.
+	m, _, _, err := marshalInputs(context.Background(), input, true, true)
+	assert.NoError(t, err)
+	res, secrets, err := unmarshalOutputs(m)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"password": "hunter2",
+		"derived":  "db-host",
+		"nested":   map[string]interface{}{"plain": "a", "secret": 1.0},
+		"plain":    "b",
+	}, res)
+	assert.Equal(t, map[string]bool{"password": true, "derived": true, "nested": true}, secrets)
+
+	// Otherwise, they are sent as plain values.
+	m, _, _, err = marshalInputs(context.Background(), input, true, false)
+	assert.NoError(t, err)
+	_, secrets, err = unmarshalOutputs(m)
+	assert.NoError(t, err)
+	assert.Empty(t, secrets)
+}
+
+func TestResourceStateSecrets(t *testing.T) {
+	state := makeResourceState(true, map[string]interface{}{"password": nil, "user": nil})
+
+	props := map[string]interface{}{"password": ToSecret("hunter2"), "user": "admin"}
+	s, _, _, err := marshalInputs(context.Background(), props, true, true)
+	assert.NoError(t, err)
+	state.resolve(false, nil, nil, "foo", "bar", s)
+
+	v, known, secret, err := state.State["password"].s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, "hunter2", v)
+
+	_, _, secret, err = state.State["user"].s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, secret)
+}
+
 func TestUnmarshalUnknownSig(t *testing.T) {
-	m, _, err := marshalInput(map[string]interface{}{
+	m, _, err := marshalInput(context.Background(), map[string]interface{}{
 		rpcTokenSpecialSigKey: "foobar",
 	})
 	assert.NoError(t, err)
-	_, err = unmarshalOutput(m)
+	_, _, err = unmarshalOutput(m)
 	assert.Error(t, err)
 }
+
+func TestMarshalInputsDeterministic(t *testing.T) {
+	a := makeResourceState(true, nil)
+	a.resolve(false, nil, nil, "a", "", nil)
+	b := makeResourceState(true, nil)
+	b.resolve(false, nil, nil, "b", "", nil)
+
+	for i := 0; i < 10; i++ {
+		props := map[string]interface{}{"y": b.urn, "x": a.urn, "z": b.id}
+		_, _, deps, err := marshalInputs(context.Background(), props, true, true)
+		assert.Nil(t, err)
+		assert.Equal(t, []URN{"a", "b", "b"}, deps)
+	}
+}
+
+func TestExportAll(t *testing.T) {
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.Nil(t, err)
+
+	ctx.Export("a", 1)
+	ctx.ExportAll(map[string]interface{}{"a": 2, "b": "three"})
+	assert.Equal(t, map[string]interface{}{"a": 2, "b": "three"}, ctx.exports)
+}
+
+type testEnum string
+
+const (
+	testEnumA testEnum = "a"
+	testEnumB testEnum = "b"
+)
+
+func (testEnum) EnumValues() []interface{} {
+	return []interface{}{testEnumA, testEnumB}
+}
+
+func (e testEnum) ToStringOutput() StringOutput {
+	return StringOutput(EnumOutput(e))
+}
+
+func TestMarshalEnums(t *testing.T) {
+	// Valid values are marshaled as their underlying type, whether passed directly or as inputs.
+	v, _, err := marshalInput(context.Background(), testEnumB)
+	assert.Nil(t, err)
+	assert.Equal(t, "b", v)
+	var input StringInput = testEnumA
+	v, _, err = marshalInput(context.Background(), input.ToStringOutput())
+	assert.Nil(t, err)
+	assert.Equal(t, "a", v)
+
+	// Invalid values are rejected before they reach the provider.
+	_, _, err = marshalInput(context.Background(), testEnum("c"))
+	assert.EqualError(t, err, "invalid value c for pulumi.testEnum; expected one of a, b")
+	invalid := testEnum("c").ToStringOutput().Apply(func(s string) (interface{}, error) {
+		return s, nil
+	})
+	_, _, err = marshalInput(context.Background(), invalid)
+	assert.NotNil(t, err)
+}
+
+func TestMarshalInputTypes(t *testing.T) {
+	// Literals and outputs may be used interchangeably as inputs, and both are lifted to plain values when marshaled.
+	var s StringInput = String("a string")
+	var i IntInput = Int(42)
+	var b BoolInput = Bool(true).ToBoolOutput()
+	var f Float64PtrInput = Float64(1.5)
+	var a ArrayInput = Array{Int(1), String("x")}
+	var m MapInput = Map{"k": Bool(false)}
+
+	input := map[string]interface{}{
+		"s": s,
+		"i": i,
+		"b": b,
+		"f": f,
+		"a": a,
+		"m": m.ToMapOutput(),
+		"p": Int(7).ToIntPtrOutput(),
+	}
+	props, _, _, err := marshalInputs(context.Background(), input, true, true)
+	assert.NoError(t, err)
+
+	res, _, err := unmarshalOutputs(props)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"s": "a string",
+		"i": 42.0,
+		"b": true,
+		"f": 1.5,
+		"a": []interface{}{1.0, "x"},
+		"m": map[string]interface{}{"k": false},
+		"p": 7.0,
+	}, res)
+}
+
+func TestMarshalInputsTimeout(t *testing.T) {
+	// An output that is never resolved fails marshaling once the context's deadline passes, naming the property.
+	never, _, _ := NewOutput()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, _, err := marshalInputs(ctx, map[string]interface{}{"a": "b", "policy": never}, true, true)
+	assert.EqualError(t, err,
+		"timed out awaiting input property policy; an output that it depends on may never be resolved")
+
+	// The deadline of a context follows the output timeout of the run.
+	c, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev", OutputTimeout: time.Minute})
+	assert.Nil(t, err)
+	awaitCtx, cancel := c.awaitContext()
+	defer cancel()
+	deadline, ok := awaitCtx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 10*time.Second)
+}
@@ -18,20 +18,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/sdk/go/pulumi/internal/dynamic"
 )
 
 // Run executes the body of a Pulumi program, granting it access to a deployment context that it may use
 // to register resources and orchestrate deployment activities.  This connects back to the Pulumi engine using gRPC.
 // If the program fails, the process will be terminated and the function will not return.
-func Run(body RunFunc) {
-	if err := RunErr(body); err != nil {
+func Run(body RunFunc, opts ...RunOption) {
+	if err := RunErr(body, opts...); err != nil {
 		fmt.Fprintf(os.Stderr, "error: program failed: %v\n", err)
 		os.Exit(1)
 	}
@@ -39,12 +43,20 @@ func Run(body RunFunc) {
 
 // RunErr executes the body of a Pulumi program, granting it access to a deployment context that it may use
 // to register resources and orchestrate deployment activities.  This connects back to the Pulumi engine using gRPC.
-func RunErr(body RunFunc) error {
+func RunErr(body RunFunc, opts ...RunOption) error {
 	// Parse the info out of environment variables.  This is a lame contract with the caller, but helps to keep
 	// boilerplate to a minimum in the average Pulumi Go program.
 	// TODO(joe): this is a fine default, but consider `...RunOpt`s to control how we get the various addresses, etc.
 	info := getEnvInfo()
 
+	// If the program is being run as the sidecar of its dynamic providers, serve them instead of running the body.
+	if serve, _ := strconv.ParseBool(os.Getenv(EnvDynamicProvider)); serve {
+		if dynamic.Serve == nil {
+			return errors.New("the program does not define any dynamic providers")
+		}
+		return dynamic.Serve()
+	}
+
 	// Validate some properties.
 	if info.Project == "" {
 		return errors.Errorf("missing project name")
@@ -56,16 +68,81 @@ func RunErr(body RunFunc) error {
 		return errors.New("missing engine RPC address")
 	}
 
-	// Create a fresh context.
-	ctx, err := NewContext(context.TODO(), info)
+	// Create a fresh context.  Its context.Context is canceled if the program is interrupted, for example because the
+	// engine is shutting down, so that callbacks passed to ApplyWithContext may stop early.
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cancelOnInterrupt(runCtx, cancel)
+
+	var options runOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	defer setApplyConcurrency(options.applyConcurrency)()
+
+	ctx, err := NewContext(runCtx, info)
 	if err != nil {
 		return err
 	}
 	defer contract.IgnoreClose(ctx)
+	ctx.transformations = options.transformations
+	ctx.autoNaming = options.autoNaming
+	if options.rpcRetries != nil {
+		ctx.rpcRetries = *options.rpcRetries
+	}
 
 	return RunWithContext(ctx, body)
 }
 
+// RunOption is an option that controls how Run and RunErr execute a program.
+type RunOption func(*runOptions)
+
+type runOptions struct {
+	applyConcurrency int
+	transformations  []ResourceTransformation
+	rpcRetries       *int
+	autoNaming       *AutoNaming
+}
+
+// WithApplyConcurrency bounds the number of callbacks passed to Apply and its variants that may run at once to n.  By
+// default, each callback runs on a goroutine of its own once its inputs are available, which may spike the memory of
+// programs with very many outputs.  Callbacks that block, for example by calling Invoke, hold one of the n workers
+// while they do, so n must be large enough that the outputs they wait for can still be computed.
+func WithApplyConcurrency(n int) RunOption {
+	return func(opts *runOptions) {
+		opts.applyConcurrency = n
+	}
+}
+
+// WithTransformations applies the given transformations to every resource that the program registers, after those in
+// the resources' own options and those of the components that they belong to.
+func WithTransformations(transformations ...ResourceTransformation) RunOption {
+	return func(opts *runOptions) {
+		opts.transformations = append(opts.transformations, transformations...)
+	}
+}
+
+// WithAutoNaming customizes the physical names of the custom resources that the program does not name itself, unless
+// their own options customize them.
+func WithAutoNaming(naming *AutoNaming) RunOption {
+	return func(opts *runOptions) {
+		opts.autoNaming = naming
+	}
+}
+
+// WithRPCRetries sets how many times the program retries calls to the resource monitor that fail because it is
+// unavailable, such as when the connection to it is reset, before failing.  Only calls that are safe to repeat, such
+// as feature queries and registrations sent to engines that deduplicate them, are retried; reading resources and
+// invoking functions are not, since they may have taken effect before the failure.  Instead, those calls wait for a
+// dropped connection to the monitor to be re-established before they are sent.  The retries back off between
+// attempts, from a fraction of a second up to a few seconds.  By default, calls are retried five times; zero disables
+// retries and waiting for the connection.
+func WithRPCRetries(n int) RunOption {
+	return func(opts *runOptions) {
+		opts.rpcRetries = &n
+	}
+}
+
 // RunWithContext runs the body of a Pulumi program using the given Context for information about the target stack,
 // configuration, and engine connection.
 func RunWithContext(ctx *Context, body RunFunc) error {
@@ -77,7 +154,7 @@ func RunWithContext(ctx *Context, body RunFunc) error {
 	if err != nil {
 		return err
 	}
-	ctx.stackR, _, err = reg.URN().await(context.TODO())
+	ctx.stackR, _, err = reg.URN().await(ctx.ctx)
 	if err != nil {
 		return err
 	}
@@ -97,8 +174,26 @@ func RunWithContext(ctx *Context, body RunFunc) error {
 	// Ensure all outstanding RPCs have completed before proceeding.  Also, prevent any new RPCs from happening.
 	ctx.waitForRPCs()
 
-	// Propagate the error from the body, if any.
-	return result
+	// Propagate the error from the body, if any, such that errors.Is and errors.As can find any of the errors.
+	if result != nil {
+		return runError{result.(*multierror.Error)}
+	}
+
+	// If the program registered resource hooks, keep serving them until the engine has finished the update.
+	return ctx.waitForShutdown()
+}
+
+// cancelOnInterrupt calls cancel if the process receives an interrupt or termination signal before the context is done.
+func cancelOnInterrupt(ctx context.Context, cancel context.CancelFunc) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case <-signals:
+		cancel()
+	case <-ctx.Done():
+	}
 }
 
 // RunFunc executes the body of a Pulumi program.  It may register resources using the deployment context
@@ -114,6 +209,10 @@ type RunInfo struct {
 	DryRun      bool
 	MonitorAddr string
 	EngineAddr  string
+	// OutputTimeout, if non-zero, is how long the inputs of a resource, or the stack's outputs, may wait for the
+	// outputs that they depend on.  If it elapses, the program fails, rather than waiting for outputs that may never
+	// be resolved.
+	OutputTimeout time.Duration
 }
 
 // getEnvInfo reads various program information from the process environment.
@@ -121,6 +220,7 @@ func getEnvInfo() RunInfo {
 	// Most of the variables are just strings, and we can read them directly.  A few of them require more parsing.
 	parallel, _ := strconv.Atoi(os.Getenv(EnvParallel))
 	dryRun, _ := strconv.ParseBool(os.Getenv(EnvDryRun))
+	outputTimeout, _ := time.ParseDuration(os.Getenv(EnvOutputTimeout))
 
 	var config map[string]string
 	if cfg := os.Getenv(EnvConfig); cfg != "" {
@@ -128,13 +228,14 @@ func getEnvInfo() RunInfo {
 	}
 
 	return RunInfo{
-		Project:     os.Getenv(EnvProject),
-		Stack:       os.Getenv(EnvStack),
-		Config:      config,
-		Parallel:    parallel,
-		DryRun:      dryRun,
-		MonitorAddr: os.Getenv(EnvMonitor),
-		EngineAddr:  os.Getenv(EnvEngine),
+		Project:       os.Getenv(EnvProject),
+		Stack:         os.Getenv(EnvStack),
+		Config:        config,
+		Parallel:      parallel,
+		DryRun:        dryRun,
+		MonitorAddr:   os.Getenv(EnvMonitor),
+		EngineAddr:    os.Getenv(EnvEngine),
+		OutputTimeout: outputTimeout,
 	}
 }
 
@@ -153,4 +254,9 @@ const (
 	EnvMonitor = "PULUMI_MONITOR"
 	// EnvEngine is the envvar used to read the current Pulumi engine RPC address.
 	EnvEngine = "PULUMI_ENGINE"
+	// EnvOutputTimeout is the envvar used to read how long to wait for outputs, such as "5m", if at all.
+	EnvOutputTimeout = "PULUMI_OUTPUT_TIMEOUT"
+	// EnvDynamicProvider is the envvar that is set to true when the program is run to serve its dynamic providers,
+	// rather than to run its body.
+	EnvDynamicProvider = "PULUMI_DYNAMIC_PROVIDER"
 )
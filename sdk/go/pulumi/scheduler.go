@@ -0,0 +1,195 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// applyPool holds the *workerPool that runs apply callbacks, or a nil *workerPool if each callback runs on a goroutine
+// of its own.
+var applyPool atomic.Value
+
+func init() {
+	applyPool.Store((*workerPool)(nil))
+}
+
+// setApplyConcurrency bounds the number of apply callbacks that may run at once to n.  If n is zero or less, each
+// callback runs on a goroutine of its own.  The returned function stops the pool's workers once they have run the
+// callbacks already queued, after which each callback again runs on a goroutine of its own.
+func setApplyConcurrency(n int) func() {
+	var pool *workerPool
+	if n > 0 {
+		pool = newWorkerPool(n)
+	}
+	applyPool.Store(pool)
+	return func() {
+		if pool != nil {
+			applyPool.Store((*workerPool)(nil))
+			pool.stop()
+		}
+	}
+}
+
+// scheduleApply runs f, which is an apply callback whose inputs are available, without blocking the caller.
+func scheduleApply(f func()) {
+	if pool := applyPool.Load().(*workerPool); pool != nil {
+		pool.submit(f)
+		return
+	}
+	go f()
+}
+
+// workerPool runs functions on a fixed number of goroutines.  Functions are queued without bound, so that submitting
+// one never blocks, even from a function that is itself running on the pool.
+type workerPool struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	queue   []func()
+	stopped bool
+}
+
+func newWorkerPool(workers int) *workerPool {
+	p := &workerPool{}
+	p.cond = sync.NewCond(&p.mutex)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *workerPool) submit(f func()) {
+	p.mutex.Lock()
+	if p.stopped {
+		// A callback that was scheduled on the pool as it stopped still runs, just not on the pool.
+		p.mutex.Unlock()
+		go f()
+		return
+	}
+	p.queue = append(p.queue, f)
+	p.mutex.Unlock()
+	p.cond.Signal()
+}
+
+// stop tells the pool's workers to exit once the queue is empty.
+func (p *workerPool) stop() {
+	p.mutex.Lock()
+	p.stopped = true
+	p.mutex.Unlock()
+	p.cond.Broadcast()
+}
+
+func (p *workerPool) work() {
+	for {
+		p.mutex.Lock()
+		for len(p.queue) == 0 {
+			if p.stopped {
+				p.mutex.Unlock()
+				return
+			}
+			p.cond.Wait()
+		}
+		f := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+		p.mutex.Unlock()
+
+		f()
+	}
+}
+
+// doneCallback is a function that is called once a context is done, unless it is canceled first.  The callbacks that
+// are registered with the same context share a single goroutine that waits for it, so that the many applies that may
+// be pending on a context cost no more than their callbacks.
+type doneCallback struct {
+	f    func()
+	done <-chan struct{} // the done channel of the context that the callback is registered with, if any.
+}
+
+// doneWatcher waits on a context's done channel on behalf of the callbacks that are registered with it.
+type doneWatcher struct {
+	callbacks map[*doneCallback]struct{}
+	stop      chan struct{}
+}
+
+// doneWatchers holds the watcher of each context that has callbacks registered, keyed by its done channel.
+var doneWatchers = struct {
+	sync.Mutex
+	m map[<-chan struct{}]*doneWatcher
+}{m: make(map[<-chan struct{}]*doneWatcher)}
+
+// register arranges for the callback to be called once ctx is done.  A context that is never done never calls it.
+func (c *doneCallback) register(ctx context.Context) {
+	done := ctx.Done()
+	if done == nil {
+		return
+	}
+
+	doneWatchers.Lock()
+	defer doneWatchers.Unlock()
+	c.done = done
+	w, has := doneWatchers.m[done]
+	if !has {
+		w = &doneWatcher{callbacks: make(map[*doneCallback]struct{}), stop: make(chan struct{})}
+		doneWatchers.m[done] = w
+		go w.watch(done)
+	}
+	w.callbacks[c] = struct{}{}
+}
+
+// cancel unregisters the callback, so that it is not called once its context is done.  Once a context has no
+// callbacks left, its watcher exits.
+func (c *doneCallback) cancel() {
+	if c.done == nil {
+		return
+	}
+
+	doneWatchers.Lock()
+	defer doneWatchers.Unlock()
+	w, has := doneWatchers.m[c.done]
+	if !has {
+		return
+	}
+	if _, has = w.callbacks[c]; !has {
+		return
+	}
+	delete(w.callbacks, c)
+	if len(w.callbacks) == 0 {
+		delete(doneWatchers.m, c.done)
+		close(w.stop)
+	}
+}
+
+func (w *doneWatcher) watch(done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-w.stop:
+		return
+	}
+
+	doneWatchers.Lock()
+	if doneWatchers.m[done] == w {
+		delete(doneWatchers.m, done)
+	}
+	callbacks := w.callbacks
+	w.callbacks = nil
+	doneWatchers.Unlock()
+
+	for c := range callbacks {
+		c.f()
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingAppliesDoNotBlockGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	// Applies to a pending output wait without a goroutine of their own.
+	out, resolve, _ := NewOutput()
+	var results []Output
+	for i := 0; i < 1000; i++ {
+		results = append(results, StringOutput(out).Apply(func(v string) (interface{}, error) {
+			return v + "!", nil
+		}))
+	}
+	assert.True(t, runtime.NumGoroutine()-before < 100)
+
+	resolve("hello")
+	for _, result := range results {
+		v, known, err := result.s.await(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, "hello!", v)
+	}
+}
+
+func TestPendingAppliesWithContextDoNotBlockGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	// Applies to a pending output that may be canceled by the same context share a goroutine that waits for it.
+	ctx, cancel := context.WithCancel(context.Background())
+	out, _, _ := NewOutput()
+	var results []Output
+	for i := 0; i < 1000; i++ {
+		results = append(results, out.ApplyWithContext(ctx, func(_ context.Context, v interface{}) (interface{}, error) {
+			return v, nil
+		}))
+	}
+	assert.True(t, runtime.NumGoroutine()-before < 100)
+
+	// Canceling the context rejects every one of them.
+	cancel()
+	for _, result := range results {
+		_, _, err := result.s.await(context.Background())
+		assert.Equal(t, context.Canceled, err)
+	}
+}
+
+func TestApplyConcurrencyStops(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	// Once the pool is stopped, its workers exit, and applies run on goroutines of their own.
+	stop := setApplyConcurrency(100)
+	stop()
+	for i := 0; i < 100 && runtime.NumGoroutine()-before >= 50; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(t, runtime.NumGoroutine()-before < 50)
+
+	v, known, err := Int(1).ToIntOutput().Apply(func(v int) (interface{}, error) {
+		return v + 1, nil
+	}).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, 2, v)
+}
+
+func TestApplyConcurrency(t *testing.T) {
+	defer setApplyConcurrency(2)()
+
+	var mutex sync.Mutex
+	running, maxRunning := 0, 0
+	var results []Output
+	for i := 0; i < 20; i++ {
+		results = append(results, Int(i).ToIntOutput().Apply(func(v int) (interface{}, error) {
+			mutex.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mutex.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mutex.Lock()
+			running--
+			mutex.Unlock()
+			return v * 2, nil
+		}))
+	}
+
+	// Every apply runs, but no more than two of them at once.
+	for i, result := range results {
+		v, known, err := result.s.await(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, i*2, v)
+	}
+	assert.True(t, maxRunning <= 2)
+
+	// Applies that depend on other applies still complete, as do those over several outputs.
+	v, _, err := Sprintf("%v-%v", results[1], IntOutput(results[2]).Apply(func(v int) (interface{}, error) {
+		return v + 1, nil
+	})).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "2-5", v)
+}
@@ -0,0 +1,115 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// StackReference is a reference to another stack, whose outputs may be read by this program.  The referenced stack's
+// outputs are read when the reference is registered, including during previews, and outputs read from them depend on
+// the reference.
+type StackReference struct {
+	*ResourceState
+
+	// Name is the name of the referenced stack.
+	Name StringOutput
+	// Outputs are the outputs of the referenced stack.
+	Outputs MapOutput
+	// SecretOutputNames are the names of the referenced stack's outputs that are secret.
+	SecretOutputNames ArrayOutput
+}
+
+// StackReferenceArgs are the arguments of a stack reference.
+type StackReferenceArgs struct {
+	// Name is the name of the referenced stack, such as "org/project/stack".  If it is empty, the name of the
+	// reference is used.
+	Name string
+}
+
+// NewStackReference registers a reference to another stack with the given name, and arguments, which may be nil.
+func NewStackReference(ctx *Context, name string, args *StackReferenceArgs,
+	opts ...ResourceOpt) (*StackReference, error) {
+
+	stack := name
+	if args != nil && args.Name != "" {
+		stack = args.Name
+	}
+
+	props := map[string]interface{}{
+		"name":              stack,
+		"outputs":           nil,
+		"secretOutputNames": nil,
+	}
+	res, err := ctx.ReadResource("pulumi:pulumi:StackReference", name, ID(stack), props, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StackReference{
+		ResourceState:     res,
+		Name:              StringOutput(res.State["name"]),
+		Outputs:           MapOutput(res.State["outputs"]),
+		SecretOutputNames: ArrayOutput(res.State["secretOutputNames"]),
+	}, nil
+}
+
+// GetOutput returns an output of the value of the referenced stack's output with the given name, which is nil if the
+// stack has no such output.  The result is secret only if that output is.  Use the As<Type>Output methods of Output,
+// such as AsStringOutput, to convert it to a typed output.
+func (ref *StackReference) GetOutput(name StringInput) Output {
+	return ref.readOutput(name, false)
+}
+
+// RequireOutput is like GetOutput, but the result is rejected if the referenced stack has no output with the given
+// name.
+func (ref *StackReference) RequireOutput(name StringInput) Output {
+	return ref.readOutput(name, true)
+}
+
+func (ref *StackReference) readOutput(name StringInput, required bool) Output {
+	// The outputs of the referenced stack are secret if any of them are, so rather than applying to them, which would
+	// make the result secret too, decide whether the result is secret by the name of the output.
+	all := All(ref.Name, name.ToStringOutput(), ref.Outputs, ref.SecretOutputNames)
+	result := newOutput(all.s.dependencies()...)
+	all.s.whenFulfilled(context.Background(), func(v interface{}, known, _ bool, err error) {
+		if err != nil || !known {
+			result.s.fulfill(nil, known, err)
+			return
+		}
+
+		values := v.([]interface{})
+		stack, output := convert(values[0], stringType).(string), convert(values[1], stringType).(string)
+		outputs, _ := values[2].(map[string]interface{})
+		value, has := outputs[output]
+		if !has && required {
+			result.s.reject(errors.Errorf("required output '%s' does not exist on stack '%s'", output, stack))
+			return
+		}
+
+		secret := false
+		secretNames, _ := values[3].([]interface{})
+		for _, secretName := range secretNames {
+			if secretName == output {
+				secret = true
+				break
+			}
+		}
+		result.s.fulfillValue(value, true, secret, nil)
+	})
+	return result
+}
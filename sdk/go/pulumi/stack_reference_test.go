@@ -0,0 +1,74 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackReference(t *testing.T) {
+	monitor := newFakeMonitor()
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "dev"})
+	assert.NoError(t, err)
+	ctx.monitor, ctx.stackR = monitor, "stack"
+
+	state, _, _, err := marshalInputs(context.Background(), map[string]interface{}{
+		"name": "org/network/prod",
+		"outputs": map[string]interface{}{
+			"vpcId":    "vpc-1234",
+			"password": ToSecret("hunter2"),
+		},
+		"secretOutputNames": []interface{}{"password"},
+	}, true, true)
+	assert.NoError(t, err)
+	monitor.reads["org/network/prod"] = state
+
+	ref, err := NewStackReference(ctx, "network", &StackReferenceArgs{Name: "org/network/prod"})
+	assert.NoError(t, err)
+
+	// Outputs are read by name, and only those that are secret in the referenced stack are secret.
+	vpcID := ref.GetOutput(String("vpcId")).AsStringOutput()
+	v, known, secret, err := vpcID.s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.False(t, secret)
+	assert.Equal(t, "vpc-1234", v)
+	deps := Output(vpcID).Dependencies()
+	assert.NotEmpty(t, deps)
+	for _, dep := range deps {
+		assert.True(t, dep == Resource(ref.ResourceState))
+	}
+
+	v, _, secret, err = ref.RequireOutput(String("password")).s.awaitValue(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, secret)
+	assert.Equal(t, "hunter2", v)
+
+	// Missing outputs are nil, unless they are required.
+	v, _, err = ref.GetOutput(String("missing")).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+	_, _, err = ref.RequireOutput(String("missing")).s.await(context.Background())
+	assert.EqualError(t, err, "required output 'missing' does not exist on stack 'org/network/prod'")
+
+	// The reference is read by the name of the stack.
+	id, _, err := ref.ID().await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ID("org/network/prod"), id)
+	ctx.waitForRPCs()
+}
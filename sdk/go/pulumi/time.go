@@ -0,0 +1,139 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DurationOutput is an Output that is typed to return time.Duration values.  Durations are marshaled as strings in the
+// format of time.Duration.String, such as "1h30m0s", and the values of resource outputs are parsed from that format.
+type DurationOutput Output
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Apply applies a transformation to the duration value when it is available.
+func (out DurationOutput) Apply(applier func(time.Duration) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v time.Duration) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the duration value when it is available.
+func (out DurationOutput) ApplyWithContext(ctx context.Context,
+	applier func(context.Context, time.Duration) (interface{}, error)) Output {
+
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		d, err := toDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		return applier(ctx, d)
+	})
+}
+
+// ToDurationOutput returns the output itself, so that a DurationOutput may be used as a DurationInput.
+func (out DurationOutput) ToDurationOutput() DurationOutput {
+	return out
+}
+
+// DurationInput is a time.Duration value that may or may not be known yet: either a Duration or a DurationOutput.
+type DurationInput interface {
+	ToDurationOutput() DurationOutput
+}
+
+// Duration is a literal time.Duration that may be used as a DurationInput.
+type Duration time.Duration
+
+// ToDurationOutput returns an output that is resolved to the duration's value.
+func (d Duration) ToDurationOutput() DurationOutput {
+	out := newOutput()
+	out.s.resolve(time.Duration(d), true)
+	return DurationOutput(out)
+}
+
+// toDuration converts the value of a DurationOutput to a time.Duration, parsing it if it is a string.
+func toDuration(v interface{}) (time.Duration, error) {
+	if s, ok := v.(string); ok {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, errors.Wrapf(err, "parsing duration %q", s)
+		}
+		return d, nil
+	}
+	return convert(v, durationType).(time.Duration), nil
+}
+
+// TimeOutput is an Output that is typed to return time.Time values.  Times are marshaled as strings in RFC3339 format,
+// and the values of resource outputs are parsed from that format.
+type TimeOutput Output
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Apply applies a transformation to the time value when it is available.
+func (out TimeOutput) Apply(applier func(time.Time) (interface{}, error)) Output {
+	return out.ApplyWithContext(context.Background(), func(_ context.Context, v time.Time) (interface{}, error) {
+		return applier(v)
+	})
+}
+
+// ApplyWithContext applies a transformation to the time value when it is available.
+func (out TimeOutput) ApplyWithContext(ctx context.Context,
+	applier func(context.Context, time.Time) (interface{}, error)) Output {
+
+	return Output(out).ApplyWithContext(ctx, func(ctx context.Context, v interface{}) (interface{}, error) {
+		t, err := toTime(v)
+		if err != nil {
+			return nil, err
+		}
+		return applier(ctx, t)
+	})
+}
+
+// ToTimeOutput returns the output itself, so that a TimeOutput may be used as a TimeInput.
+func (out TimeOutput) ToTimeOutput() TimeOutput {
+	return out
+}
+
+// TimeInput is a time.Time value that may or may not be known yet: either a Time or a TimeOutput.
+type TimeInput interface {
+	ToTimeOutput() TimeOutput
+}
+
+// Time is a literal time.Time that may be used as a TimeInput.
+type Time time.Time
+
+// ToTimeOutput returns an output that is resolved to the time's value.
+func (t Time) ToTimeOutput() TimeOutput {
+	out := newOutput()
+	out.s.resolve(time.Time(t), true)
+	return TimeOutput(out)
+}
+
+// toTime converts the value of a TimeOutput to a time.Time, parsing it if it is a string.
+func toTime(v interface{}) (time.Time, error) {
+	if s, ok := v.(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return time.Time{}, errors.Wrapf(err, "parsing time %q", s)
+		}
+		return t, nil
+	}
+	return convert(v, timeType).(time.Time), nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationOutputs(t *testing.T) {
+	// Literal durations apply as time.Durations, and marshal as duration strings.
+	ttl := Duration(90 * time.Minute).ToDurationOutput()
+	v, known, err := ttl.Apply(func(d time.Duration) (interface{}, error) {
+		return d.Hours(), nil
+	}).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, 1.5, v)
+
+	pm, _, _, err := marshalInputs(context.Background(), map[string]interface{}{
+		"ttl":     ttl,
+		"literal": Duration(time.Second),
+		"plain":   time.Millisecond,
+	}, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "1h30m0s", pm.Fields["ttl"].GetStringValue())
+	assert.Equal(t, "1s", pm.Fields["literal"].GetStringValue())
+	assert.Equal(t, "1ms", pm.Fields["plain"].GetStringValue())
+
+	// Outputs resolved to duration strings, as resource outputs are, are parsed.
+	out, resolve, _ := NewOutput()
+	resolve("2m")
+	v, _, err = DurationOutput(out).Apply(func(d time.Duration) (interface{}, error) {
+		return d, nil
+	}).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, v)
+
+	// Malformed strings reject the result.
+	bad, resolve, _ := NewOutput()
+	resolve("soon")
+	_, _, err = DurationOutput(bad).Apply(func(d time.Duration) (interface{}, error) {
+		return d, nil
+	}).s.await(context.Background())
+	assert.Error(t, err)
+}
+
+func TestTimeOutputs(t *testing.T) {
+	when := time.Date(2019, time.October, 1, 12, 30, 0, 0, time.UTC)
+
+	// Literal times apply as time.Times, and marshal in RFC3339 format.
+	rotate := Time(when).ToTimeOutput()
+	v, known, err := rotate.Apply(func(t time.Time) (interface{}, error) {
+		return t.Year(), nil
+	}).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, 2019, v)
+
+	pm, _, _, err := marshalInputs(context.Background(), map[string]interface{}{
+		"rotate": rotate,
+		"plain":  when.Add(time.Hour),
+	}, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-10-01T12:30:00Z", pm.Fields["rotate"].GetStringValue())
+	assert.Equal(t, "2019-10-01T13:30:00Z", pm.Fields["plain"].GetStringValue())
+
+	// Outputs resolved to RFC3339 strings, as resource outputs are, are parsed.
+	out, resolve, _ := NewOutput()
+	resolve("2019-10-01T14:30:00+02:00")
+	v, _, err = TimeOutput(out).Apply(func(t time.Time) (interface{}, error) {
+		return t, nil
+	}).s.await(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, when.Equal(v.(time.Time)))
+
+	// Malformed strings reject the result.
+	bad, resolve, _ := NewOutput()
+	resolve("tomorrow")
+	_, _, err = TimeOutput(bad).Apply(func(t time.Time) (interface{}, error) {
+		return t, nil
+	}).s.await(context.Background())
+	assert.Error(t, err)
+}
@@ -109,20 +109,20 @@ func main() {
 		engineAddress = args[0]
 	}
 
-	// Fire up a gRPC server, letting the kernel choose a free port.
-	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+	// Fire up a gRPC server, letting the kernel choose a free port or socket.
+	addr, done, err := rpcutil.ServeLocal(nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
 			host := newLanguageHost(nodePath, runPath, engineAddress, tracing, typescript)
 			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
 			return nil
 		},
-	}, nil)
+	}, nil, true /*pipes*/)
 	if err != nil {
 		cmdutil.Exit(errors.Wrapf(err, "could not start language host RPC server"))
 	}
 
-	// Otherwise, print out the port so that the spawner knows how to reach us.
-	fmt.Printf("%d\n", port)
+	// Otherwise, print out the address so that the spawner knows how to reach us.
+	fmt.Printf("%s\n", rpcutil.HandshakeAddress(addr))
 
 	// And finally wait for the server to stop serving.
 	if err := <-done; err != nil {
@@ -394,7 +394,7 @@ func (host *nodeLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest
 	tracingSpan := opentracing.SpanFromContext(ctx)
 
 	// Make a connection to the real monitor that we will forward messages to.
-	conn, err := grpc.Dial(req.GetMonitorAddress(), grpc.WithInsecure())
+	conn, err := grpc.Dial(req.GetMonitorAddress(), grpc.WithInsecure(), rpcutil.WithLocalDialer())
 	if err != nil {
 		return nil, err
 	}
@@ -411,12 +411,12 @@ func (host *nodeLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest
 	}()
 
 	// Launch the rpc server giving it the real monitor to forward messages to.
-	port, serverDone, err := rpcutil.Serve(0, serverCancel, []func(*grpc.Server) error{
+	addr, serverDone, err := rpcutil.ServeLocal(serverCancel, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
 			pulumirpc.RegisterResourceMonitorServer(srv, &monitorProxy{target})
 			return nil
 		},
-	}, tracingSpan)
+	}, tracingSpan, false /*pipes*/)
 	if err != nil {
 		return nil, err
 	}
@@ -448,7 +448,7 @@ func (host *nodeLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest
 	}()
 
 	// now, launch the nodejs process and actually run the user code in it.
-	go host.execNodejs(responseChannel, req, fmt.Sprintf("127.0.0.1:%d", port), pipes.directory())
+	go host.execNodejs(responseChannel, req, addr, pipes.directory())
 
 	// Wait for one of our launched goroutines to signal that we're done.  This might be our proxy
 	// (in the case of errors), or the launched nodejs completing (either successfully, or with
@@ -233,3 +233,13 @@ func (p *monitorProxy) SupportsFeature(
 	ctx context.Context, req *pulumirpc.SupportsFeatureRequest) (*pulumirpc.SupportsFeatureResponse, error) {
 	return p.target.SupportsFeature(ctx, req)
 }
+
+func (p *monitorProxy) RegisterResourceHook(
+	ctx context.Context, req *pulumirpc.RegisterResourceHookRequest) (*pbempty.Empty, error) {
+	return p.target.RegisterResourceHook(ctx, req)
+}
+
+func (p *monitorProxy) SignalAndWaitForShutdown(
+	ctx context.Context, req *pbempty.Empty) (*pbempty.Empty, error) {
+	return p.target.SignalAndWaitForShutdown(ctx, req)
+}
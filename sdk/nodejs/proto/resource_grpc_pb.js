@@ -77,6 +77,17 @@ function deserialize_pulumirpc_ReadResourceResponse(buffer_arg) {
   return resource_pb.ReadResourceResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_RegisterResourceHookRequest(arg) {
+  if (!(arg instanceof resource_pb.RegisterResourceHookRequest)) {
+    throw new Error('Expected argument of type pulumirpc.RegisterResourceHookRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_RegisterResourceHookRequest(buffer_arg) {
+  return resource_pb.RegisterResourceHookRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_RegisterResourceOutputsRequest(arg) {
   if (!(arg instanceof resource_pb.RegisterResourceOutputsRequest)) {
     throw new Error('Expected argument of type pulumirpc.RegisterResourceOutputsRequest');
@@ -110,6 +121,28 @@ function deserialize_pulumirpc_RegisterResourceResponse(buffer_arg) {
   return resource_pb.RegisterResourceResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_RunResourceHookRequest(arg) {
+  if (!(arg instanceof resource_pb.RunResourceHookRequest)) {
+    throw new Error('Expected argument of type pulumirpc.RunResourceHookRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_RunResourceHookRequest(buffer_arg) {
+  return resource_pb.RunResourceHookRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_RunResourceHookResponse(arg) {
+  if (!(arg instanceof resource_pb.RunResourceHookResponse)) {
+    throw new Error('Expected argument of type pulumirpc.RunResourceHookResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_RunResourceHookResponse(buffer_arg) {
+  return resource_pb.RunResourceHookResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_SupportsFeatureRequest(arg) {
   if (!(arg instanceof resource_pb.SupportsFeatureRequest)) {
     throw new Error('Expected argument of type pulumirpc.SupportsFeatureRequest');
@@ -201,6 +234,44 @@ var ResourceMonitorService = exports.ResourceMonitorService = {
     responseSerialize: serialize_google_protobuf_Empty,
     responseDeserialize: deserialize_google_protobuf_Empty,
   },
+  registerResourceHook: {
+    path: '/pulumirpc.ResourceMonitor/RegisterResourceHook',
+    requestStream: false,
+    responseStream: false,
+    requestType: resource_pb.RegisterResourceHookRequest,
+    responseType: google_protobuf_empty_pb.Empty,
+    requestSerialize: serialize_pulumirpc_RegisterResourceHookRequest,
+    requestDeserialize: deserialize_pulumirpc_RegisterResourceHookRequest,
+    responseSerialize: serialize_google_protobuf_Empty,
+    responseDeserialize: deserialize_google_protobuf_Empty,
+  },
+  signalAndWaitForShutdown: {
+    path: '/pulumirpc.ResourceMonitor/SignalAndWaitForShutdown',
+    requestStream: false,
+    responseStream: false,
+    requestType: google_protobuf_empty_pb.Empty,
+    responseType: google_protobuf_empty_pb.Empty,
+    requestSerialize: serialize_google_protobuf_Empty,
+    requestDeserialize: deserialize_google_protobuf_Empty,
+    responseSerialize: serialize_google_protobuf_Empty,
+    responseDeserialize: deserialize_google_protobuf_Empty,
+  },
 };
 
 exports.ResourceMonitorClient = grpc.makeGenericClientConstructor(ResourceMonitorService);
+// ResourceHooks is the interface a program serves so that the engine can run the resource hooks it registered.
+var ResourceHooksService = exports.ResourceHooksService = {
+  runHook: {
+    path: '/pulumirpc.ResourceHooks/RunHook',
+    requestStream: false,
+    responseStream: false,
+    requestType: resource_pb.RunResourceHookRequest,
+    responseType: resource_pb.RunResourceHookResponse,
+    requestSerialize: serialize_pulumirpc_RunResourceHookRequest,
+    requestDeserialize: deserialize_pulumirpc_RunResourceHookRequest,
+    responseSerialize: serialize_pulumirpc_RunResourceHookResponse,
+    responseDeserialize: deserialize_pulumirpc_RunResourceHookResponse,
+  },
+};
+
+exports.ResourceHooksClient = grpc.makeGenericClientConstructor(ResourceHooksService);
@@ -16,11 +16,15 @@ var google_protobuf_struct_pb = require('google-protobuf/google/protobuf/struct_
 var provider_pb = require('./provider_pb.js');
 goog.exportSymbol('proto.pulumirpc.ReadResourceRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.ReadResourceResponse', null, global);
+goog.exportSymbol('proto.pulumirpc.RegisterResourceHookRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceOutputsRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.CustomTimeouts', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.PropertyDependencies', null, global);
+goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceResponse', null, global);
+goog.exportSymbol('proto.pulumirpc.RunResourceHookRequest', null, global);
+goog.exportSymbol('proto.pulumirpc.RunResourceHookResponse', null, global);
 goog.exportSymbol('proto.pulumirpc.SupportsFeatureRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.SupportsFeatureResponse', null, global);
 
@@ -1047,7 +1051,8 @@ proto.pulumirpc.RegisterResourceRequest.toObject = function(includeInstance, msg
     importid: jspb.Message.getFieldWithDefault(msg, 16, ""),
     customtimeouts: (f = msg.getCustomtimeouts()) && proto.pulumirpc.RegisterResourceRequest.CustomTimeouts.toObject(includeInstance, f),
     deletebeforereplacedefined: jspb.Message.getFieldWithDefault(msg, 18, false),
-    supportspartialvalues: jspb.Message.getFieldWithDefault(msg, 19, false)
+    supportspartialvalues: jspb.Message.getFieldWithDefault(msg, 19, false),
+    hooks: (f = msg.getHooks()) && proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1164,6 +1169,11 @@ proto.pulumirpc.RegisterResourceRequest.deserializeBinaryFromReader = function(m
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setSupportspartialvalues(value);
       break;
+    case 20:
+      var value = new proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding;
+      reader.readMessage(value,proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.deserializeBinaryFromReader);
+      msg.setHooks(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1325,6 +1335,14 @@ proto.pulumirpc.RegisterResourceRequest.serializeBinaryToWriter = function(messa
       f
     );
   }
+  f = message.getHooks();
+  if (f != null) {
+    writer.writeMessage(
+      20,
+      f,
+      proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -1687,127 +1705,241 @@ proto.pulumirpc.RegisterResourceRequest.CustomTimeouts.prototype.setDelete = fun
 };
 
 
+
 /**
- * optional string type = 1;
- * @return {string}
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getType = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.repeatedFields_, null);
 };
+goog.inherits(proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.displayName = 'proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.repeatedFields_ = [1,2,3,4,5,6];
 
 
-/** @param {string} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setType = function(value) {
-  jspb.Message.setProto3StringField(this, 1, value);
-};
-
 
+if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
- * optional string name = 2;
- * @return {string}
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getName = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
-};
-
-
-/** @param {string} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setName = function(value) {
-  jspb.Message.setProto3StringField(this, 2, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.toObject(opt_includeInstance, this);
 };
 
 
 /**
- * optional string parent = 3;
- * @return {string}
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getParent = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
-};
-
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    beforecreateList: jspb.Message.getRepeatedField(msg, 1),
+    aftercreateList: jspb.Message.getRepeatedField(msg, 2),
+    beforeupdateList: jspb.Message.getRepeatedField(msg, 3),
+    afterupdateList: jspb.Message.getRepeatedField(msg, 4),
+    beforedeleteList: jspb.Message.getRepeatedField(msg, 5),
+    afterdeleteList: jspb.Message.getRepeatedField(msg, 6)
+  };
 
-/** @param {string} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setParent = function(value) {
-  jspb.Message.setProto3StringField(this, 3, value);
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
 };
+}
 
 
 /**
- * optional bool custom = 4;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getCustom = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 4, false));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding;
+  return proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.deserializeBinaryFromReader(msg, reader);
 };
 
 
-/** @param {boolean} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setCustom = function(value) {
-  jspb.Message.setProto3BooleanField(this, 4, value);
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding}
+ */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addBeforecreate(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAftercreate(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addBeforeupdate(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAfterupdate(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addBeforedelete(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAfterdelete(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
 };
 
 
 /**
- * optional google.protobuf.Struct object = 5;
- * @return {?proto.google.protobuf.Struct}
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getObject = function() {
-  return /** @type{?proto.google.protobuf.Struct} */ (
-    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 5));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
 };
 
 
-/** @param {?proto.google.protobuf.Struct|undefined} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setObject = function(value) {
-  jspb.Message.setWrapperField(this, 5, value);
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getBeforecreateList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      1,
+      f
+    );
+  }
+  f = message.getAftercreateList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      2,
+      f
+    );
+  }
+  f = message.getBeforeupdateList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      3,
+      f
+    );
+  }
+  f = message.getAfterupdateList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      4,
+      f
+    );
+  }
+  f = message.getBeforedeleteList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      5,
+      f
+    );
+  }
+  f = message.getAfterdeleteList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      6,
+      f
+    );
+  }
 };
 
 
-proto.pulumirpc.RegisterResourceRequest.prototype.clearObject = function() {
-  this.setObject(undefined);
+/**
+ * repeated string beforeCreate = 1;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getBeforecreateList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 1));
 };
 
 
-/**
- * Returns whether this field is set.
- * @return {!boolean}
- */
-proto.pulumirpc.RegisterResourceRequest.prototype.hasObject = function() {
-  return jspb.Message.getField(this, 5) != null;
+/** @param {!Array.<string>} value */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setBeforecreateList = function(value) {
+  jspb.Message.setField(this, 1, value || []);
 };
 
 
 /**
- * optional bool protect = 6;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * @param {!string} value
+ * @param {number=} opt_index
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getProtect = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 6, false));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addBeforecreate = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 1, value, opt_index);
 };
 
 
-/** @param {boolean} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setProtect = function(value) {
-  jspb.Message.setProto3BooleanField(this, 6, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearBeforecreateList = function() {
+  this.setBeforecreateList([]);
 };
 
 
 /**
- * repeated string dependencies = 7;
+ * repeated string afterCreate = 2;
  * @return {!Array.<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getDependenciesList = function() {
-  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 7));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getAftercreateList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 2));
 };
 
 
 /** @param {!Array.<string>} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setDependenciesList = function(value) {
-  jspb.Message.setField(this, 7, value || []);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setAftercreateList = function(value) {
+  jspb.Message.setField(this, 2, value || []);
 };
 
 
@@ -1815,93 +1947,347 @@ proto.pulumirpc.RegisterResourceRequest.prototype.setDependenciesList = function
  * @param {!string} value
  * @param {number=} opt_index
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.addDependencies = function(value, opt_index) {
-  jspb.Message.addToRepeatedField(this, 7, value, opt_index);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addAftercreate = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 2, value, opt_index);
 };
 
 
-proto.pulumirpc.RegisterResourceRequest.prototype.clearDependenciesList = function() {
-  this.setDependenciesList([]);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearAftercreateList = function() {
+  this.setAftercreateList([]);
 };
 
 
 /**
- * optional string provider = 8;
- * @return {string}
+ * repeated string beforeUpdate = 3;
+ * @return {!Array.<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getProvider = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 8, ""));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getBeforeupdateList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 3));
 };
 
 
-/** @param {string} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setProvider = function(value) {
-  jspb.Message.setProto3StringField(this, 8, value);
+/** @param {!Array.<string>} value */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setBeforeupdateList = function(value) {
+  jspb.Message.setField(this, 3, value || []);
 };
 
 
 /**
- * map<string, PropertyDependencies> propertyDependencies = 9;
- * @param {boolean=} opt_noLazyCreate Do not create the map if
- * empty, instead returning `undefined`
- * @return {!jspb.Map<string,!proto.pulumirpc.RegisterResourceRequest.PropertyDependencies>}
+ * @param {!string} value
+ * @param {number=} opt_index
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getPropertydependenciesMap = function(opt_noLazyCreate) {
-  return /** @type {!jspb.Map<string,!proto.pulumirpc.RegisterResourceRequest.PropertyDependencies>} */ (
-      jspb.Message.getMapField(this, 9, opt_noLazyCreate,
-      proto.pulumirpc.RegisterResourceRequest.PropertyDependencies));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addBeforeupdate = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 3, value, opt_index);
 };
 
 
-proto.pulumirpc.RegisterResourceRequest.prototype.clearPropertydependenciesMap = function() {
-  this.getPropertydependenciesMap().clear();
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearBeforeupdateList = function() {
+  this.setBeforeupdateList([]);
 };
 
 
 /**
- * optional bool deleteBeforeReplace = 10;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * repeated string afterUpdate = 4;
+ * @return {!Array.<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getDeletebeforereplace = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 10, false));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getAfterupdateList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 4));
 };
 
 
-/** @param {boolean} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setDeletebeforereplace = function(value) {
-  jspb.Message.setProto3BooleanField(this, 10, value);
+/** @param {!Array.<string>} value */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setAfterupdateList = function(value) {
+  jspb.Message.setField(this, 4, value || []);
 };
 
 
 /**
- * optional string version = 11;
- * @return {string}
+ * @param {!string} value
+ * @param {number=} opt_index
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getVersion = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 11, ""));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addAfterupdate = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 4, value, opt_index);
 };
 
 
-/** @param {string} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setVersion = function(value) {
-  jspb.Message.setProto3StringField(this, 11, value);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearAfterupdateList = function() {
+  this.setAfterupdateList([]);
 };
 
 
 /**
- * repeated string ignoreChanges = 12;
+ * repeated string beforeDelete = 5;
  * @return {!Array.<string>}
  */
-proto.pulumirpc.RegisterResourceRequest.prototype.getIgnorechangesList = function() {
-  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 12));
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getBeforedeleteList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 5));
 };
 
 
 /** @param {!Array.<string>} value */
-proto.pulumirpc.RegisterResourceRequest.prototype.setIgnorechangesList = function(value) {
-  jspb.Message.setField(this, 12, value || []);
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setBeforedeleteList = function(value) {
+  jspb.Message.setField(this, 5, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addBeforedelete = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 5, value, opt_index);
+};
+
+
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearBeforedeleteList = function() {
+  this.setBeforedeleteList([]);
+};
+
+
+/**
+ * repeated string afterDelete = 6;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.getAfterdeleteList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 6));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.setAfterdeleteList = function(value) {
+  jspb.Message.setField(this, 6, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.addAfterdelete = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 6, value, opt_index);
+};
+
+
+proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding.prototype.clearAfterdeleteList = function() {
+  this.setAfterdeleteList([]);
+};
+
+
+/**
+ * optional string type = 1;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getType = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setType = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string name = 2;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setName = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string parent = 3;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getParent = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setParent = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional bool custom = 4;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getCustom = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 4, false));
+};
+
+
+/** @param {boolean} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setCustom = function(value) {
+  jspb.Message.setProto3BooleanField(this, 4, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct object = 5;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getObject = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 5));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setObject = function(value) {
+  jspb.Message.setWrapperField(this, 5, value);
+};
+
+
+proto.pulumirpc.RegisterResourceRequest.prototype.clearObject = function() {
+  this.setObject(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.hasObject = function() {
+  return jspb.Message.getField(this, 5) != null;
+};
+
+
+/**
+ * optional bool protect = 6;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getProtect = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 6, false));
+};
+
+
+/** @param {boolean} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setProtect = function(value) {
+  jspb.Message.setProto3BooleanField(this, 6, value);
+};
+
+
+/**
+ * repeated string dependencies = 7;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getDependenciesList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 7));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setDependenciesList = function(value) {
+  jspb.Message.setField(this, 7, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.addDependencies = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 7, value, opt_index);
+};
+
+
+proto.pulumirpc.RegisterResourceRequest.prototype.clearDependenciesList = function() {
+  this.setDependenciesList([]);
+};
+
+
+/**
+ * optional string provider = 8;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getProvider = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 8, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setProvider = function(value) {
+  jspb.Message.setProto3StringField(this, 8, value);
+};
+
+
+/**
+ * map<string, PropertyDependencies> propertyDependencies = 9;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,!proto.pulumirpc.RegisterResourceRequest.PropertyDependencies>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getPropertydependenciesMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,!proto.pulumirpc.RegisterResourceRequest.PropertyDependencies>} */ (
+      jspb.Message.getMapField(this, 9, opt_noLazyCreate,
+      proto.pulumirpc.RegisterResourceRequest.PropertyDependencies));
+};
+
+
+proto.pulumirpc.RegisterResourceRequest.prototype.clearPropertydependenciesMap = function() {
+  this.getPropertydependenciesMap().clear();
+};
+
+
+/**
+ * optional bool deleteBeforeReplace = 10;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getDeletebeforereplace = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 10, false));
+};
+
+
+/** @param {boolean} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setDeletebeforereplace = function(value) {
+  jspb.Message.setProto3BooleanField(this, 10, value);
+};
+
+
+/**
+ * optional string version = 11;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getVersion = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 11, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setVersion = function(value) {
+  jspb.Message.setProto3StringField(this, 11, value);
+};
+
+
+/**
+ * repeated string ignoreChanges = 12;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getIgnorechangesList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 12));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setIgnorechangesList = function(value) {
+  jspb.Message.setField(this, 12, value || []);
 };
 
 
@@ -2073,6 +2459,681 @@ proto.pulumirpc.RegisterResourceRequest.prototype.setSupportspartialvalues = fun
 };
 
 
+/**
+ * optional ResourceHooksBinding hooks = 20;
+ * @return {?proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getHooks = function() {
+  return /** @type{?proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding, 20));
+};
+
+
+/** @param {?proto.pulumirpc.RegisterResourceRequest.ResourceHooksBinding|undefined} value */
+proto.pulumirpc.RegisterResourceRequest.prototype.setHooks = function(value) {
+  jspb.Message.setWrapperField(this, 20, value);
+};
+
+
+proto.pulumirpc.RegisterResourceRequest.prototype.clearHooks = function() {
+  this.setHooks(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.hasHooks = function() {
+  return jspb.Message.getField(this, 20) != null;
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.RegisterResourceResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.RegisterResourceResponse.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.RegisterResourceResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.RegisterResourceResponse.displayName = 'proto.pulumirpc.RegisterResourceResponse';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.RegisterResourceResponse.repeatedFields_ = [5];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RegisterResourceResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    urn: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    id: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    object: (f = msg.getObject()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    stable: jspb.Message.getFieldWithDefault(msg, 4, false),
+    stablesList: jspb.Message.getRepeatedField(msg, 5)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RegisterResourceResponse}
+ */
+proto.pulumirpc.RegisterResourceResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RegisterResourceResponse;
+  return proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RegisterResourceResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RegisterResourceResponse}
+ */
+proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setId(value);
+      break;
+    case 3:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setObject(value);
+      break;
+    case 4:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setStable(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addStables(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RegisterResourceResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RegisterResourceResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getId();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getObject();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+  f = message.getStable();
+  if (f) {
+    writer.writeBool(
+      4,
+      f
+    );
+  }
+  f = message.getStablesList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      5,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string urn = 1;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceResponse.prototype.setUrn = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string id = 2;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceResponse.prototype.setId = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct object = 3;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getObject = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 3));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.RegisterResourceResponse.prototype.setObject = function(value) {
+  jspb.Message.setWrapperField(this, 3, value);
+};
+
+
+proto.pulumirpc.RegisterResourceResponse.prototype.clearObject = function() {
+  this.setObject(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.hasObject = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+/**
+ * optional bool stable = 4;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getStable = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 4, false));
+};
+
+
+/** @param {boolean} value */
+proto.pulumirpc.RegisterResourceResponse.prototype.setStable = function(value) {
+  jspb.Message.setProto3BooleanField(this, 4, value);
+};
+
+
+/**
+ * repeated string stables = 5;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.getStablesList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 5));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.RegisterResourceResponse.prototype.setStablesList = function(value) {
+  jspb.Message.setField(this, 5, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.RegisterResourceResponse.prototype.addStables = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 5, value, opt_index);
+};
+
+
+proto.pulumirpc.RegisterResourceResponse.prototype.clearStablesList = function() {
+  this.setStablesList([]);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.RegisterResourceOutputsRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.RegisterResourceOutputsRequest.displayName = 'proto.pulumirpc.RegisterResourceOutputsRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceOutputsRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    urn: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    outputs: (f = msg.getOutputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RegisterResourceOutputsRequest}
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RegisterResourceOutputsRequest;
+  return proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RegisterResourceOutputsRequest}
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
+      break;
+    case 2:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setOutputs(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RegisterResourceOutputsRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getOutputs();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional string urn = 1;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.setUrn = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct outputs = 2;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.getOutputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 2));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.setOutputs = function(value) {
+  jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.clearOutputs = function() {
+  this.setOutputs(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.RegisterResourceOutputsRequest.prototype.hasOutputs = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.RegisterResourceHookRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.RegisterResourceHookRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.RegisterResourceHookRequest.displayName = 'proto.pulumirpc.RegisterResourceHookRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.RegisterResourceHookRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceHookRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RegisterResourceHookRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceHookRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    address: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RegisterResourceHookRequest}
+ */
+proto.pulumirpc.RegisterResourceHookRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RegisterResourceHookRequest;
+  return proto.pulumirpc.RegisterResourceHookRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RegisterResourceHookRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RegisterResourceHookRequest}
+ */
+proto.pulumirpc.RegisterResourceHookRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setAddress(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.RegisterResourceHookRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RegisterResourceHookRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RegisterResourceHookRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceHookRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getAddress();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string name = 1;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceHookRequest.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceHookRequest.prototype.setName = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string address = 2;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceHookRequest.prototype.getAddress = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RegisterResourceHookRequest.prototype.setAddress = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
 
 /**
  * Generated by JsPbCodeGenerator.
@@ -2084,20 +3145,13 @@ proto.pulumirpc.RegisterResourceRequest.prototype.setSupportspartialvalues = fun
  * @extends {jspb.Message}
  * @constructor
  */
-proto.pulumirpc.RegisterResourceResponse = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.RegisterResourceResponse.repeatedFields_, null);
+proto.pulumirpc.RunResourceHookRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.pulumirpc.RegisterResourceResponse, jspb.Message);
+goog.inherits(proto.pulumirpc.RunResourceHookRequest, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.pulumirpc.RegisterResourceResponse.displayName = 'proto.pulumirpc.RegisterResourceResponse';
+  proto.pulumirpc.RunResourceHookRequest.displayName = 'proto.pulumirpc.RunResourceHookRequest';
 }
-/**
- * List of repeated fields within this message type.
- * @private {!Array<number>}
- * @const
- */
-proto.pulumirpc.RegisterResourceResponse.repeatedFields_ = [5];
-
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -2111,8 +3165,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.RegisterResourceResponse.toObject(opt_includeInstance, this);
+proto.pulumirpc.RunResourceHookRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RunResourceHookRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -2121,17 +3175,19 @@ proto.pulumirpc.RegisterResourceResponse.prototype.toObject = function(opt_inclu
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.RegisterResourceResponse} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.RunResourceHookRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceResponse.toObject = function(includeInstance, msg) {
+proto.pulumirpc.RunResourceHookRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    urn: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    id: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    object: (f = msg.getObject()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
-    stable: jspb.Message.getFieldWithDefault(msg, 4, false),
-    stablesList: jspb.Message.getRepeatedField(msg, 5)
+    hook: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    urn: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    id: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    newinputs: (f = msg.getNewinputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    oldinputs: (f = msg.getOldinputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    newoutputs: (f = msg.getNewoutputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    oldoutputs: (f = msg.getOldoutputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -2145,23 +3201,23 @@ proto.pulumirpc.RegisterResourceResponse.toObject = function(includeInstance, ms
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.RegisterResourceResponse}
+ * @return {!proto.pulumirpc.RunResourceHookRequest}
  */
-proto.pulumirpc.RegisterResourceResponse.deserializeBinary = function(bytes) {
+proto.pulumirpc.RunResourceHookRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.RegisterResourceResponse;
-  return proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.RunResourceHookRequest;
+  return proto.pulumirpc.RunResourceHookRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.pulumirpc.RegisterResourceResponse} msg The message object to deserialize into.
+ * @param {!proto.pulumirpc.RunResourceHookRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.RegisterResourceResponse}
+ * @return {!proto.pulumirpc.RunResourceHookRequest}
  */
-proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.RunResourceHookRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -2170,24 +3226,35 @@ proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader = function(
     switch (field) {
     case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.setUrn(value);
+      msg.setHook(value);
       break;
     case 2:
       var value = /** @type {string} */ (reader.readString());
-      msg.setId(value);
+      msg.setUrn(value);
       break;
     case 3:
-      var value = new google_protobuf_struct_pb.Struct;
-      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
-      msg.setObject(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.setId(value);
       break;
     case 4:
-      var value = /** @type {boolean} */ (reader.readBool());
-      msg.setStable(value);
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setNewinputs(value);
       break;
     case 5:
-      var value = /** @type {string} */ (reader.readString());
-      msg.addStables(value);
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setOldinputs(value);
+      break;
+    case 6:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setNewoutputs(value);
+      break;
+    case 7:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setOldoutputs(value);
       break;
     default:
       reader.skipField();
@@ -2202,9 +3269,9 @@ proto.pulumirpc.RegisterResourceResponse.deserializeBinaryFromReader = function(
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.serializeBinary = function() {
+proto.pulumirpc.RunResourceHookRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.RegisterResourceResponse.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.RunResourceHookRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -2212,99 +3279,131 @@ proto.pulumirpc.RegisterResourceResponse.prototype.serializeBinary = function()
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.RegisterResourceResponse} message
+ * @param {!proto.pulumirpc.RunResourceHookRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceResponse.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.RunResourceHookRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getUrn();
+  f = message.getHook();
   if (f.length > 0) {
     writer.writeString(
       1,
       f
     );
   }
-  f = message.getId();
+  f = message.getUrn();
   if (f.length > 0) {
     writer.writeString(
       2,
       f
     );
   }
-  f = message.getObject();
+  f = message.getId();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getNewinputs();
   if (f != null) {
     writer.writeMessage(
-      3,
+      4,
       f,
       google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
-  f = message.getStable();
-  if (f) {
-    writer.writeBool(
-      4,
-      f
+  f = message.getOldinputs();
+  if (f != null) {
+    writer.writeMessage(
+      5,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
-  f = message.getStablesList();
-  if (f.length > 0) {
-    writer.writeRepeatedString(
-      5,
-      f
+  f = message.getNewoutputs();
+  if (f != null) {
+    writer.writeMessage(
+      6,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+  f = message.getOldoutputs();
+  if (f != null) {
+    writer.writeMessage(
+      7,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional string urn = 1;
+ * optional string hook = 1;
  * @return {string}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getUrn = function() {
+proto.pulumirpc.RunResourceHookRequest.prototype.getHook = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /** @param {string} value */
-proto.pulumirpc.RegisterResourceResponse.prototype.setUrn = function(value) {
+proto.pulumirpc.RunResourceHookRequest.prototype.setHook = function(value) {
   jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
 /**
- * optional string id = 2;
+ * optional string urn = 2;
  * @return {string}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getId = function() {
+proto.pulumirpc.RunResourceHookRequest.prototype.getUrn = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 
 /** @param {string} value */
-proto.pulumirpc.RegisterResourceResponse.prototype.setId = function(value) {
+proto.pulumirpc.RunResourceHookRequest.prototype.setUrn = function(value) {
   jspb.Message.setProto3StringField(this, 2, value);
 };
 
 
 /**
- * optional google.protobuf.Struct object = 3;
+ * optional string id = 3;
+ * @return {string}
+ */
+proto.pulumirpc.RunResourceHookRequest.prototype.getId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.RunResourceHookRequest.prototype.setId = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct newInputs = 4;
  * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getObject = function() {
+proto.pulumirpc.RunResourceHookRequest.prototype.getNewinputs = function() {
   return /** @type{?proto.google.protobuf.Struct} */ (
-    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 3));
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 4));
 };
 
 
 /** @param {?proto.google.protobuf.Struct|undefined} value */
-proto.pulumirpc.RegisterResourceResponse.prototype.setObject = function(value) {
-  jspb.Message.setWrapperField(this, 3, value);
+proto.pulumirpc.RunResourceHookRequest.prototype.setNewinputs = function(value) {
+  jspb.Message.setWrapperField(this, 4, value);
 };
 
 
-proto.pulumirpc.RegisterResourceResponse.prototype.clearObject = function() {
-  this.setObject(undefined);
+proto.pulumirpc.RunResourceHookRequest.prototype.clearNewinputs = function() {
+  this.setNewinputs(undefined);
 };
 
 
@@ -2312,54 +3411,98 @@ proto.pulumirpc.RegisterResourceResponse.prototype.clearObject = function() {
  * Returns whether this field is set.
  * @return {!boolean}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.hasObject = function() {
-  return jspb.Message.getField(this, 3) != null;
+proto.pulumirpc.RunResourceHookRequest.prototype.hasNewinputs = function() {
+  return jspb.Message.getField(this, 4) != null;
 };
 
 
 /**
- * optional bool stable = 4;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * optional google.protobuf.Struct oldInputs = 5;
+ * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getStable = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 4, false));
+proto.pulumirpc.RunResourceHookRequest.prototype.getOldinputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 5));
 };
 
 
-/** @param {boolean} value */
-proto.pulumirpc.RegisterResourceResponse.prototype.setStable = function(value) {
-  jspb.Message.setProto3BooleanField(this, 4, value);
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.RunResourceHookRequest.prototype.setOldinputs = function(value) {
+  jspb.Message.setWrapperField(this, 5, value);
+};
+
+
+proto.pulumirpc.RunResourceHookRequest.prototype.clearOldinputs = function() {
+  this.setOldinputs(undefined);
 };
 
 
 /**
- * repeated string stables = 5;
- * @return {!Array.<string>}
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.getStablesList = function() {
-  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 5));
+proto.pulumirpc.RunResourceHookRequest.prototype.hasOldinputs = function() {
+  return jspb.Message.getField(this, 5) != null;
 };
 
 
-/** @param {!Array.<string>} value */
-proto.pulumirpc.RegisterResourceResponse.prototype.setStablesList = function(value) {
-  jspb.Message.setField(this, 5, value || []);
+/**
+ * optional google.protobuf.Struct newOutputs = 6;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.RunResourceHookRequest.prototype.getNewoutputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 6));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.RunResourceHookRequest.prototype.setNewoutputs = function(value) {
+  jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+proto.pulumirpc.RunResourceHookRequest.prototype.clearNewoutputs = function() {
+  this.setNewoutputs(undefined);
 };
 
 
 /**
- * @param {!string} value
- * @param {number=} opt_index
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.pulumirpc.RegisterResourceResponse.prototype.addStables = function(value, opt_index) {
-  jspb.Message.addToRepeatedField(this, 5, value, opt_index);
+proto.pulumirpc.RunResourceHookRequest.prototype.hasNewoutputs = function() {
+  return jspb.Message.getField(this, 6) != null;
 };
 
 
-proto.pulumirpc.RegisterResourceResponse.prototype.clearStablesList = function() {
-  this.setStablesList([]);
+/**
+ * optional google.protobuf.Struct oldOutputs = 7;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.RunResourceHookRequest.prototype.getOldoutputs = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 7));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.RunResourceHookRequest.prototype.setOldoutputs = function(value) {
+  jspb.Message.setWrapperField(this, 7, value);
+};
+
+
+proto.pulumirpc.RunResourceHookRequest.prototype.clearOldoutputs = function() {
+  this.setOldoutputs(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.RunResourceHookRequest.prototype.hasOldoutputs = function() {
+  return jspb.Message.getField(this, 7) != null;
 };
 
 
@@ -2374,12 +3517,12 @@ proto.pulumirpc.RegisterResourceResponse.prototype.clearStablesList = function()
  * @extends {jspb.Message}
  * @constructor
  */
-proto.pulumirpc.RegisterResourceOutputsRequest = function(opt_data) {
+proto.pulumirpc.RunResourceHookResponse = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.pulumirpc.RegisterResourceOutputsRequest, jspb.Message);
+goog.inherits(proto.pulumirpc.RunResourceHookResponse, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.pulumirpc.RegisterResourceOutputsRequest.displayName = 'proto.pulumirpc.RegisterResourceOutputsRequest';
+  proto.pulumirpc.RunResourceHookResponse.displayName = 'proto.pulumirpc.RunResourceHookResponse';
 }
 
 
@@ -2394,8 +3537,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.RegisterResourceOutputsRequest.toObject(opt_includeInstance, this);
+proto.pulumirpc.RunResourceHookResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RunResourceHookResponse.toObject(opt_includeInstance, this);
 };
 
 
@@ -2404,14 +3547,13 @@ proto.pulumirpc.RegisterResourceOutputsRequest.prototype.toObject = function(opt
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.RunResourceHookResponse} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.toObject = function(includeInstance, msg) {
+proto.pulumirpc.RunResourceHookResponse.toObject = function(includeInstance, msg) {
   var f, obj = {
-    urn: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    outputs: (f = msg.getOutputs()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
+    error: jspb.Message.getFieldWithDefault(msg, 1, "")
   };
 
   if (includeInstance) {
@@ -2425,23 +3567,23 @@ proto.pulumirpc.RegisterResourceOutputsRequest.toObject = function(includeInstan
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.RegisterResourceOutputsRequest}
+ * @return {!proto.pulumirpc.RunResourceHookResponse}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinary = function(bytes) {
+proto.pulumirpc.RunResourceHookResponse.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.RegisterResourceOutputsRequest;
-  return proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.RunResourceHookResponse;
+  return proto.pulumirpc.RunResourceHookResponse.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} msg The message object to deserialize into.
+ * @param {!proto.pulumirpc.RunResourceHookResponse} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.RegisterResourceOutputsRequest}
+ * @return {!proto.pulumirpc.RunResourceHookResponse}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.RunResourceHookResponse.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -2450,12 +3592,7 @@ proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader = fun
     switch (field) {
     case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.setUrn(value);
-      break;
-    case 2:
-      var value = new google_protobuf_struct_pb.Struct;
-      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
-      msg.setOutputs(value);
+      msg.setError(value);
       break;
     default:
       reader.skipField();
@@ -2470,9 +3607,9 @@ proto.pulumirpc.RegisterResourceOutputsRequest.deserializeBinaryFromReader = fun
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.serializeBinary = function() {
+proto.pulumirpc.RunResourceHookResponse.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.RegisterResourceOutputsRequest.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.RunResourceHookResponse.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -2480,73 +3617,35 @@ proto.pulumirpc.RegisterResourceOutputsRequest.prototype.serializeBinary = funct
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.RegisterResourceOutputsRequest} message
+ * @param {!proto.pulumirpc.RunResourceHookResponse} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.RunResourceHookResponse.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getUrn();
+  f = message.getError();
   if (f.length > 0) {
     writer.writeString(
       1,
       f
     );
   }
-  f = message.getOutputs();
-  if (f != null) {
-    writer.writeMessage(
-      2,
-      f,
-      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
-    );
-  }
 };
 
 
 /**
- * optional string urn = 1;
+ * optional string error = 1;
  * @return {string}
  */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.getUrn = function() {
+proto.pulumirpc.RunResourceHookResponse.prototype.getError = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /** @param {string} value */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.setUrn = function(value) {
+proto.pulumirpc.RunResourceHookResponse.prototype.setError = function(value) {
   jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
-/**
- * optional google.protobuf.Struct outputs = 2;
- * @return {?proto.google.protobuf.Struct}
- */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.getOutputs = function() {
-  return /** @type{?proto.google.protobuf.Struct} */ (
-    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 2));
-};
-
-
-/** @param {?proto.google.protobuf.Struct|undefined} value */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.setOutputs = function(value) {
-  jspb.Message.setWrapperField(this, 2, value);
-};
-
-
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.clearOutputs = function() {
-  this.setOutputs(undefined);
-};
-
-
-/**
- * Returns whether this field is set.
- * @return {!boolean}
- */
-proto.pulumirpc.RegisterResourceOutputsRequest.prototype.hasOutputs = function() {
-  return jspb.Message.getField(this, 2) != null;
-};
-
-
 goog.object.extend(exports, proto.pulumirpc);
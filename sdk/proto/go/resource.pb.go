@@ -38,7 +38,7 @@ func (m *SupportsFeatureRequest) Reset()         { *m = SupportsFeatureRequest{}
 func (m *SupportsFeatureRequest) String() string { return proto.CompactTextString(m) }
 func (*SupportsFeatureRequest) ProtoMessage()    {}
 func (*SupportsFeatureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_beee7c3faa8096b0, []int{0}
+	return fileDescriptor_resource_097308f7fb469bb5, []int{0}
 }
 func (m *SupportsFeatureRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SupportsFeatureRequest.Unmarshal(m, b)
@@ -76,7 +76,7 @@ func (m *SupportsFeatureResponse) Reset()         { *m = SupportsFeatureResponse
 func (m *SupportsFeatureResponse) String() string { return proto.CompactTextString(m) }
 func (*SupportsFeatureResponse) ProtoMessage()    {}
 func (*SupportsFeatureResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_beee7c3faa8096b0, []int{1}
+	return fileDescriptor_resource_097308f7fb469bb5, []int{1}
 }
 func (m *SupportsFeatureResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SupportsFeatureResponse.Unmarshal(m, b)
@@ -125,7 +125,7 @@ func (m *ReadResourceRequest) Reset()         { *m = ReadResourceRequest{} }
 func (m *ReadResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceRequest) ProtoMessage()    {}
 func (*ReadResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_beee7c3faa8096b0, []int{2}
+	return fileDescriptor_resource_097308f7fb469bb5, []int{2}
 }
 func (m *ReadResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceRequest.Unmarshal(m, b)
@@ -235,7 +235,7 @@ func (m *ReadResourceResponse) Reset()         { *m = ReadResourceResponse{} }
 func (m *ReadResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceResponse) ProtoMessage()    {}
 func (*ReadResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_beee7c3faa8096b0, []int{3}
+	return fileDescriptor_resource_097308f7fb469bb5, []int{3}
 }
 func (m *ReadResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceResponse.Unmarshal(m, b)
@@ -290,6 +290,7 @@ type RegisterResourceRequest struct {
 	CustomTimeouts             *RegisterResourceRequest_CustomTimeouts                  `protobuf:"bytes,17,opt,name=customTimeouts" json:"customTimeouts,omitempty"`
 	DeleteBeforeReplaceDefined bool                                                     `protobuf:"varint,18,opt,name=deleteBeforeReplaceDefined" json:"deleteBeforeReplaceDefined,omitempty"`
 	SupportsPartialValues      bool                                                     `protobuf:"varint,19,opt,name=supportsPartialValues" json:"supportsPartialValues,omitempty"`
+	Hooks                      *RegisterResourceRequest_ResourceHooksBinding            `protobuf:"bytes,20,opt,name=hooks" json:"hooks,omitempty"`
 	XXX_NoUnkeyedLiteral       struct{}                                                 `json:"-"`
 	XXX_unrecognized           []byte                                                   `json:"-"`
 	XXX_sizecache              int32                                                    `json:"-"`
@@ -299,7 +300,7 @@ func (m *RegisterResourceRequest) Reset()         { *m = RegisterResourceRequest
 func (m *RegisterResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceRequest) ProtoMessage()    {}
 func (*RegisterResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_beee7c3faa8096b0, []int{4}
+	return fileDescriptor_resource_097308f7fb469bb5, []int{4}
 }
 func (m *RegisterResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest.Unmarshal(m, b)
@@ -452,6 +453,13 @@ func (m *RegisterResourceRequest) GetSupportsPartialValues() bool {
 	return false
 }
 
+func (m *RegisterResourceRequest) GetHooks() *RegisterResourceRequest_ResourceHooksBinding {
+	if m != nil {
+		return m.Hooks
+	}
+	return nil
+}
+
 // PropertyDependencies describes the resources that a particular property depends on.
 type RegisterResourceRequest_PropertyDependencies struct {
 	Urns                 []string `protobuf:"bytes,1,rep,name=urns" json:"urns,omitempty"`
@@ -468,7 +476,7 @@ func (m *RegisterResourceRequest_PropertyDependencies) String() string {
 }
 func (*RegisterResourceRequest_PropertyDependencies) ProtoMessage() {}
 func (*RegisterResourceRequest_PropertyDependencies) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_beee7c3faa8096b0, []int{4, 0}
+	return fileDescriptor_resource_097308f7fb469bb5, []int{4, 0}
 }
 func (m *RegisterResourceRequest_PropertyDependencies) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest_PropertyDependencies.Unmarshal(m, b)
@@ -511,7 +519,7 @@ func (m *RegisterResourceRequest_CustomTimeouts) Reset() {
 func (m *RegisterResourceRequest_CustomTimeouts) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceRequest_CustomTimeouts) ProtoMessage()    {}
 func (*RegisterResourceRequest_CustomTimeouts) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_beee7c3faa8096b0, []int{4, 1}
+	return fileDescriptor_resource_097308f7fb469bb5, []int{4, 1}
 }
 func (m *RegisterResourceRequest_CustomTimeouts) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Unmarshal(m, b)
@@ -552,6 +560,89 @@ func (m *RegisterResourceRequest_CustomTimeouts) GetDelete() string {
 	return ""
 }
 
+// ResourceHooksBinding names the resource hooks, registered by RegisterResourceHook, to run around each operation.
+type RegisterResourceRequest_ResourceHooksBinding struct {
+	BeforeCreate         []string `protobuf:"bytes,1,rep,name=beforeCreate" json:"beforeCreate,omitempty"`
+	AfterCreate          []string `protobuf:"bytes,2,rep,name=afterCreate" json:"afterCreate,omitempty"`
+	BeforeUpdate         []string `protobuf:"bytes,3,rep,name=beforeUpdate" json:"beforeUpdate,omitempty"`
+	AfterUpdate          []string `protobuf:"bytes,4,rep,name=afterUpdate" json:"afterUpdate,omitempty"`
+	BeforeDelete         []string `protobuf:"bytes,5,rep,name=beforeDelete" json:"beforeDelete,omitempty"`
+	AfterDelete          []string `protobuf:"bytes,6,rep,name=afterDelete" json:"afterDelete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterResourceRequest_ResourceHooksBinding) Reset() {
+	*m = RegisterResourceRequest_ResourceHooksBinding{}
+}
+func (m *RegisterResourceRequest_ResourceHooksBinding) String() string {
+	return proto.CompactTextString(m)
+}
+func (*RegisterResourceRequest_ResourceHooksBinding) ProtoMessage() {}
+func (*RegisterResourceRequest_ResourceHooksBinding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_097308f7fb469bb5, []int{4, 2}
+}
+func (m *RegisterResourceRequest_ResourceHooksBinding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterResourceRequest_ResourceHooksBinding.Unmarshal(m, b)
+}
+func (m *RegisterResourceRequest_ResourceHooksBinding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterResourceRequest_ResourceHooksBinding.Marshal(b, m, deterministic)
+}
+func (dst *RegisterResourceRequest_ResourceHooksBinding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterResourceRequest_ResourceHooksBinding.Merge(dst, src)
+}
+func (m *RegisterResourceRequest_ResourceHooksBinding) XXX_Size() int {
+	return xxx_messageInfo_RegisterResourceRequest_ResourceHooksBinding.Size(m)
+}
+func (m *RegisterResourceRequest_ResourceHooksBinding) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterResourceRequest_ResourceHooksBinding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterResourceRequest_ResourceHooksBinding proto.InternalMessageInfo
+
+func (m *RegisterResourceRequest_ResourceHooksBinding) GetBeforeCreate() []string {
+	if m != nil {
+		return m.BeforeCreate
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest_ResourceHooksBinding) GetAfterCreate() []string {
+	if m != nil {
+		return m.AfterCreate
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest_ResourceHooksBinding) GetBeforeUpdate() []string {
+	if m != nil {
+		return m.BeforeUpdate
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest_ResourceHooksBinding) GetAfterUpdate() []string {
+	if m != nil {
+		return m.AfterUpdate
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest_ResourceHooksBinding) GetBeforeDelete() []string {
+	if m != nil {
+		return m.BeforeDelete
+	}
+	return nil
+}
+
+func (m *RegisterResourceRequest_ResourceHooksBinding) GetAfterDelete() []string {
+	if m != nil {
+		return m.AfterDelete
+	}
+	return nil
+}
+
 // RegisterResourceResponse is returned by the engine after a resource has finished being initialized.  It includes the
 // auto-assigned URN, the provider-assigned ID, and any other properties initialized by the engine.
 type RegisterResourceResponse struct {
@@ -569,7 +660,7 @@ func (m *RegisterResourceResponse) Reset()         { *m = RegisterResourceRespon
 func (m *RegisterResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceResponse) ProtoMessage()    {}
 func (*RegisterResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_beee7c3faa8096b0, []int{5}
+	return fileDescriptor_resource_097308f7fb469bb5, []int{5}
 }
 func (m *RegisterResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceResponse.Unmarshal(m, b)
@@ -637,7 +728,7 @@ func (m *RegisterResourceOutputsRequest) Reset()         { *m = RegisterResource
 func (m *RegisterResourceOutputsRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceOutputsRequest) ProtoMessage()    {}
 func (*RegisterResourceOutputsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_beee7c3faa8096b0, []int{6}
+	return fileDescriptor_resource_097308f7fb469bb5, []int{6}
 }
 func (m *RegisterResourceOutputsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceOutputsRequest.Unmarshal(m, b)
@@ -671,6 +762,180 @@ func (m *RegisterResourceOutputsRequest) GetOutputs() *_struct.Struct {
 	return nil
 }
 
+// RegisterResourceHookRequest registers a named resource hook that the engine may later run, by calling RunHook on the
+// ResourceHooks service listening at the given address.
+type RegisterResourceHookRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterResourceHookRequest) Reset()         { *m = RegisterResourceHookRequest{} }
+func (m *RegisterResourceHookRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterResourceHookRequest) ProtoMessage()    {}
+func (*RegisterResourceHookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_097308f7fb469bb5, []int{7}
+}
+func (m *RegisterResourceHookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterResourceHookRequest.Unmarshal(m, b)
+}
+func (m *RegisterResourceHookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterResourceHookRequest.Marshal(b, m, deterministic)
+}
+func (dst *RegisterResourceHookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterResourceHookRequest.Merge(dst, src)
+}
+func (m *RegisterResourceHookRequest) XXX_Size() int {
+	return xxx_messageInfo_RegisterResourceHookRequest.Size(m)
+}
+func (m *RegisterResourceHookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterResourceHookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterResourceHookRequest proto.InternalMessageInfo
+
+func (m *RegisterResourceHookRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RegisterResourceHookRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+// RunResourceHookRequest asks a program to run one of its resource hooks.
+type RunResourceHookRequest struct {
+	Hook                 string          `protobuf:"bytes,1,opt,name=hook" json:"hook,omitempty"`
+	Urn                  string          `protobuf:"bytes,2,opt,name=urn" json:"urn,omitempty"`
+	Id                   string          `protobuf:"bytes,3,opt,name=id" json:"id,omitempty"`
+	NewInputs            *_struct.Struct `protobuf:"bytes,4,opt,name=newInputs" json:"newInputs,omitempty"`
+	OldInputs            *_struct.Struct `protobuf:"bytes,5,opt,name=oldInputs" json:"oldInputs,omitempty"`
+	NewOutputs           *_struct.Struct `protobuf:"bytes,6,opt,name=newOutputs" json:"newOutputs,omitempty"`
+	OldOutputs           *_struct.Struct `protobuf:"bytes,7,opt,name=oldOutputs" json:"oldOutputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RunResourceHookRequest) Reset()         { *m = RunResourceHookRequest{} }
+func (m *RunResourceHookRequest) String() string { return proto.CompactTextString(m) }
+func (*RunResourceHookRequest) ProtoMessage()    {}
+func (*RunResourceHookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_097308f7fb469bb5, []int{8}
+}
+func (m *RunResourceHookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunResourceHookRequest.Unmarshal(m, b)
+}
+func (m *RunResourceHookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunResourceHookRequest.Marshal(b, m, deterministic)
+}
+func (dst *RunResourceHookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunResourceHookRequest.Merge(dst, src)
+}
+func (m *RunResourceHookRequest) XXX_Size() int {
+	return xxx_messageInfo_RunResourceHookRequest.Size(m)
+}
+func (m *RunResourceHookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunResourceHookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunResourceHookRequest proto.InternalMessageInfo
+
+func (m *RunResourceHookRequest) GetHook() string {
+	if m != nil {
+		return m.Hook
+	}
+	return ""
+}
+
+func (m *RunResourceHookRequest) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *RunResourceHookRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RunResourceHookRequest) GetNewInputs() *_struct.Struct {
+	if m != nil {
+		return m.NewInputs
+	}
+	return nil
+}
+
+func (m *RunResourceHookRequest) GetOldInputs() *_struct.Struct {
+	if m != nil {
+		return m.OldInputs
+	}
+	return nil
+}
+
+func (m *RunResourceHookRequest) GetNewOutputs() *_struct.Struct {
+	if m != nil {
+		return m.NewOutputs
+	}
+	return nil
+}
+
+func (m *RunResourceHookRequest) GetOldOutputs() *_struct.Struct {
+	if m != nil {
+		return m.OldOutputs
+	}
+	return nil
+}
+
+// RunResourceHookResponse is the result of running a resource hook.
+type RunResourceHookResponse struct {
+	Error                string   `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunResourceHookResponse) Reset()         { *m = RunResourceHookResponse{} }
+func (m *RunResourceHookResponse) String() string { return proto.CompactTextString(m) }
+func (*RunResourceHookResponse) ProtoMessage()    {}
+func (*RunResourceHookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_097308f7fb469bb5, []int{9}
+}
+func (m *RunResourceHookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunResourceHookResponse.Unmarshal(m, b)
+}
+func (m *RunResourceHookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunResourceHookResponse.Marshal(b, m, deterministic)
+}
+func (dst *RunResourceHookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunResourceHookResponse.Merge(dst, src)
+}
+func (m *RunResourceHookResponse) XXX_Size() int {
+	return xxx_messageInfo_RunResourceHookResponse.Size(m)
+}
+func (m *RunResourceHookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunResourceHookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunResourceHookResponse proto.InternalMessageInfo
+
+func (m *RunResourceHookResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*SupportsFeatureRequest)(nil), "pulumirpc.SupportsFeatureRequest")
 	proto.RegisterType((*SupportsFeatureResponse)(nil), "pulumirpc.SupportsFeatureResponse")
@@ -680,8 +945,12 @@ func init() {
 	proto.RegisterMapType((map[string]*RegisterResourceRequest_PropertyDependencies)(nil), "pulumirpc.RegisterResourceRequest.PropertyDependenciesEntry")
 	proto.RegisterType((*RegisterResourceRequest_PropertyDependencies)(nil), "pulumirpc.RegisterResourceRequest.PropertyDependencies")
 	proto.RegisterType((*RegisterResourceRequest_CustomTimeouts)(nil), "pulumirpc.RegisterResourceRequest.CustomTimeouts")
+	proto.RegisterType((*RegisterResourceRequest_ResourceHooksBinding)(nil), "pulumirpc.RegisterResourceRequest.ResourceHooksBinding")
 	proto.RegisterType((*RegisterResourceResponse)(nil), "pulumirpc.RegisterResourceResponse")
 	proto.RegisterType((*RegisterResourceOutputsRequest)(nil), "pulumirpc.RegisterResourceOutputsRequest")
+	proto.RegisterType((*RegisterResourceHookRequest)(nil), "pulumirpc.RegisterResourceHookRequest")
+	proto.RegisterType((*RunResourceHookRequest)(nil), "pulumirpc.RunResourceHookRequest")
+	proto.RegisterType((*RunResourceHookResponse)(nil), "pulumirpc.RunResourceHookResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -701,6 +970,8 @@ type ResourceMonitorClient interface {
 	ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResponse, error)
 	RegisterResource(ctx context.Context, in *RegisterResourceRequest, opts ...grpc.CallOption) (*RegisterResourceResponse, error)
 	RegisterResourceOutputs(ctx context.Context, in *RegisterResourceOutputsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RegisterResourceHook(ctx context.Context, in *RegisterResourceHookRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SignalAndWaitForShutdown(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type resourceMonitorClient struct {
@@ -788,6 +1059,24 @@ func (c *resourceMonitorClient) RegisterResourceOutputs(ctx context.Context, in
 	return out, nil
 }
 
+func (c *resourceMonitorClient) RegisterResourceHook(ctx context.Context, in *RegisterResourceHookRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := grpc.Invoke(ctx, "/pulumirpc.ResourceMonitor/RegisterResourceHook", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceMonitorClient) SignalAndWaitForShutdown(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := grpc.Invoke(ctx, "/pulumirpc.ResourceMonitor/SignalAndWaitForShutdown", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ResourceMonitor service
 
 type ResourceMonitorServer interface {
@@ -797,6 +1086,8 @@ type ResourceMonitorServer interface {
 	ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResponse, error)
 	RegisterResource(context.Context, *RegisterResourceRequest) (*RegisterResourceResponse, error)
 	RegisterResourceOutputs(context.Context, *RegisterResourceOutputsRequest) (*empty.Empty, error)
+	RegisterResourceHook(context.Context, *RegisterResourceHookRequest) (*empty.Empty, error)
+	SignalAndWaitForShutdown(context.Context, *empty.Empty) (*empty.Empty, error)
 }
 
 func RegisterResourceMonitorServer(s *grpc.Server, srv ResourceMonitorServer) {
@@ -914,6 +1205,42 @@ func _ResourceMonitor_RegisterResourceOutputs_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceMonitor_RegisterResourceHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterResourceHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceMonitorServer).RegisterResourceHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceMonitor/RegisterResourceHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceMonitorServer).RegisterResourceHook(ctx, req.(*RegisterResourceHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceMonitor_SignalAndWaitForShutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceMonitorServer).SignalAndWaitForShutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceMonitor/SignalAndWaitForShutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceMonitorServer).SignalAndWaitForShutdown(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ResourceMonitor_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pulumirpc.ResourceMonitor",
 	HandlerType: (*ResourceMonitorServer)(nil),
@@ -938,6 +1265,14 @@ var _ResourceMonitor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterResourceOutputs",
 			Handler:    _ResourceMonitor_RegisterResourceOutputs_Handler,
 		},
+		{
+			MethodName: "RegisterResourceHook",
+			Handler:    _ResourceMonitor_RegisterResourceHook_Handler,
+		},
+		{
+			MethodName: "SignalAndWaitForShutdown",
+			Handler:    _ResourceMonitor_SignalAndWaitForShutdown_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -949,64 +1284,137 @@ var _ResourceMonitor_serviceDesc = grpc.ServiceDesc{
 	Metadata: "resource.proto",
 }
 
-func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_beee7c3faa8096b0) }
-
-var fileDescriptor_resource_beee7c3faa8096b0 = []byte{
-	// 882 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x56, 0x51, 0x6f, 0x23, 0x35,
-	0x10, 0xbe, 0x4d, 0x7a, 0x69, 0x32, 0xed, 0xa5, 0xc5, 0x2d, 0xa9, 0x6f, 0x41, 0xa5, 0x2c, 0x3c,
-	0x04, 0x1e, 0xd2, 0xbb, 0x82, 0x74, 0x07, 0x42, 0x20, 0x71, 0x77, 0xa0, 0x7b, 0x38, 0x71, 0x6c,
-	0x11, 0x02, 0x24, 0x90, 0xdc, 0xdd, 0x69, 0x6e, 0xe9, 0x66, 0x6d, 0x6c, 0x6f, 0xa5, 0xbc, 0xf1,
-	0xca, 0xaf, 0xe0, 0x2f, 0xf1, 0x5b, 0xf8, 0x05, 0xc8, 0x5e, 0x3b, 0x64, 0xb3, 0x9b, 0x36, 0x70,
-	0x6f, 0x9e, 0x6f, 0xc6, 0xb3, 0xf6, 0x37, 0xdf, 0x8c, 0x17, 0x86, 0x12, 0x15, 0x2f, 0x65, 0x82,
-	0x13, 0x21, 0xb9, 0xe6, 0x64, 0x20, 0xca, 0xbc, 0x9c, 0x65, 0x52, 0x24, 0xe1, 0x5b, 0x53, 0xce,
-	0xa7, 0x39, 0x9e, 0x5a, 0xc7, 0x45, 0x79, 0x79, 0x8a, 0x33, 0xa1, 0xe7, 0x55, 0x5c, 0xf8, 0xf6,
-	0xaa, 0x53, 0x69, 0x59, 0x26, 0xda, 0x79, 0x87, 0x42, 0xf2, 0xeb, 0x2c, 0x45, 0x59, 0xd9, 0xd1,
-	0x18, 0x46, 0xe7, 0xa5, 0x10, 0x5c, 0x6a, 0xf5, 0x15, 0x32, 0x5d, 0x4a, 0x8c, 0xf1, 0xb7, 0x12,
-	0x95, 0x26, 0x43, 0xe8, 0x64, 0x29, 0x0d, 0x4e, 0x82, 0xf1, 0x20, 0xee, 0x64, 0x69, 0xf4, 0x09,
-	0x1c, 0x35, 0x22, 0x95, 0xe0, 0x85, 0x42, 0x72, 0x0c, 0xf0, 0x8a, 0x29, 0xe7, 0xb5, 0x5b, 0xfa,
-	0xf1, 0x12, 0x12, 0xfd, 0xdd, 0x81, 0x83, 0x18, 0x59, 0x1a, 0xbb, 0x1b, 0xad, 0xf9, 0x04, 0x21,
-	0xb0, 0xa5, 0xe7, 0x02, 0x69, 0xc7, 0x22, 0x76, 0x6d, 0xb0, 0x82, 0xcd, 0x90, 0x76, 0x2b, 0xcc,
-	0xac, 0xc9, 0x08, 0x7a, 0x82, 0x49, 0x2c, 0x34, 0xdd, 0xb2, 0xa8, 0xb3, 0xc8, 0x23, 0x00, 0x21,
-	0xb9, 0x40, 0xa9, 0x33, 0x54, 0xf4, 0xee, 0x49, 0x30, 0xde, 0x39, 0x3b, 0x9a, 0x54, 0x7c, 0x4c,
-	0x3c, 0x1f, 0x93, 0x73, 0xcb, 0x47, 0xbc, 0x14, 0x4a, 0x22, 0xd8, 0x4d, 0x51, 0x60, 0x91, 0x62,
-	0x91, 0x98, 0xad, 0xbd, 0x93, 0xee, 0x78, 0x10, 0xd7, 0x30, 0x12, 0x42, 0xdf, 0x73, 0x47, 0xb7,
-	0xed, 0x67, 0x17, 0x36, 0xa1, 0xb0, 0x7d, 0x8d, 0x52, 0x65, 0xbc, 0xa0, 0x7d, 0xeb, 0xf2, 0x26,
-	0x79, 0x1f, 0xee, 0xb1, 0x24, 0x41, 0xa1, 0xcf, 0x31, 0x91, 0xa8, 0x15, 0x1d, 0x58, 0x76, 0xea,
-	0x20, 0x79, 0x0c, 0x47, 0x2c, 0x4d, 0x33, 0x9d, 0xf1, 0x82, 0xe5, 0x15, 0xf8, 0x4d, 0xa9, 0x45,
-	0xa9, 0x15, 0x05, 0x7b, 0x94, 0x75, 0x6e, 0xf3, 0x65, 0x96, 0x67, 0x4c, 0xa1, 0xa2, 0x3b, 0x36,
-	0xd2, 0x9b, 0x11, 0x83, 0xc3, 0x3a, 0xe7, 0xae, 0x58, 0xfb, 0xd0, 0x2d, 0x65, 0xe1, 0x58, 0x37,
-	0xcb, 0x15, 0xda, 0x3a, 0x1b, 0xd3, 0x16, 0xfd, 0xd5, 0x87, 0xa3, 0x18, 0xa7, 0x99, 0xd2, 0x28,
-	0x57, 0x6b, 0xeb, 0x6b, 0x19, 0xb4, 0xd4, 0xb2, 0xd3, 0x5a, 0xcb, 0x6e, 0xad, 0x96, 0x23, 0xe8,
-	0x25, 0xa5, 0xd2, 0x7c, 0x66, 0x6b, 0xdc, 0x8f, 0x9d, 0x45, 0x4e, 0xa1, 0xc7, 0x2f, 0x7e, 0xc5,
-	0x44, 0xdf, 0x56, 0x5f, 0x17, 0x66, 0x18, 0x32, 0x2e, 0xb3, 0xa3, 0x67, 0x33, 0x79, 0xb3, 0x51,
-	0xf5, 0xed, 0x5b, 0xaa, 0xde, 0x5f, 0xa9, 0xba, 0x80, 0x43, 0x47, 0xc6, 0xfc, 0xe9, 0x72, 0x9e,
-	0xc1, 0x49, 0x77, 0xbc, 0x73, 0xf6, 0xd9, 0x64, 0xd1, 0xb0, 0x93, 0x35, 0x24, 0x4d, 0x5e, 0xb6,
-	0x6c, 0x7f, 0x56, 0x68, 0x39, 0x8f, 0x5b, 0x33, 0x93, 0x07, 0x70, 0x90, 0x62, 0x8e, 0x1a, 0xbf,
-	0xc4, 0x4b, 0x6e, 0x1a, 0x50, 0xe4, 0x2c, 0x41, 0x0a, 0xf6, 0x5e, 0x6d, 0xae, 0x65, 0x65, 0xee,
-	0x34, 0x94, 0x99, 0x4d, 0x0b, 0x2e, 0xf1, 0xc9, 0x2b, 0x56, 0x4c, 0x51, 0xd1, 0x5d, 0x7b, 0xfd,
-	0x3a, 0xd8, 0xd4, 0xef, 0xbd, 0xff, 0xa8, 0xdf, 0xe1, 0xc6, 0xfa, 0xdd, 0xab, 0xe9, 0xd7, 0x30,
-	0x9f, 0xcd, 0xcc, 0xf8, 0x78, 0x9e, 0xd2, 0xfd, 0x8a, 0x79, 0x6f, 0x93, 0x1f, 0x61, 0x58, 0xc9,
-	0xe1, 0xbb, 0x6c, 0x86, 0xdc, 0x7c, 0xe6, 0x0d, 0x2b, 0x86, 0x87, 0x1b, 0x70, 0xfe, 0xa4, 0xb6,
-	0x31, 0x5e, 0x49, 0x44, 0x3e, 0x87, 0xb0, 0x85, 0xc7, 0xa7, 0x78, 0x99, 0x15, 0x98, 0x52, 0x62,
-	0x6f, 0x7f, 0x43, 0x04, 0xf9, 0x18, 0xde, 0x54, 0x6e, 0x4c, 0xbe, 0x64, 0x52, 0x67, 0x2c, 0xff,
-	0x9e, 0xe5, 0x25, 0x2a, 0x7a, 0x60, 0xb7, 0xb6, 0x3b, 0xc3, 0x0f, 0xe1, 0xb0, 0x4d, 0x0b, 0xa6,
-	0x63, 0x4a, 0x59, 0x28, 0x1a, 0x58, 0x6e, 0xec, 0x3a, 0xfc, 0x01, 0x86, 0xf5, 0x3b, 0xd8, 0x5e,
-	0x91, 0xc8, 0xb4, 0xef, 0x36, 0x67, 0x19, 0xbc, 0x14, 0xa9, 0xc1, 0xab, 0x8e, 0x73, 0x96, 0xc1,
-	0xab, 0x1b, 0xf8, 0x9e, 0xab, 0xac, 0xf0, 0xf7, 0x00, 0xee, 0xaf, 0x95, 0xa4, 0x19, 0x1c, 0x57,
-	0x38, 0xf7, 0x83, 0xe3, 0x0a, 0xe7, 0xe4, 0x05, 0xdc, 0xbd, 0x36, 0xe7, 0x77, 0x33, 0xe3, 0xd1,
-	0xff, 0x54, 0x7c, 0x5c, 0x65, 0xf9, 0xb4, 0xf3, 0x38, 0x88, 0xfe, 0x0c, 0x80, 0x36, 0xf7, 0xae,
-	0x1d, 0x5d, 0xd5, 0x0b, 0xd2, 0x59, 0xbc, 0x20, 0xff, 0x4e, 0x87, 0xee, 0x66, 0xd3, 0x61, 0x04,
-	0x3d, 0xa5, 0xd9, 0x45, 0x8e, 0x7e, 0xcc, 0x54, 0x96, 0xd1, 0x65, 0xb5, 0x32, 0xef, 0x88, 0xd5,
-	0xa5, 0x33, 0x23, 0x84, 0xe3, 0xd5, 0x03, 0x3a, 0x31, 0xfb, 0xd1, 0xd7, 0x3c, 0xe6, 0x43, 0xd8,
-	0xe6, 0xae, 0x1f, 0x6e, 0x19, 0xaf, 0x3e, 0xee, 0xec, 0x8f, 0x2d, 0xd8, 0xf3, 0xf9, 0x5f, 0xf0,
-	0x22, 0xd3, 0x5c, 0x92, 0x9f, 0x60, 0x6f, 0xe5, 0x09, 0x26, 0xef, 0x2e, 0x71, 0xde, 0xfe, 0x90,
-	0x87, 0xd1, 0x4d, 0x21, 0x15, 0xb3, 0xd1, 0x1d, 0xf2, 0x05, 0xf4, 0x9e, 0x17, 0xd7, 0xfc, 0x0a,
-	0x09, 0x5d, 0x8a, 0xaf, 0x20, 0x9f, 0xe9, 0x7e, 0x8b, 0x67, 0x91, 0xe0, 0x6b, 0xd8, 0x3d, 0xd7,
-	0x12, 0xd9, 0xec, 0xb5, 0xd2, 0x3c, 0x08, 0xc8, 0xb7, 0xb0, 0xbb, 0xfc, 0x70, 0x91, 0xe3, 0x9a,
-	0xac, 0x1a, 0x7f, 0x11, 0xe1, 0x3b, 0x6b, 0xfd, 0x8b, 0xb3, 0xfd, 0x0c, 0xfb, 0xab, 0x35, 0x23,
-	0xd1, 0xed, 0x6a, 0x0d, 0xdf, 0xbb, 0x31, 0x66, 0x91, 0xfe, 0x97, 0xe6, 0x33, 0xe8, 0xe7, 0xdb,
-	0x07, 0x37, 0x64, 0xa8, 0xcb, 0x26, 0x1c, 0x35, 0x34, 0xf1, 0xcc, 0xfc, 0xd6, 0x45, 0x77, 0x2e,
-	0x7a, 0x16, 0xf9, 0xe8, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x00, 0xa9, 0x5c, 0x60, 0x13, 0x0a,
-	0x00, 0x00,
+// Client API for ResourceHooks service
+
+type ResourceHooksClient interface {
+	RunHook(ctx context.Context, in *RunResourceHookRequest, opts ...grpc.CallOption) (*RunResourceHookResponse, error)
+}
+
+type resourceHooksClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewResourceHooksClient(cc *grpc.ClientConn) ResourceHooksClient {
+	return &resourceHooksClient{cc}
+}
+
+func (c *resourceHooksClient) RunHook(ctx context.Context, in *RunResourceHookRequest, opts ...grpc.CallOption) (*RunResourceHookResponse, error) {
+	out := new(RunResourceHookResponse)
+	err := grpc.Invoke(ctx, "/pulumirpc.ResourceHooks/RunHook", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ResourceHooks service
+
+type ResourceHooksServer interface {
+	RunHook(context.Context, *RunResourceHookRequest) (*RunResourceHookResponse, error)
+}
+
+func RegisterResourceHooksServer(s *grpc.Server, srv ResourceHooksServer) {
+	s.RegisterService(&_ResourceHooks_serviceDesc, srv)
+}
+
+func _ResourceHooks_RunHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunResourceHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceHooksServer).RunHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceHooks/RunHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceHooksServer).RunHook(ctx, req.(*RunResourceHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ResourceHooks_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pulumirpc.ResourceHooks",
+	HandlerType: (*ResourceHooksServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RunHook",
+			Handler:    _ResourceHooks_RunHook_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "resource.proto",
+}
+
+func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_097308f7fb469bb5) }
+
+var fileDescriptor_resource_097308f7fb469bb5 = []byte{
+	// 1025 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x56, 0x51, 0x6f, 0xe3, 0x44,
+	0x17, 0x5d, 0x27, 0x4d, 0xd2, 0xdc, 0xb4, 0x49, 0xbf, 0x69, 0xb6, 0x9d, 0x75, 0x3f, 0x76, 0x8b,
+	0x59, 0x41, 0xd8, 0x15, 0x59, 0x28, 0x0f, 0x20, 0xb4, 0x12, 0xb0, 0xed, 0x16, 0x2a, 0x81, 0x28,
+	0x09, 0xb0, 0x68, 0x25, 0x90, 0xa6, 0xf6, 0x6d, 0x6a, 0xea, 0xcc, 0x98, 0x99, 0x71, 0xab, 0x3c,
+	0xf0, 0xc0, 0xaf, 0xe0, 0x77, 0xf0, 0xd3, 0x78, 0xe7, 0x01, 0x79, 0x6c, 0x07, 0xc7, 0x71, 0x9c,
+	0x4a, 0x3c, 0xce, 0xcc, 0xb9, 0x77, 0xee, 0x39, 0x73, 0xee, 0xb5, 0xa1, 0x2b, 0x51, 0x89, 0x48,
+	0xba, 0x38, 0x0c, 0xa5, 0xd0, 0x82, 0xb4, 0xc3, 0x28, 0x88, 0xa6, 0xbe, 0x0c, 0x5d, 0xfb, 0x60,
+	0x22, 0xc4, 0x24, 0xc0, 0x67, 0xe6, 0xe0, 0x22, 0xba, 0x7c, 0x86, 0xd3, 0x50, 0xcf, 0x12, 0x9c,
+	0xfd, 0xff, 0xe2, 0xa1, 0xd2, 0x32, 0x72, 0x75, 0x7a, 0xda, 0x0d, 0xa5, 0xb8, 0xf1, 0x3d, 0x94,
+	0xc9, 0xda, 0x79, 0x0c, 0x7b, 0xe3, 0x28, 0x0c, 0x85, 0xd4, 0xea, 0x14, 0x99, 0x8e, 0x24, 0x8e,
+	0xf0, 0xd7, 0x08, 0x95, 0x26, 0x00, 0x35, 0xdf, 0xa3, 0xd6, 0xa1, 0x35, 0x68, 0x3b, 0xef, 0xc1,
+	0xfe, 0x12, 0x4a, 0x85, 0x82, 0x2b, 0x24, 0x04, 0xe0, 0x8a, 0xa9, 0xf4, 0xd4, 0xc0, 0x37, 0x9d,
+	0xbf, 0x2d, 0xd8, 0x1d, 0x21, 0xf3, 0x46, 0x29, 0x83, 0x92, 0x94, 0x64, 0x0b, 0x36, 0xf4, 0x2c,
+	0x44, 0x5a, 0xcb, 0x56, 0x9c, 0x4d, 0x91, 0xd6, 0xcd, 0xaa, 0x0b, 0xcd, 0x90, 0x49, 0xe4, 0x9a,
+	0x6e, 0x98, 0xf5, 0x53, 0x80, 0x50, 0x8a, 0x10, 0xa5, 0xf6, 0x51, 0xd1, 0xc6, 0xa1, 0x35, 0xe8,
+	0x1c, 0xed, 0x0f, 0x13, 0x9e, 0xc3, 0x8c, 0xe7, 0x70, 0x6c, 0x78, 0x92, 0x3e, 0x6c, 0x79, 0x18,
+	0x22, 0xf7, 0x90, 0xbb, 0x31, 0xbc, 0x79, 0x58, 0x1f, 0xb4, 0xc9, 0x0e, 0x6c, 0x66, 0xcc, 0x69,
+	0xcb, 0x24, 0xed, 0x41, 0xeb, 0x06, 0xa5, 0xf2, 0x05, 0xa7, 0x9b, 0x66, 0xe3, 0x3e, 0x6c, 0x33,
+	0xd7, 0xc5, 0x50, 0x8f, 0xd1, 0x95, 0xa8, 0x15, 0x6d, 0xc7, 0x64, 0xc8, 0x23, 0xd8, 0x67, 0x9e,
+	0xe7, 0x6b, 0x5f, 0x70, 0x16, 0x24, 0x47, 0xdf, 0x44, 0x3a, 0x8c, 0xb4, 0xa2, 0x60, 0x52, 0xf7,
+	0xa0, 0xc5, 0x02, 0x9f, 0x29, 0x54, 0xb4, 0x13, 0x6f, 0x38, 0xe7, 0xd0, 0x5f, 0x64, 0x9f, 0x4a,
+	0xd5, 0x81, 0x7a, 0x24, 0x39, 0xb5, 0x4a, 0x38, 0xd5, 0x2a, 0x39, 0x39, 0xbf, 0x6f, 0xc2, 0xfe,
+	0x08, 0x27, 0xbe, 0xd2, 0x28, 0x8b, 0xa2, 0x66, 0x42, 0x5a, 0x0b, 0x42, 0xd6, 0x0a, 0x42, 0xce,
+	0x85, 0x75, 0x23, 0xa5, 0xc5, 0xd4, 0x08, 0xbb, 0x49, 0xde, 0x81, 0xa6, 0xb8, 0xf8, 0x05, 0x5d,
+	0xbd, 0x4e, 0xd4, 0x1e, 0xb4, 0xe2, 0xad, 0x18, 0xd9, 0x34, 0x91, 0x45, 0x95, 0x5b, 0x4b, 0x2a,
+	0x27, 0xa2, 0xbe, 0x86, 0x7e, 0x4a, 0x73, 0x76, 0x92, 0xc7, 0xb7, 0x0f, 0xeb, 0x83, 0xce, 0xd1,
+	0xf3, 0xe1, 0xdc, 0xd4, 0xc3, 0x15, 0xfc, 0x86, 0xe7, 0x25, 0xe1, 0x2f, 0xb9, 0x96, 0x33, 0x72,
+	0x00, 0xbb, 0x1e, 0x06, 0xa8, 0xf1, 0x05, 0x5e, 0x0a, 0x89, 0x23, 0x0c, 0x03, 0xe6, 0x22, 0x05,
+	0x53, 0x60, 0xee, 0x79, 0x3b, 0xd9, 0xf3, 0xfa, 0x13, 0x2e, 0x24, 0x1e, 0x5f, 0x31, 0x3e, 0x41,
+	0x45, 0xb7, 0x0e, 0xeb, 0x65, 0xaf, 0xbe, 0xbd, 0xee, 0xd5, 0xbb, 0xc5, 0x57, 0xef, 0x65, 0xdc,
+	0xfd, 0x69, 0xdc, 0x04, 0x67, 0x1e, 0xdd, 0x31, 0x37, 0x9e, 0x41, 0x37, 0x51, 0xfb, 0x3b, 0x7f,
+	0x8a, 0x22, 0x0e, 0xfd, 0x9f, 0x51, 0xf9, 0x83, 0x3b, 0xb0, 0x3e, 0x5e, 0x08, 0x24, 0x0e, 0xd8,
+	0x25, 0x54, 0x4f, 0xf0, 0xd2, 0xe7, 0xe8, 0x51, 0x62, 0x4a, 0x7e, 0x03, 0xee, 0xab, 0xb4, 0x49,
+	0xcf, 0x99, 0xd4, 0x3e, 0x0b, 0x7e, 0x60, 0x41, 0x84, 0x8a, 0xee, 0x9a, 0xe3, 0x53, 0x68, 0x5c,
+	0x09, 0x71, 0xad, 0x68, 0xdf, 0x14, 0xf1, 0xd1, 0x1d, 0x8a, 0xc8, 0xd6, 0x5f, 0xc6, 0x71, 0x2f,
+	0x7c, 0xee, 0xf9, 0x7c, 0x62, 0x3f, 0x86, 0x7e, 0xd9, 0x93, 0xc4, 0xce, 0x8b, 0x24, 0x57, 0xd4,
+	0x8a, 0xd5, 0xb0, 0x3f, 0x83, 0x6e, 0x81, 0x42, 0xec, 0x3d, 0x89, 0x4c, 0x67, 0x4e, 0xed, 0x42,
+	0x33, 0x0a, 0x3d, 0xa6, 0x73, 0x5e, 0x4d, 0x28, 0x26, 0x5e, 0xb5, 0xff, 0xb0, 0xa0, 0x5f, 0x56,
+	0x40, 0x6c, 0xbd, 0x0b, 0xa3, 0xc2, 0x71, 0x96, 0x2e, 0x96, 0x7f, 0x17, 0x3a, 0xec, 0x52, 0xa3,
+	0x4c, 0x37, 0x6b, 0x66, 0x73, 0x0e, 0xfd, 0x3e, 0xb9, 0xa9, 0xbe, 0x00, 0x4d, 0x37, 0x37, 0x16,
+	0xa1, 0x27, 0x49, 0x11, 0x8d, 0x05, 0x68, 0xba, 0x69, 0x66, 0x89, 0xad, 0xe1, 0xc1, 0x6a, 0x53,
+	0x76, 0xa0, 0x7e, 0x8d, 0xb3, 0x94, 0xe3, 0x29, 0x34, 0x6e, 0xe2, 0x37, 0xa0, 0xb5, 0x3b, 0x6b,
+	0x5e, 0x96, 0xf9, 0x93, 0xda, 0xc7, 0x96, 0xf3, 0x1b, 0xd0, 0xe5, 0x98, 0xb2, 0xc9, 0x92, 0x4c,
+	0xd9, 0x44, 0xd4, 0x7f, 0x1b, 0xbc, 0x5e, 0xdd, 0xe0, 0x5d, 0x68, 0x2a, 0xcd, 0x2e, 0x02, 0x4c,
+	0x27, 0x43, 0x0f, 0x5a, 0xc9, 0x5a, 0x25, 0x4a, 0x38, 0xaf, 0xe0, 0x61, 0xf1, 0xfa, 0xb4, 0x21,
+	0xb2, 0x41, 0xb4, 0x50, 0xc4, 0x00, 0x5a, 0x22, 0xed, 0x97, 0x35, 0xb3, 0xed, 0x39, 0x1c, 0x14,
+	0x13, 0xc7, 0xcf, 0x9d, 0x1b, 0x6f, 0x66, 0xa0, 0x59, 0xd9, 0xd0, 0x66, 0x9e, 0x27, 0x51, 0x25,
+	0x69, 0xdb, 0xce, 0x5f, 0x16, 0xec, 0x8d, 0x22, 0xbe, 0x22, 0x32, 0x36, 0x7c, 0x1a, 0x99, 0x56,
+	0x57, 0xcb, 0x49, 0x94, 0xcc, 0xc4, 0x27, 0xd0, 0xe6, 0x78, 0x7b, 0xc6, 0x4d, 0xad, 0x1b, 0xd5,
+	0x2a, 0x3d, 0x81, 0xb6, 0x08, 0xbc, 0x14, 0xbb, 0x66, 0x64, 0x3e, 0x05, 0xe0, 0x78, 0x9b, 0x0d,
+	0x8d, 0xe6, 0x5a, 0xb0, 0x08, 0xbc, 0x0c, 0xdc, 0xaa, 0x56, 0x6c, 0x00, 0xfb, 0x4b, 0x94, 0x53,
+	0x23, 0x6c, 0x43, 0x03, 0xa5, 0x14, 0x32, 0x21, 0x7d, 0xf4, 0x67, 0x03, 0x7a, 0x19, 0xee, 0x6b,
+	0xc1, 0x7d, 0x2d, 0x24, 0x79, 0x0d, 0xbd, 0xc2, 0xb7, 0x9c, 0xbc, 0x99, 0xf3, 0x65, 0xf9, 0xdf,
+	0x80, 0xed, 0x54, 0x41, 0x92, 0xcb, 0x9d, 0x7b, 0xe4, 0x53, 0x68, 0x9e, 0xf1, 0x1b, 0x71, 0x8d,
+	0x84, 0xe6, 0xf0, 0xc9, 0x56, 0x96, 0xe9, 0x41, 0xc9, 0xc9, 0x3c, 0xc1, 0x17, 0xb0, 0x35, 0xd6,
+	0x12, 0xd9, 0xf4, 0x3f, 0xa5, 0x79, 0xdf, 0x22, 0xdf, 0xc2, 0x56, 0xfe, 0x1b, 0x4c, 0x1e, 0x2e,
+	0xb4, 0xde, 0xd2, 0xaf, 0x89, 0xfd, 0x68, 0xe5, 0xf9, 0xbc, 0xb6, 0x9f, 0x60, 0xa7, 0x68, 0x54,
+	0xe2, 0xac, 0xef, 0x68, 0xfb, 0xad, 0x4a, 0xcc, 0x3c, 0xfd, 0xcf, 0xcb, 0x9f, 0xf8, 0xd4, 0x0f,
+	0xe4, 0xdd, 0x8a, 0x0c, 0x8b, 0x4d, 0x68, 0xef, 0x2d, 0x99, 0xe6, 0x65, 0xfc, 0x6f, 0xe8, 0xdc,
+	0x23, 0x3f, 0x42, 0xbf, 0x18, 0x1b, 0x5b, 0x87, 0xbc, 0x5d, 0x91, 0x3c, 0xd7, 0x4e, 0x15, 0x99,
+	0xbf, 0x02, 0x3a, 0xf6, 0x27, 0x9c, 0x05, 0x9f, 0x73, 0xef, 0x15, 0xf3, 0xf5, 0xa9, 0x90, 0xe3,
+	0xab, 0x48, 0x7b, 0xe2, 0x96, 0x93, 0x15, 0x51, 0xab, 0xb3, 0x1d, 0xb9, 0xb0, 0x9d, 0xbf, 0x5e,
+	0x91, 0x11, 0xb4, 0x46, 0x11, 0x37, 0xb5, 0xe6, 0x8d, 0x5a, 0xde, 0xf5, 0xb6, 0x53, 0x05, 0xc9,
+	0xc4, 0xbe, 0x68, 0x9a, 0x6b, 0x3f, 0xfc, 0x67, 0x00, 0x0b, 0xf6, 0x4c, 0xe4, 0x65, 0x0b, 0x00,
+	0x00,
 }
@@ -46,6 +46,16 @@ class ResourceMonitorStub(object):
         request_serializer=resource__pb2.RegisterResourceOutputsRequest.SerializeToString,
         response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
         )
+    self.RegisterResourceHook = channel.unary_unary(
+        '/pulumirpc.ResourceMonitor/RegisterResourceHook',
+        request_serializer=resource__pb2.RegisterResourceHookRequest.SerializeToString,
+        response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
+        )
+    self.SignalAndWaitForShutdown = channel.unary_unary(
+        '/pulumirpc.ResourceMonitor/SignalAndWaitForShutdown',
+        request_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
+        response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
+        )
 
 
 class ResourceMonitorServicer(object):
@@ -94,6 +104,20 @@ class ResourceMonitorServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def RegisterResourceHook(self, request, context):
+    # missing associated documentation comment in .proto file
+    pass
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+  def SignalAndWaitForShutdown(self, request, context):
+    # missing associated documentation comment in .proto file
+    pass
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
 
 def add_ResourceMonitorServicer_to_server(servicer, server):
   rpc_method_handlers = {
@@ -127,7 +151,59 @@ def add_ResourceMonitorServicer_to_server(servicer, server):
           request_deserializer=resource__pb2.RegisterResourceOutputsRequest.FromString,
           response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
       ),
+      'RegisterResourceHook': grpc.unary_unary_rpc_method_handler(
+          servicer.RegisterResourceHook,
+          request_deserializer=resource__pb2.RegisterResourceHookRequest.FromString,
+          response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
+      ),
+      'SignalAndWaitForShutdown': grpc.unary_unary_rpc_method_handler(
+          servicer.SignalAndWaitForShutdown,
+          request_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
+          response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
+      ),
   }
   generic_handler = grpc.method_handlers_generic_handler(
       'pulumirpc.ResourceMonitor', rpc_method_handlers)
   server.add_generic_rpc_handlers((generic_handler,))
+
+
+class ResourceHooksStub(object):
+  """ResourceHooks is the interface a program serves so that the engine can run the resource hooks it registered.
+  """
+
+  def __init__(self, channel):
+    """Constructor.
+
+    Args:
+      channel: A grpc.Channel.
+    """
+    self.RunHook = channel.unary_unary(
+        '/pulumirpc.ResourceHooks/RunHook',
+        request_serializer=resource__pb2.RunResourceHookRequest.SerializeToString,
+        response_deserializer=resource__pb2.RunResourceHookResponse.FromString,
+        )
+
+
+class ResourceHooksServicer(object):
+  """ResourceHooks is the interface a program serves so that the engine can run the resource hooks it registered.
+  """
+
+  def RunHook(self, request, context):
+    # missing associated documentation comment in .proto file
+    pass
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+
+def add_ResourceHooksServicer_to_server(servicer, server):
+  rpc_method_handlers = {
+      'RunHook': grpc.unary_unary_rpc_method_handler(
+          servicer.RunHook,
+          request_deserializer=resource__pb2.RunResourceHookRequest.FromString,
+          response_serializer=resource__pb2.RunResourceHookResponse.SerializeToString,
+      ),
+  }
+  generic_handler = grpc.method_handlers_generic_handler(
+      'pulumirpc.ResourceHooks', rpc_method_handlers)
+  server.add_generic_rpc_handlers((generic_handler,))